@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -52,11 +53,15 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:8080", 
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
+		ExposeHeaders:    "Idempotent-Replayed",
 		AllowCredentials: true,
 	}))
 	
-	routes.SetupRoutes(app, db, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	
+	routes.SetupRoutes(ctx, app, db, cfg)
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -64,6 +69,7 @@ func main() {
 	go func() {
 		<-c
 		log.Println("Shutting down server...")
+		cancel()
 		_ = app.Shutdown()
 	}()
 
@@ -61,7 +61,7 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 		})
 	}
 	
-	if req.Price < 0 {
+	if req.Price.IsNegative() {
 		validationErrors = append(validationErrors, utils.ErrorDetail{
 			Field:   "price",
 			Message: "Harga tiket tidak boleh negatif",
@@ -81,6 +81,13 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Hanya organizer yang dapat membuat event", fiber.StatusForbidden)
 		case "tanggal event tidak boleh di masa lalu":
 			return utils.ErrorResponse(c, utils.ErrorCodeEventDateInvalid, "Tanggal event tidak boleh di masa lalu", fiber.StatusBadRequest)
+		case "mata uang tidak didukung":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Mata uang tidak didukung", fiber.StatusBadRequest)
+		case "mata uang harga harus sama dengan mata uang event":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Mata uang harga harus sama dengan mata uang event", fiber.StatusBadRequest)
+		case "batas waktu refund tidak boleh negatif", "persentase refund harus lebih dari 0 dan maksimal 100",
+			"batas waktu transfer tidak boleh negatif", "batas jumlah transfer tidak boleh negatif":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
 		default:
 			return utils.ServerError(c, "Gagal membuat event: "+err.Error())
 		}
@@ -178,8 +185,17 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 			return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk mengubah event ini", fiber.StatusForbidden)
 		case "kapasitas tidak boleh lebih kecil dari jumlah tiket yang sudah terjual":
 			return utils.ErrorResponse(c, utils.ErrorCodeEventCapacityLow, "Kapasitas tidak boleh lebih kecil dari jumlah tiket yang sudah terjual", fiber.StatusBadRequest)
+		case "kapasitas tidak boleh lebih kecil dari total kuota tipe tiket":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventCapacityLow, "Kapasitas tidak boleh lebih kecil dari total kuota tipe tiket", fiber.StatusBadRequest)
 		case "status tidak valid":
 			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Status event tidak valid", fiber.StatusBadRequest)
+		case "mata uang harga harus sama dengan mata uang event":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Mata uang harga harus sama dengan mata uang event", fiber.StatusBadRequest)
+		case "event yang sudah dibatalkan tidak dapat diaktifkan kembali":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventCancelled, "Event yang sudah dibatalkan tidak dapat diaktifkan kembali", fiber.StatusBadRequest)
+		case "batas waktu refund tidak boleh negatif", "persentase refund harus lebih dari 0 dan maksimal 100",
+			"batas waktu transfer tidak boleh negatif", "batas jumlah transfer tidak boleh negatif":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
 		default:
 			return utils.ServerError(c, "Gagal mengubah event: "+err.Error())
 		}
@@ -279,4 +295,132 @@ func (h *EventHandler) GetEventSales(c *fiber.Ctx) error {
 	}
 	
 	return utils.SuccessResponse(c, "Data penjualan event berhasil diambil", sales)
+}
+
+func (h *EventHandler) CreateTicketType(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.TicketTypeRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	ticketType, err := h.eventUsecase.CreateTicketType(c.Context(), eventID, userID, req)
+	if err != nil {
+		return ticketTypeErrorResponse(c, err, "Gagal membuat tipe tiket: ")
+	}
+	
+	return utils.CreatedResponse(c, "Tipe tiket berhasil dibuat", ticketType)
+}
+
+func (h *EventHandler) GetTicketTypes(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	ticketTypes, err := h.eventUsecase.GetTicketTypes(c.Context(), eventID)
+	if err != nil {
+		return ticketTypeErrorResponse(c, err, "Gagal mendapatkan daftar tipe tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Daftar tipe tiket berhasil diambil", ticketTypes)
+}
+
+func (h *EventHandler) UpdateTicketType(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	ticketTypeID, err := strconv.Atoi(c.Params("typeId"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID tipe tiket tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.TicketTypeRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	ticketType, err := h.eventUsecase.UpdateTicketType(c.Context(), eventID, ticketTypeID, userID, req)
+	if err != nil {
+		return ticketTypeErrorResponse(c, err, "Gagal mengubah tipe tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Tipe tiket berhasil diperbarui", ticketType)
+}
+
+func (h *EventHandler) DeleteTicketType(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	ticketTypeID, err := strconv.Atoi(c.Params("typeId"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID tipe tiket tidak valid", fiber.StatusBadRequest)
+	}
+	
+	err = h.eventUsecase.DeleteTicketType(c.Context(), eventID, ticketTypeID, userID)
+	if err != nil {
+		return ticketTypeErrorResponse(c, err, "Gagal menghapus tipe tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Tipe tiket berhasil dihapus", nil)
+}
+
+// ticketTypeErrorResponse memetakan error dari use case tipe tiket yang dipakai bersama oleh keempat endpoint CRUD
+func ticketTypeErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "event tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk mengelola tipe tiket event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk mengelola tipe tiket event ini", fiber.StatusForbidden)
+	case "tipe tiket tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketTypeNotFound, "Tipe tiket tidak ditemukan", fiber.StatusNotFound)
+	case "nama tipe tiket harus diisi",
+		"harga tipe tiket tidak boleh negatif",
+		"mata uang harga harus sama dengan mata uang event",
+		"kuota tipe tiket harus lebih dari 0",
+		"batas pembelian per transaksi tidak boleh negatif",
+		"akhir penjualan harus setelah awal penjualan":
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
+	case "nama tipe tiket sudah digunakan di event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "Nama tipe tiket sudah digunakan di event ini", fiber.StatusConflict)
+	case "total kuota tipe tiket melebihi kapasitas event":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventCapacityLow, "Total kuota tipe tiket melebihi kapasitas event", fiber.StatusBadRequest)
+	case "kuota tidak boleh lebih kecil dari jumlah tiket yang sudah terjual":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventCapacityLow, "Kuota tidak boleh lebih kecil dari jumlah tiket yang sudah terjual", fiber.StatusBadRequest)
+	case "tipe tiket yang sudah terjual tidak dapat dihapus":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketAlreadySold, "Tipe tiket yang sudah terjual tidak dapat dihapus", fiber.StatusConflict)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
 }
\ No newline at end of file
@@ -0,0 +1,60 @@
+//internal/delivery/http/handler/file_handler.go
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"path"
+
+	"github.com/gofiber/fiber/v2"
+
+	"ticket-system/internal/domain/storage"
+	"ticket-system/pkg/utils"
+)
+
+// SignedFileStorage adalah storage yang file-nya disajikan sendiri oleh aplikasi
+// (storage lokal), sehingga signature URL unduhan harus diperiksa di sini.
+type SignedFileStorage interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	VerifySignature(key, expires, signature string) error
+}
+
+type FileHandler struct {
+	fileStorage SignedFileStorage
+}
+
+func NewFileHandler(fileStorage SignedFileStorage) *FileHandler {
+	return &FileHandler{
+		fileStorage: fileStorage,
+	}
+}
+
+// Download menyajikan file dari URL yang dibuat oleh SignedURL. Endpoint ini tidak memakai JWT,
+// aksesnya dibatasi oleh signature dan waktu kedaluwarsa pada query string.
+func (h *FileHandler) Download(c *fiber.Ctx) error {
+	key := c.Params("*")
+
+	if err := h.fileStorage.VerifySignature(key, c.Query("expires"), c.Query("signature")); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Tautan unduhan tidak valid atau sudah kedaluwarsa", fiber.StatusForbidden)
+	}
+
+	file, err := h.fileStorage.Get(c.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return utils.NotFoundError(c, "File tidak ditemukan")
+		}
+		log.Printf("Gagal membaca file %s: %v", key, err)
+		return utils.ServerError(c, "Gagal membaca file")
+	}
+
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	}
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+
+	return c.SendStream(file)
+}
@@ -0,0 +1,49 @@
+//internal/delivery/http/handler/payment_handler.go
+
+package handler
+
+import (
+	"log"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type PaymentHandler struct {
+	paymentUsecase usecase.PaymentUsecase
+}
+
+func NewPaymentHandler(paymentUsecase usecase.PaymentUsecase) *PaymentHandler {
+	return &PaymentHandler{
+		paymentUsecase: paymentUsecase,
+	}
+}
+
+// HandleNotification menerima notifikasi HTTP dari payment gateway. Endpoint ini publik,
+// keasliannya dijamin oleh signature yang diverifikasi di usecase.
+func (h *PaymentHandler) HandleNotification(c *fiber.Ctx) error {
+	// Fiber memakai ulang buffer body setelah request selesai, jadi payload disalin
+	payload := append([]byte(nil), c.Body()...)
+	
+	err := h.paymentUsecase.HandleNotification(c.Context(), payload)
+	if err != nil {
+		switch err.Error() {
+		case "payload notifikasi tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Payload notifikasi tidak valid", fiber.StatusBadRequest)
+		case "signature notifikasi tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Signature notifikasi tidak valid", fiber.StatusForbidden)
+		case "transaksi tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Transaksi tidak ditemukan", fiber.StatusNotFound)
+		case "data pembayaran tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Data pembayaran tidak ditemukan", fiber.StatusNotFound)
+		case "nominal pembayaran tidak sesuai dengan transaksi":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Nominal pembayaran tidak sesuai dengan transaksi", fiber.StatusBadRequest)
+		default:
+			log.Printf("Gagal memproses notifikasi pembayaran: %v", err)
+			return utils.ServerError(c, "Gagal memproses notifikasi pembayaran")
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Notifikasi pembayaran berhasil diproses", nil)
+}
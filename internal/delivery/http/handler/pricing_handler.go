@@ -0,0 +1,144 @@
+//internal/delivery/http/handler/pricing_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type PricingHandler struct {
+	pricingUsecase usecase.PricingUsecase
+}
+
+func NewPricingHandler(pricingUsecase usecase.PricingUsecase) *PricingHandler {
+	return &PricingHandler{
+		pricingUsecase: pricingUsecase,
+	}
+}
+
+func (h *PricingHandler) GetOrganizerPricing(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	rule, err := h.pricingUsecase.GetOrganizerPricing(c.Context(), userID)
+	if err != nil {
+		return utils.ServerError(c, "Gagal mendapatkan pengaturan biaya: "+err.Error())
+	}
+	
+	return utils.SuccessResponse(c, "Pengaturan biaya berhasil diambil", rule)
+}
+
+func (h *PricingHandler) UpdateOrganizerPricing(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.PricingRuleRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	rule, err := h.pricingUsecase.UpdateOrganizerPricing(c.Context(), userID, req)
+	if err != nil {
+		return pricingErrorResponse(c, err, "Gagal menyimpan pengaturan biaya: ")
+	}
+	
+	return utils.SuccessResponse(c, "Pengaturan biaya berhasil disimpan", rule)
+}
+
+func (h *PricingHandler) GetEventPricing(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	rule, err := h.pricingUsecase.GetEventPricing(c.Context(), eventID, userID)
+	if err != nil {
+		return pricingErrorResponse(c, err, "Gagal mendapatkan pengaturan biaya event: ")
+	}
+	
+	return utils.SuccessResponse(c, "Pengaturan biaya event berhasil diambil", rule)
+}
+
+func (h *PricingHandler) UpdateEventPricing(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.PricingRuleRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	rule, err := h.pricingUsecase.UpdateEventPricing(c.Context(), eventID, userID, req)
+	if err != nil {
+		return pricingErrorResponse(c, err, "Gagal menyimpan pengaturan biaya event: ")
+	}
+	
+	return utils.SuccessResponse(c, "Pengaturan biaya event berhasil disimpan", rule)
+}
+
+func (h *PricingHandler) DeleteEventPricing(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	err = h.pricingUsecase.DeleteEventPricing(c.Context(), eventID, userID)
+	if err != nil {
+		return pricingErrorResponse(c, err, "Gagal menghapus pengaturan biaya event: ")
+	}
+	
+	return utils.SuccessResponse(c, "Event kembali memakai pengaturan biaya default", nil)
+}
+
+func pricingErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "event tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk mengatur biaya event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk mengatur biaya event ini", fiber.StatusForbidden)
+	case "persentase biaya layanan harus antara 0 dan 100",
+		"biaya layanan per tiket tidak boleh negatif",
+		"persentase pajak harus antara 0 dan 100",
+		"mata uang biaya layanan harus sama dengan mata uang event":
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
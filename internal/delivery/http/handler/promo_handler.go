@@ -0,0 +1,133 @@
+//internal/delivery/http/handler/promo_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type PromoHandler struct {
+	promoUsecase usecase.PromoUsecase
+}
+
+func NewPromoHandler(promoUsecase usecase.PromoUsecase) *PromoHandler {
+	return &PromoHandler{
+		promoUsecase: promoUsecase,
+	}
+}
+
+func (h *PromoHandler) CreatePromoCode(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.PromoCodeRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	promo, err := h.promoUsecase.CreatePromoCode(c.Context(), userID, req)
+	if err != nil {
+		return promoErrorResponse(c, err, "Gagal membuat kode promo: ")
+	}
+	
+	return utils.CreatedResponse(c, "Kode promo berhasil dibuat", promo)
+}
+
+func (h *PromoHandler) GetPromoCodes(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	promos, err := h.promoUsecase.GetPromoCodes(c.Context(), userID)
+	if err != nil {
+		return utils.ServerError(c, "Gagal mendapatkan daftar kode promo: "+err.Error())
+	}
+	
+	return utils.SuccessResponse(c, "Daftar kode promo berhasil diambil", promos)
+}
+
+func (h *PromoHandler) UpdatePromoCode(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	promoID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID kode promo tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.PromoCodeRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	promo, err := h.promoUsecase.UpdatePromoCode(c.Context(), promoID, userID, req)
+	if err != nil {
+		return promoErrorResponse(c, err, "Gagal mengubah kode promo: ")
+	}
+	
+	return utils.SuccessResponse(c, "Kode promo berhasil diperbarui", promo)
+}
+
+func (h *PromoHandler) DeactivatePromoCode(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	promoID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID kode promo tidak valid", fiber.StatusBadRequest)
+	}
+	
+	err = h.promoUsecase.DeactivatePromoCode(c.Context(), promoID, userID)
+	if err != nil {
+		return promoErrorResponse(c, err, "Gagal menonaktifkan kode promo: ")
+	}
+	
+	return utils.SuccessResponse(c, "Kode promo berhasil dinonaktifkan", nil)
+}
+
+func promoErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "kode promo tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodePromoNotFound, "Kode promo tidak ditemukan", fiber.StatusNotFound)
+	case "event tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk membuat kode promo di event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk membuat kode promo di event ini", fiber.StatusForbidden)
+	case "kode promo sudah digunakan":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "Kode promo sudah digunakan", fiber.StatusConflict)
+	case "kode promo harus diisi",
+		"kode promo hanya boleh berisi 3-50 huruf, angka, tanda hubung, atau garis bawah",
+		"tipe diskon tidak valid",
+		"diskon persentase tidak boleh lebih dari 100",
+		"nilai diskon harus lebih dari 0",
+		"mata uang diskon harus sama dengan mata uang event",
+		"batas pemakaian tidak boleh negatif",
+		"batas pemakaian tidak boleh lebih kecil dari jumlah pemakaian",
+		"minimum jumlah tiket tidak boleh negatif",
+		"akhir masa berlaku harus setelah awal masa berlaku":
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,182 @@
+//internal/delivery/http/handler/refund_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type RefundHandler struct {
+	refundUsecase usecase.RefundUsecase
+}
+
+func NewRefundHandler(refundUsecase usecase.RefundUsecase) *RefundHandler {
+	return &RefundHandler{
+		refundUsecase: refundUsecase,
+	}
+}
+
+func (h *RefundHandler) RequestRefund(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transactionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID transaksi tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.RequestRefundRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	refund, err := h.refundUsecase.RequestRefund(c.Context(), userID, transactionID, req)
+	if err != nil {
+		return refundErrorResponse(c, err, "Gagal mengajukan refund: ")
+	}
+	
+	return utils.CreatedResponse(c, "Pengajuan refund berhasil dikirim", refund)
+}
+
+func (h *RefundHandler) GetTransactionRefund(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transactionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID transaksi tidak valid", fiber.StatusBadRequest)
+	}
+	
+	refund, err := h.refundUsecase.GetTransactionRefund(c.Context(), userID, transactionID)
+	if err != nil {
+		return refundErrorResponse(c, err, "Gagal mendapatkan data refund: ")
+	}
+	
+	return utils.SuccessResponse(c, "Data refund berhasil diambil", refund)
+}
+
+func (h *RefundHandler) GetEventRefunds(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	refunds, err := h.refundUsecase.GetEventRefunds(c.Context(), userID, eventID)
+	if err != nil {
+		return refundErrorResponse(c, err, "Gagal mendapatkan daftar refund: ")
+	}
+	
+	return utils.SuccessResponse(c, "Daftar refund berhasil diambil", refunds)
+}
+
+func (h *RefundHandler) ApproveRefund(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	refundID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID refund tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.ApproveRefundRequest
+	
+	// Body boleh kosong untuk menyetujui nominal yang diajukan
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+		}
+	}
+	
+	refund, err := h.refundUsecase.ApproveRefund(c.Context(), userID, refundID, req)
+	if err != nil {
+		return refundErrorResponse(c, err, "Gagal menyetujui refund: ")
+	}
+	
+	return utils.SuccessResponse(c, "Refund berhasil disetujui", refund)
+}
+
+func (h *RefundHandler) RejectRefund(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	refundID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID refund tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.RejectRefundRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	refund, err := h.refundUsecase.RejectRefund(c.Context(), userID, refundID, req)
+	if err != nil {
+		return refundErrorResponse(c, err, "Gagal menolak refund: ")
+	}
+	
+	return utils.SuccessResponse(c, "Refund berhasil ditolak", refund)
+}
+
+func refundErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "transaksi tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Transaksi tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk transaksi ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeTransactionAccessDenied, "Anda tidak memiliki izin untuk transaksi ini", fiber.StatusForbidden)
+	case "event tidak ditemukan", "event terkait tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk mengelola refund event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk mengelola refund event ini", fiber.StatusForbidden)
+	case "refund tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeRefundNotFound, "Refund tidak ditemukan", fiber.StatusNotFound)
+	case "transaksi sudah memiliki pengajuan refund":
+		return utils.ErrorResponse(c, utils.ErrorCodeRefundExists, "Transaksi sudah memiliki pengajuan refund", fiber.StatusConflict)
+	case "refund sedang diproses, silakan muat ulang":
+		return utils.ErrorResponse(c, utils.ErrorCodeRefundConflict, "Refund sedang diproses, silakan muat ulang", fiber.StatusConflict)
+	case "hanya transaksi dengan status paid yang dapat direfund",
+		"event dibatalkan, refund akan diproses otomatis",
+		"event ini tidak menerima pengajuan refund",
+		"batas waktu pengajuan refund sudah lewat",
+		"transaksi dengan tiket yang sudah digunakan tidak dapat direfund",
+		"transaksi dengan tiket yang sudah dipindahtangankan tidak dapat direfund",
+		"hanya refund berstatus requested atau failed yang dapat disetujui",
+		"hanya refund berstatus requested yang dapat ditolak":
+		return utils.ErrorResponse(c, utils.ErrorCodeRefundNotAllowed, err.Error(), fiber.StatusBadRequest)
+	case "alasan refund harus diisi", "alasan penolakan harus diisi":
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, err.Error(), fiber.StatusBadRequest)
+	case "mata uang refund harus sama dengan mata uang transaksi",
+		"nominal refund harus lebih dari 0 dan tidak melebihi total transaksi":
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+//internal/delivery/http/handler/seat_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type SeatHandler struct {
+	seatUsecase usecase.SeatUsecase
+}
+
+func NewSeatHandler(seatUsecase usecase.SeatUsecase) *SeatHandler {
+	return &SeatHandler{
+		seatUsecase: seatUsecase,
+	}
+}
+
+func (h *SeatHandler) GetSeatMap(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+
+	seatMap, err := h.seatUsecase.GetSeatMap(c.Context(), eventID)
+	if err != nil {
+		return seatErrorResponse(c, err, "Gagal mendapatkan peta kursi: ")
+	}
+
+	return utils.SuccessResponse(c, "Peta kursi berhasil diambil", seatMap)
+}
+
+func (h *SeatHandler) HoldSeats(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+
+	var req usecase.HoldSeatsRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+
+	hold, err := h.seatUsecase.HoldSeats(c.Context(), userID, eventID, req)
+	if err != nil {
+		return seatErrorResponse(c, err, "Gagal mengunci kursi: ")
+	}
+
+	return utils.CreatedResponse(c, "Kursi berhasil dikunci", hold)
+}
+
+func (h *SeatHandler) GetSeatHold(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+
+	hold, err := h.seatUsecase.GetSeatHold(c.Context(), userID, eventID)
+	if err != nil {
+		return seatErrorResponse(c, err, "Gagal mendapatkan hold kursi: ")
+	}
+
+	return utils.SuccessResponse(c, "Hold kursi berhasil diambil", hold)
+}
+
+func (h *SeatHandler) ReleaseSeatHold(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+
+	if err := h.seatUsecase.ReleaseSeatHold(c.Context(), userID, eventID); err != nil {
+		return seatErrorResponse(c, err, "Gagal melepas kursi: ")
+	}
+
+	return utils.SuccessResponse(c, "Kursi berhasil dilepas", nil)
+}
+
+func seatErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "event tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "event tidak aktif":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventCancelled, "Event tidak aktif", fiber.StatusBadRequest)
+	case "event tidak memiliki kursi bernomor", "kursi tidak ditemukan di denah venue event", "venue tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeSeatNotFound, err.Error(), fiber.StatusNotFound)
+	case "kursi harus dipilih untuk event dengan denah venue", "kursi yang dipilih tidak boleh duplikat",
+		"jumlah kursi yang dipilih melebihi batas per pembeli":
+		return utils.ErrorResponse(c, utils.ErrorCodeSeatSelection, err.Error(), fiber.StatusBadRequest)
+	case "kursi yang dipilih sudah tidak tersedia":
+		return utils.ErrorResponse(c, utils.ErrorCodeSeatUnavailable, "Kursi yang dipilih sudah tidak tersedia", fiber.StatusConflict)
+	case "anda tidak sedang memegang kursi di event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeSeatHoldExpired, "Anda tidak sedang memegang kursi di event ini", fiber.StatusNotFound)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
@@ -0,0 +1,288 @@
+//internal/delivery/http/handler/ticket_handler.go
+
+package handler
+
+import (
+	"log"
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type TicketHandler struct {
+	ticketUsecase usecase.TicketUsecase
+}
+
+func NewTicketHandler(ticketUsecase usecase.TicketUsecase) *TicketHandler {
+	return &TicketHandler{
+		ticketUsecase: ticketUsecase,
+	}
+}
+
+func (h *TicketHandler) GetUserTickets(c *fiber.Ctx) error {
+	log.Println("GetUserTickets handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	
+	tickets, total, err := h.ticketUsecase.GetUserTickets(c.Context(), userID, page, limit)
+	if err != nil {
+		return utils.ServerError(c, "Gagal mendapatkan daftar tiket: "+err.Error())
+	}
+	
+	meta := fiber.Map{
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	}
+	
+	return utils.SuccessResponse(c, "Daftar tiket berhasil diambil", tickets, meta)
+}
+
+func (h *TicketHandler) GetTicketByCode(c *fiber.Ctx) error {
+	log.Println("GetTicketByCode handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	code := c.Params("code")
+	if code == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Kode tiket diperlukan", fiber.StatusBadRequest)
+	}
+	
+	ticket, err := h.ticketUsecase.GetTicketByCode(c.Context(), userID, code)
+	if err != nil {
+		switch err.Error() {
+		case "tiket tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketNotFound, "Tiket tidak ditemukan", fiber.StatusNotFound)
+		case "anda tidak memiliki izin untuk melihat tiket ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk melihat tiket ini", fiber.StatusForbidden)
+		default:
+			return utils.ServerError(c, "Gagal mendapatkan detail tiket: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Detail tiket berhasil diambil", ticket)
+}
+
+func (h *TicketHandler) GetTicketQR(c *fiber.Ctx) error {
+	log.Println("GetTicketQR handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	png, err := h.ticketUsecase.GetTicketQR(c.Context(), userID, c.Params("code"))
+	if err != nil {
+		switch err.Error() {
+		case "tiket tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketNotFound, "Tiket tidak ditemukan", fiber.StatusNotFound)
+		case "anda tidak memiliki izin untuk melihat tiket ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk melihat tiket ini", fiber.StatusForbidden)
+		default:
+			return utils.ServerError(c, "Gagal membuat QR tiket: "+err.Error())
+		}
+	}
+	
+	// QR berisi data rahasia tiket, jangan disimpan oleh cache bersama
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Send(png)
+}
+
+func (h *TicketHandler) CheckIn(c *fiber.Ctx) error {
+	log.Println("CheckIn handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	organizerID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.CheckInRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	ticket, err := h.ticketUsecase.CheckIn(c.Context(), organizerID, eventID, req)
+	if err != nil {
+		switch err.Error() {
+		case "qr tiket harus diisi":
+			return utils.ValidationError(c, "Validasi gagal", []utils.ErrorDetail{
+				{Field: "qr_payload", Message: "QR tiket harus diisi"},
+			})
+		case "event tidak ditemukan":
+			return utils.EventNotFoundError(c, "Event tidak ditemukan")
+		case "anda tidak memiliki izin untuk melakukan check-in di event ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk melakukan check-in di event ini", fiber.StatusForbidden)
+		case "qr tiket tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketInvalidQR, "QR tiket tidak valid", fiber.StatusBadRequest)
+		case "tiket bukan untuk event ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketWrongEvent, "Tiket bukan untuk event ini", fiber.StatusUnprocessableEntity)
+		case "tiket sudah digunakan":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketAlreadyUsed, "Tiket sudah digunakan", fiber.StatusConflict, ticket)
+		case "tiket sudah di-refund":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketNotActive, "Tiket sudah di-refund", fiber.StatusConflict, ticket)
+		case "tiket sudah dibatalkan":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketNotActive, "Tiket sudah dibatalkan", fiber.StatusConflict, ticket)
+		default:
+			return utils.ServerError(c, "Gagal melakukan check-in: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Check-in berhasil", ticket)
+}
+
+func (h *TicketHandler) GetCheckInManifest(c *fiber.Ctx) error {
+	log.Println("GetCheckInManifest handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	organizerID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	manifest, err := h.ticketUsecase.GetCheckInManifest(c.Context(), organizerID, eventID)
+	if err != nil {
+		switch err.Error() {
+		case "event tidak ditemukan":
+			return utils.EventNotFoundError(c, "Event tidak ditemukan")
+		case "anda tidak memiliki izin untuk melakukan check-in di event ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk melakukan check-in di event ini", fiber.StatusForbidden)
+		case "manifest check-in offline belum dikonfigurasi":
+			return utils.ErrorResponse(c, utils.ErrorCodeServiceUnavailable, "Manifest check-in offline belum dikonfigurasi", fiber.StatusServiceUnavailable)
+		default:
+			return utils.ServerError(c, "Gagal membuat manifest check-in: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Manifest check-in berhasil dibuat", manifest)
+}
+
+func (h *TicketHandler) GetCheckInPublicKey(c *fiber.Ctx) error {
+	log.Println("GetCheckInPublicKey handler called with path:", c.Path())
+	
+	publicKey, err := h.ticketUsecase.GetCheckInPublicKey(c.Context())
+	if err != nil {
+		switch err.Error() {
+		case "manifest check-in offline belum dikonfigurasi":
+			return utils.ErrorResponse(c, utils.ErrorCodeServiceUnavailable, "Manifest check-in offline belum dikonfigurasi", fiber.StatusServiceUnavailable)
+		default:
+			return utils.ServerError(c, "Gagal mengambil public key check-in: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Public key check-in berhasil diambil", publicKey)
+}
+
+func (h *TicketHandler) SyncCheckIns(c *fiber.Ctx) error {
+	log.Println("SyncCheckIns handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	organizerID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.CheckInSyncRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	result, err := h.ticketUsecase.SyncCheckIns(c.Context(), organizerID, eventID, req)
+	if err != nil {
+		switch err.Error() {
+		case "device id harus diisi":
+			return utils.ValidationError(c, "Validasi gagal", []utils.ErrorDetail{
+				{Field: "device_id", Message: "Device ID harus diisi"},
+			})
+		case "data scan tidak boleh kosong":
+			return utils.ValidationError(c, "Validasi gagal", []utils.ErrorDetail{
+				{Field: "scans", Message: "Data scan tidak boleh kosong"},
+			})
+		case "jumlah scan melebihi batas per sinkronisasi":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceLimit, "Jumlah scan melebihi batas per sinkronisasi", fiber.StatusBadRequest)
+		case "event tidak ditemukan":
+			return utils.EventNotFoundError(c, "Event tidak ditemukan")
+		case "anda tidak memiliki izin untuk melakukan check-in di event ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk melakukan check-in di event ini", fiber.StatusForbidden)
+		default:
+			return utils.ServerError(c, "Gagal menyinkronkan data check-in: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Data check-in berhasil disinkronkan", result)
+}
+
+func (h *TicketHandler) GetAttendance(c *fiber.Ctx) error {
+	log.Println("GetAttendance handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	organizerID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	attendance, err := h.ticketUsecase.GetAttendance(c.Context(), organizerID, eventID)
+	if err != nil {
+		switch err.Error() {
+		case "event tidak ditemukan":
+			return utils.EventNotFoundError(c, "Event tidak ditemukan")
+		case "anda tidak memiliki izin untuk melakukan check-in di event ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk melakukan check-in di event ini", fiber.StatusForbidden)
+		default:
+			return utils.ServerError(c, "Gagal mendapatkan data kehadiran: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Data kehadiran berhasil diambil", attendance)
+}
@@ -0,0 +1,170 @@
+//internal/delivery/http/handler/ticket_transfer_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type TicketTransferHandler struct {
+	transferUsecase usecase.TicketTransferUsecase
+}
+
+func NewTicketTransferHandler(transferUsecase usecase.TicketTransferUsecase) *TicketTransferHandler {
+	return &TicketTransferHandler{
+		transferUsecase: transferUsecase,
+	}
+}
+
+func (h *TicketTransferHandler) InitiateTransfer(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.InitiateTransferRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	transfer, err := h.transferUsecase.InitiateTransfer(c.Context(), userID, c.Params("code"), req)
+	if err != nil {
+		return ticketTransferErrorResponse(c, err, "Gagal mengirim transfer tiket: ")
+	}
+	
+	return utils.CreatedResponse(c, "Transfer tiket berhasil dikirim, menunggu konfirmasi penerima", transfer)
+}
+
+func (h *TicketTransferHandler) GetTicketTransfers(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transfers, err := h.transferUsecase.GetTicketTransfers(c.Context(), userID, c.Params("code"))
+	if err != nil {
+		return ticketTransferErrorResponse(c, err, "Gagal mendapatkan riwayat transfer tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Riwayat transfer tiket berhasil diambil", transfers)
+}
+
+func (h *TicketTransferHandler) GetPendingTransfers(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transfers, err := h.transferUsecase.GetPendingTransfers(c.Context(), userID)
+	if err != nil {
+		return ticketTransferErrorResponse(c, err, "Gagal mendapatkan daftar transfer tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Daftar transfer tiket berhasil diambil", transfers)
+}
+
+func (h *TicketTransferHandler) AcceptTransfer(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transferID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID transfer tidak valid", fiber.StatusBadRequest)
+	}
+	
+	transfer, err := h.transferUsecase.AcceptTransfer(c.Context(), userID, transferID)
+	if err != nil {
+		return ticketTransferErrorResponse(c, err, "Gagal menerima transfer tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Transfer tiket berhasil diterima", transfer)
+}
+
+func (h *TicketTransferHandler) DeclineTransfer(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transferID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID transfer tidak valid", fiber.StatusBadRequest)
+	}
+	
+	transfer, err := h.transferUsecase.DeclineTransfer(c.Context(), userID, transferID)
+	if err != nil {
+		return ticketTransferErrorResponse(c, err, "Gagal menolak transfer tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Transfer tiket berhasil ditolak", transfer)
+}
+
+func (h *TicketTransferHandler) CancelTransfer(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transferID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID transfer tidak valid", fiber.StatusBadRequest)
+	}
+	
+	transfer, err := h.transferUsecase.CancelTransfer(c.Context(), userID, transferID)
+	if err != nil {
+		return ticketTransferErrorResponse(c, err, "Gagal membatalkan transfer tiket: ")
+	}
+	
+	return utils.SuccessResponse(c, "Transfer tiket berhasil dibatalkan", transfer)
+}
+
+func ticketTransferErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "tiket tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketNotFound, "Tiket tidak ditemukan", fiber.StatusNotFound)
+	case "transfer tiket tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketTransferNotFound, "Transfer tiket tidak ditemukan", fiber.StatusNotFound)
+	case "event terkait tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk memindahtangankan tiket ini",
+		"anda tidak memiliki izin untuk melihat tiket ini",
+		"anda tidak memiliki izin untuk transfer tiket ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, err.Error(), fiber.StatusForbidden)
+	case "penerima transfer harus diisi":
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Penerima transfer harus diisi", fiber.StatusBadRequest)
+	case "penerima transfer tidak ditemukan", "tiket tidak dapat dipindahtangankan ke akun sendiri":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketTransferRecipient, err.Error(), fiber.StatusBadRequest)
+	case "hanya tiket aktif yang dapat dipindahtangankan",
+		"tiket untuk event yang tidak aktif tidak dapat dipindahtangankan",
+		"event ini tidak mengizinkan transfer tiket",
+		"batas waktu transfer tiket sudah lewat",
+		"tiket sudah mencapai batas jumlah transfer",
+		"tiket dengan pengajuan refund tidak dapat dipindahtangankan":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketTransferNotAllowed, err.Error(), fiber.StatusBadRequest)
+	case "tiket masih memiliki transfer yang menunggu konfirmasi":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketTransferExists, "Tiket masih memiliki transfer yang menunggu konfirmasi", fiber.StatusConflict)
+	case "transfer tiket sudah diproses", "status tiket atau transfer sudah berubah, silakan muat ulang":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketTransferConflict, err.Error(), fiber.StatusConflict)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
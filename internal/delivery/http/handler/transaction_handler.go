@@ -3,6 +3,7 @@
 package handler
 
 import (
+	"io"
 	"log"
 	"strconv"
 	"github.com/gofiber/fiber/v2"
@@ -46,7 +47,8 @@ func (h *TransactionHandler) CreateTransaction(c *fiber.Ctx) error {
 		})
 	}
 	
-	if req.Quantity <= 0 {
+	// Untuk event dengan tipe tiket atau kursi bernomor, jumlah dihitung dari items atau seat_ids
+	if len(req.Items) == 0 && len(req.SeatIDs) == 0 && req.Quantity <= 0 {
 		validationErrors = append(validationErrors, utils.ErrorDetail{
 			Field:   "quantity",
 			Message: "Jumlah tiket harus lebih dari 0",
@@ -77,10 +79,50 @@ func (h *TransactionHandler) CreateTransaction(c *fiber.Ctx) error {
 			return utils.ErrorResponse(c, utils.ErrorCodeTicketSoldOut, "Tidak cukup tiket tersedia", fiber.StatusBadRequest)
 		case "jumlah tiket harus lebih dari 0":
 			return utils.ErrorResponse(c, utils.ErrorCodeTicketInvalidQuantity, "Jumlah tiket harus lebih dari 0", fiber.StatusBadRequest)
+		case "tipe tiket harus dipilih":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketTypeRequired, "Tipe tiket harus dipilih", fiber.StatusBadRequest)
+		case "tipe tiket tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketTypeNotFound, "Tipe tiket tidak ditemukan", fiber.StatusNotFound)
+		case "tipe tiket tidak sedang dijual":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketTypeNotOnSale, "Tipe tiket tidak sedang dijual", fiber.StatusBadRequest)
+		case "jumlah tiket melebihi batas pembelian per transaksi":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketTypeLimit, "Jumlah tiket melebihi batas pembelian per transaksi", fiber.StatusBadRequest)
+		case "kuota tipe tiket tidak mencukupi":
+			return utils.ErrorResponse(c, utils.ErrorCodeTicketSoldOut, "Kuota tipe tiket tidak mencukupi", fiber.StatusBadRequest)
+		case "kode promo tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodePromoNotFound, "Kode promo tidak valid", fiber.StatusBadRequest)
+		case "kode promo tidak berlaku saat ini":
+			return utils.ErrorResponse(c, utils.ErrorCodePromoNotApplicable, "Kode promo tidak berlaku saat ini", fiber.StatusBadRequest)
+		case "jumlah tiket belum memenuhi minimum kode promo":
+			return utils.ErrorResponse(c, utils.ErrorCodePromoNotApplicable, "Jumlah tiket belum memenuhi minimum kode promo", fiber.StatusBadRequest)
+		case "kode promo sudah habis digunakan":
+			return utils.ErrorResponse(c, utils.ErrorCodePromoExhausted, "Kode promo sudah habis digunakan", fiber.StatusConflict)
+		case "batas pemakaian kode promo untuk akun anda sudah tercapai":
+			return utils.ErrorResponse(c, utils.ErrorCodePromoExhausted, "Batas pemakaian kode promo untuk akun anda sudah tercapai", fiber.StatusConflict)
 		case "metode pembayaran harus dipilih":
 			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Metode pembayaran harus dipilih", fiber.StatusBadRequest)
 		case "metode pembayaran tidak valid":
 			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Metode pembayaran tidak valid", fiber.StatusBadRequest)
+		case "pembayaran midtrans hanya mendukung mata uang IDR":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Pembayaran Midtrans hanya mendukung mata uang IDR", fiber.StatusBadRequest)
+		case "mata uang biaya layanan tidak sesuai dengan mata uang event":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Mata uang biaya layanan tidak sesuai dengan mata uang event", fiber.StatusBadRequest)
+		case "gagal membuat pembayaran, silakan coba lagi":
+			return utils.ErrorResponse(c, utils.ErrorCodeExternalServiceError, "Gagal membuat pembayaran, silakan coba lagi", fiber.StatusBadGateway)
+		case "penawaran waitlist tidak ditemukan atau sudah tidak berlaku":
+			return utils.ErrorResponse(c, utils.ErrorCodeWaitlistOfferInvalid, "Penawaran waitlist tidak ditemukan atau sudah tidak berlaku", fiber.StatusConflict)
+		case "jumlah tiket harus sama dengan jumlah pada penawaran waitlist":
+			return utils.ErrorResponse(c, utils.ErrorCodeWaitlistOfferInvalid, "Jumlah tiket harus sama dengan jumlah pada penawaran waitlist", fiber.StatusBadRequest)
+		case "kursi harus dipilih untuk event dengan denah venue":
+			return utils.ErrorResponse(c, utils.ErrorCodeSeatSelection, "Kursi harus dipilih untuk event dengan denah venue", fiber.StatusBadRequest)
+		case "kursi yang dipilih tidak boleh duplikat":
+			return utils.ErrorResponse(c, utils.ErrorCodeSeatSelection, "Kursi yang dipilih tidak boleh duplikat", fiber.StatusBadRequest)
+		case "jumlah tiket harus sama dengan jumlah kursi yang dipilih":
+			return utils.ErrorResponse(c, utils.ErrorCodeSeatSelection, "Jumlah tiket harus sama dengan jumlah kursi yang dipilih", fiber.StatusBadRequest)
+		case "event tidak memiliki kursi bernomor":
+			return utils.ErrorResponse(c, utils.ErrorCodeSeatNotFound, "Event tidak memiliki kursi bernomor", fiber.StatusBadRequest)
+		case "hold kursi tidak ditemukan atau sudah kedaluwarsa":
+			return utils.ErrorResponse(c, utils.ErrorCodeSeatHoldExpired, "Hold kursi tidak ditemukan atau sudah kedaluwarsa, silakan pilih kursi lagi", fiber.StatusConflict)
 		default:
 			return utils.ServerError(c, "Gagal membuat transaksi: "+err.Error())
 		}
@@ -110,7 +152,7 @@ func (h *TransactionHandler) GetTransactionByID(c *fiber.Ctx) error {
 		case "transaksi tidak ditemukan":
 			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Transaksi tidak ditemukan", fiber.StatusNotFound)
 		case "anda tidak memiliki izin untuk melihat transaksi ini":
-			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk melihat transaksi ini", fiber.StatusForbidden)
+			return utils.ErrorResponse(c, utils.ErrorCodeTransactionAccessDenied, "Anda tidak memiliki izin untuk melihat transaksi ini", fiber.StatusForbidden)
 		case "event terkait tidak ditemukan":
 			return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event terkait tidak ditemukan", fiber.StatusNotFound)
 		default:
@@ -142,7 +184,7 @@ func (h *TransactionHandler) GetTransactionByCode(c *fiber.Ctx) error {
 		case "transaksi tidak ditemukan":
 			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Transaksi tidak ditemukan", fiber.StatusNotFound)
 		case "anda tidak memiliki izin untuk melihat transaksi ini":
-			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk melihat transaksi ini", fiber.StatusForbidden)
+			return utils.ErrorResponse(c, utils.ErrorCodeTransactionAccessDenied, "Anda tidak memiliki izin untuk melihat transaksi ini", fiber.StatusForbidden)
 		case "event terkait tidak ditemukan":
 			return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event terkait tidak ditemukan", fiber.StatusNotFound)
 		default:
@@ -197,10 +239,8 @@ func (h *TransactionHandler) UploadPaymentProof(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
 	}
 	
-	var req usecase.UploadPaymentProofRequest
-	
-	if err := c.BodyParser(&req); err != nil {
-		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	req := usecase.UploadPaymentProofRequest{
+		TransactionID: c.FormValue("transaction_id"),
 	}
 	
 	var validationErrors []utils.ErrorDetail
@@ -212,10 +252,11 @@ func (h *TransactionHandler) UploadPaymentProof(c *fiber.Ctx) error {
 		})
 	}
 	
-	if req.ProofURL == "" {
+	fileHeader, err := c.FormFile("proof")
+	if err != nil {
 		validationErrors = append(validationErrors, utils.ErrorDetail{
-			Field:   "proof_url",
-			Message: "URL bukti pembayaran tidak boleh kosong",
+			Field:   "proof",
+			Message: "File bukti pembayaran tidak boleh kosong",
 		})
 	}
 	
@@ -223,6 +264,18 @@ func (h *TransactionHandler) UploadPaymentProof(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "Validasi gagal", validationErrors)
 	}
 	
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "File bukti pembayaran tidak dapat dibaca", fiber.StatusBadRequest)
+	}
+	defer file.Close()
+	
+	req.FileName = fileHeader.Filename
+	req.Content, err = io.ReadAll(file)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "File bukti pembayaran tidak dapat dibaca", fiber.StatusBadRequest)
+	}
+	
 	err = h.transactionUsecase.UploadPaymentProof(c.Context(), userID, req)
 	if err != nil {
 		switch err.Error() {
@@ -234,8 +287,18 @@ func (h *TransactionHandler) UploadPaymentProof(c *fiber.Ctx) error {
 			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk transaksi ini", fiber.StatusForbidden)
 		case "bukti pembayaran hanya dapat diunggah untuk transaksi dengan status pending":
 			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Bukti pembayaran hanya dapat diunggah untuk transaksi dengan status pending", fiber.StatusBadRequest)
-		case "URL bukti pembayaran tidak boleh kosong":
-			return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "URL bukti pembayaran tidak boleh kosong", fiber.StatusBadRequest)
+		case "file bukti pembayaran tidak boleh kosong":
+			return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "File bukti pembayaran tidak boleh kosong", fiber.StatusBadRequest)
+		case "ukuran file bukti pembayaran melebihi batas":
+			return utils.ErrorResponse(c, utils.ErrorCodeFileTooLarge, "Ukuran file bukti pembayaran melebihi batas", fiber.StatusRequestEntityTooLarge)
+		case "tipe file bukti pembayaran harus JPG, PNG, atau PDF":
+			return utils.ErrorResponse(c, utils.ErrorCodeUnsupportedFileType, "Tipe file bukti pembayaran harus JPG, PNG, atau PDF", fiber.StatusUnsupportedMediaType)
+		case "file bukti pembayaran terdeteksi mengandung virus":
+			return utils.ErrorResponse(c, utils.ErrorCodeInfectedFile, "File bukti pembayaran terdeteksi mengandung virus", fiber.StatusUnprocessableEntity)
+		case "status transaksi sudah berubah, silakan coba lagi":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "Status transaksi sudah berubah, silakan coba lagi", fiber.StatusConflict)
+		case "perubahan status transaksi tidak diizinkan":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Perubahan status transaksi tidak diizinkan", fiber.StatusBadRequest)
 		default:
 			return utils.ServerError(c, "Gagal mengunggah bukti pembayaran: "+err.Error())
 		}
@@ -268,6 +331,10 @@ func (h *TransactionHandler) CancelTransaction(c *fiber.Ctx) error {
 			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk transaksi ini", fiber.StatusForbidden)
 		case "hanya transaksi dengan status pending yang dapat dibatalkan":
 			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Hanya transaksi dengan status pending yang dapat dibatalkan", fiber.StatusBadRequest)
+		case "status transaksi sudah berubah, silakan coba lagi":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "Status transaksi sudah berubah, silakan coba lagi", fiber.StatusConflict)
+		case "perubahan status transaksi tidak diizinkan":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Perubahan status transaksi tidak diizinkan", fiber.StatusBadRequest)
 		default:
 			return utils.ServerError(c, "Gagal membatalkan transaksi: "+err.Error())
 		}
@@ -298,12 +365,68 @@ func (h *TransactionHandler) VerifyPayment(c *fiber.Ctx) error {
 			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Hanya organizer yang dapat memverifikasi pembayaran", fiber.StatusForbidden)
 		case "transaksi tidak ditemukan":
 			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Transaksi tidak ditemukan", fiber.StatusNotFound)
+		case "event terkait tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event terkait tidak ditemukan", fiber.StatusNotFound)
+		case "anda tidak memiliki izin untuk memverifikasi pembayaran transaksi ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeTransactionOwnership, "Anda tidak memiliki izin untuk memverifikasi pembayaran transaksi ini", fiber.StatusForbidden)
 		case "hanya transaksi dengan status menunggu verifikasi yang dapat diverifikasi":
 			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Hanya transaksi dengan status menunggu verifikasi yang dapat diverifikasi", fiber.StatusBadRequest)
+		case "status transaksi sudah berubah, silakan coba lagi":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "Status transaksi sudah berubah, silakan coba lagi", fiber.StatusConflict)
+		case "perubahan status transaksi tidak diizinkan":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Perubahan status transaksi tidak diizinkan", fiber.StatusBadRequest)
 		default:
 			return utils.ServerError(c, "Gagal memverifikasi pembayaran: "+err.Error())
 		}
 	}
 	
 	return utils.SuccessResponse(c, "Pembayaran berhasil diverifikasi", nil)
+}
+
+func (h *TransactionHandler) RejectPayment(c *fiber.Ctx) error {
+	log.Println("RejectPayment handler called with path:", c.Path())
+	
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	organizerID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	transactionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID transaksi tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.RejectPaymentRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	err = h.transactionUsecase.RejectPayment(c.Context(), organizerID, transactionID, req)
+	if err != nil {
+		switch err.Error() {
+		case "hanya organizer yang dapat menolak pembayaran":
+			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Hanya organizer yang dapat menolak pembayaran", fiber.StatusForbidden)
+		case "alasan penolakan harus diisi":
+			return utils.ValidationError(c, "Validasi gagal", []utils.ErrorDetail{
+				{Field: "reason", Message: "Alasan penolakan harus diisi"},
+			})
+		case "transaksi tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "Transaksi tidak ditemukan", fiber.StatusNotFound)
+		case "event terkait tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event terkait tidak ditemukan", fiber.StatusNotFound)
+		case "anda tidak memiliki izin untuk menolak pembayaran transaksi ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeTransactionOwnership, "Anda tidak memiliki izin untuk menolak pembayaran transaksi ini", fiber.StatusForbidden)
+		case "hanya transaksi dengan status menunggu verifikasi yang dapat ditolak":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Hanya transaksi dengan status menunggu verifikasi yang dapat ditolak", fiber.StatusBadRequest)
+		case "status transaksi sudah berubah, silakan coba lagi":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "Status transaksi sudah berubah, silakan coba lagi", fiber.StatusConflict)
+		default:
+			return utils.ServerError(c, "Gagal menolak pembayaran: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Pembayaran berhasil ditolak", nil)
 }
\ No newline at end of file
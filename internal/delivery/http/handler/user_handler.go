@@ -113,6 +113,71 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, "Login berhasil", resp)
 }
 
+func (h *UserHandler) RefreshToken(c *fiber.Ctx) error {
+	var req usecase.RefreshTokenRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	if req.RefreshToken == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Refresh token tidak boleh kosong", fiber.StatusBadRequest)
+	}
+	
+	resp, err := h.userUsecase.RefreshToken(c.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "refresh token tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Refresh token tidak valid", fiber.StatusUnauthorized)
+		case "refresh token sudah kedaluwarsa":
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Refresh token sudah kedaluwarsa, silakan login kembali", fiber.StatusUnauthorized)
+		case "refresh token sudah pernah dipakai, silakan login kembali":
+			return utils.ErrorResponse(c, utils.ErrorCodeRefreshTokenReused, "Refresh token sudah pernah dipakai, seluruh sesi dicabut. Silakan login kembali", fiber.StatusUnauthorized)
+		default:
+			return utils.ServerError(c, "Gagal memperbarui token: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Token berhasil diperbarui", resp)
+}
+
+
+func (h *UserHandler) Logout(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	var req usecase.LogoutRequest
+	
+	// Body opsional, hanya berisi refresh token yang ikut dicabut
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+		}
+	}
+	
+	if err := h.userUsecase.Logout(c.Context(), claims, req); err != nil {
+		if err.Error() == "token tidak valid" {
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+		}
+		return utils.ServerError(c, "Gagal logout: "+err.Error())
+	}
+	
+	return utils.SuccessResponse(c, "Logout berhasil", nil)
+}
+
+func (h *UserHandler) LogoutAll(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	if err := h.userUsecase.LogoutAll(c.Context(), userID); err != nil {
+		return utils.ServerError(c, "Gagal logout dari semua perangkat: "+err.Error())
+	}
+	
+	return utils.SuccessResponse(c, "Logout dari semua perangkat berhasil", nil)
+}
 
 func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	claims := c.Locals("claims").(*utils.JWTClaim)
@@ -201,4 +266,301 @@ func (h *UserHandler) ResendVerificationEmail(c *fiber.Ctx) error {
 	}
 	
 	return utils.SuccessResponse(c, "Email verifikasi berhasil dikirim ulang", nil)
-}
\ No newline at end of file
+}
+func (h *UserHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req usecase.ForgotPasswordRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	if req.Email == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Email tidak boleh kosong", fiber.StatusBadRequest)
+	}
+	
+	err := h.userUsecase.ForgotPassword(c.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "format email tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidFormat, "Format email tidak valid", fiber.StatusBadRequest)
+		default:
+			return utils.ServerError(c, "Gagal memproses permintaan reset password: "+err.Error())
+		}
+	}
+	
+	// Pesan sama untuk email terdaftar maupun tidak agar keberadaan akun tidak bocor
+	return utils.SuccessResponse(c, "Jika email terdaftar, tautan reset password sudah dikirim", nil)
+}
+
+func (h *UserHandler) ResetPassword(c *fiber.Ctx) error {
+	var req usecase.ResetPasswordRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var validationErrors []utils.ErrorDetail
+	
+	if req.Token == "" {
+		validationErrors = append(validationErrors, utils.ErrorDetail{
+			Field:   "token",
+			Message: "Token tidak boleh kosong",
+		})
+	}
+	
+	if req.Password == "" {
+		validationErrors = append(validationErrors, utils.ErrorDetail{
+			Field:   "password",
+			Message: "Password tidak boleh kosong",
+		})
+	}
+	
+	if len(validationErrors) > 0 {
+		return utils.ValidationError(c, "Validasi gagal", validationErrors)
+	}
+	
+	err := h.userUsecase.ResetPassword(c.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "password harus minimal 6 karakter":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidFormat, "Password harus minimal 6 karakter", fiber.StatusBadRequest)
+		case "password dan retype password tidak cocok":
+			return utils.ErrorResponse(c, utils.ErrorCodePasswordMismatch, "Password dan konfirmasi password tidak cocok", fiber.StatusBadRequest)
+		case "token reset password tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token reset password tidak valid", fiber.StatusBadRequest)
+		case "token reset password sudah kedaluwarsa":
+			return utils.ErrorResponse(c, utils.ErrorCodeVerificationExpired, "Token reset password sudah kedaluwarsa", fiber.StatusBadRequest)
+		default:
+			return utils.ServerError(c, "Gagal reset password: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Password berhasil direset, silakan login kembali", nil)
+}
+
+func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.ChangePasswordRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var validationErrors []utils.ErrorDetail
+	
+	if req.CurrentPassword == "" {
+		validationErrors = append(validationErrors, utils.ErrorDetail{
+			Field:   "current_password",
+			Message: "Password saat ini tidak boleh kosong",
+		})
+	}
+	
+	if req.NewPassword == "" {
+		validationErrors = append(validationErrors, utils.ErrorDetail{
+			Field:   "new_password",
+			Message: "Password baru tidak boleh kosong",
+		})
+	}
+	
+	if len(validationErrors) > 0 {
+		return utils.ValidationError(c, "Validasi gagal", validationErrors)
+	}
+	
+	err = h.userUsecase.ChangePassword(c.Context(), userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "password harus minimal 6 karakter":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidFormat, "Password harus minimal 6 karakter", fiber.StatusBadRequest)
+		case "password dan retype password tidak cocok":
+			return utils.ErrorResponse(c, utils.ErrorCodePasswordMismatch, "Password dan konfirmasi password tidak cocok", fiber.StatusBadRequest)
+		case "password saat ini salah":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidCredentials, "Password saat ini salah", fiber.StatusBadRequest)
+		case "password baru harus berbeda dari password saat ini":
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Password baru harus berbeda dari password saat ini", fiber.StatusBadRequest)
+		case "user tidak ditemukan":
+			return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "User tidak ditemukan", fiber.StatusNotFound)
+		default:
+			return utils.ServerError(c, "Gagal mengganti password: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Password berhasil diganti, silakan login kembali", nil)
+}
+
+func (h *UserHandler) LoginMFA(c *fiber.Ctx) error {
+	var req usecase.LoginMFARequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	if req.MFAToken == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "MFA token tidak boleh kosong", fiber.StatusBadRequest)
+	}
+	
+	if req.Code == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Kode 2FA tidak boleh kosong", fiber.StatusBadRequest)
+	}
+	
+	resp, err := h.userUsecase.LoginMFA(c.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "token mfa tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "MFA token tidak valid atau sudah kedaluwarsa, silakan login kembali", fiber.StatusUnauthorized)
+		case "kode 2fa tidak valid":
+			return utils.ErrorResponse(c, utils.ErrorCodeMFAInvalid, "Kode 2FA tidak valid", fiber.StatusUnauthorized)
+		default:
+			return utils.ServerError(c, "Gagal melakukan login: "+err.Error())
+		}
+	}
+	
+	return utils.SuccessResponse(c, "Login berhasil", resp)
+}
+
+func (h *UserHandler) GetMFAStatus(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	status, err := h.userUsecase.GetMFAStatus(c.Context(), userID)
+	if err != nil {
+		return utils.ServerError(c, "Gagal mendapatkan status 2FA: "+err.Error())
+	}
+	
+	return utils.SuccessResponse(c, "Status 2FA berhasil diambil", status)
+}
+
+func (h *UserHandler) SetupMFA(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	setup, err := h.userUsecase.SetupMFA(c.Context(), userID)
+	if err != nil {
+		return mfaErrorResponse(c, err, "Gagal menyiapkan 2FA: ")
+	}
+	
+	return utils.SuccessResponse(c, "Pindai QR code dengan aplikasi authenticator lalu konfirmasi dengan kode 6 digit", setup)
+}
+
+func (h *UserHandler) EnableMFA(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.MFACodeRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	if req.Code == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Kode 2FA tidak boleh kosong", fiber.StatusBadRequest)
+	}
+	
+	codes, err := h.userUsecase.EnableMFA(c.Context(), userID, req)
+	if err != nil {
+		return mfaErrorResponse(c, err, "Gagal mengaktifkan 2FA: ")
+	}
+	
+	return utils.SuccessResponse(c, "2FA berhasil diaktifkan. Simpan recovery code di tempat aman lalu login kembali", codes)
+}
+
+func (h *UserHandler) DisableMFA(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.DisableMFARequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var validationErrors []utils.ErrorDetail
+	
+	if req.Password == "" {
+		validationErrors = append(validationErrors, utils.ErrorDetail{
+			Field:   "password",
+			Message: "Password tidak boleh kosong",
+		})
+	}
+	
+	if req.Code == "" {
+		validationErrors = append(validationErrors, utils.ErrorDetail{
+			Field:   "code",
+			Message: "Kode 2FA tidak boleh kosong",
+		})
+	}
+	
+	if len(validationErrors) > 0 {
+		return utils.ValidationError(c, "Validasi gagal", validationErrors)
+	}
+	
+	if err := h.userUsecase.DisableMFA(c.Context(), userID, req); err != nil {
+		return mfaErrorResponse(c, err, "Gagal menonaktifkan 2FA: ")
+	}
+	
+	return utils.SuccessResponse(c, "2FA berhasil dinonaktifkan", nil)
+}
+
+func (h *UserHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	var req usecase.MFACodeRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	if req.Code == "" {
+		return utils.ErrorResponse(c, utils.ErrorCodeMissingRequiredField, "Kode 2FA tidak boleh kosong", fiber.StatusBadRequest)
+	}
+	
+	codes, err := h.userUsecase.RegenerateRecoveryCodes(c.Context(), userID, req)
+	if err != nil {
+		return mfaErrorResponse(c, err, "Gagal membuat recovery code baru: ")
+	}
+	
+	return utils.SuccessResponse(c, "Recovery code baru berhasil dibuat, recovery code lama tidak berlaku lagi", codes)
+}
+
+func mfaErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "kode 2fa tidak valid":
+		return utils.ErrorResponse(c, utils.ErrorCodeMFAInvalid, "Kode 2FA tidak valid", fiber.StatusBadRequest)
+	case "password saat ini salah":
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidCredentials, "Password saat ini salah", fiber.StatusBadRequest)
+	case "2fa sudah aktif":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceAlreadyExist, "2FA sudah aktif", fiber.StatusConflict)
+	case "2fa belum di-setup", "2fa belum aktif":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "2FA belum aktif, jalankan setup terlebih dahulu", fiber.StatusBadRequest)
+	case "user tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceNotFound, "User tidak ditemukan", fiber.StatusNotFound)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
@@ -0,0 +1,159 @@
+//internal/delivery/http/handler/venue_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type VenueHandler struct {
+	venueUsecase usecase.VenueUsecase
+}
+
+func NewVenueHandler(venueUsecase usecase.VenueUsecase) *VenueHandler {
+	return &VenueHandler{
+		venueUsecase: venueUsecase,
+	}
+}
+
+func (h *VenueHandler) CreateVenue(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	var req usecase.CreateVenueRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+
+	venue, err := h.venueUsecase.CreateVenue(c.Context(), userID, req)
+	if err != nil {
+		return venueErrorResponse(c, err, "Gagal membuat venue: ")
+	}
+
+	return utils.CreatedResponse(c, "Venue berhasil dibuat", venue)
+}
+
+func (h *VenueHandler) GetVenues(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	venues, err := h.venueUsecase.GetVenues(c.Context(), userID)
+	if err != nil {
+		return utils.ServerError(c, "Gagal mendapatkan daftar venue: "+err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Daftar venue berhasil diambil", venues)
+}
+
+func (h *VenueHandler) GetVenue(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	venueID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID venue tidak valid", fiber.StatusBadRequest)
+	}
+
+	venue, err := h.venueUsecase.GetVenue(c.Context(), venueID, userID)
+	if err != nil {
+		return venueErrorResponse(c, err, "Gagal mendapatkan venue: ")
+	}
+
+	return utils.SuccessResponse(c, "Venue berhasil diambil", venue)
+}
+
+func (h *VenueHandler) DeleteVenue(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	venueID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID venue tidak valid", fiber.StatusBadRequest)
+	}
+
+	if err := h.venueUsecase.DeleteVenue(c.Context(), venueID, userID); err != nil {
+		return venueErrorResponse(c, err, "Gagal menghapus venue: ")
+	}
+
+	return utils.SuccessResponse(c, "Venue berhasil dihapus", nil)
+}
+
+func (h *VenueHandler) AssignVenue(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+
+	var req usecase.AssignVenueRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+
+	if req.VenueID <= 0 {
+		return utils.ValidationError(c, "Validasi gagal", []utils.ErrorDetail{
+			{Field: "venue_id", Message: "ID venue tidak valid"},
+		})
+	}
+
+	event, err := h.venueUsecase.AssignVenue(c.Context(), eventID, userID, req)
+	if err != nil {
+		return venueErrorResponse(c, err, "Gagal memasang venue ke event: ")
+	}
+
+	return utils.SuccessResponse(c, "Venue event berhasil diatur", event)
+}
+
+func venueErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "nama venue harus diisi", "nama section harus diisi", "label baris harus diisi", "nomor kursi harus diisi",
+		"nomor kursi tidak boleh duplikat dalam satu baris", "venue harus memiliki minimal satu kursi":
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, err.Error(), fiber.StatusBadRequest)
+	case "jumlah kursi venue melebihi batas maksimal":
+		return utils.ErrorResponse(c, utils.ErrorCodeResourceLimit, "Jumlah kursi venue melebihi batas maksimal", fiber.StatusBadRequest)
+	case "venue tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeVenueNotFound, "Venue tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk mengelola venue ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeVenueOwnership, "Anda tidak memiliki izin untuk mengelola venue ini", fiber.StatusForbidden)
+	case "venue masih dipakai oleh event":
+		return utils.ErrorResponse(c, utils.ErrorCodeVenueInUse, "Venue masih dipakai oleh event", fiber.StatusConflict)
+	case "event tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "anda tidak memiliki izin untuk mengatur venue event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventOwnership, "Anda tidak memiliki izin untuk mengatur venue event ini", fiber.StatusForbidden)
+	case "venue tidak dapat diubah setelah tiket terjual":
+		return utils.ErrorResponse(c, utils.ErrorCodeVenueInUse, "Venue tidak dapat diubah setelah tiket terjual", fiber.StatusConflict)
+	case "total kuota tipe tiket melebihi jumlah kursi venue":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventCapacityLow, "Total kuota tipe tiket melebihi jumlah kursi venue", fiber.StatusBadRequest)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
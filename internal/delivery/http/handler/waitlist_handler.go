@@ -0,0 +1,110 @@
+//internal/delivery/http/handler/waitlist_handler.go
+
+package handler
+
+import (
+	"strconv"
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type WaitlistHandler struct {
+	waitlistUsecase usecase.WaitlistUsecase
+}
+
+func NewWaitlistHandler(waitlistUsecase usecase.WaitlistUsecase) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistUsecase: waitlistUsecase,
+	}
+}
+
+func (h *WaitlistHandler) JoinWaitlist(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	var req usecase.JoinWaitlistRequest
+	
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Format JSON tidak valid", fiber.StatusBadRequest)
+	}
+	
+	entry, err := h.waitlistUsecase.JoinWaitlist(c.Context(), userID, eventID, req)
+	if err != nil {
+		return waitlistErrorResponse(c, err, "Gagal mendaftar waitlist: ")
+	}
+	
+	return utils.CreatedResponse(c, "Berhasil masuk waitlist", entry)
+}
+
+func (h *WaitlistHandler) GetWaitlistEntry(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	entry, err := h.waitlistUsecase.GetWaitlistEntry(c.Context(), userID, eventID)
+	if err != nil {
+		return waitlistErrorResponse(c, err, "Gagal mendapatkan data waitlist: ")
+	}
+	
+	return utils.SuccessResponse(c, "Data waitlist berhasil diambil", entry)
+}
+
+func (h *WaitlistHandler) LeaveWaitlist(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*utils.JWTClaim)
+	
+	userID, err := utils.GetUserIDFromToken(claims)
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid", fiber.StatusUnauthorized)
+	}
+	
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "ID event tidak valid", fiber.StatusBadRequest)
+	}
+	
+	if err := h.waitlistUsecase.LeaveWaitlist(c.Context(), userID, eventID); err != nil {
+		return waitlistErrorResponse(c, err, "Gagal keluar dari waitlist: ")
+	}
+	
+	return utils.SuccessResponse(c, "Berhasil keluar dari waitlist", nil)
+}
+
+func waitlistErrorResponse(c *fiber.Ctx, err error, serverMessage string) error {
+	switch err.Error() {
+	case "event tidak ditemukan":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventNotFound, "Event tidak ditemukan", fiber.StatusNotFound)
+	case "event tidak aktif":
+		return utils.ErrorResponse(c, utils.ErrorCodeEventCancelled, "Event tidak aktif", fiber.StatusBadRequest)
+	case "jumlah tiket harus lebih dari 0", "jumlah tiket melebihi kapasitas event":
+		return utils.ErrorResponse(c, utils.ErrorCodeTicketInvalidQuantity, err.Error(), fiber.StatusBadRequest)
+	case "tiket masih tersedia, silakan langsung membeli":
+		return utils.ErrorResponse(c, utils.ErrorCodeWaitlistNotNeeded, "Tiket masih tersedia, silakan langsung membeli", fiber.StatusBadRequest)
+	case "anda sudah terdaftar di waitlist event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeWaitlistExists, "Anda sudah terdaftar di waitlist event ini", fiber.StatusConflict)
+	case "anda tidak terdaftar di waitlist event ini":
+		return utils.ErrorResponse(c, utils.ErrorCodeWaitlistNotFound, "Anda tidak terdaftar di waitlist event ini", fiber.StatusNotFound)
+	case "status waitlist sudah berubah, silakan muat ulang":
+		return utils.ErrorResponse(c, utils.ErrorCodeWaitlistOfferInvalid, "Status waitlist sudah berubah, silakan muat ulang", fiber.StatusConflict)
+	default:
+		return utils.ServerError(c, serverMessage+err.Error())
+	}
+}
@@ -7,16 +7,23 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"ticket-system/internal/usecase"
 	"ticket-system/pkg/utils"
 )
 
 type AuthMiddleware struct {
-	jwtSecret string
+	jwtSecret              string
+	tokenRevocationUsecase usecase.TokenRevocationUsecase
+	organizerMFARequired   bool
 }
 
-func NewAuthMiddleware(jwtSecret string) *AuthMiddleware {
+// NewAuthMiddleware membuat middleware auth. Jika organizerMFARequired true, RoleCheck menolak
+// organizer yang sesinya tidak dibuka lewat verifikasi 2FA.
+func NewAuthMiddleware(jwtSecret string, tokenRevocationUsecase usecase.TokenRevocationUsecase, organizerMFARequired bool) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtSecret: jwtSecret,
+		jwtSecret:              jwtSecret,
+		tokenRevocationUsecase: tokenRevocationUsecase,
+		organizerMFARequired:   organizerMFARequired,
 	}
 }
 
@@ -43,6 +50,22 @@ func (m *AuthMiddleware) AuthenticateJWT() fiber.Handler {
 			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid: "+err.Error(), fiber.StatusUnauthorized)
 		}
 
+		// Refresh token dan token lain yang bukan access token tidak boleh dipakai sebagai bearer token
+		if claims.TokenType != utils.TokenTypeAccess {
+			log.Printf("Auth failed: Token type '%s' is not an access token", claims.TokenType)
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, "Token tidak valid: bukan access token", fiber.StatusUnauthorized)
+		}
+
+		revoked, err := m.tokenRevocationUsecase.IsRevoked(c.Context(), claims)
+		if err != nil {
+			log.Printf("Auth failed: Revocation check error: %v", err)
+			return utils.ServerError(c, "Gagal memeriksa status token")
+		}
+		if revoked {
+			log.Printf("Auth failed: Token for user %s has been revoked", claims.Username)
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenRevoked, "Token sudah dicabut, silakan login kembali", fiber.StatusUnauthorized)
+		}
+
 		log.Printf("Token valid, user: %s, role: %s", claims.Username, claims.Role)
 		c.Locals("claims", claims)
 		return c.Next()
@@ -73,6 +96,13 @@ func (m *AuthMiddleware) RoleCheck(roles []string) fiber.Handler {
 			return utils.ErrorResponse(c, utils.ErrorCodeUnauthorized, "Anda tidak memiliki izin untuk mengakses resource ini. Role Anda: "+userRole, fiber.StatusForbidden)
 		}
 
+		// Organizer mengelola verifikasi pembayaran dan pencairan dana, sehingga bisa diwajibkan 2FA.
+		// Endpoint enrolment 2FA tidak memakai RoleCheck agar organizer tetap bisa mengaktifkannya.
+		if m.organizerMFARequired && userRole == "organizer" && !claims.MFA {
+			log.Printf("RoleCheck failed: Organizer '%s' has not completed MFA", claims.Username)
+			return utils.ErrorResponse(c, utils.ErrorCodeMFARequired, "Organizer wajib mengaktifkan 2FA dan login kembali", fiber.StatusForbidden)
+		}
+
 		log.Println("RoleCheck passed")
 		return c.Next()
 	}
@@ -0,0 +1,196 @@
+//internal/delivery/http/middleware/idempotency_middleware.go
+
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyKeyLocalsField = "idempotency_key"
+)
+
+type IdempotencyMiddleware struct {
+	idempotencyUsecase usecase.IdempotencyUsecase
+}
+
+func NewIdempotencyMiddleware(idempotencyUsecase usecase.IdempotencyUsecase) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		idempotencyUsecase: idempotencyUsecase,
+	}
+}
+
+// Handle menerapkan header Idempotency-Key pada request POST dan PUT. Harus dipasang setelah
+// AuthenticateJWT karena key disimpan per user. Respons pertama yang bukan 5xx disimpan dan
+// diputar ulang untuk retry dengan key dan body yang sama. Request tanpa header diteruskan
+// apa adanya.
+func (m *IdempotencyMiddleware) Handle() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodPost && c.Method() != fiber.MethodPut {
+			return c.Next()
+		}
+
+		idempotencyKey := c.Get(IdempotencyKeyHeader)
+		// Group dengan prefix yang sama bisa memasang middleware ini lebih dari sekali
+		if idempotencyKey == "" || c.Locals(idempotencyKeyLocalsField) != nil {
+			return c.Next()
+		}
+
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "Idempotency-Key maksimal 255 karakter", fiber.StatusBadRequest)
+		}
+
+		claims, ok := c.Locals("claims").(*utils.JWTClaim)
+		if !ok {
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenMissing, "Token diperlukan", fiber.StatusUnauthorized)
+		}
+
+		userID, err := utils.GetUserIDFromToken(claims)
+		if err != nil {
+			return utils.ErrorResponse(c, utils.ErrorCodeTokenInvalid, err.Error(), fiber.StatusUnauthorized)
+		}
+
+		key, err := m.idempotencyUsecase.Begin(c.UserContext(), usecase.BeginIdempotencyRequest{
+			UserID:      userID,
+			Key:         idempotencyKey,
+			Method:      c.Method(),
+			Path:        c.Path(),
+			RequestHash: fingerprintRequest(c),
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrIdempotencyKeyMismatch):
+				return utils.ErrorResponse(c, utils.ErrorCodeIdempotencyKeyMismatch, err.Error(), fiber.StatusUnprocessableEntity)
+			case errors.Is(err, usecase.ErrIdempotencyKeyInProgress):
+				return utils.ErrorResponse(c, utils.ErrorCodeIdempotencyKeyInProgress, err.Error(), fiber.StatusConflict)
+			default:
+				log.Printf("Gagal memproses idempotency key %q: %v", idempotencyKey, err)
+				return utils.ServerError(c, "Gagal memproses Idempotency-Key")
+			}
+		}
+
+		if key.Status == entity.IdempotencyKeyCompleted {
+			c.Set(IdempotentReplayedHeader, "true")
+			if key.ContentType != "" {
+				c.Set(fiber.HeaderContentType, key.ContentType)
+			}
+			return c.Status(key.ResponseCode).Send(key.ResponseBody)
+		}
+
+		c.Locals(idempotencyKeyLocalsField, idempotencyKey)
+
+		// Error dari handler dan respons 5xx tidak disimpan agar klien bisa mengulang request
+		if err := c.Next(); err != nil {
+			m.release(c, key)
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			m.release(c, key)
+			return nil
+		}
+
+		// Buffer respons milik fasthttp dipakai ulang, jadi body harus disalin sebelum disimpan
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		if err := m.idempotencyUsecase.Complete(c.UserContext(), key, status, contentType, body); err != nil {
+			log.Printf("Gagal menyimpan respons idempotency key %q: %v", idempotencyKey, err)
+		}
+
+		return nil
+	}
+}
+
+func (m *IdempotencyMiddleware) release(c *fiber.Ctx, key *entity.IdempotencyKey) {
+	if err := m.idempotencyUsecase.Release(c.UserContext(), key); err != nil {
+		log.Printf("Gagal melepas idempotency key %q: %v", key.Key, err)
+	}
+}
+
+// fingerprintRequest menghitung sha256 dari method, URL lengkap, dan body request. Body
+// multipart dihitung dari isinya karena boundary dibuat acak di setiap percobaan klien.
+func fingerprintRequest(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(c.OriginalURL()))
+	h.Write([]byte{'\n'})
+
+	mediaType, _, err := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	if err != nil || mediaType != fiber.MIMEMultipartForm || !writeMultipartFingerprint(c, h) {
+		h.Write(c.Body())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeMultipartFingerprint menulis field form (terurut menurut nama) dan sha256 setiap file ke h.
+// Mengembalikan false jika form tidak bisa dibaca sehingga body mentah yang dipakai.
+func writeMultipartFingerprint(c *fiber.Ctx, h hash.Hash) bool {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return false
+	}
+
+	h.Write([]byte(fiber.MIMEMultipartForm))
+	h.Write([]byte{'\n'})
+
+	for _, name := range sortedKeys(form.Value) {
+		for _, value := range form.Value[name] {
+			h.Write([]byte(name + "=" + value))
+			h.Write([]byte{'\n'})
+		}
+	}
+
+	for _, name := range sortedKeys(form.File) {
+		for _, file := range form.File[name] {
+			sum, err := hashMultipartFile(file)
+			if err != nil {
+				return false
+			}
+			h.Write([]byte(name + "=" + file.Filename + ":" + sum))
+			h.Write([]byte{'\n'})
+		}
+	}
+
+	return true
+}
+
+func hashMultipartFile(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
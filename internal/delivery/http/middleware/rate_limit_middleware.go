@@ -0,0 +1,50 @@
+//internal/delivery/http/middleware/rate_limit_middleware.go
+
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"ticket-system/pkg/utils"
+)
+
+type RateLimitMiddleware struct {
+	max        int
+	expiration time.Duration
+}
+
+func NewRateLimitMiddleware(maxRequests string, windowMinutes string) *RateLimitMiddleware {
+	max, _ := strconv.Atoi(maxRequests)
+	if max <= 0 {
+		max = 5 // default 5 request
+	}
+
+	window, _ := strconv.Atoi(windowMinutes)
+	if window <= 0 {
+		window = 15 // default 15 menit
+	}
+
+	return &RateLimitMiddleware{
+		max:        max,
+		expiration: time.Duration(window) * time.Minute,
+	}
+}
+
+// Limit membatasi jumlah request per IP dalam satu jendela waktu. Setiap pemanggilan membuat
+// penghitung sendiri, sehingga batas satu route tidak ikut menghabiskan kuota route lain.
+// Penghitung disimpan di memori proses.
+func (m *RateLimitMiddleware) Limit() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        m.max,
+		Expiration: m.expiration,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return utils.ErrorResponse(c, utils.ErrorCodeTooManyRequests, "Terlalu banyak permintaan, silakan coba lagi nanti", fiber.StatusTooManyRequests)
+		},
+	})
+}
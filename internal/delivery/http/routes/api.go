@@ -2,31 +2,61 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	
 	"ticket-system/internal/delivery/http/handler"
 	"ticket-system/internal/delivery/http/middleware"
+	"ticket-system/internal/domain/storage"
+	"ticket-system/internal/gateway/midtrans"
 	"ticket-system/internal/repository/postgres"
+	"ticket-system/internal/storage/local"
+	"ticket-system/internal/storage/s3"
+	"ticket-system/internal/storage/scanner"
 	"ticket-system/internal/usecase"
+	"ticket-system/internal/worker"
 	"ticket-system/pkg/config"
 	"ticket-system/pkg/utils"
 )
 
-func SetupRoutes(app *fiber.App, db *sql.DB, cfg *config.Config) {
+func SetupRoutes(ctx context.Context, app *fiber.App, db *sql.DB, cfg *config.Config) {
 	app.Use(recover.New())
 	
 	userRepo := postgres.NewUserRepository(db)
 	userProfileRepo := postgres.NewUserProfileRepository(db)
 	emailVerificationRepo := postgres.NewEmailVerificationRepository(db)
+	refreshTokenRepo := postgres.NewRefreshTokenRepository(db)
+	tokenRevocationRepo := postgres.NewTokenRevocationRepository(db)
+	passwordResetRepo := postgres.NewPasswordResetRepository(db)
+	userMFARepo := postgres.NewUserMFARepository(db)
 	eventRepo := postgres.NewEventRepository(db)
 	transactionRepo := postgres.NewTransactionRepository(db)
+	paymentRepo := postgres.NewPaymentRepository(db)
+	statusHistoryRepo := postgres.NewTransactionStatusHistoryRepository(db)
+	ticketRepo := postgres.NewTicketRepository(db)
+	ticketScanRepo := postgres.NewTicketScanRepository(db)
+	ticketTypeRepo := postgres.NewTicketTypeRepository(db)
+	transactionItemRepo := postgres.NewTransactionItemRepository(db)
+	promoCodeRepo := postgres.NewPromoCodeRepository(db)
+	pricingRuleRepo := postgres.NewPricingRuleRepository(db)
+	refundRepo := postgres.NewRefundRepository(db)
+	idempotencyKeyRepo := postgres.NewIdempotencyKeyRepository(db)
+	waitlistRepo := postgres.NewWaitlistRepository(db)
+	ticketTransferRepo := postgres.NewTicketTransferRepository(db)
+	venueRepo := postgres.NewVenueRepository(db)
+	seatHoldRepo := postgres.NewSeatHoldRepository(db)
+	txManager := postgres.NewTxManager(db)
 	
-	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTSecret)
+	tokenRevocationUsecase := usecase.NewTokenRevocationUsecase(tokenRevocationRepo)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTSecret, tokenRevocationUsecase, cfg.OrganizerMFARequired == "true")
 	loggerMiddleware := middleware.NewLoggerMiddleware()
+	passwordResetLimiter := middleware.NewRateLimitMiddleware(cfg.PasswordResetRateLimit, cfg.PasswordResetRateWindow)
+	mfaLimiter := middleware.NewRateLimitMiddleware(cfg.MFARateLimit, cfg.MFARateWindow)
 	
 	smtpConfig := utils.SMTPConfig{
 		Host:     cfg.SMTPHost,
@@ -46,25 +76,116 @@ func SetupRoutes(app *fiber.App, db *sql.DB, cfg *config.Config) {
 		userRepo, 
 		userProfileRepo, 
 		emailVerificationRepo,
+		refreshTokenRepo,
+		tokenRevocationRepo,
+		passwordResetRepo,
+		userMFARepo,
+		txManager,
 		cfg.JWTSecret, 
-		cfg.TokenExpiry, 
+		cfg.AccessTokenExpiry,
+		cfg.RefreshTokenExpiry,
+		cfg.PasswordResetExpiry,
+		cfg.MFAChallengeExpiry,
 		smtpConfig,
 		appURL,
+		cfg.PasswordResetURL,
+		cfg.MFASecretKey,
 	)
 	
-	eventUsecase := usecase.NewEventUsecase(eventRepo, userRepo)
+	eventUsecase := usecase.NewEventUsecase(eventRepo, userRepo, ticketTypeRepo, transactionRepo)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(transactionRepo, eventRepo, userRepo)
+	paymentGateway := midtrans.NewClient(midtrans.Config{
+		ServerKey:   cfg.MidtransServerKey,
+		Environment: cfg.MidtransEnvironment,
+	})
+	
+	// Storage lokal disajikan lewat /api/files, sedangkan S3 memakai presigned URL miliknya sendiri
+	var blobStorage storage.BlobStorage
+	var localStorage *local.Storage
+	if cfg.StorageDriver == "s3" {
+		s3Storage, err := s3.NewStorage(s3.Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle == "true",
+		})
+		if err != nil {
+			log.Fatalf("Gagal menyiapkan storage S3: %v", err)
+		}
+		blobStorage = s3Storage
+	} else {
+		signingKey := cfg.StorageSigningKey
+		if signingKey == "" {
+			signingKey = cfg.JWTSecret
+		}
+		localStorage = local.NewStorage(cfg.StorageLocalDir, strings.TrimRight(appURL, "/")+"/api/files", signingKey)
+		blobStorage = localStorage
+	}
+	
+	transactionUsecase := usecase.NewTransactionUsecase(transactionRepo, eventRepo, userRepo, paymentRepo, statusHistoryRepo, ticketRepo, ticketTypeRepo, transactionItemRepo, promoCodeRepo, pricingRuleRepo, waitlistRepo, seatHoldRepo, txManager, paymentGateway, blobStorage, scanner.NewNoopScanner(), cfg.PaymentDeadline, cfg.MaxPaymentRejections, cfg.MaxPaymentProofSize, cfg.PaymentProofURLTTL, cfg.PlatformFeePercent, smtpConfig)
+	
+	paymentUsecase := usecase.NewPaymentUsecase(transactionRepo, eventRepo, paymentRepo, statusHistoryRepo, ticketRepo, ticketTypeRepo, transactionItemRepo, promoCodeRepo, seatHoldRepo, refundRepo, txManager, paymentGateway)
+	
+	qrSecret := cfg.TicketQRSecret
+	if qrSecret == "" {
+		qrSecret = cfg.JWTSecret
+	}
+	manifestKey, err := utils.ParseTicketManifestKey(cfg.TicketManifestSigningKey)
+	if err != nil {
+		log.Fatalf("Gagal membaca TICKET_MANIFEST_SIGNING_KEY: %v", err)
+	}
+	promoUsecase := usecase.NewPromoUsecase(promoCodeRepo, eventRepo)
+	pricingUsecase := usecase.NewPricingUsecase(pricingRuleRepo, eventRepo)
+	refundUsecase := usecase.NewRefundUsecase(refundRepo, transactionRepo, eventRepo, statusHistoryRepo, ticketRepo, ticketTypeRepo, transactionItemRepo, promoCodeRepo, seatHoldRepo, txManager, paymentGateway)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyKeyRepo, cfg.IdempotencyKeyTTL)
+	waitlistUsecase := usecase.NewWaitlistUsecase(waitlistRepo, eventRepo, userRepo, txManager, cfg.WaitlistOfferTTL, smtpConfig)
+	venueUsecase := usecase.NewVenueUsecase(venueRepo, eventRepo, ticketTypeRepo)
+	seatUsecase := usecase.NewSeatUsecase(seatHoldRepo, venueRepo, eventRepo, cfg.SeatHoldTTL)
+	
+	ticketUsecase := usecase.NewTicketUsecase(ticketRepo, ticketScanRepo, eventRepo, txManager, qrSecret, manifestKey)
+	ticketTransferUsecase := usecase.NewTicketTransferUsecase(ticketTransferRepo, ticketRepo, eventRepo, userRepo, refundRepo, txManager, smtpConfig)
+	
+	go worker.NewTransactionExpiryWorker(transactionUsecase, cfg.ExpirySweepInterval).Start(ctx)
+	go worker.NewRefundWorker(refundUsecase, cfg.RefundSweepInterval).Start(ctx)
+	go worker.NewIdempotencyKeyCleanupWorker(idempotencyUsecase, cfg.IdempotencyCleanupInterval).Start(ctx)
+	go worker.NewWaitlistWorker(waitlistUsecase, cfg.WaitlistSweepInterval).Start(ctx)
+	go worker.NewSeatHoldWorker(seatUsecase, cfg.SeatHoldSweepInterval).Start(ctx)
+	go worker.NewTokenRevocationCleanupWorker(tokenRevocationUsecase, cfg.TokenRevocationCleanupInterval).Start(ctx)
 	
 	userHandler := handler.NewUserHandler(userUsecase)
 	eventHandler := handler.NewEventHandler(eventUsecase)
 	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
+	ticketHandler := handler.NewTicketHandler(ticketUsecase)
+	ticketTransferHandler := handler.NewTicketTransferHandler(ticketTransferUsecase)
+	promoHandler := handler.NewPromoHandler(promoUsecase)
+	pricingHandler := handler.NewPricingHandler(pricingUsecase)
+	refundHandler := handler.NewRefundHandler(refundUsecase)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistUsecase)
+	venueHandler := handler.NewVenueHandler(venueUsecase)
+	seatHandler := handler.NewSeatHandler(seatUsecase)
+	
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(idempotencyUsecase)
 	
 	api := app.Group("/api", loggerMiddleware.LogRequest())
 
-	SetupUserRoutes(api, userHandler, authMiddleware, loggerMiddleware)
-	SetupEventRoutes(api, eventHandler, authMiddleware)
-	SetupTransactionRoutes(api, transactionHandler, authMiddleware)
+	SetupUserRoutes(api, userHandler, authMiddleware, loggerMiddleware, passwordResetLimiter, mfaLimiter)
+	organizerRoutes := SetupEventRoutes(api, eventHandler, authMiddleware)
+	transactionRoutes := SetupTransactionRoutes(api, transactionHandler, authMiddleware, idempotencyMiddleware)
+	SetupPaymentRoutes(api, paymentHandler)
+	SetupTicketRoutes(api, ticketHandler, authMiddleware)
+	SetupTicketTransferRoutes(api, ticketTransferHandler, authMiddleware)
+	SetupPromoRoutes(api, promoHandler, authMiddleware)
+	SetupPricingRoutes(api, pricingHandler, authMiddleware)
+	SetupRefundRoutes(transactionRoutes, organizerRoutes, refundHandler, idempotencyMiddleware)
+	SetupWaitlistRoutes(api, waitlistHandler, authMiddleware)
+	SetupVenueRoutes(api, venueHandler, authMiddleware)
+	SetupSeatRoutes(api, seatHandler, authMiddleware)
+	if localStorage != nil {
+		SetupFileRoutes(api, handler.NewFileHandler(localStorage))
+	}
 	
 	log.Println("Registered routes:")
 	for _, r := range app.GetRoutes() {
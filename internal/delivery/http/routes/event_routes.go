@@ -13,10 +13,11 @@ func SetupEventRoutes(
 	router fiber.Router,
 	eventHandler *handler.EventHandler,
 	authMiddleware *middleware.AuthMiddleware,
-) {
+) fiber.Router {
 	// Public routes 
 	router.Get("/events", eventHandler.GetEventList)
 	router.Get("/events/:id", eventHandler.GetEventByID)
+	router.Get("/events/:id/ticket-types", eventHandler.GetTicketTypes)
 	
 	// Protected routes 
 	organizerRoutes := router.Group("/organizer")
@@ -28,5 +29,10 @@ func SetupEventRoutes(
 	organizerRoutes.Put("/events/:id", eventHandler.UpdateEvent)
 	organizerRoutes.Delete("/events/:id", eventHandler.DeleteEvent)
 	organizerRoutes.Get("/events/:id/sales", eventHandler.GetEventSales)
+	organizerRoutes.Post("/events/:id/ticket-types", eventHandler.CreateTicketType)
+	organizerRoutes.Get("/events/:id/ticket-types", eventHandler.GetTicketTypes)
+	organizerRoutes.Put("/events/:id/ticket-types/:typeId", eventHandler.UpdateTicketType)
+	organizerRoutes.Delete("/events/:id/ticket-types/:typeId", eventHandler.DeleteTicketType)
 	
+	return organizerRoutes
 }
\ No newline at end of file
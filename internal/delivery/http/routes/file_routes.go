@@ -0,0 +1,17 @@
+//internal/delivery/http/routes/file_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"ticket-system/internal/delivery/http/handler"
+)
+
+func SetupFileRoutes(
+	router fiber.Router,
+	fileHandler *handler.FileHandler,
+) {
+	// Public route, akses dibatasi oleh signature pada URL unduhan
+	router.Get("/files/*", fileHandler.Download)
+}
@@ -0,0 +1,17 @@
+//internal/delivery/http/routes/payment_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+)
+
+func SetupPaymentRoutes(
+	router fiber.Router,
+	paymentHandler *handler.PaymentHandler,
+) {
+	// Public route, dipanggil oleh payment gateway dan diverifikasi lewat signature
+	router.Post("/payments/notifications", paymentHandler.HandleNotification)
+}
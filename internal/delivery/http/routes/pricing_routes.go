@@ -0,0 +1,26 @@
+//internal/delivery/http/routes/pricing_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupPricingRoutes(
+	router fiber.Router,
+	pricingHandler *handler.PricingHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	pricingRoutes := router.Group("/organizer")
+	pricingRoutes.Use(authMiddleware.AuthenticateJWT())
+	pricingRoutes.Use(authMiddleware.RoleCheck([]string{"organizer"}))
+	
+	pricingRoutes.Get("/pricing", pricingHandler.GetOrganizerPricing)
+	pricingRoutes.Put("/pricing", pricingHandler.UpdateOrganizerPricing)
+	pricingRoutes.Get("/events/:id/pricing", pricingHandler.GetEventPricing)
+	pricingRoutes.Put("/events/:id/pricing", pricingHandler.UpdateEventPricing)
+	pricingRoutes.Delete("/events/:id/pricing", pricingHandler.DeleteEventPricing)
+}
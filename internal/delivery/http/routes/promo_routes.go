@@ -0,0 +1,25 @@
+//internal/delivery/http/routes/promo_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupPromoRoutes(
+	router fiber.Router,
+	promoHandler *handler.PromoHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	promoRoutes := router.Group("/organizer/promo-codes")
+	promoRoutes.Use(authMiddleware.AuthenticateJWT())
+	promoRoutes.Use(authMiddleware.RoleCheck([]string{"organizer"}))
+	
+	promoRoutes.Post("", promoHandler.CreatePromoCode)
+	promoRoutes.Get("", promoHandler.GetPromoCodes)
+	promoRoutes.Put("/:id", promoHandler.UpdatePromoCode)
+	promoRoutes.Delete("/:id", promoHandler.DeactivatePromoCode)
+}
\ No newline at end of file
@@ -0,0 +1,26 @@
+//internal/delivery/http/routes/refund_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+// SetupRefundRoutes mendaftarkan route refund ke group yang sudah dibuat SetupTransactionRoutes
+// dan SetupEventRoutes, sehingga autentikasi dan idempotency tidak terpasang dua kali
+func SetupRefundRoutes(
+	transactionRoutes fiber.Router,
+	organizerRoutes fiber.Router,
+	refundHandler *handler.RefundHandler,
+	idempotencyMiddleware *middleware.IdempotencyMiddleware,
+) {
+	transactionRoutes.Post("/:id/refund", refundHandler.RequestRefund)
+	transactionRoutes.Get("/:id/refund", refundHandler.GetTransactionRefund)
+	
+	organizerRoutes.Get("/events/:id/refunds", refundHandler.GetEventRefunds)
+	organizerRoutes.Put("/refunds/:id/approve", idempotencyMiddleware.Handle(), refundHandler.ApproveRefund)
+	organizerRoutes.Put("/refunds/:id/reject", idempotencyMiddleware.Handle(), refundHandler.RejectRefund)
+}
@@ -0,0 +1,23 @@
+//internal/delivery/http/routes/seat_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupSeatRoutes(
+	router fiber.Router,
+	seatHandler *handler.SeatHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	authenticated := authMiddleware.AuthenticateJWT()
+	
+	router.Get("/events/:id/seats", seatHandler.GetSeatMap)
+	router.Post("/events/:id/seat-holds", authenticated, seatHandler.HoldSeats)
+	router.Get("/events/:id/seat-holds", authenticated, seatHandler.GetSeatHold)
+	router.Delete("/events/:id/seat-holds", authenticated, seatHandler.ReleaseSeatHold)
+}
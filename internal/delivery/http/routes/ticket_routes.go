@@ -0,0 +1,33 @@
+//internal/delivery/http/routes/ticket_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupTicketRoutes(
+	router fiber.Router,
+	ticketHandler *handler.TicketHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	ticketRoutes := router.Group("/tickets")
+	ticketRoutes.Use(authMiddleware.AuthenticateJWT())
+
+	ticketRoutes.Get("/:code/qr", ticketHandler.GetTicketQR)
+	ticketRoutes.Get("/:code", ticketHandler.GetTicketByCode)
+	ticketRoutes.Get("", ticketHandler.GetUserTickets)
+	
+	organizerRoutes := router.Group("/organizer/events")
+	organizerRoutes.Use(authMiddleware.AuthenticateJWT())
+	organizerRoutes.Use(authMiddleware.RoleCheck([]string{"organizer"}))
+	
+	organizerRoutes.Get("/checkin-public-key", ticketHandler.GetCheckInPublicKey)
+	organizerRoutes.Post("/:id/checkin", ticketHandler.CheckIn)
+	organizerRoutes.Get("/:id/checkin-manifest", ticketHandler.GetCheckInManifest)
+	organizerRoutes.Post("/:id/checkin-sync", ticketHandler.SyncCheckIns)
+	organizerRoutes.Get("/:id/attendance", ticketHandler.GetAttendance)
+}
@@ -0,0 +1,29 @@
+//internal/delivery/http/routes/ticket_transfer_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupTicketTransferRoutes(
+	router fiber.Router,
+	transferHandler *handler.TicketTransferHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	authenticated := authMiddleware.AuthenticateJWT()
+	
+	router.Post("/tickets/:code/transfer", authenticated, transferHandler.InitiateTransfer)
+	router.Get("/tickets/:code/transfers", authenticated, transferHandler.GetTicketTransfers)
+	
+	transferRoutes := router.Group("/ticket-transfers")
+	transferRoutes.Use(authenticated)
+	
+	transferRoutes.Get("", transferHandler.GetPendingTransfers)
+	transferRoutes.Put("/:id/accept", transferHandler.AcceptTransfer)
+	transferRoutes.Put("/:id/decline", transferHandler.DeclineTransfer)
+	transferRoutes.Put("/:id/cancel", transferHandler.CancelTransfer)
+}
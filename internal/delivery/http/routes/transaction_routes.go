@@ -13,9 +13,11 @@ func SetupTransactionRoutes(
 	router fiber.Router,
 	transactionHandler *handler.TransactionHandler,
 	authMiddleware *middleware.AuthMiddleware,
-) {
+	idempotencyMiddleware *middleware.IdempotencyMiddleware,
+) fiber.Router {
 	transactionRoutes := router.Group("/transactions")
 	transactionRoutes.Use(authMiddleware.AuthenticateJWT())
+	transactionRoutes.Use(idempotencyMiddleware.Handle())
 
 	transactionRoutes.Get("/code", transactionHandler.GetTransactionByCode)
 	transactionRoutes.Post("/proof", transactionHandler.UploadPaymentProof)
@@ -27,6 +29,10 @@ func SetupTransactionRoutes(
 	organizerRoutes := router.Group("/organizer/transactions")
 	organizerRoutes.Use(authMiddleware.AuthenticateJWT())
 	organizerRoutes.Use(authMiddleware.RoleCheck([]string{"organizer"}))
+	organizerRoutes.Use(idempotencyMiddleware.Handle())
 
 	organizerRoutes.Put("/:id/verify", transactionHandler.VerifyPayment)
+	organizerRoutes.Put("/:id/reject", transactionHandler.RejectPayment)
+
+	return transactionRoutes
 }
\ No newline at end of file
@@ -14,13 +14,32 @@ func SetupUserRoutes(
 	userHandler *handler.UserHandler,
 	authMiddleware *middleware.AuthMiddleware,
 	loggerMiddleware *middleware.LoggerMiddleware,
+	passwordResetLimiter *middleware.RateLimitMiddleware,
+	mfaLimiter *middleware.RateLimitMiddleware,
 ) {
 	// Public routes
 	router.Post("/register", userHandler.Register)
 	router.Post("/login", userHandler.Login)
+	router.Post("/login/mfa", mfaLimiter.Limit(), userHandler.LoginMFA)
+	router.Post("/auth/refresh", userHandler.RefreshToken)
 	router.Get("/verify-email", userHandler.VerifyEmail)
 	router.Post("/resend-verification", userHandler.ResendVerificationEmail)
+	router.Post("/forgot-password", passwordResetLimiter.Limit(), userHandler.ForgotPassword)
+	router.Post("/reset-password", passwordResetLimiter.Limit(), userHandler.ResetPassword)
 	
 	// Protected routes
 	router.Put("/profile", authMiddleware.AuthenticateJWT(), userHandler.UpdateProfile)
+	router.Post("/logout", authMiddleware.AuthenticateJWT(), userHandler.Logout)
+	router.Post("/logout-all", authMiddleware.AuthenticateJWT(), userHandler.LogoutAll)
+	router.Put("/change-password", authMiddleware.AuthenticateJWT(), userHandler.ChangePassword)
+	
+	// 2FA sengaja tanpa RoleCheck agar organizer yang diwajibkan 2FA tetap bisa mengaktifkannya
+	mfaRoutes := router.Group("/mfa")
+	mfaRoutes.Use(authMiddleware.AuthenticateJWT())
+	
+	mfaRoutes.Get("/", userHandler.GetMFAStatus)
+	mfaRoutes.Post("/setup", userHandler.SetupMFA)
+	mfaRoutes.Post("/enable", mfaLimiter.Limit(), userHandler.EnableMFA)
+	mfaRoutes.Post("/disable", mfaLimiter.Limit(), userHandler.DisableMFA)
+	mfaRoutes.Post("/recovery-codes", mfaLimiter.Limit(), userHandler.RegenerateRecoveryCodes)
 }
\ No newline at end of file
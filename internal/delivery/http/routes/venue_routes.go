@@ -0,0 +1,31 @@
+//internal/delivery/http/routes/venue_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupVenueRoutes(
+	router fiber.Router,
+	venueHandler *handler.VenueHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	venueRoutes := router.Group("/organizer/venues")
+	venueRoutes.Use(authMiddleware.AuthenticateJWT())
+	venueRoutes.Use(authMiddleware.RoleCheck([]string{"organizer"}))
+	
+	venueRoutes.Post("", venueHandler.CreateVenue)
+	venueRoutes.Get("", venueHandler.GetVenues)
+	venueRoutes.Get("/:id", venueHandler.GetVenue)
+	venueRoutes.Delete("/:id", venueHandler.DeleteVenue)
+	
+	eventRoutes := router.Group("/organizer/events")
+	eventRoutes.Use(authMiddleware.AuthenticateJWT())
+	eventRoutes.Use(authMiddleware.RoleCheck([]string{"organizer"}))
+	
+	eventRoutes.Put("/:id/venue", venueHandler.AssignVenue)
+}
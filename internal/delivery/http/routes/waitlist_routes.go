@@ -0,0 +1,22 @@
+//internal/delivery/http/routes/waitlist_routes.go
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+)
+
+func SetupWaitlistRoutes(
+	router fiber.Router,
+	waitlistHandler *handler.WaitlistHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	authenticated := authMiddleware.AuthenticateJWT()
+	
+	router.Post("/events/:id/waitlist", authenticated, waitlistHandler.JoinWaitlist)
+	router.Get("/events/:id/waitlist", authenticated, waitlistHandler.GetWaitlistEntry)
+	router.Delete("/events/:id/waitlist", authenticated, waitlistHandler.LeaveWaitlist)
+}
@@ -13,8 +13,15 @@ type Event struct {
 	EventDate   time.Time `json:"event_date"`
 	MaxCapacity int       `json:"max_capacity"`
 	TicketsSold int       `json:"tickets_sold"`
-	Price       float64   `json:"price"`
+	Price       Money     `json:"price"`
+	Currency    string    `json:"currency"`
 	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	RefundPolicy   RefundPolicy   `json:"refund_policy"`
+	TransferPolicy TransferPolicy `json:"transfer_policy"`
+
+	// VenueID diisi untuk event dengan kursi bernomor, MaxCapacity mengikuti jumlah kursi venue
+	VenueID int `json:"venue_id,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,19 @@
+//internal/domain/entity/event_revenue.go
+
+package entity
+
+// EventRevenue adalah pendapatan event dari transaksi berstatus paid. Nominal diambil dari nilai
+// yang tercatat saat transaksi dibuat, sehingga perubahan harga setelahnya tidak berpengaruh dan
+// transaksi yang belum dibayar tidak ikut dihitung.
+type EventRevenue struct {
+	// Subtotal adalah total harga tiket sebelum diskon promo, termasuk transaksi tanpa tipe tiket
+	Subtotal Money
+	Discount Money
+	// ByTicketType berisi jumlah subtotal item per ID tipe tiket
+	ByTicketType map[int]Money
+}
+
+// Net mengembalikan pendapatan tiket setelah diskon promo
+func (r *EventRevenue) Net() Money {
+	return r.Subtotal.Sub(r.Discount)
+}
@@ -0,0 +1,29 @@
+//internal/domain/entity/idempotency_key.go
+
+package entity
+
+import "time"
+
+type IdempotencyKeyStatus string
+
+const (
+	IdempotencyKeyProcessing IdempotencyKeyStatus = "processing"
+	IdempotencyKeyCompleted  IdempotencyKeyStatus = "completed"
+)
+
+// IdempotencyKey menyimpan sidik request dan respons pertama untuk satu header Idempotency-Key
+// milik seorang user. Retry dengan key dan body yang sama akan menerima respons yang tersimpan.
+type IdempotencyKey struct {
+	ID           int                  `json:"id"`
+	UserID       int                  `json:"user_id"`
+	Key          string               `json:"key"`
+	Method       string               `json:"method"`
+	Path         string               `json:"path"`
+	RequestHash  string               `json:"request_hash"`
+	Status       IdempotencyKeyStatus `json:"status"`
+	ResponseCode int                  `json:"response_code,omitempty"`
+	ResponseBody []byte               `json:"-"`
+	ContentType  string               `json:"content_type,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+	ExpiresAt    time.Time            `json:"expires_at"`
+}
@@ -0,0 +1,379 @@
+//internal/domain/entity/money.go
+
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency dipakai untuk data lama dan request yang tidak menyebutkan mata uang
+const DefaultCurrency = "IDR"
+
+// currencyExponents adalah jumlah digit desimal satuan terkecil tiap mata uang yang didukung.
+// IDR sengaja memakai 0 (ISO 4217 menulis 2) karena sen tidak lagi beredar dan payment
+// gateway menolak gross_amount rupiah yang berpecahan.
+var currencyExponents = map[string]int{
+	"IDR": 0,
+	"SGD": 2,
+	"USD": 2,
+}
+
+var (
+	ErrInvalidMoney        = errors.New("format nominal tidak valid")
+	ErrUnsupportedCurrency = errors.New("mata uang tidak didukung")
+	ErrInvalidPercent      = errors.New("format persentase tidak valid")
+)
+
+// Money adalah nominal uang dalam satuan terkecil mata uangnya (integer) beserta kode mata uang,
+// sehingga penjumlahan harga, diskon dan biaya tidak bergeser seperti float64. Zero value
+// diperlakukan sebagai 0 dalam DefaultCurrency.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney membuat Money dari nominal satuan terkecil, mata uang kosong diisi DefaultCurrency
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: NormalizeCurrency(currency)}
+}
+
+// IDR membuat Money rupiah, satuan terkecilnya adalah rupiah penuh
+func IDR(amount int64) Money {
+	return Money{Amount: amount, Currency: "IDR"}
+}
+
+// NormalizeCurrency merapikan kode mata uang menjadi huruf besar, kode kosong menjadi DefaultCurrency
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
+
+func IsSupportedCurrency(code string) bool {
+	_, ok := currencyExponents[NormalizeCurrency(code)]
+	return ok
+}
+
+// ParseMoney membaca nominal desimal dalam satuan utama (mis. "150000.00" atau "10.5") ke satuan
+// terkecil mata uang. Digit di bawah satuan terkecil dibulatkan setengah menjauhi nol, misalnya
+// nilai DECIMAL lama "99999.50" rupiah menjadi 100000.
+func ParseMoney(value, currency string) (Money, error) {
+	currency = NormalizeCurrency(currency)
+	exponent, ok := currencyExponents[currency]
+	if !ok {
+		return Money{}, ErrUnsupportedCurrency
+	}
+
+	amount, err := parseDecimal(value, exponent, true)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// CurrencyCode mengembalikan kode mata uang yang sudah dinormalisasi
+func (m Money) CurrencyCode() string {
+	return NormalizeCurrency(m.Currency)
+}
+
+// InCurrency mengisi mata uang yang kosong dengan currency, dipakai untuk nominal dari request
+// yang hanya mengirim angka. Mata uang yang sudah terisi tidak diubah.
+func (m Money) InCurrency(currency string) Money {
+	if strings.TrimSpace(m.Currency) == "" {
+		m.Currency = currency
+	}
+	m.Currency = NormalizeCurrency(m.Currency)
+	return m
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.CurrencyCode() == other.CurrencyCode()
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add dan operasi aritmatika lain panic bila mata uang berbeda atau hasilnya melampaui int64.
+// Keduanya adalah kesalahan program karena mata uang dan batas nominal sudah divalidasi di usecase.
+func (m Money) Add(other Money) Money {
+	m.mustSameCurrency(other)
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		panic("money: hasil penjumlahan melampaui batas")
+	}
+	return Money{Amount: sum, Currency: m.CurrencyCode()}
+}
+
+func (m Money) Sub(other Money) Money {
+	return m.Add(other.Neg())
+}
+
+func (m Money) Neg() Money {
+	if m.Amount == math.MinInt64 {
+		panic("money: hasil negasi melampaui batas")
+	}
+	return Money{Amount: -m.Amount, Currency: m.CurrencyCode()}
+}
+
+// Mul mengalikan nominal dengan jumlah barang, misalnya harga satuan dikali kuantitas
+func (m Money) Mul(quantity int64) Money {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(quantity))
+	if !product.IsInt64() {
+		panic("money: hasil perkalian melampaui batas")
+	}
+	return Money{Amount: product.Int64(), Currency: m.CurrencyCode()}
+}
+
+// Percent menghitung persentase nominal dan membulatkannya ke satuan terkecil dengan aturan
+// setengah menjauhi nol. Aturan yang sama dipakai untuk diskon maupun biaya supaya hasil
+// perhitungan bisa direproduksi di laporan.
+func (m Money) Percent(rate Percent) Money {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(int64(rate)))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(percentScale), new(big.Int))
+
+	// 2*|sisa| >= pembagi berarti sisa setengah atau lebih
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(big.NewInt(percentScale)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		panic("money: hasil persentase melampaui batas")
+	}
+	return Money{Amount: quotient.Int64(), Currency: m.CurrencyCode()}
+}
+
+// Cmp mengembalikan -1, 0 atau 1 seperti big.Int.Cmp
+func (m Money) Cmp(other Money) int {
+	m.mustSameCurrency(other)
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Equal membandingkan nominal dan mata uang tanpa panic, sehingga aman untuk data dari luar
+func (m Money) Equal(other Money) bool {
+	return m.SameCurrency(other) && m.Amount == other.Amount
+}
+
+func (m Money) Min(other Money) Money {
+	if m.Cmp(other) <= 0 {
+		return m.InCurrency(DefaultCurrency)
+	}
+	return other.InCurrency(DefaultCurrency)
+}
+
+// Decimal menulis nominal dalam satuan utama, misalnya "150000" untuk IDR atau "10.50" untuk USD.
+// Format ini yang disimpan ke kolom NUMERIC.
+func (m Money) Decimal() string {
+	exponent := currencyExponents[m.CurrencyCode()]
+
+	digits := strconv.FormatUint(absInt64(m.Amount), 10)
+	sign := ""
+	if m.Amount < 0 {
+		sign = "-"
+	}
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	split := len(digits) - exponent
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+func (m Money) String() string {
+	return m.CurrencyCode() + " " + m.Decimal()
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Amount, m.CurrencyCode()})
+}
+
+// UnmarshalJSON menerima objek {"amount": 150000, "currency": "IDR"} atau angka bulat saja dalam
+// satuan terkecil. Angka saja membiarkan mata uang kosong untuk diisi usecase lewat InCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var raw struct {
+			Amount   json.Number `json:"amount"`
+			Currency string      `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		amount, err := parseMinorUnits(string(raw.Amount))
+		if err != nil {
+			return err
+		}
+		if raw.Currency != "" && !IsSupportedCurrency(raw.Currency) {
+			return ErrUnsupportedCurrency
+		}
+		*m = Money{Amount: amount, Currency: strings.ToUpper(strings.TrimSpace(raw.Currency))}
+		return nil
+	}
+
+	amount, err := parseMinorUnits(string(data))
+	if err != nil {
+		return err
+	}
+	*m = Money{Amount: amount}
+	return nil
+}
+
+func (m Money) mustSameCurrency(other Money) {
+	if !m.SameCurrency(other) {
+		panic(fmt.Sprintf("money: mata uang berbeda (%s dan %s)", m.CurrencyCode(), other.CurrencyCode()))
+	}
+}
+
+// Percent adalah persentase dalam basis poin (1/100 persen), misalnya 1250 untuk 12,5%.
+// Di JSON ditulis sebagai angka persen biasa seperti 12.5.
+type Percent int64
+
+const (
+	OneHundredPercent Percent = 10000
+
+	percentScale = int64(OneHundredPercent)
+)
+
+// ParsePercent membaca persentase dengan paling banyak dua digit desimal
+func ParsePercent(value string) (Percent, error) {
+	basisPoints, err := parseDecimal(value, 2, false)
+	if err != nil {
+		return 0, ErrInvalidPercent
+	}
+	return Percent(basisPoints), nil
+}
+
+func (p Percent) String() string {
+	value := Money{Amount: int64(p), Currency: "USD"}.Decimal()
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(bytes.TrimSpace(data)), `"`)
+	if value == "null" {
+		return nil
+	}
+
+	parsed, err := ParsePercent(value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// parseMinorUnits membaca nominal satuan terkecil dari JSON, pecahan ditolak karena
+// satuan terkecil memang tidak bisa dibagi lagi
+func parseMinorUnits(value string) (int64, error) {
+	amount, err := parseDecimal(strings.Trim(value, `"`), 0, false)
+	if err != nil {
+		return 0, ErrInvalidMoney
+	}
+	return amount, nil
+}
+
+// parseDecimal mengubah teks desimal menjadi integer berskala 10^scale. Digit lebih dari scale
+// dibulatkan setengah menjauhi nol bila round bernilai true, selain itu ditolak kecuali nol.
+func parseDecimal(value string, scale int, round bool) (int64, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		negative = value[0] == '-'
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, ErrInvalidMoney
+	}
+
+	roundUp := false
+	if len(fraction) > scale {
+		extra := fraction[scale:]
+		fraction = fraction[:scale]
+		if strings.Trim(extra, "0") != "" {
+			if !round {
+				return 0, ErrInvalidMoney
+			}
+			roundUp = extra[0] >= '5'
+		}
+	}
+	fraction += strings.Repeat("0", scale-len(fraction))
+
+	digits := strings.TrimLeft(whole+fraction, "0")
+	if digits == "" {
+		digits = "0"
+	}
+
+	result, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return 0, ErrInvalidMoney
+	}
+	if roundUp {
+		result.Add(result, big.NewInt(1))
+	}
+	if negative {
+		result.Neg(result)
+	}
+	if !result.IsInt64() {
+		return 0, ErrInvalidMoney
+	}
+
+	return result.Int64(), nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func absInt64(value int64) uint64 {
+	if value < 0 {
+		return uint64(-(value + 1)) + 1
+	}
+	return uint64(value)
+}
@@ -0,0 +1,21 @@
+//internal/domain/entity/password_reset.go
+
+package entity
+
+import "time"
+
+// PasswordReset adalah token reset password yang dikirim lewat email. Seperti refresh token,
+// hanya hash SHA-256 token yang disimpan. Token hanya bisa dipakai sekali (UsedAt terisi).
+type PasswordReset struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	TokenHash string    `json:"-"`
+	ExpiredAt time.Time `json:"expired_at"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Used bernilai true jika token sudah dipakai mengganti password
+func (r *PasswordReset) Used() bool {
+	return !r.UsedAt.IsZero()
+}
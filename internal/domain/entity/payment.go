@@ -0,0 +1,23 @@
+//internal/domain/entity/payment.go
+
+package entity
+
+import "time"
+
+type Payment struct {
+	ID                    int       `json:"id"`
+	TransactionID         int       `json:"transaction_id"`
+	MidtransTransactionID string    `json:"midtrans_transaction_id,omitempty"`
+	PaymentType           string    `json:"payment_type,omitempty"`
+	Amount                Money     `json:"amount"`
+	Status                string    `json:"status"`
+	StatusCode            string    `json:"status_code,omitempty"`
+	StatusMessage         string    `json:"status_message,omitempty"`
+	PaymentTime           time.Time `json:"payment_time,omitempty"`
+	ExpiryTime            time.Time `json:"expiry_time,omitempty"`
+	SnapToken             string    `json:"snap_token,omitempty"`
+	RedirectURL           string    `json:"redirect_url,omitempty"`
+	CallbackData          []byte    `json:"-"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
@@ -0,0 +1,80 @@
+//internal/domain/entity/pricing_rule.go
+
+package entity
+
+import "time"
+
+// PricingRule mengatur biaya layanan dan PPN yang dibebankan organizer ke pembeli. EventID 0
+// adalah aturan default organizer, aturan khusus event menggantikan aturan default secara utuh.
+type PricingRule struct {
+	ID                  int       `json:"id"`
+	OwnerID             int       `json:"owner_id"`
+	EventID             int       `json:"event_id,omitempty"`
+	ServiceFeePercent   Percent   `json:"service_fee_percent"`
+	ServiceFeePerTicket Money     `json:"service_fee_per_ticket"`
+	TaxPercent          Percent   `json:"tax_percent"`
+	PassPlatformFee     bool      `json:"pass_platform_fee"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// AppliesTo mengecek apakah biaya per tiket bisa ditagihkan dalam mata uang transaksi
+func (r *PricingRule) AppliesTo(currency string) bool {
+	if r == nil || r.ServiceFeePerTicket.IsZero() {
+		return true
+	}
+	return r.ServiceFeePerTicket.CurrencyCode() == NormalizeCurrency(currency)
+}
+
+// PriceBreakdown adalah rincian harga satu transaksi. PlatformFee selalu dicatat untuk laporan
+// keuangan, tetapi hanya masuk ke Total bila PlatformFeeToBuyer bernilai true.
+type PriceBreakdown struct {
+	Subtotal           Money
+	Discount           Money
+	ServiceFee         Money
+	PlatformFee        Money
+	PlatformFeeToBuyer bool
+	Tax                Money
+	Total              Money
+}
+
+// CalculatePrice menjalankan urutan perhitungan harga transaksi:
+//  1. subtotal dikurangi diskon promo menjadi dasar biaya
+//  2. biaya layanan organizer = persen dari dasar biaya + biaya per tiket
+//  3. komisi platform = persen dari dasar biaya, ditagihkan ke pembeli bila PassPlatformFee
+//  4. PPN = persen dari dasar biaya ditambah semua biaya yang ditagihkan ke pembeli
+//
+// Setiap komponen dibulatkan sendiri dengan Money.Percent sehingga Total selalu sama dengan
+// jumlah komponennya. Rule nil berarti organizer belum mengatur biaya maupun pajak.
+func CalculatePrice(subtotal, discount Money, quantity int, rule *PricingRule, platformRate Percent) PriceBreakdown {
+	currency := subtotal.CurrencyCode()
+	discount = discount.InCurrency(currency)
+	base := subtotal.Sub(discount)
+
+	breakdown := PriceBreakdown{
+		Subtotal:    subtotal,
+		Discount:    discount,
+		ServiceFee:  NewMoney(0, currency),
+		PlatformFee: base.Percent(platformRate),
+		Tax:         NewMoney(0, currency),
+	}
+
+	taxable := base
+	if rule != nil {
+		breakdown.ServiceFee = base.Percent(rule.ServiceFeePercent)
+		if !rule.ServiceFeePerTicket.IsZero() {
+			breakdown.ServiceFee = breakdown.ServiceFee.Add(rule.ServiceFeePerTicket.Mul(int64(quantity)))
+		}
+		taxable = taxable.Add(breakdown.ServiceFee)
+
+		if rule.PassPlatformFee {
+			breakdown.PlatformFeeToBuyer = true
+			taxable = taxable.Add(breakdown.PlatformFee)
+		}
+
+		breakdown.Tax = taxable.Percent(rule.TaxPercent)
+	}
+
+	breakdown.Total = taxable.Add(breakdown.Tax)
+	return breakdown
+}
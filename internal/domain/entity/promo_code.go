@@ -0,0 +1,69 @@
+//internal/domain/entity/promo_code.go
+
+package entity
+
+import "time"
+
+type PromoDiscountType string
+
+const (
+	PromoDiscountPercentage PromoDiscountType = "percentage"
+	PromoDiscountFixed      PromoDiscountType = "fixed"
+)
+
+// PromoCode adalah kode diskon milik organizer. EventID 0 berarti kode berlaku untuk semua
+// event organizer tersebut, MaxUses dan PerUserLimit 0 berarti tidak dibatasi.
+// DiscountPercent dipakai untuk tipe percentage dan DiscountAmount untuk tipe fixed.
+type PromoCode struct {
+	ID              int               `json:"id"`
+	OwnerID         int               `json:"owner_id"`
+	EventID         int               `json:"event_id,omitempty"`
+	Code            string            `json:"code"`
+	DiscountType    PromoDiscountType `json:"discount_type"`
+	DiscountPercent Percent           `json:"discount_percent,omitempty"`
+	DiscountAmount  Money             `json:"discount_amount"`
+	MaxUses         int               `json:"max_uses"`
+	UsedCount       int               `json:"used_count"`
+	PerUserLimit    int               `json:"per_user_limit"`
+	MinQuantity     int               `json:"min_quantity"`
+	ValidFrom       time.Time         `json:"valid_from,omitempty"`
+	ValidUntil      time.Time         `json:"valid_until,omitempty"`
+	IsActive        bool              `json:"is_active"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// ValidAt mengecek periode berlaku kode. Batas yang kosong berarti tidak dibatasi.
+func (p *PromoCode) ValidAt(now time.Time) bool {
+	if !p.ValidFrom.IsZero() && now.Before(p.ValidFrom) {
+		return false
+	}
+	if !p.ValidUntil.IsZero() && !now.Before(p.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// AppliesTo mengecek apakah potongan kode bisa dipakai untuk mata uang transaksi.
+// Kode persentase berlaku untuk mata uang apa pun.
+func (p *PromoCode) AppliesTo(currency string) bool {
+	if p.DiscountType == PromoDiscountPercentage {
+		return true
+	}
+	return p.DiscountAmount.CurrencyCode() == NormalizeCurrency(currency)
+}
+
+// Discount menghitung potongan untuk subtotal transaksi. Diskon persentase dibulatkan ke satuan
+// terkecil mata uang dengan Money.Percent dan hasilnya tidak pernah melebihi subtotal, supaya
+// rincian item yang dikirim ke payment gateway tetap sama dengan total yang ditagihkan.
+func (p *PromoCode) Discount(subtotal Money) Money {
+	discount := NewMoney(0, subtotal.CurrencyCode())
+	switch p.DiscountType {
+	case PromoDiscountPercentage:
+		discount = subtotal.Percent(p.DiscountPercent)
+	case PromoDiscountFixed:
+		discount = p.DiscountAmount
+	}
+
+	return discount.Min(subtotal)
+}
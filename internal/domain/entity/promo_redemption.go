@@ -0,0 +1,25 @@
+//internal/domain/entity/promo_redemption.go
+
+package entity
+
+import "time"
+
+type PromoRedemptionStatus string
+
+const (
+	PromoRedemptionActive   PromoRedemptionStatus = "active"
+	PromoRedemptionReleased PromoRedemptionStatus = "released"
+)
+
+// PromoRedemption mencatat pemakaian kode promo oleh satu transaksi. Redemption dilepas
+// (released) ketika transaksi batal atau kedaluwarsa sehingga kuota kode kembali.
+type PromoRedemption struct {
+	ID             int                   `json:"id"`
+	PromoCodeID    int                   `json:"promo_code_id"`
+	TransactionID  int                   `json:"transaction_id"`
+	UserID         int                   `json:"user_id"`
+	DiscountAmount Money                 `json:"discount_amount"`
+	Status         PromoRedemptionStatus `json:"status"`
+	CreatedAt      time.Time             `json:"created_at"`
+	ReleasedAt     time.Time             `json:"released_at,omitempty"`
+}
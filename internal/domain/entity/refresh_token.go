@@ -0,0 +1,30 @@
+//internal/domain/entity/refresh_token.go
+
+package entity
+
+import "time"
+
+// RefreshToken adalah refresh token opaque milik satu sesi login. Token asli hanya dikirim ke
+// klien, yang disimpan hanya hash SHA-256 miliknya. Setiap refresh menerbitkan token baru dengan
+// FamilyID yang sama dan menandai token lama sebagai rotated, sehingga token rotated yang dipakai
+// lagi menandakan token bocor dan seluruh family dicabut.
+type RefreshToken struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	FamilyID  string    `json:"family_id"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RotatedAt time.Time `json:"rotated_at,omitempty"`
+	RevokedAt time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Rotated bernilai true jika token sudah ditukar dengan token baru
+func (t *RefreshToken) Rotated() bool {
+	return !t.RotatedAt.IsZero()
+}
+
+// Revoked bernilai true jika sesi token sudah dicabut
+func (t *RefreshToken) Revoked() bool {
+	return !t.RevokedAt.IsZero()
+}
@@ -0,0 +1,92 @@
+//internal/domain/entity/refund.go
+
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusRequested  RefundStatus = "requested"
+	RefundStatusApproved   RefundStatus = "approved"
+	RefundStatusRejected   RefundStatus = "rejected"
+	RefundStatusProcessing RefundStatus = "processing"
+	RefundStatusCompleted  RefundStatus = "completed"
+	RefundStatusFailed     RefundStatus = "failed"
+)
+
+// Asal pengajuan refund
+const (
+	RefundSourceBuyer          = "buyer"
+	RefundSourceEventCancelled = "event_cancelled"
+	// RefundSourceLatePayment adalah pembayaran yang diterima gateway setelah transaksi berakhir
+	RefundSourceLatePayment = "late_payment"
+)
+
+// ErrInvalidRefundTransition dikembalikan ketika perubahan status refund tidak ada di tabel transisi
+var ErrInvalidRefundTransition = errors.New("perubahan status refund tidak diizinkan")
+
+// refundTransitions mengikuti alur transactionTransitions. Refund yang gagal di payment gateway
+// bisa disetujui ulang oleh organizer untuk dicoba lagi.
+var refundTransitions = map[RefundStatus][]RefundStatus{
+	RefundStatusRequested:  {RefundStatusApproved, RefundStatusRejected},
+	RefundStatusApproved:   {RefundStatusProcessing},
+	RefundStatusProcessing: {RefundStatusCompleted, RefundStatusFailed},
+	RefundStatusFailed:     {RefundStatusApproved},
+}
+
+func (s RefundStatus) CanTransitionTo(next RefundStatus) bool {
+	for _, allowed := range refundTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Refund adalah pengembalian dana satu transaksi. Amount bisa lebih kecil dari total transaksi
+// (refund sebagian) sesuai kebijakan refund event atau keputusan organizer, tetapi transaksi dan
+// seluruh tiketnya tetap berstatus refunded setelah refund selesai.
+type Refund struct {
+	ID              int          `json:"id"`
+	TransactionID   int          `json:"transaction_id"`
+	EventID         int          `json:"event_id"`
+	UserID          int          `json:"user_id"`
+	Amount          Money        `json:"amount"`
+	Status          RefundStatus `json:"status"`
+	Source          string       `json:"source"`
+	Reason          string       `json:"reason,omitempty"`
+	ReviewedBy      int          `json:"reviewed_by,omitempty"`
+	ReviewedAt      time.Time    `json:"reviewed_at,omitempty"`
+	RejectReason    string       `json:"reject_reason,omitempty"`
+	GatewayRefundID string       `json:"gateway_refund_id,omitempty"`
+	FailureReason   string       `json:"failure_reason,omitempty"`
+	// ProcessingStartedAt dicatat saat refund diklaim untuk dikirim ke payment gateway
+	ProcessingStartedAt time.Time `json:"processing_started_at,omitempty"`
+	CompletedAt         time.Time `json:"completed_at,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// RefundPolicy mengatur refund atas permintaan pembeli. Pembatalan event selalu dikembalikan
+// penuh tanpa melihat kebijakan ini.
+type RefundPolicy struct {
+	Allowed bool `json:"allowed"`
+	// DeadlineHours adalah batas pengajuan refund dalam jam sebelum event dimulai
+	DeadlineHours int `json:"deadline_hours"`
+	// Percent adalah porsi total transaksi yang dikembalikan
+	Percent Percent `json:"percent"`
+}
+
+// Deadline mengembalikan waktu terakhir pembeli boleh mengajukan refund
+func (p RefundPolicy) Deadline(eventDate time.Time) time.Time {
+	return eventDate.Add(-time.Duration(p.DeadlineHours) * time.Hour)
+}
+
+// Amount menghitung nominal refund untuk total transaksi sesuai Percent
+func (p RefundPolicy) Amount(total Money) Money {
+	return total.Percent(p.Percent)
+}
@@ -0,0 +1,33 @@
+//internal/domain/entity/seat_hold.go
+
+package entity
+
+import "time"
+
+type SeatHoldStatus string
+
+const (
+	SeatHoldStatusHeld     SeatHoldStatus = "held"
+	SeatHoldStatusBooked   SeatHoldStatus = "booked"
+	SeatHoldStatusReleased SeatHoldStatus = "released"
+)
+
+// SeatHold mengunci satu kursi event untuk satu pembeli. Hold berstatus held hanya berlaku
+// sampai ExpiresAt selama pembeli checkout, lalu menjadi booked saat transaksinya dibuat dan
+// released saat kedaluwarsa atau transaksinya berakhir tanpa pembayaran.
+type SeatHold struct {
+	ID            int            `json:"id"`
+	EventID       int            `json:"event_id"`
+	SeatID        int            `json:"seat_id"`
+	UserID        int            `json:"user_id"`
+	TransactionID int            `json:"transaction_id,omitempty"`
+	Status        SeatHoldStatus `json:"status"`
+	ExpiresAt     time.Time      `json:"expires_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// Occupies bernilai true jika kursi tidak bisa diambil pembeli lain pada waktu now
+func (h *SeatHold) Occupies(now time.Time) bool {
+	return h.Status == SeatHoldStatusBooked || (h.Status == SeatHoldStatusHeld && h.ExpiresAt.After(now))
+}
@@ -0,0 +1,36 @@
+//internal/domain/entity/ticket.go
+
+package entity
+
+import "time"
+
+type TicketStatus string
+
+const (
+	TicketStatusActive    TicketStatus = "active"
+	TicketStatusUsed      TicketStatus = "used"
+	TicketStatusCancelled TicketStatus = "cancelled"
+	TicketStatusRefunded  TicketStatus = "refunded"
+)
+
+// Ticket adalah satu kursi dari transaksi yang sudah dibayar
+type Ticket struct {
+	ID            int          `json:"id"`
+	TransactionID int          `json:"transaction_id"`
+	EventID       int          `json:"event_id"`
+	TicketTypeID  int          `json:"ticket_type_id,omitempty"`
+	UserID        int          `json:"user_id"`
+	TicketCode    string       `json:"ticket_code"`
+	SeatNumber    int          `json:"seat_number"`
+	SeatID        int          `json:"seat_id,omitempty"`
+	SeatLabel     string       `json:"seat_label,omitempty"`
+	QRNonce       string       `json:"-"`
+	Status        TicketStatus `json:"status"`
+	PurchaseDate  time.Time    `json:"purchase_date"`
+	CheckedInAt   time.Time    `json:"checked_in_at,omitempty"`
+	CheckedInBy   int          `json:"checked_in_by,omitempty"`
+	CheckedInGate string       `json:"checked_in_gate,omitempty"`
+	TransferCount int          `json:"transfer_count"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
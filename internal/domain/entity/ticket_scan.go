@@ -0,0 +1,29 @@
+//internal/domain/entity/ticket_scan.go
+
+package entity
+
+import "time"
+
+type TicketScanResult string
+
+const (
+	TicketScanResultCheckedIn  TicketScanResult = "checked_in"
+	TicketScanResultDuplicate  TicketScanResult = "duplicate"
+	TicketScanResultInvalid    TicketScanResult = "invalid"
+	TicketScanResultWrongEvent TicketScanResult = "wrong_event"
+	TicketScanResultNotActive  TicketScanResult = "not_active"
+)
+
+// TicketScan adalah satu scan QR dari perangkat check-in yang disinkronkan ke server
+type TicketScan struct {
+	ID          int              `json:"id"`
+	EventID     int              `json:"event_id"`
+	TicketID    int              `json:"ticket_id,omitempty"`
+	DeviceID    string           `json:"device_id"`
+	Gate        string           `json:"gate"`
+	PayloadHash string           `json:"-"`
+	ScannedAt   time.Time        `json:"scanned_at"`
+	Result      TicketScanResult `json:"result"`
+	SyncedBy    int              `json:"synced_by"`
+	SyncedAt    time.Time        `json:"synced_at"`
+}
@@ -0,0 +1,47 @@
+//internal/domain/entity/ticket_transfer.go
+
+package entity
+
+import "time"
+
+type TicketTransferStatus string
+
+const (
+	TicketTransferStatusPending   TicketTransferStatus = "pending"
+	TicketTransferStatusAccepted  TicketTransferStatus = "accepted"
+	TicketTransferStatusDeclined  TicketTransferStatus = "declined"
+	TicketTransferStatusCancelled TicketTransferStatus = "cancelled"
+)
+
+// TicketTransfer adalah satu perpindahan kepemilikan tiket. Riwayat transfer dengan status
+// accepted membentuk rantai kepemilikan tiket dari pembeli pertama sampai pemilik sekarang.
+type TicketTransfer struct {
+	ID          int                  `json:"id"`
+	TicketID    int                  `json:"ticket_id"`
+	EventID     int                  `json:"event_id"`
+	FromUserID  int                  `json:"from_user_id"`
+	ToUserID    int                  `json:"to_user_id"`
+	Status      TicketTransferStatus `json:"status"`
+	RespondedAt time.Time            `json:"responded_at,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// TransferPolicy mengatur pemindahtanganan tiket oleh pemiliknya
+type TransferPolicy struct {
+	Allowed bool `json:"allowed"`
+	// DeadlineHours adalah batas transfer dalam jam sebelum event dimulai
+	DeadlineHours int `json:"deadline_hours"`
+	// MaxTransfers adalah batas berapa kali satu tiket boleh dipindahtangankan, 0 berarti tanpa batas
+	MaxTransfers int `json:"max_transfers"`
+}
+
+// Deadline mengembalikan waktu terakhir tiket boleh dipindahtangankan
+func (p TransferPolicy) Deadline(eventDate time.Time) time.Time {
+	return eventDate.Add(-time.Duration(p.DeadlineHours) * time.Hour)
+}
+
+// LimitReached menandakan tiket sudah dipindahtangankan sebanyak batas kebijakan
+func (p TransferPolicy) LimitReached(transferCount int) bool {
+	return p.MaxTransfers > 0 && transferCount >= p.MaxTransfers
+}
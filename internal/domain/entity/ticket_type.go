@@ -0,0 +1,32 @@
+//internal/domain/entity/ticket_type.go
+
+package entity
+
+import "time"
+
+// TicketType adalah kategori tiket dalam satu event dengan harga dan kuota sendiri
+type TicketType struct {
+	ID          int       `json:"id"`
+	EventID     int       `json:"event_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Price       Money     `json:"price"`
+	Quota       int       `json:"quota"`
+	Sold        int       `json:"sold"`
+	SalesStart  time.Time `json:"sales_start,omitempty"`
+	SalesEnd    time.Time `json:"sales_end,omitempty"`
+	MaxPerOrder int       `json:"max_per_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OnSale mengecek apakah tipe tiket sedang dalam periode penjualan. Batas yang kosong berarti tidak dibatasi.
+func (t *TicketType) OnSale(now time.Time) bool {
+	if !t.SalesStart.IsZero() && now.Before(t.SalesStart) {
+		return false
+	}
+	if !t.SalesEnd.IsZero() && !now.Before(t.SalesEnd) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+//internal/domain/entity/token_revocation.go
+
+package entity
+
+import "time"
+
+// TokenRevocation mencabut access token sebelum masa berlakunya habis. Jika JTI terisi, hanya
+// token dengan jti tersebut yang dicabut (logout). Jika kosong, semua access token milik UserID
+// dengan versi token di bawah TokenVersion ikut dicabut (logout dari semua perangkat atau ganti
+// password). ExpiresAt adalah saat token terakhir yang terdampak kedaluwarsa, setelah itu entry
+// boleh dihapus.
+type TokenRevocation struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"user_id"`
+	JTI          string    `json:"jti,omitempty"`
+	TokenVersion int       `json:"token_version,omitempty"`
+	RevokedAt    time.Time `json:"revoked_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Covers bernilai true jika access token dengan jti dan versi token tersebut ikut dicabut.
+// Pencabutan seluruh sesi dibandingkan lewat versi, bukan waktu terbit yang dibulatkan ke detik,
+// sehingga token yang terbit setelah pencabutan tetap berlaku walau di detik yang sama.
+func (r *TokenRevocation) Covers(userID int, jti string, tokenVersion int) bool {
+	if r.JTI != "" {
+		return r.JTI == jti
+	}
+	return r.UserID == userID && tokenVersion < r.TokenVersion
+}
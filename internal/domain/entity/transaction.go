@@ -5,18 +5,28 @@ package entity
 import "time"
 
 type Transaction struct {
-	ID              int       `json:"id"`
-	UserID          int       `json:"user_id"`
-	EventID         int       `json:"event_id"`
-	TransactionCode string    `json:"transaction_code"`
-	Quantity        int       `json:"quantity"`
-	TotalAmount     float64   `json:"total_amount"`
-	Status          string    `json:"status"` 
-	PaymentMethod   string    `json:"payment_method"`
-	PaymentDetail   string    `json:"payment_detail"`
-	PaymentProof    string    `json:"payment_proof"`
-	VerifiedAt      time.Time `json:"verified_at,omitempty"`
-	VerifiedBy      int       `json:"verified_by,omitempty"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
-}
\ No newline at end of file
+	ID              int               `json:"id"`
+	UserID          int               `json:"user_id"`
+	EventID         int               `json:"event_id"`
+	TransactionCode string            `json:"transaction_code"`
+	Quantity        int               `json:"quantity"`
+	TotalAmount     Money             `json:"total_amount"`
+	Status          TransactionStatus `json:"status"`
+	PaymentMethod   string            `json:"payment_method"`
+	PaymentDetail   string            `json:"payment_detail"`
+	PaymentProof    string            `json:"payment_proof"`
+	VerifiedAt      time.Time         `json:"verified_at,omitempty"`
+	VerifiedBy      int               `json:"verified_by,omitempty"`
+	ExpiresAt       time.Time         `json:"expires_at"`
+	PromoCodeID     int               `json:"promo_code_id,omitempty"`
+	DiscountAmount  Money             `json:"discount_amount"`
+	// Rincian harga: TotalAmount = SubtotalAmount - DiscountAmount + ServiceFeeAmount + TaxAmount,
+	// ditambah PlatformFeeAmount bila PlatformFeeToBuyer
+	SubtotalAmount     Money     `json:"subtotal_amount"`
+	ServiceFeeAmount   Money     `json:"service_fee_amount"`
+	PlatformFeeAmount  Money     `json:"platform_fee_amount"`
+	PlatformFeeToBuyer bool      `json:"platform_fee_to_buyer"`
+	TaxAmount          Money     `json:"tax_amount"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
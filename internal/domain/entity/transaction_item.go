@@ -0,0 +1,15 @@
+//internal/domain/entity/transaction_item.go
+
+package entity
+
+// TransactionItem mencatat jumlah dan harga satuan per tipe tiket saat transaksi dibuat,
+// sehingga perubahan harga tipe tiket setelahnya tidak mengubah nilai transaksi lama
+type TransactionItem struct {
+	ID             int    `json:"id"`
+	TransactionID  int    `json:"transaction_id"`
+	TicketTypeID   int    `json:"ticket_type_id"`
+	TicketTypeName string `json:"ticket_type_name"`
+	Quantity       int    `json:"quantity"`
+	UnitPrice      Money  `json:"unit_price"`
+	Subtotal       Money  `json:"subtotal"`
+}
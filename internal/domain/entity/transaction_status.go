@@ -0,0 +1,76 @@
+//internal/domain/entity/transaction_status.go
+
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+type TransactionStatus string
+
+const (
+	TransactionStatusPending             TransactionStatus = "pending"
+	TransactionStatusWaitingVerification TransactionStatus = "waiting_verification"
+	TransactionStatusPaid                TransactionStatus = "paid"
+	TransactionStatusCancelled           TransactionStatus = "cancelled"
+	TransactionStatusExpired             TransactionStatus = "expired"
+	TransactionStatusFailed              TransactionStatus = "failed"
+	TransactionStatusRefunded            TransactionStatus = "refunded"
+	TransactionStatusRejected            TransactionStatus = "rejected"
+)
+
+// ErrInvalidStatusTransition dikembalikan ketika perubahan status tidak ada di tabel transisi
+var ErrInvalidStatusTransition = errors.New("perubahan status transaksi tidak diizinkan")
+
+// transactionTransitions adalah satu-satunya sumber kebenaran perubahan status transaksi.
+// Status yang tidak punya tujuan (cancelled, expired, failed, refunded, rejected) adalah status akhir.
+var transactionTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending: {
+		TransactionStatusWaitingVerification,
+		TransactionStatusPaid,
+		TransactionStatusCancelled,
+		TransactionStatusExpired,
+		TransactionStatusFailed,
+	},
+	TransactionStatusWaitingVerification: {
+		TransactionStatusPaid,
+		TransactionStatusPending,
+		TransactionStatusRejected,
+	},
+	TransactionStatusPaid: {
+		TransactionStatusRefunded,
+	},
+}
+
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range transactionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s TransactionStatus) IsFinal() bool {
+	return len(transactionTransitions[s]) == 0
+}
+
+// Pihak yang melakukan perubahan status
+const (
+	StatusActorUser           = "user"
+	StatusActorOrganizer      = "organizer"
+	StatusActorSystem         = "system"
+	StatusActorPaymentGateway = "payment_gateway"
+)
+
+type TransactionStatusHistory struct {
+	ID            int               `json:"id"`
+	TransactionID int               `json:"transaction_id"`
+	FromStatus    TransactionStatus `json:"from_status,omitempty"`
+	ToStatus      TransactionStatus `json:"to_status"`
+	ActorType     string            `json:"actor_type"`
+	ActorID       int               `json:"actor_id,omitempty"`
+	Reason        string            `json:"reason,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
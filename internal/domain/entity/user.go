@@ -7,12 +7,15 @@ import (
 )
 
 type User struct {
-	ID         int       `json:"id"`
-	Username   string    `json:"username"`
-	Email      string    `json:"email"`
-	Password   string    `json:"-"`
-	Role       string    `json:"role"`
-	IsVerified bool      `json:"is_verified"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-}
\ No newline at end of file
+	ID         int    `json:"id"`
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	Password   string `json:"-"`
+	Role       string `json:"role"`
+	IsVerified bool   `json:"is_verified"`
+	// TokenVersion naik setiap semua sesi user dicabut, access token dengan versi lebih lama
+	// tidak berlaku lagi
+	TokenVersion int       `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+//internal/domain/entity/user_mfa.go
+
+package entity
+
+import "time"
+
+// UserMFA menyimpan secret TOTP (RFC 6238) milik user dalam bentuk terenkripsi. Baris dibuat saat
+// setup dan baru berlaku setelah user mengonfirmasi kode pertama (EnabledAt terisi).
+type UserMFA struct {
+	UserID          int       `json:"user_id"`
+	SecretEncrypted string    `json:"-"`
+	EnabledAt       time.Time `json:"enabled_at,omitempty"`
+	// LastUsedStep adalah time step TOTP terakhir yang diterima, kode dari step yang sama atau
+	// lebih lama ditolak agar satu kode tidak bisa dipakai dua kali
+	LastUsedStep int64     `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Enabled bernilai true jika enrolment sudah dikonfirmasi dan login wajib melewati 2FA
+func (m *UserMFA) Enabled() bool {
+	return !m.EnabledAt.IsZero()
+}
+
+// MFARecoveryCode adalah kode cadangan sekali pakai untuk login saat aplikasi authenticator
+// tidak tersedia. Kode disimpan sebagai hash argon2 seperti password.
+type MFARecoveryCode struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	CodeHash  string    `json:"-"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *MFARecoveryCode) Used() bool {
+	return !c.UsedAt.IsZero()
+}
@@ -0,0 +1,38 @@
+//internal/domain/entity/venue.go
+
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Venue adalah denah tempat duduk milik organizer yang bisa dipakai ulang oleh beberapa event.
+// Denah tidak bisa diubah setelah dibuat karena kursinya direferensikan oleh hold dan tiket.
+type Venue struct {
+	ID        int       `json:"id"`
+	OwnerID   int       `json:"owner_id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	SeatCount int       `json:"seat_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// VenueSeat adalah satu kursi di denah venue. X dan Y adalah posisi kursi pada denah dalam
+// satuan bebas yang ditentukan organizer, dipakai klien untuk menggambar peta kursi.
+type VenueSeat struct {
+	ID         int     `json:"id"`
+	VenueID    int     `json:"venue_id"`
+	Section    string  `json:"section"`
+	Row        string  `json:"row"`
+	Number     string  `json:"number"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Accessible bool    `json:"accessible"`
+}
+
+// Label mengembalikan nama kursi yang dicetak di tiket, misalnya "VIP B-12"
+func (s VenueSeat) Label() string {
+	return fmt.Sprintf("%s %s-%s", s.Section, s.Row, s.Number)
+}
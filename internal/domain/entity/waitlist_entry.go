@@ -0,0 +1,37 @@
+//internal/domain/entity/waitlist_entry.go
+
+package entity
+
+import "time"
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusClaimed   WaitlistStatus = "claimed"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+	WaitlistStatusCancelled WaitlistStatus = "cancelled"
+)
+
+// WaitlistEntry adalah antrean pembeli untuk event yang kapasitasnya habis. Saat ditawari,
+// kursi sejumlah Quantity langsung dipesan di event sampai OfferExpiresAt sehingga pembeli lain
+// tidak bisa mengambilnya. Penawaran yang tidak diklaim berstatus expired dan kursinya ditawarkan
+// ke antrean berikutnya.
+type WaitlistEntry struct {
+	ID                   int            `json:"id"`
+	EventID              int            `json:"event_id"`
+	UserID               int            `json:"user_id"`
+	Quantity             int            `json:"quantity"`
+	Status               WaitlistStatus `json:"status"`
+	OfferedAt            time.Time      `json:"offered_at,omitempty"`
+	OfferExpiresAt       time.Time      `json:"offer_expires_at,omitempty"`
+	ClaimedTransactionID int            `json:"claimed_transaction_id,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+// Active bernilai true selama entry masih menunggu atau memegang penawaran
+func (e *WaitlistEntry) Active() bool {
+	return e.Status == WaitlistStatusWaiting || e.Status == WaitlistStatusOffered
+}
@@ -0,0 +1,89 @@
+//internal/domain/gateway/payment_gateway.go
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+// ErrInvalidSignature dikembalikan ketika notifikasi tidak ditandatangani oleh payment gateway
+var ErrInvalidSignature = errors.New("signature notifikasi tidak valid")
+
+// ErrInvalidNotification dikembalikan ketika payload notifikasi tidak bisa dibaca
+var ErrInvalidNotification = errors.New("payload notifikasi tidak valid")
+
+// Hasil pembayaran yang sudah diterjemahkan dari status milik masing-masing gateway
+const (
+	PaymentResultPending  = "pending"
+	PaymentResultPaid     = "paid"
+	PaymentResultExpired  = "expired"
+	PaymentResultFailed   = "failed"
+	PaymentResultRefunded = "refunded"
+)
+
+type PaymentItem struct {
+	ID       string
+	Name     string
+	Price    int64
+	Quantity int
+}
+
+type PaymentCustomer struct {
+	FirstName string
+	Email     string
+	Phone     string
+}
+
+// PaymentRequest berisi data yang dibutuhkan payment gateway untuk membuat sesi pembayaran.
+// OrderID memakai transaction_code agar notifikasi dari gateway bisa dicocokkan kembali.
+type PaymentRequest struct {
+	OrderID       string
+	GrossAmount   int64
+	Items         []PaymentItem
+	Customer      PaymentCustomer
+	ExpiryMinutes int
+}
+
+type PaymentSession struct {
+	Token       string
+	RedirectURL string
+}
+
+// PaymentNotification adalah notifikasi dari gateway yang signature-nya sudah diverifikasi.
+// GatewayStatus menyimpan status asli gateway, Result adalah terjemahannya.
+type PaymentNotification struct {
+	OrderID              string
+	GatewayTransactionID string
+	PaymentType          string
+	GatewayStatus        string
+	StatusCode           string
+	StatusMessage        string
+	GrossAmount          entity.Money
+	PaymentTime          time.Time
+	Result               string
+	Payload              []byte
+}
+
+// RefundRequest mengembalikan sebagian atau seluruh dana sebuah order. RefundKey harus unik per
+// pengajuan refund agar gateway menolak permintaan ganda ketika request diulang.
+type RefundRequest struct {
+	OrderID   string
+	RefundKey string
+	Amount    int64
+	Reason    string
+}
+
+type RefundResult struct {
+	GatewayRefundID string
+	Amount          int64
+}
+
+type PaymentGateway interface {
+	CreatePayment(ctx context.Context, req PaymentRequest) (*PaymentSession, error)
+	ParseNotification(payload []byte) (*PaymentNotification, error)
+	Refund(ctx context.Context, req RefundRequest) (*RefundResult, error)
+}
@@ -0,0 +1,41 @@
+//internal/domain/repository/errors.go
+
+package repository
+
+import "errors"
+
+// ErrTicketSoldOut dikembalikan ketika reservasi kursi gagal karena sisa kapasitas event tidak mencukupi
+var ErrTicketSoldOut = errors.New("jumlah tiket yang diminta melebihi kapasitas")
+
+// ErrTicketTypeSoldOut dikembalikan ketika sisa kuota salah satu tipe tiket tidak mencukupi
+var ErrTicketTypeSoldOut = errors.New("kuota tipe tiket tidak mencukupi")
+
+// ErrPromoCodeExhausted dikembalikan ketika kode promo sudah mencapai batas total pemakaian
+var ErrPromoCodeExhausted = errors.New("kode promo sudah habis digunakan")
+
+// ErrPromoUserLimitReached dikembalikan ketika pembeli sudah mencapai batas pemakaian kode promo per user
+var ErrPromoUserLimitReached = errors.New("batas pemakaian kode promo untuk akun anda sudah tercapai")
+
+// ErrStatusConflict dikembalikan ketika status transaksi sudah diubah proses lain sebelum update dijalankan
+var ErrStatusConflict = errors.New("status transaksi sudah berubah, silakan coba lagi")
+
+// ErrRefundExists dikembalikan ketika transaksi sudah memiliki refund yang belum ditolak
+var ErrRefundExists = errors.New("transaksi sudah memiliki pengajuan refund")
+
+// ErrIdempotencyKeyExists dikembalikan ketika idempotency key masih dipakai oleh request lain
+var ErrIdempotencyKeyExists = errors.New("idempotency key sudah digunakan")
+
+// ErrWaitlistEntryExists dikembalikan ketika user masih memiliki antrean aktif di event yang sama
+var ErrWaitlistEntryExists = errors.New("anda sudah terdaftar di waitlist event ini")
+
+// ErrTicketTransferExists dikembalikan ketika tiket masih memiliki transfer yang menunggu konfirmasi penerima
+var ErrTicketTransferExists = errors.New("tiket masih memiliki transfer yang menunggu konfirmasi")
+
+// ErrVenueInUse dikembalikan ketika venue yang akan dihapus masih dipakai event
+var ErrVenueInUse = errors.New("venue masih dipakai oleh event")
+
+// ErrSeatUnavailable dikembalikan ketika kursi yang diminta sedang dipegang atau sudah dipesan pembeli lain
+var ErrSeatUnavailable = errors.New("kursi yang dipilih sudah tidak tersedia")
+
+// ErrSeatHoldExpired dikembalikan ketika kursi yang dibeli tidak lagi dipegang pembeli
+var ErrSeatHoldExpired = errors.New("hold kursi tidak ditemukan atau sudah kedaluwarsa")
@@ -14,7 +14,12 @@ type EventRepository interface {
 	CountAll(ctx context.Context) (int, error)
 	FindByOwnerID(ctx context.Context, ownerID, offset, limit int) ([]entity.Event, error)
 	CountByOwnerID(ctx context.Context, ownerID int) (int, error)
+	// Update tidak mengubah tickets_sold. Mengembalikan ErrStatusConflict jika tiket yang sudah
+	// terjual melebihi MaxCapacity yang baru.
 	Update(ctx context.Context, event *entity.Event) error
 	Delete(ctx context.Context, id int) error
 	UpdateTicketsSold(ctx context.Context, eventID, quantity int) error
+	// AssignVenue memasang denah venue dan mengubah kapasitas event menjadi jumlah kursinya.
+	// Mengembalikan ErrStatusConflict jika event sudah memiliki penjualan.
+	AssignVenue(ctx context.Context, eventID, venueID, capacity int) error
 }
\ No newline at end of file
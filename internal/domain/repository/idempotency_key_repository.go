@@ -0,0 +1,25 @@
+//internal/domain/repository/idempotency_key_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type IdempotencyKeyRepository interface {
+	// Create menyimpan key baru berstatus processing. Key yang sudah kedaluwarsa atau masih
+	// processing sejak sebelum staleBefore diambil alih. Mengembalikan ErrIdempotencyKeyExists
+	// jika key masih dipakai request lain.
+	Create(ctx context.Context, key *entity.IdempotencyKey, staleBefore time.Time) (int, error)
+	// FindByKey mengembalikan key yang belum kedaluwarsa atau nil jika tidak ada
+	FindByKey(ctx context.Context, userID int, key string) (*entity.IdempotencyKey, error)
+	// Complete menyimpan respons dan mengubah status menjadi completed. Mengembalikan
+	// ErrStatusConflict jika key sudah tidak berstatus processing.
+	Complete(ctx context.Context, key *entity.IdempotencyKey) error
+	// Delete melepas key yang masih processing agar request bisa diulang dengan key yang sama
+	Delete(ctx context.Context, id int) error
+	DeleteExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,22 @@
+//internal/domain/repository/password_reset_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *entity.PasswordReset) (int, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordReset, error)
+	// FindLatestByUserID mengembalikan permintaan reset terakhir user, nil jika belum pernah ada
+	FindLatestByUserID(ctx context.Context, userID int) (*entity.PasswordReset, error)
+	// MarkUsed menandai token sudah dipakai. Mengembalikan ErrStatusConflict jika token sudah
+	// dipakai lebih dulu oleh request lain.
+	MarkUsed(ctx context.Context, id int, usedAt time.Time) error
+	// DeleteUnusedByUserID menghapus token user yang belum dipakai agar tautan lama tidak berlaku
+	DeleteUnusedByUserID(ctx context.Context, userID int) error
+}
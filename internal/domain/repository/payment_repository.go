@@ -0,0 +1,14 @@
+//internal/domain/repository/payment_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entity.Payment) (int, error)
+	FindByTransactionID(ctx context.Context, transactionID int) (*entity.Payment, error)
+	UpdateFromNotification(ctx context.Context, payment *entity.Payment) error
+}
@@ -0,0 +1,19 @@
+//internal/domain/repository/pricing_rule_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type PricingRuleRepository interface {
+	// FindDefault mengembalikan aturan default organizer (event_id kosong) atau nil jika belum diatur
+	FindDefault(ctx context.Context, ownerID int) (*entity.PricingRule, error)
+	FindByEventID(ctx context.Context, eventID int) (*entity.PricingRule, error)
+	// FindApplicable mengembalikan aturan khusus event jika ada, jika tidak aturan default organizer
+	FindApplicable(ctx context.Context, ownerID, eventID int) (*entity.PricingRule, error)
+	// Upsert membuat atau mengganti aturan untuk pasangan owner/event yang sama
+	Upsert(ctx context.Context, rule *entity.PricingRule) error
+	DeleteByEventID(ctx context.Context, eventID int) error
+}
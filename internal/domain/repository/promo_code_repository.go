@@ -0,0 +1,23 @@
+//internal/domain/repository/promo_code_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type PromoCodeRepository interface {
+	Create(ctx context.Context, promo *entity.PromoCode) (int, error)
+	FindByID(ctx context.Context, id int) (*entity.PromoCode, error)
+	FindByOwnerID(ctx context.Context, ownerID int) ([]entity.PromoCode, error)
+	// FindApplicable mencari kode milik ownerID yang khusus untuk eventID atau berlaku untuk semua event
+	FindApplicable(ctx context.Context, code string, eventID, ownerID int) (*entity.PromoCode, error)
+	Update(ctx context.Context, promo *entity.PromoCode) error
+	// Redeem mencatat pemakaian kode dan menaikkan used_count secara atomik. Mengembalikan
+	// ErrPromoCodeExhausted atau ErrPromoUserLimitReached jika batas pemakaian sudah tercapai.
+	Redeem(ctx context.Context, redemption *entity.PromoRedemption) error
+	// Release melepas redemption aktif milik transaksi dan mengembalikan kuota kode. Aman dipanggil
+	// untuk transaksi tanpa kode promo maupun yang sudah dilepas sebelumnya.
+	Release(ctx context.Context, transactionID int) error
+}
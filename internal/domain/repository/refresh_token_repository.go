@@ -0,0 +1,23 @@
+//internal/domain/repository/refresh_token_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) (int, error)
+	// FindByHash mengembalikan token termasuk yang sudah rotated atau dicabut, nil jika tidak ada
+	FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// MarkRotated menandai token sudah ditukar. Mengembalikan ErrStatusConflict jika token sudah
+	// rotated atau dicabut lebih dulu oleh request lain.
+	MarkRotated(ctx context.Context, id int, rotatedAt time.Time) error
+	// RevokeFamily mencabut semua token yang belum dicabut dalam satu family
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	// RevokeByUserID mencabut semua refresh token user yang belum dicabut
+	RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time) error
+}
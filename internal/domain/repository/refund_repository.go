@@ -0,0 +1,35 @@
+//internal/domain/repository/refund_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type RefundRepository interface {
+	// Create mengembalikan ErrRefundExists jika transaksi sudah memiliki refund yang belum ditolak
+	Create(ctx context.Context, refund *entity.Refund) (int, error)
+	FindByID(ctx context.Context, id int) (*entity.Refund, error)
+	// FindByTransactionID mengembalikan refund terbaru milik transaksi atau nil jika belum ada
+	FindByTransactionID(ctx context.Context, transactionID int) (*entity.Refund, error)
+	FindByEventID(ctx context.Context, eventID int) ([]entity.Refund, error)
+	FindByStatus(ctx context.Context, status entity.RefundStatus, limit int) ([]entity.Refund, error)
+	// UpdateStatus hanya mengubah refund yang masih berstatus from. Mengembalikan
+	// ErrStatusConflict jika refund sudah diubah proses lain.
+	UpdateStatus(ctx context.Context, id int, from, to entity.RefundStatus) error
+	// StartProcessing mengklaim refund approved, atau refund processing yang dimulai sebelum
+	// staleBefore, menjadi processing dan mencatat waktu mulainya. Mengembalikan
+	// ErrStatusConflict jika refund sudah diambil proses lain.
+	StartProcessing(ctx context.Context, id int, staleBefore time.Time) error
+	// FindStaleProcessing mengembalikan refund processing yang dimulai sebelum staleBefore
+	FindStaleProcessing(ctx context.Context, staleBefore time.Time, limit int) ([]entity.Refund, error)
+	// Update menyimpan nominal, data review, dan hasil pemrosesan refund tanpa mengubah status
+	Update(ctx context.Context, refund *entity.Refund) error
+	// QueueCancelledEventRefunds menyiapkan refund penuh berstatus approved untuk transaksi paid
+	// milik event yang dibatalkan, termasuk pengajuan pembeli yang belum direview. Mengembalikan
+	// jumlah refund yang masuk antrean.
+	QueueCancelledEventRefunds(ctx context.Context, limit int) (int, error)
+}
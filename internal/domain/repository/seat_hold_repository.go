@@ -0,0 +1,32 @@
+//internal/domain/repository/seat_hold_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type SeatHoldRepository interface {
+	// Hold melepas hold held milik user di event lalu mengunci seatIDs sampai expiresAt. Semua
+	// kursi dikunci sekaligus atau tidak sama sekali: mengembalikan ErrSeatUnavailable jika
+	// salah satu kursi masih dipegang pembeli lain.
+	Hold(ctx context.Context, eventID, userID int, seatIDs []int, now, expiresAt time.Time) ([]entity.SeatHold, error)
+	// FindActiveByEventID mengembalikan hold yang masih menempati kursi event pada waktu now
+	FindActiveByEventID(ctx context.Context, eventID int, now time.Time) ([]entity.SeatHold, error)
+	// FindHeldByUser mengembalikan hold held milik user di event yang belum kedaluwarsa
+	FindHeldByUser(ctx context.Context, eventID, userID int, now time.Time) ([]entity.SeatHold, error)
+	// FindSeatsByTransactionID mengembalikan kursi yang dipesan transaksi, urut per section dan baris
+	FindSeatsByTransactionID(ctx context.Context, transactionID int) ([]entity.VenueSeat, error)
+	// Book mengubah hold held milik user menjadi booked untuk transaksi. Mengembalikan
+	// ErrSeatHoldExpired jika ada kursi yang tidak lagi dipegang user pada waktu now.
+	Book(ctx context.Context, eventID, userID, transactionID int, seatIDs []int, now time.Time) error
+	// ReleaseByUser melepas semua hold held milik user di event
+	ReleaseByUser(ctx context.Context, eventID, userID int) (int, error)
+	// ReleaseByTransactionID melepas kursi booked milik transaksi yang berakhir tanpa tiket aktif
+	ReleaseByTransactionID(ctx context.Context, transactionID int) error
+	// ExpireHolds melepas hold held yang melewati expiresAt
+	ExpireHolds(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,33 @@
+//internal/domain/repository/ticket_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type TicketRepository interface {
+	// CreateBatch menyimpan tiket satu per satu dan melewati kursi yang sudah pernah diterbitkan
+	CreateBatch(ctx context.Context, tickets []*entity.Ticket) error
+	FindByID(ctx context.Context, id int) (*entity.Ticket, error)
+	FindByCode(ctx context.Context, code string) (*entity.Ticket, error)
+	FindByTransactionID(ctx context.Context, transactionID int) ([]entity.Ticket, error)
+	FindByEventID(ctx context.Context, eventID int) ([]entity.Ticket, error)
+	FindByUserID(ctx context.Context, userID, offset, limit int) ([]entity.Ticket, error)
+	CountByUserID(ctx context.Context, userID int) (int, error)
+	// UpdateStatusByTransactionID mengubah semua tiket transaksi yang masih berstatus from
+	UpdateStatusByTransactionID(ctx context.Context, transactionID int, from, to entity.TicketStatus) (int, error)
+	// CheckIn menandai tiket aktif sebagai used. Mengembalikan ErrStatusConflict jika tiket
+	// sudah tidak aktif, termasuk ketika kalah dari scan lain yang berjalan bersamaan.
+	CheckIn(ctx context.Context, ticketID, staffID int, gate string) error
+	// CheckInAt mencatat check-in hasil sinkronisasi offline. Waktu check-in paling awal yang
+	// menang: tiket aktif ditandai used, tiket yang sudah used hanya diperbarui jika scannedAt
+	// lebih awal dari check-in yang tercatat. Mengembalikan false jika scan ini bukan yang pertama.
+	CheckInAt(ctx context.Context, ticketID, staffID int, gate string, scannedAt time.Time) (bool, error)
+	// Transfer memindahkan tiket aktif milik fromUserID ke toUserID dan mengganti nonce QR.
+	// Mengembalikan ErrStatusConflict jika tiket sudah tidak aktif atau sudah berpindah pemilik.
+	Transfer(ctx context.Context, ticketID, fromUserID, toUserID int, qrNonce string) error
+}
@@ -0,0 +1,14 @@
+//internal/domain/repository/ticket_scan_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type TicketScanRepository interface {
+	// Create mengembalikan false jika scan yang sama (perangkat, QR, waktu scan) sudah pernah disinkronkan
+	Create(ctx context.Context, scan *entity.TicketScan) (bool, error)
+	CountByEventAndResult(ctx context.Context, eventID int, result entity.TicketScanResult) (int, error)
+}
@@ -0,0 +1,22 @@
+//internal/domain/repository/ticket_transfer_repository.go
+
+package repository
+
+import (
+	"context"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type TicketTransferRepository interface {
+	// Create mengembalikan ErrTicketTransferExists jika tiket masih memiliki transfer pending
+	Create(ctx context.Context, transfer *entity.TicketTransfer) (int, error)
+	FindByID(ctx context.Context, id int) (*entity.TicketTransfer, error)
+	// FindByTicketID mengembalikan semua transfer tiket, diurutkan dari yang paling lama
+	FindByTicketID(ctx context.Context, ticketID int) ([]entity.TicketTransfer, error)
+	// FindPendingByUserID mengembalikan transfer pending yang dikirim maupun diterima user
+	FindPendingByUserID(ctx context.Context, userID int) ([]entity.TicketTransfer, error)
+	// UpdateStatus mengubah status transfer hanya jika status saat ini masih from.
+	// Mengembalikan ErrStatusConflict jika transfer sudah diproses request lain.
+	UpdateStatus(ctx context.Context, id int, from, to entity.TicketTransferStatus) error
+}
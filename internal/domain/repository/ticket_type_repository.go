@@ -0,0 +1,19 @@
+//internal/domain/repository/ticket_type_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type TicketTypeRepository interface {
+	Create(ctx context.Context, ticketType *entity.TicketType) (int, error)
+	FindByID(ctx context.Context, id int) (*entity.TicketType, error)
+	FindByEventID(ctx context.Context, eventID int) ([]entity.TicketType, error)
+	Update(ctx context.Context, ticketType *entity.TicketType) error
+	Delete(ctx context.Context, id int) error
+	// Reserve menambah sold secara kondisional, mengembalikan ErrTicketTypeSoldOut jika kuota tidak cukup
+	Reserve(ctx context.Context, id, quantity int) error
+	Release(ctx context.Context, id, quantity int) error
+}
@@ -0,0 +1,19 @@
+//internal/domain/repository/token_revocation_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type TokenRevocationRepository interface {
+	// Create menyimpan pencabutan, jti yang sudah dicabut diabaikan
+	Create(ctx context.Context, revocation *entity.TokenRevocation) error
+	// IsRevoked mengecek apakah access token sudah dicabut. Implementasi boleh menjawab dari cache
+	// yang disinkronkan berkala dengan penyimpanan utama.
+	IsRevoked(ctx context.Context, userID int, jti string, tokenVersion int) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,13 @@
+//internal/domain/repository/transaction_item_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type TransactionItemRepository interface {
+	CreateBatch(ctx context.Context, items []*entity.TransactionItem) error
+	FindByTransactionID(ctx context.Context, transactionID int) ([]entity.TransactionItem, error)
+}
@@ -4,17 +4,25 @@ package repository
 
 import (
 	"context"
+	"time"
 	"ticket-system/internal/domain/entity"
 )
 
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *entity.Transaction) (int, error)
+	CreateWithReservation(ctx context.Context, transaction *entity.Transaction) (int, error)
 	FindByID(ctx context.Context, id int) (*entity.Transaction, error)
 	FindByCode(ctx context.Context, code string) (*entity.Transaction, error)
+	FindByCodeForUpdate(ctx context.Context, code string) (*entity.Transaction, error)
 	FindByUserID(ctx context.Context, userID, offset, limit int) ([]entity.Transaction, error)
 	CountByUserID(ctx context.Context, userID int) (int, error)
 	Update(ctx context.Context, transaction *entity.Transaction) error
-	UpdateStatus(ctx context.Context, id int, status string) error
+	UpdateStatus(ctx context.Context, id int, from, to entity.TransactionStatus) error
 	UpdatePaymentProof(ctx context.Context, id int, proofURL string) error
+	UpdateExpiresAt(ctx context.Context, id int, expiresAt time.Time) error
 	VerifyPayment(ctx context.Context, id, verifierID int) error
+	ExpireOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Transaction, error)
+	// SumPaidRevenueByEvent menjumlahkan nominal transaksi paid milik event. currency adalah mata
+	// uang event, dipakai juga saat event belum punya transaksi paid.
+	SumPaidRevenueByEvent(ctx context.Context, eventID int, currency string) (*entity.EventRevenue, error)
 }
\ No newline at end of file
@@ -0,0 +1,14 @@
+//internal/domain/repository/transaction_status_history_repository.go
+
+package repository
+
+import (
+	"context"
+	"ticket-system/internal/domain/entity"
+)
+
+type TransactionStatusHistoryRepository interface {
+	Create(ctx context.Context, history *entity.TransactionStatusHistory) (int, error)
+	FindByTransactionID(ctx context.Context, transactionID int) ([]entity.TransactionStatusHistory, error)
+	CountByTransition(ctx context.Context, transactionID int, from, to entity.TransactionStatus) (int, error)
+}
@@ -0,0 +1,11 @@
+//internal/domain/repository/tx_manager.go
+
+package repository
+
+import "context"
+
+// TxManager menjalankan beberapa operasi repository dalam satu transaksi database.
+// Repository yang dipanggil dengan ctx dari fn otomatis memakai transaksi yang sama.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
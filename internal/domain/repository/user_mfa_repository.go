@@ -0,0 +1,31 @@
+//internal/domain/repository/user_mfa_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type UserMFARepository interface {
+	FindByUserID(ctx context.Context, userID int) (*entity.UserMFA, error)
+	// SavePending menyimpan secret baru yang belum dikonfirmasi, menggantikan setup sebelumnya.
+	// Mengembalikan ErrStatusConflict jika 2FA user sudah aktif.
+	SavePending(ctx context.Context, mfa *entity.UserMFA) error
+	// Enable mengaktifkan 2FA yang masih pending. Mengembalikan ErrStatusConflict jika 2FA sudah
+	// aktif lebih dulu.
+	Enable(ctx context.Context, userID int, enabledAt time.Time, step int64) error
+	// ConsumeStep mencatat time step TOTP yang dipakai. Mengembalikan ErrStatusConflict jika step
+	// tersebut atau yang lebih baru sudah pernah dipakai.
+	ConsumeStep(ctx context.Context, userID int, step int64) error
+	// Delete menonaktifkan 2FA beserta seluruh recovery code user
+	Delete(ctx context.Context, userID int) error
+
+	// ReplaceRecoveryCodes menghapus recovery code lama dan menyimpan hash kode baru
+	ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error
+	FindUnusedRecoveryCodes(ctx context.Context, userID int) ([]entity.MFARecoveryCode, error)
+	// MarkRecoveryCodeUsed mengembalikan ErrStatusConflict jika kode sudah dipakai request lain
+	MarkRecoveryCodeUsed(ctx context.Context, id int, usedAt time.Time) error
+}
@@ -10,10 +10,15 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) (int, error)
 	FindByID(ctx context.Context, id int) (*entity.User, error)
+	// FindByIDForUpdate mengunci baris user sampai transaksi database selesai
+	FindByIDForUpdate(ctx context.Context, id int) (*entity.User, error)
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id int) error
 	UpdateVerificationStatus(ctx context.Context, userID int, isVerified bool) error
+	UpdatePassword(ctx context.Context, userID int, hashedPassword string) error
+	// IncrementTokenVersion menaikkan versi token user dan mengembalikan versi barunya
+	IncrementTokenVersion(ctx context.Context, userID int) (int, error)
 	CreateDefaultProfile(ctx context.Context, userID int) error
 }
\ No newline at end of file
@@ -0,0 +1,19 @@
+//internal/domain/repository/venue_repository.go
+
+package repository
+
+import (
+	"context"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type VenueRepository interface {
+	// Create menyimpan venue beserta seluruh kursinya dalam satu transaksi database
+	Create(ctx context.Context, venue *entity.Venue, seats []*entity.VenueSeat) (int, error)
+	FindByID(ctx context.Context, id int) (*entity.Venue, error)
+	FindByOwnerID(ctx context.Context, ownerID int) ([]entity.Venue, error)
+	FindSeatsByVenueID(ctx context.Context, venueID int) ([]entity.VenueSeat, error)
+	// Delete mengembalikan ErrVenueInUse jika venue masih dipakai event
+	Delete(ctx context.Context, id int) error
+}
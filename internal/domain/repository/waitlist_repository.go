@@ -0,0 +1,35 @@
+//internal/domain/repository/waitlist_repository.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type WaitlistRepository interface {
+	// Create mengembalikan ErrWaitlistEntryExists jika user masih memiliki antrean aktif di event
+	Create(ctx context.Context, entry *entity.WaitlistEntry) (int, error)
+	FindByID(ctx context.Context, id int) (*entity.WaitlistEntry, error)
+	// FindActive mengembalikan antrean waiting atau offered milik user di event, nil jika tidak ada
+	FindActive(ctx context.Context, eventID, userID int) (*entity.WaitlistEntry, error)
+	// CountWaitingAhead menghitung antrean waiting yang mendaftar lebih dulu dari entry
+	CountWaitingAhead(ctx context.Context, entry *entity.WaitlistEntry) (int, error)
+	HasWaiting(ctx context.Context, eventID int) (bool, error)
+	// FindEventIDsWithWaiting mengembalikan event aktif yang masih memiliki antrean waiting
+	FindEventIDsWithWaiting(ctx context.Context) ([]int, error)
+	// UpdateStatus hanya mengubah entry yang masih berstatus from. Mengembalikan
+	// ErrStatusConflict jika entry sudah diubah proses lain.
+	UpdateStatus(ctx context.Context, id int, from, to entity.WaitlistStatus) error
+	// Claim menandai penawaran yang belum kedaluwarsa sebagai claimed oleh transaksi. Mengembalikan
+	// ErrStatusConflict jika penawaran sudah tidak berlaku.
+	Claim(ctx context.Context, id, transactionID int, now time.Time) error
+	// OfferSeats menawarkan kursi kosong event ke antrean waiting secara FIFO dan langsung memesan
+	// kursinya. Antrean berhenti di entry pertama yang jumlahnya tidak muat.
+	OfferSeats(ctx context.Context, eventID int, now, expiresAt time.Time) ([]entity.WaitlistEntry, error)
+	// ExpireOffers mengubah penawaran yang melewati batas klaim menjadi expired tanpa
+	// mengembalikan kursinya. Pemanggil melepas kursi di transaksi database yang sama.
+	ExpireOffers(ctx context.Context, now time.Time, limit int) ([]entity.WaitlistEntry, error)
+}
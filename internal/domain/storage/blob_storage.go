@@ -0,0 +1,32 @@
+//internal/domain/storage/blob_storage.go
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrObjectNotFound dikembalikan ketika file dengan key tersebut tidak ada di storage
+var ErrObjectNotFound = errors.New("file tidak ditemukan")
+
+// ErrInfectedFile dikembalikan oleh VirusScanner ketika file terdeteksi berbahaya
+var ErrInfectedFile = errors.New("file terdeteksi mengandung virus")
+
+// BlobStorage menyimpan file biner (misalnya bukti pembayaran) berdasarkan key.
+// Key berupa path relatif seperti "payment-proofs/12/1700000000.jpg".
+type BlobStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL membuat URL unduhan yang hanya berlaku selama ttl
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// VirusScanner dipanggil sebelum file disimpan. Implementasi mengembalikan ErrInfectedFile
+// jika file berbahaya, atau error lain jika pemindaian gagal dilakukan.
+type VirusScanner interface {
+	Scan(ctx context.Context, content []byte) error
+}
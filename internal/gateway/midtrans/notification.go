@@ -0,0 +1,114 @@
+//internal/gateway/midtrans/notification.go
+
+package midtrans
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
+)
+
+// Waktu di notifikasi Midtrans tidak menyertakan zona, selalu dalam WIB
+var midtransLocation = time.FixedZone("WIB", 7*60*60)
+
+type notificationPayload struct {
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionID     string `json:"transaction_id"`
+	StatusMessage     string `json:"status_message"`
+	StatusCode        string `json:"status_code"`
+	SignatureKey      string `json:"signature_key"`
+	SettlementTime    string `json:"settlement_time"`
+	PaymentType       string `json:"payment_type"`
+	OrderID           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	FraudStatus       string `json:"fraud_status"`
+}
+
+// ParseNotification memverifikasi signature_key lalu menerjemahkan status Midtrans.
+// Signature adalah SHA512 dari order_id + status_code + gross_amount + server key.
+func (c *Client) ParseNotification(payload []byte) (*gateway.PaymentNotification, error) {
+	var notification notificationPayload
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, gateway.ErrInvalidNotification
+	}
+
+	if notification.OrderID == "" || notification.SignatureKey == "" {
+		return nil, gateway.ErrInvalidSignature
+	}
+
+	if !c.validSignature(notification) {
+		return nil, gateway.ErrInvalidSignature
+	}
+
+	// Midtrans hanya memproses rupiah, gross_amount dikirim sebagai "150000.00"
+	grossAmount, err := entity.ParseMoney(notification.GrossAmount, "IDR")
+	if err != nil {
+		return nil, gateway.ErrInvalidNotification
+	}
+
+	paymentTime := notification.SettlementTime
+	if paymentTime == "" {
+		paymentTime = notification.TransactionTime
+	}
+
+	result := &gateway.PaymentNotification{
+		OrderID:              notification.OrderID,
+		GatewayTransactionID: notification.TransactionID,
+		PaymentType:          notification.PaymentType,
+		GatewayStatus:        notification.TransactionStatus,
+		StatusCode:           notification.StatusCode,
+		StatusMessage:        notification.StatusMessage,
+		GrossAmount:          grossAmount,
+		Result:               mapTransactionStatus(notification.TransactionStatus, notification.FraudStatus),
+		Payload:              payload,
+	}
+
+	if paymentTime != "" {
+		if parsed, err := time.ParseInLocation("2006-01-02 15:04:05", paymentTime, midtransLocation); err == nil {
+			result.PaymentTime = parsed
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Client) validSignature(notification notificationPayload) bool {
+	sum := sha512.Sum512([]byte(notification.OrderID + notification.StatusCode + notification.GrossAmount + c.serverKey))
+	expected := hex.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(notification.SignatureKey)) == 1
+}
+
+// mapTransactionStatus mengikuti panduan status Midtrans. Status yang tidak mengubah
+// transaksi (misalnya partial_refund) dikembalikan kosong dan hanya dicatat.
+func mapTransactionStatus(transactionStatus, fraudStatus string) string {
+	switch transactionStatus {
+	case "capture":
+		switch fraudStatus {
+		case "challenge":
+			return gateway.PaymentResultPending
+		case "deny":
+			return gateway.PaymentResultFailed
+		default:
+			return gateway.PaymentResultPaid
+		}
+	case "settlement":
+		return gateway.PaymentResultPaid
+	case "pending":
+		return gateway.PaymentResultPending
+	case "deny", "cancel", "failure":
+		return gateway.PaymentResultFailed
+	case "expire":
+		return gateway.PaymentResultExpired
+	case "refund":
+		return gateway.PaymentResultRefunded
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,81 @@
+//internal/gateway/midtrans/refund_client.go
+
+package midtrans
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"ticket-system/internal/domain/gateway"
+)
+
+type refundRequest struct {
+	RefundKey string `json:"refund_key"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type refundResponse struct {
+	StatusCode         string `json:"status_code"`
+	StatusMessage      string `json:"status_message"`
+	RefundChargebackID int64  `json:"refund_chargeback_id"`
+	RefundAmount       string `json:"refund_amount"`
+	RefundKey          string `json:"refund_key"`
+	TransactionStatus  string `json:"transaction_status"`
+}
+
+// Refund memanggil Core API POST /v2/{order_id}/refund. Midtrans selalu membalas HTTP 200
+// sehingga keberhasilan ditentukan dari status_code di dalam body.
+func (c *Client) Refund(ctx context.Context, req gateway.RefundRequest) (*gateway.RefundResult, error) {
+	body, err := json.Marshal(refundRequest{
+		RefundKey: req.RefundKey,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.apiURL + "/v2/" + url.PathEscape(req.OrderID) + "/refund"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.SetBasicAuth(c.serverKey, "")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghubungi midtrans: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result refundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("respons midtrans tidak valid (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK || result.StatusCode != "200" {
+		return nil, fmt.Errorf("midtrans menolak refund (kode %s): %s", result.StatusCode, result.StatusMessage)
+	}
+
+	refunded := req.Amount
+	if result.RefundAmount != "" {
+		amount, err := strconv.ParseFloat(result.RefundAmount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("nominal refund dari midtrans tidak valid: %s", result.RefundAmount)
+		}
+		refunded = int64(amount)
+	}
+
+	return &gateway.RefundResult{
+		GatewayRefundID: strconv.FormatInt(result.RefundChargebackID, 10),
+		Amount:          refunded,
+	}, nil
+}
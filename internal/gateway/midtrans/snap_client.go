@@ -0,0 +1,180 @@
+//internal/gateway/midtrans/snap_client.go
+
+package midtrans
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/gateway"
+)
+
+const (
+	SandboxSnapURL    = "https://app.sandbox.midtrans.com"
+	ProductionSnapURL = "https://app.midtrans.com"
+
+	// Core API dipakai untuk operasi setelah pembayaran seperti refund
+	SandboxAPIURL    = "https://api.sandbox.midtrans.com"
+	ProductionAPIURL = "https://api.midtrans.com"
+
+	// Midtrans menolak nama item yang lebih dari 50 karakter
+	maxItemNameLength = 50
+)
+
+type Config struct {
+	ServerKey   string
+	Environment string // sandbox atau production
+	SnapURL     string // opsional, untuk mengarahkan ke server tiruan saat test
+	APIURL      string // opsional, sama seperti SnapURL untuk Core API
+	HTTPClient  *http.Client
+}
+
+type Client struct {
+	serverKey  string
+	snapURL    string
+	apiURL     string
+	httpClient *http.Client
+}
+
+func NewClient(cfg Config) *Client {
+	snapURL := cfg.SnapURL
+	if snapURL == "" {
+		snapURL = SandboxSnapURL
+		if cfg.Environment == "production" {
+			snapURL = ProductionSnapURL
+		}
+	}
+
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = SandboxAPIURL
+		if cfg.Environment == "production" {
+			apiURL = ProductionAPIURL
+		}
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 15 * time.Second}
+	}
+
+	return &Client{
+		serverKey:  cfg.ServerKey,
+		snapURL:    strings.TrimRight(snapURL, "/"),
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+type snapTransactionDetails struct {
+	OrderID     string `json:"order_id"`
+	GrossAmount int64  `json:"gross_amount"`
+}
+
+type snapItemDetail struct {
+	ID       string `json:"id"`
+	Price    int64  `json:"price"`
+	Quantity int    `json:"quantity"`
+	Name     string `json:"name"`
+}
+
+type snapCustomerDetails struct {
+	FirstName string `json:"first_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+}
+
+type snapExpiry struct {
+	Unit     string `json:"unit"`
+	Duration int    `json:"duration"`
+}
+
+type snapRequest struct {
+	TransactionDetails snapTransactionDetails `json:"transaction_details"`
+	ItemDetails        []snapItemDetail       `json:"item_details,omitempty"`
+	CustomerDetails    *snapCustomerDetails   `json:"customer_details,omitempty"`
+	Expiry             *snapExpiry            `json:"expiry,omitempty"`
+}
+
+type snapResponse struct {
+	Token         string   `json:"token"`
+	RedirectURL   string   `json:"redirect_url"`
+	ErrorMessages []string `json:"error_messages"`
+}
+
+// CreatePayment membuat transaksi Snap dan mengembalikan token serta URL halaman pembayaran
+func (c *Client) CreatePayment(ctx context.Context, req gateway.PaymentRequest) (*gateway.PaymentSession, error) {
+	payload := snapRequest{
+		TransactionDetails: snapTransactionDetails{
+			OrderID:     req.OrderID,
+			GrossAmount: req.GrossAmount,
+		},
+	}
+
+	for _, item := range req.Items {
+		name := item.Name
+		if len(name) > maxItemNameLength {
+			name = name[:maxItemNameLength]
+		}
+		payload.ItemDetails = append(payload.ItemDetails, snapItemDetail{
+			ID:       item.ID,
+			Price:    item.Price,
+			Quantity: item.Quantity,
+			Name:     name,
+		})
+	}
+
+	if req.Customer != (gateway.PaymentCustomer{}) {
+		payload.CustomerDetails = &snapCustomerDetails{
+			FirstName: req.Customer.FirstName,
+			Email:     req.Customer.Email,
+			Phone:     req.Customer.Phone,
+		}
+	}
+
+	if req.ExpiryMinutes > 0 {
+		payload.Expiry = &snapExpiry{Unit: "minutes", Duration: req.ExpiryMinutes}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.snapURL+"/snap/v1/transactions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.SetBasicAuth(c.serverKey, "")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghubungi midtrans: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result snapResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("respons midtrans tidak valid (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("midtrans menolak transaksi (HTTP %d): %s", resp.StatusCode, strings.Join(result.ErrorMessages, "; "))
+	}
+
+	if result.Token == "" || result.RedirectURL == "" {
+		return nil, fmt.Errorf("respons midtrans tidak berisi token pembayaran")
+	}
+
+	return &gateway.PaymentSession{
+		Token:       result.Token,
+		RedirectURL: result.RedirectURL,
+	}, nil
+}
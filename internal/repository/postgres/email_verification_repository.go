@@ -27,7 +27,7 @@ func (r *emailVerificationRepository) Create(ctx context.Context, verification *
 	`
 
 	var id int
-	err := r.db.QueryRowContext(
+	err := executor(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		verification.UserID,
@@ -50,7 +50,7 @@ func (r *emailVerificationRepository) FindByToken(ctx context.Context, token str
 	`
 
 	var verification entity.EmailVerification
-	err := r.db.QueryRowContext(ctx, query, token).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, token).Scan(
 		&verification.ID,
 		&verification.UserID,
 		&verification.Token,
@@ -70,12 +70,12 @@ func (r *emailVerificationRepository) FindByToken(ctx context.Context, token str
 
 func (r *emailVerificationRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM email_verifications WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	return err
 }
 
 func (r *emailVerificationRepository) DeleteByUserID(ctx context.Context, userID int) error {
 	query := `DELETE FROM email_verifications WHERE user_id = $1`
-	_, err := r.db.ExecContext(ctx, query, userID)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, userID)
 	return err
 }
\ No newline at end of file
@@ -21,15 +21,61 @@ func NewEventRepository(db *sql.DB) *eventRepository {
 	}
 }
 
+func scanEvent(row rowScanner) (*entity.Event, error) {
+	var event entity.Event
+	var refundPercent string
+	var venueID sql.NullInt64
+	price := newMoneyScan(&event.Price)
+	
+	err := row.Scan(
+		&event.ID,
+		&event.OwnerID,
+		&event.Title,
+		&event.Description,
+		&event.Location,
+		&event.EventDate,
+		&event.MaxCapacity,
+		&event.TicketsSold,
+		price.Amount(0),
+		price.Currency(),
+		&event.Status,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+		&event.RefundPolicy.Allowed,
+		&event.RefundPolicy.DeadlineHours,
+		&refundPercent,
+		&event.TransferPolicy.Allowed,
+		&event.TransferPolicy.DeadlineHours,
+		&event.TransferPolicy.MaxTransfers,
+		&venueID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.VenueID = int(venueID.Int64)
+	if err := price.parse(); err != nil {
+		return nil, err
+	}
+	event.Currency = event.Price.Currency
+	
+	event.RefundPolicy.Percent, err = entity.ParsePercent(refundPercent)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &event, nil
+}
+
 func (r *eventRepository) Create(ctx context.Context, event *entity.Event) (int, error) {
 	query := `
-		INSERT INTO events (owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO events (owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, currency, status, created_at, updated_at,
+			refund_allowed, refund_deadline_hours, refund_percent, transfer_allowed, transfer_deadline_hours, transfer_max_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		RETURNING id
 	`
 	
 	var id int
-	err := r.db.QueryRowContext(
+	err := executor(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		event.OwnerID,
@@ -39,10 +85,17 @@ func (r *eventRepository) Create(ctx context.Context, event *entity.Event) (int,
 		event.EventDate,
 		event.MaxCapacity,
 		event.TicketsSold,
-		event.Price,
+		event.Price.Decimal(),
+		entity.NormalizeCurrency(event.Currency),
 		event.Status,
 		event.CreatedAt,
 		event.UpdatedAt,
+		event.RefundPolicy.Allowed,
+		event.RefundPolicy.DeadlineHours,
+		event.RefundPolicy.Percent.String(),
+		event.TransferPolicy.Allowed,
+		event.TransferPolicy.DeadlineHours,
+		event.TransferPolicy.MaxTransfers,
 	).Scan(&id)
 	
 	if err != nil {
@@ -54,27 +107,14 @@ func (r *eventRepository) Create(ctx context.Context, event *entity.Event) (int,
 
 func (r *eventRepository) FindByID(ctx context.Context, id int) (*entity.Event, error) {
 	query := `
-		SELECT id, owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, status, created_at, updated_at
+		SELECT id, owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, currency, status, created_at, updated_at,
+			refund_allowed, refund_deadline_hours, refund_percent,
+			transfer_allowed, transfer_deadline_hours, transfer_max_count, venue_id
 		FROM events
 		WHERE id = $1
 	`
 	
-	var event entity.Event
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&event.ID,
-		&event.OwnerID,
-		&event.Title,
-		&event.Description,
-		&event.Location,
-		&event.EventDate,
-		&event.MaxCapacity,
-		&event.TicketsSold,
-		&event.Price,
-		&event.Status,
-		&event.CreatedAt,
-		&event.UpdatedAt,
-	)
-	
+	event, err := scanEvent(executor(ctx, r.db).QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -82,19 +122,21 @@ func (r *eventRepository) FindByID(ctx context.Context, id int) (*entity.Event,
 		return nil, err
 	}
 	
-	return &event, nil
+	return event, nil
 }
 
 func (r *eventRepository) FindAll(ctx context.Context, offset, limit int) ([]entity.Event, error) {
 	query := `
-		SELECT id, owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, status, created_at, updated_at
+		SELECT id, owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, currency, status, created_at, updated_at,
+			refund_allowed, refund_deadline_hours, refund_percent,
+			transfer_allowed, transfer_deadline_hours, transfer_max_count, venue_id
 		FROM events
 		WHERE status = 'active'
 		ORDER BY event_date ASC
 		LIMIT $1 OFFSET $2
 	`
 	
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -102,25 +144,11 @@ func (r *eventRepository) FindAll(ctx context.Context, offset, limit int) ([]ent
 	
 	var events []entity.Event
 	for rows.Next() {
-		var event entity.Event
-		err := rows.Scan(
-			&event.ID,
-			&event.OwnerID,
-			&event.Title,
-			&event.Description,
-			&event.Location,
-			&event.EventDate,
-			&event.MaxCapacity,
-			&event.TicketsSold,
-			&event.Price,
-			&event.Status,
-			&event.CreatedAt,
-			&event.UpdatedAt,
-		)
+		event, err := scanEvent(rows)
 		if err != nil {
 			return nil, err
 		}
-		events = append(events, event)
+		events = append(events, *event)
 	}
 	
 	return events, nil
@@ -130,7 +158,7 @@ func (r *eventRepository) CountAll(ctx context.Context) (int, error) {
 	query := `SELECT COUNT(*) FROM events WHERE status = 'active'`
 	
 	var count int
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -140,14 +168,16 @@ func (r *eventRepository) CountAll(ctx context.Context) (int, error) {
 
 func (r *eventRepository) FindByOwnerID(ctx context.Context, ownerID, offset, limit int) ([]entity.Event, error) {
 	query := `
-		SELECT id, owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, status, created_at, updated_at
+		SELECT id, owner_id, title, description, location, event_date, max_capacity, tickets_sold, price, currency, status, created_at, updated_at,
+			refund_allowed, refund_deadline_hours, refund_percent,
+			transfer_allowed, transfer_deadline_hours, transfer_max_count, venue_id
 		FROM events
 		WHERE owner_id = $1
 		ORDER BY event_date ASC
 		LIMIT $2 OFFSET $3
 	`
 	
-	rows, err := r.db.QueryContext(ctx, query, ownerID, limit, offset)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, ownerID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -155,25 +185,11 @@ func (r *eventRepository) FindByOwnerID(ctx context.Context, ownerID, offset, li
 	
 	var events []entity.Event
 	for rows.Next() {
-		var event entity.Event
-		err := rows.Scan(
-			&event.ID,
-			&event.OwnerID,
-			&event.Title,
-			&event.Description,
-			&event.Location,
-			&event.EventDate,
-			&event.MaxCapacity,
-			&event.TicketsSold,
-			&event.Price,
-			&event.Status,
-			&event.CreatedAt,
-			&event.UpdatedAt,
-		)
+		event, err := scanEvent(rows)
 		if err != nil {
 			return nil, err
 		}
-		events = append(events, event)
+		events = append(events, *event)
 	}
 	
 	return events, nil
@@ -183,7 +199,7 @@ func (r *eventRepository) CountByOwnerID(ctx context.Context, ownerID int) (int,
 	query := `SELECT COUNT(*) FROM events WHERE owner_id = $1`
 	
 	var count int
-	err := r.db.QueryRowContext(ctx, query, ownerID).Scan(&count)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, ownerID).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -191,14 +207,18 @@ func (r *eventRepository) CountByOwnerID(ctx context.Context, ownerID int) (int,
 	return count, nil
 }
 
+// Update tidak menulis tickets_sold karena kolom itu hanya diubah lewat reservasi dan pelepasan
+// kursi. Kapasitas baru hanya diterima jika tidak lebih kecil dari tiket yang terjual saat update.
 func (r *eventRepository) Update(ctx context.Context, event *entity.Event) error {
 	query := `
 		UPDATE events
-		SET title = $1, description = $2, location = $3, event_date = $4, max_capacity = $5, tickets_sold = $6, price = $7, status = $8, updated_at = $9
-		WHERE id = $10
+		SET title = $1, description = $2, location = $3, event_date = $4, max_capacity = $5, price = $6, status = $7, updated_at = $8,
+			refund_allowed = $9, refund_deadline_hours = $10, refund_percent = $11,
+			transfer_allowed = $12, transfer_deadline_hours = $13, transfer_max_count = $14
+		WHERE id = $15 AND tickets_sold <= $5
 	`
 	
-	_, err := r.db.ExecContext(
+	result, err := executor(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		event.Title,
@@ -206,19 +226,27 @@ func (r *eventRepository) Update(ctx context.Context, event *entity.Event) error
 		event.Location,
 		event.EventDate,
 		event.MaxCapacity,
-		event.TicketsSold,
-		event.Price,
+		event.Price.Decimal(),
 		event.Status,
 		time.Now(),
+		event.RefundPolicy.Allowed,
+		event.RefundPolicy.DeadlineHours,
+		event.RefundPolicy.Percent.String(),
+		event.TransferPolicy.Allowed,
+		event.TransferPolicy.DeadlineHours,
+		event.TransferPolicy.MaxTransfers,
 		event.ID,
 	)
+	if err != nil {
+		return err
+	}
 	
-	return err
+	return expectOneRow(result)
 }
 
 func (r *eventRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM events WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	return err
 }
 
@@ -229,7 +257,7 @@ func (r *eventRepository) UpdateTicketsSold(ctx context.Context, eventID, quanti
 		WHERE id = $3
 	`
 	
-	_, err := r.db.ExecContext(
+	_, err := executor(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		quantity,
@@ -238,4 +266,19 @@ func (r *eventRepository) UpdateTicketsSold(ctx context.Context, eventID, quanti
 	)
 	
 	return err
-}
\ No newline at end of file
+}
+
+func (r *eventRepository) AssignVenue(ctx context.Context, eventID, venueID, capacity int) error {
+	query := `
+		UPDATE events
+		SET venue_id = $1, max_capacity = $2, updated_at = $3
+		WHERE id = $4 AND tickets_sold = 0
+	`
+	
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, nullInt(venueID), capacity, time.Now(), eventID)
+	if err != nil {
+		return err
+	}
+	
+	return expectOneRow(result)
+}
@@ -0,0 +1,144 @@
+//internal/repository/postgres/idempotency_key_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+type idempotencyKeyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyKeyRepository(db *sql.DB) *idempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db: db,
+	}
+}
+
+// Create memakai ON CONFLICT DO UPDATE yang hanya berlaku untuk key kedaluwarsa atau key
+// processing yang tertinggal (misalnya server berhenti di tengah request). Key lain tidak
+// disentuh sehingga RETURNING kosong dan dipetakan ke ErrIdempotencyKeyExists.
+func (r *idempotencyKeyRepository) Create(ctx context.Context, key *entity.IdempotencyKey, staleBefore time.Time) (int, error) {
+	query := `
+		INSERT INTO idempotency_keys (
+			user_id, idempotency_key, method, path, request_hash, status, created_at, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		SET method = EXCLUDED.method, path = EXCLUDED.path, request_hash = EXCLUDED.request_hash,
+			status = EXCLUDED.status, response_code = NULL, response_body = NULL, content_type = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.status = 'processing' AND idempotency_keys.created_at < $9)
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		key.UserID,
+		key.Key,
+		key.Method,
+		key.Path,
+		key.RequestHash,
+		key.Status,
+		key.CreatedAt,
+		key.ExpiresAt,
+		staleBefore,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrIdempotencyKeyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *idempotencyKeyRepository) FindByKey(ctx context.Context, userID int, key string) (*entity.IdempotencyKey, error) {
+	query := `
+		SELECT id, user_id, idempotency_key, method, path, request_hash, status,
+			response_code, response_body, content_type, created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2 AND expires_at > NOW()
+	`
+
+	var k entity.IdempotencyKey
+	var responseCode sql.NullInt64
+	var contentType sql.NullString
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID, key).Scan(
+		&k.ID,
+		&k.UserID,
+		&k.Key,
+		&k.Method,
+		&k.Path,
+		&k.RequestHash,
+		&k.Status,
+		&responseCode,
+		&k.ResponseBody,
+		&contentType,
+		&k.CreatedAt,
+		&k.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	k.ResponseCode = int(responseCode.Int64)
+	k.ContentType = contentType.String
+
+	return &k, nil
+}
+
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, key *entity.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = 'completed', response_code = $1, response_body = $2, content_type = $3
+		WHERE id = $4 AND status = 'processing'
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		key.ResponseCode,
+		key.ResponseBody,
+		nullString(key.ContentType),
+		key.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *idempotencyKeyRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM idempotency_keys WHERE id = $1 AND status = 'processing'`
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *idempotencyKeyRepository) DeleteExpired(ctx context.Context) (int, error) {
+	result, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
@@ -0,0 +1,41 @@
+//internal/repository/postgres/money.go
+
+package postgres
+
+import "ticket-system/internal/domain/entity"
+
+// moneyScan menampung kolom NUMERIC dan kolom currency dari satu baris. Nominal baru bisa
+// diubah ke satuan terkecil setelah mata uangnya diketahui, jadi konversi dilakukan di parse
+// setelah Scan selesai.
+type moneyScan struct {
+	targets  []*entity.Money
+	amounts  []string
+	currency string
+}
+
+func newMoneyScan(targets ...*entity.Money) *moneyScan {
+	return &moneyScan{
+		targets: targets,
+		amounts: make([]string, len(targets)),
+	}
+}
+
+// Amount mengembalikan tujuan scan untuk nominal ke-i sesuai urutan targets
+func (m *moneyScan) Amount(i int) *string {
+	return &m.amounts[i]
+}
+
+func (m *moneyScan) Currency() *string {
+	return &m.currency
+}
+
+func (m *moneyScan) parse() error {
+	for i, target := range m.targets {
+		money, err := entity.ParseMoney(m.amounts[i], m.currency)
+		if err != nil {
+			return err
+		}
+		*target = money
+	}
+	return nil
+}
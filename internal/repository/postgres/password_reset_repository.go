@@ -0,0 +1,104 @@
+//internal/repository/postgres/password_reset_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+const passwordResetColumns = `id, user_id, token_hash, expired_at, used_at, created_at`
+
+type passwordResetRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepository(db *sql.DB) *passwordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+func scanPasswordReset(row rowScanner) (*entity.PasswordReset, error) {
+	var reset entity.PasswordReset
+	var usedAt sql.NullTime
+
+	err := row.Scan(
+		&reset.ID,
+		&reset.UserID,
+		&reset.TokenHash,
+		&reset.ExpiredAt,
+		&usedAt,
+		&reset.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	reset.UsedAt = usedAt.Time
+
+	return &reset, nil
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, reset *entity.PasswordReset) (int, error) {
+	query := `
+		INSERT INTO password_resets (user_id, token_hash, expired_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		reset.UserID,
+		reset.TokenHash,
+		reset.ExpiredAt,
+		reset.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *passwordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordReset, error) {
+	query := `SELECT ` + passwordResetColumns + ` FROM password_resets WHERE token_hash = $1`
+	return scanPasswordReset(executor(ctx, r.db).QueryRowContext(ctx, query, tokenHash))
+}
+
+func (r *passwordResetRepository) FindLatestByUserID(ctx context.Context, userID int) (*entity.PasswordReset, error) {
+	query := `
+		SELECT ` + passwordResetColumns + `
+		FROM password_resets
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	return scanPasswordReset(executor(ctx, r.db).QueryRowContext(ctx, query, userID))
+}
+
+func (r *passwordResetRepository) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	query := `UPDATE password_resets SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *passwordResetRepository) DeleteUnusedByUserID(ctx context.Context, userID int) error {
+	query := `DELETE FROM password_resets WHERE user_id = $1 AND used_at IS NULL`
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,167 @@
+//internal/repository/postgres/payment_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"ticket-system/internal/domain/entity"
+)
+
+const paymentColumns = `id, transaction_id, midtrans_transaction_id, payment_type, amount, currency, status,
+			midtrans_status_code, midtrans_status_message, payment_time, expiry_time,
+			snap_token, redirect_url, callback_data, created_at, updated_at`
+
+type paymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) *paymentRepository {
+	return &paymentRepository{
+		db: db,
+	}
+}
+
+func scanPayment(row rowScanner) (*entity.Payment, error) {
+	var payment entity.Payment
+	var midtransTransactionID, paymentType, statusCode, statusMessage, snapToken, redirectURL sql.NullString
+	var paymentTime, expiryTime sql.NullTime
+	var callbackData []byte
+	amount := newMoneyScan(&payment.Amount)
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.TransactionID,
+		&midtransTransactionID,
+		&paymentType,
+		amount.Amount(0),
+		amount.Currency(),
+		&payment.Status,
+		&statusCode,
+		&statusMessage,
+		&paymentTime,
+		&expiryTime,
+		&snapToken,
+		&redirectURL,
+		&callbackData,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := amount.parse(); err != nil {
+		return nil, err
+	}
+
+	payment.MidtransTransactionID = midtransTransactionID.String
+	payment.PaymentType = paymentType.String
+	payment.StatusCode = statusCode.String
+	payment.StatusMessage = statusMessage.String
+	payment.SnapToken = snapToken.String
+	payment.RedirectURL = redirectURL.String
+	payment.CallbackData = callbackData
+	if paymentTime.Valid {
+		payment.PaymentTime = paymentTime.Time
+	}
+	if expiryTime.Valid {
+		payment.ExpiryTime = expiryTime.Time
+	}
+
+	return &payment, nil
+}
+
+func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) (int, error) {
+	query := `
+		INSERT INTO payments (
+			transaction_id, payment_type, amount, currency, status, expiry_time,
+			snap_token, redirect_url, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		payment.TransactionID,
+		payment.PaymentType,
+		payment.Amount.Decimal(),
+		payment.Amount.CurrencyCode(),
+		payment.Status,
+		nullTime(payment.ExpiryTime),
+		payment.SnapToken,
+		payment.RedirectURL,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *paymentRepository) FindByTransactionID(ctx context.Context, transactionID int) (*entity.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE transaction_id = $1
+	`
+
+	payment, err := scanPayment(executor(ctx, r.db).QueryRowContext(ctx, query, transactionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// UpdateFromNotification menyimpan data terakhir dari notifikasi gateway beserta payload mentahnya
+func (r *paymentRepository) UpdateFromNotification(ctx context.Context, payment *entity.Payment) error {
+	query := `
+		UPDATE payments
+		SET midtrans_transaction_id = $1, payment_type = $2, status = $3,
+			midtrans_status_code = $4, midtrans_status_message = $5, payment_time = $6,
+			callback_data = $7, updated_at = NOW()
+		WHERE transaction_id = $8
+	`
+
+	var callbackData sql.NullString
+	if len(payment.CallbackData) > 0 {
+		callbackData = sql.NullString{String: string(payment.CallbackData), Valid: true}
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		nullString(payment.MidtransTransactionID),
+		payment.PaymentType,
+		payment.Status,
+		payment.StatusCode,
+		payment.StatusMessage,
+		nullTime(payment.PaymentTime),
+		callbackData,
+		payment.TransactionID,
+	)
+	return err
+}
+
+// midtrans_transaction_id bersifat UNIQUE, jadi string kosong harus disimpan sebagai NULL
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func nullInt(i int) sql.NullInt64 {
+	if i == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(i), Valid: true}
+}
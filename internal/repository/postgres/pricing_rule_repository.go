@@ -0,0 +1,147 @@
+//internal/repository/postgres/pricing_rule_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"ticket-system/internal/domain/entity"
+)
+
+const pricingRuleColumns = `id, owner_id, event_id, service_fee_percent, service_fee_per_ticket, currency,
+			tax_percent, pass_platform_fee, created_at, updated_at`
+
+type pricingRuleRepository struct {
+	db *sql.DB
+}
+
+func NewPricingRuleRepository(db *sql.DB) *pricingRuleRepository {
+	return &pricingRuleRepository{
+		db: db,
+	}
+}
+
+func scanPricingRule(row rowScanner) (*entity.PricingRule, error) {
+	var rule entity.PricingRule
+	var eventID sql.NullInt64
+	var serviceFeePercent, taxPercent string
+	money := newMoneyScan(&rule.ServiceFeePerTicket)
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.OwnerID,
+		&eventID,
+		&serviceFeePercent,
+		money.Amount(0),
+		money.Currency(),
+		&taxPercent,
+		&rule.PassPlatformFee,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := money.parse(); err != nil {
+		return nil, err
+	}
+	if rule.ServiceFeePercent, err = entity.ParsePercent(serviceFeePercent); err != nil {
+		return nil, err
+	}
+	if rule.TaxPercent, err = entity.ParsePercent(taxPercent); err != nil {
+		return nil, err
+	}
+
+	rule.EventID = int(eventID.Int64)
+
+	return &rule, nil
+}
+
+func (r *pricingRuleRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.PricingRule, error) {
+	rule, err := scanPricingRule(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *pricingRuleRepository) FindDefault(ctx context.Context, ownerID int) (*entity.PricingRule, error) {
+	query := `
+		SELECT ` + pricingRuleColumns + `
+		FROM pricing_rules
+		WHERE owner_id = $1 AND event_id IS NULL
+	`
+
+	return r.findOne(ctx, query, ownerID)
+}
+
+func (r *pricingRuleRepository) FindByEventID(ctx context.Context, eventID int) (*entity.PricingRule, error) {
+	query := `
+		SELECT ` + pricingRuleColumns + `
+		FROM pricing_rules
+		WHERE event_id = $1
+	`
+
+	return r.findOne(ctx, query, eventID)
+}
+
+func (r *pricingRuleRepository) FindApplicable(ctx context.Context, ownerID, eventID int) (*entity.PricingRule, error) {
+	query := `
+		SELECT ` + pricingRuleColumns + `
+		FROM pricing_rules
+		WHERE owner_id = $1 AND (event_id IS NULL OR event_id = $2)
+		ORDER BY event_id NULLS LAST
+		LIMIT 1
+	`
+
+	return r.findOne(ctx, query, ownerID, eventID)
+}
+
+// Upsert memakai dua target ON CONFLICT karena aturan default dijaga oleh unique index parsial
+// pada owner_id, sedangkan aturan event dijaga oleh UNIQUE(event_id)
+func (r *pricingRuleRepository) Upsert(ctx context.Context, rule *entity.PricingRule) error {
+	conflict := `(owner_id) WHERE event_id IS NULL`
+	if rule.EventID != 0 {
+		conflict = `(event_id)`
+	}
+
+	query := `
+		INSERT INTO pricing_rules (
+			owner_id, event_id, service_fee_percent, service_fee_per_ticket, currency,
+			tax_percent, pass_platform_fee, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		ON CONFLICT ` + conflict + ` DO UPDATE
+		SET service_fee_percent = EXCLUDED.service_fee_percent,
+			service_fee_per_ticket = EXCLUDED.service_fee_per_ticket,
+			currency = EXCLUDED.currency,
+			tax_percent = EXCLUDED.tax_percent,
+			pass_platform_fee = EXCLUDED.pass_platform_fee,
+			updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	return executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		rule.OwnerID,
+		nullInt(rule.EventID),
+		rule.ServiceFeePercent.String(),
+		rule.ServiceFeePerTicket.Decimal(),
+		rule.ServiceFeePerTicket.CurrencyCode(),
+		rule.TaxPercent.String(),
+		rule.PassPlatformFee,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *pricingRuleRepository) DeleteByEventID(ctx context.Context, eventID int) error {
+	query := `DELETE FROM pricing_rules WHERE event_id = $1`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, eventID)
+	return err
+}
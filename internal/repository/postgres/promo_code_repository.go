@@ -0,0 +1,291 @@
+//internal/repository/postgres/promo_code_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const promoCodeColumns = `id, owner_id, event_id, code, discount_type, discount_value, currency, max_uses,
+			used_count, per_user_limit, min_quantity, valid_from, valid_until, is_active, created_at, updated_at`
+
+type promoCodeRepository struct {
+	db *sql.DB
+}
+
+func NewPromoCodeRepository(db *sql.DB) *promoCodeRepository {
+	return &promoCodeRepository{
+		db: db,
+	}
+}
+
+func scanPromoCode(row rowScanner) (*entity.PromoCode, error) {
+	var promo entity.PromoCode
+	var eventID sql.NullInt64
+	var validFrom, validUntil sql.NullTime
+	var discountValue, currency string
+
+	err := row.Scan(
+		&promo.ID,
+		&promo.OwnerID,
+		&eventID,
+		&promo.Code,
+		&promo.DiscountType,
+		&discountValue,
+		&currency,
+		&promo.MaxUses,
+		&promo.UsedCount,
+		&promo.PerUserLimit,
+		&promo.MinQuantity,
+		&validFrom,
+		&validUntil,
+		&promo.IsActive,
+		&promo.CreatedAt,
+		&promo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// discount_value menyimpan persen untuk tipe percentage dan nominal untuk tipe fixed
+	if promo.DiscountType == entity.PromoDiscountPercentage {
+		promo.DiscountPercent, err = entity.ParsePercent(discountValue)
+		promo.DiscountAmount = entity.NewMoney(0, currency)
+	} else {
+		promo.DiscountAmount, err = entity.ParseMoney(discountValue, currency)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	promo.EventID = int(eventID.Int64)
+	promo.ValidFrom = validFrom.Time
+	promo.ValidUntil = validUntil.Time
+
+	return &promo, nil
+}
+
+func promoDiscountValue(promo *entity.PromoCode) string {
+	if promo.DiscountType == entity.PromoDiscountPercentage {
+		return promo.DiscountPercent.String()
+	}
+	return promo.DiscountAmount.Decimal()
+}
+
+func (r *promoCodeRepository) Create(ctx context.Context, promo *entity.PromoCode) (int, error) {
+	query := `
+		INSERT INTO promo_codes (
+			owner_id, event_id, code, discount_type, discount_value, currency, max_uses, used_count,
+			per_user_limit, min_quantity, valid_from, valid_until, is_active, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, 0, $8, $9, $10, $11, $12, NOW(), NOW())
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		promo.OwnerID,
+		nullInt(promo.EventID),
+		promo.Code,
+		promo.DiscountType,
+		promoDiscountValue(promo),
+		promo.DiscountAmount.CurrencyCode(),
+		promo.MaxUses,
+		promo.PerUserLimit,
+		promo.MinQuantity,
+		nullTime(promo.ValidFrom),
+		nullTime(promo.ValidUntil),
+		promo.IsActive,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *promoCodeRepository) FindByID(ctx context.Context, id int) (*entity.PromoCode, error) {
+	query := `
+		SELECT ` + promoCodeColumns + `
+		FROM promo_codes
+		WHERE id = $1
+	`
+
+	promo, err := scanPromoCode(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+func (r *promoCodeRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]entity.PromoCode, error) {
+	query := `
+		SELECT ` + promoCodeColumns + `
+		FROM promo_codes
+		WHERE owner_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promos []entity.PromoCode
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		promos = append(promos, *promo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promos, nil
+}
+
+func (r *promoCodeRepository) FindApplicable(ctx context.Context, code string, eventID, ownerID int) (*entity.PromoCode, error) {
+	query := `
+		SELECT ` + promoCodeColumns + `
+		FROM promo_codes
+		WHERE owner_id = $1 AND UPPER(code) = UPPER($2) AND (event_id IS NULL OR event_id = $3)
+	`
+
+	promo, err := scanPromoCode(executor(ctx, r.db).QueryRowContext(ctx, query, ownerID, code, eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+// Update tidak menyentuh used_count, yang hanya diubah lewat Redeem dan Release
+func (r *promoCodeRepository) Update(ctx context.Context, promo *entity.PromoCode) error {
+	query := `
+		UPDATE promo_codes
+		SET event_id = $1, code = $2, discount_type = $3, discount_value = $4, currency = $5,
+			max_uses = $6, per_user_limit = $7, min_quantity = $8, valid_from = $9, valid_until = $10,
+			is_active = $11, updated_at = NOW()
+		WHERE id = $12
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		nullInt(promo.EventID),
+		promo.Code,
+		promo.DiscountType,
+		promoDiscountValue(promo),
+		promo.DiscountAmount.CurrencyCode(),
+		promo.MaxUses,
+		promo.PerUserLimit,
+		promo.MinQuantity,
+		nullTime(promo.ValidFrom),
+		nullTime(promo.ValidUntil),
+		promo.IsActive,
+		promo.ID,
+	)
+	return err
+}
+
+// Redeem mengunci baris kode promo dengan FOR UPDATE sebelum menghitung pemakaian, sehingga
+// pembeli yang memakai kode yang sama secara bersamaan diproses satu per satu dan batas total
+// maupun batas per user tidak bisa terlewati
+func (r *promoCodeRepository) Redeem(ctx context.Context, redemption *entity.PromoRedemption) error {
+	return NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		var maxUses, usedCount, perUserLimit int
+		err := executor(ctx, r.db).QueryRowContext(ctx, `
+			SELECT max_uses, used_count, per_user_limit
+			FROM promo_codes
+			WHERE id = $1
+			FOR UPDATE
+		`, redemption.PromoCodeID).Scan(&maxUses, &usedCount, &perUserLimit)
+		if err != nil {
+			return err
+		}
+
+		if maxUses > 0 && usedCount >= maxUses {
+			return repository.ErrPromoCodeExhausted
+		}
+
+		if perUserLimit > 0 {
+			var userRedemptions int
+			err := executor(ctx, r.db).QueryRowContext(ctx, `
+				SELECT COUNT(*)
+				FROM promo_redemptions
+				WHERE promo_code_id = $1 AND user_id = $2 AND status = 'active'
+			`, redemption.PromoCodeID, redemption.UserID).Scan(&userRedemptions)
+			if err != nil {
+				return err
+			}
+
+			if userRedemptions >= perUserLimit {
+				return repository.ErrPromoUserLimitReached
+			}
+		}
+
+		err = executor(ctx, r.db).QueryRowContext(ctx, `
+			INSERT INTO promo_redemptions (
+				promo_code_id, transaction_id, user_id, discount_amount, currency, status, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			RETURNING id, created_at
+		`,
+			redemption.PromoCodeID,
+			redemption.TransactionID,
+			redemption.UserID,
+			redemption.DiscountAmount.Decimal(),
+			redemption.DiscountAmount.CurrencyCode(),
+			entity.PromoRedemptionActive,
+		).Scan(&redemption.ID, &redemption.CreatedAt)
+		if err != nil {
+			return err
+		}
+		redemption.Status = entity.PromoRedemptionActive
+
+		_, err = executor(ctx, r.db).ExecContext(ctx, `
+			UPDATE promo_codes SET used_count = used_count + 1, updated_at = NOW() WHERE id = $1
+		`, redemption.PromoCodeID)
+		return err
+	})
+}
+
+func (r *promoCodeRepository) Release(ctx context.Context, transactionID int) error {
+	return NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		var promoCodeID int
+		err := executor(ctx, r.db).QueryRowContext(ctx, `
+			UPDATE promo_redemptions
+			SET status = $1, released_at = NOW()
+			WHERE transaction_id = $2 AND status = $3
+			RETURNING promo_code_id
+		`, entity.PromoRedemptionReleased, transactionID, entity.PromoRedemptionActive).Scan(&promoCodeID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		_, err = executor(ctx, r.db).ExecContext(ctx, `
+			UPDATE promo_codes SET used_count = GREATEST(used_count - 1, 0), updated_at = NOW() WHERE id = $1
+		`, promoCodeID)
+		return err
+	})
+}
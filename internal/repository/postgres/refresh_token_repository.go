@@ -0,0 +1,117 @@
+//internal/repository/postgres/refresh_token_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type refreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *refreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) (int, error) {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, rotated_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var token entity.RefreshToken
+	var rotatedAt, revokedAt sql.NullTime
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&rotatedAt,
+		&revokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	token.RotatedAt = rotatedAt.Time
+	token.RevokedAt = revokedAt.Time
+
+	return &token, nil
+}
+
+// MarkRotated hanya berlaku untuk token yang belum rotated maupun dicabut, sehingga dari dua
+// refresh bersamaan dengan token yang sama hanya satu yang berhasil
+func (r *refreshTokenRepository) MarkRotated(ctx context.Context, id int, rotatedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET rotated_at = $1
+		WHERE id = $2 AND rotated_at IS NULL AND revoked_at IS NULL
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, rotatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, revokedAt, familyID)
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, revokedAt, userID)
+	return err
+}
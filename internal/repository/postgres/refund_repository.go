@@ -0,0 +1,316 @@
+//internal/repository/postgres/refund_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const refundColumns = `id, transaction_id, event_id, user_id, amount, currency, status, source, reason,
+			reviewed_by, reviewed_at, reject_reason, gateway_refund_id, failure_reason, processing_started_at,
+			completed_at, created_at, updated_at`
+
+type refundRepository struct {
+	db *sql.DB
+}
+
+func NewRefundRepository(db *sql.DB) *refundRepository {
+	return &refundRepository{
+		db: db,
+	}
+}
+
+func scanRefund(row rowScanner) (*entity.Refund, error) {
+	var refund entity.Refund
+	var reason, rejectReason, gatewayRefundID, failureReason sql.NullString
+	var reviewedBy sql.NullInt64
+	var reviewedAt, processingStartedAt, completedAt sql.NullTime
+	amount := newMoneyScan(&refund.Amount)
+
+	err := row.Scan(
+		&refund.ID,
+		&refund.TransactionID,
+		&refund.EventID,
+		&refund.UserID,
+		amount.Amount(0),
+		amount.Currency(),
+		&refund.Status,
+		&refund.Source,
+		&reason,
+		&reviewedBy,
+		&reviewedAt,
+		&rejectReason,
+		&gatewayRefundID,
+		&failureReason,
+		&processingStartedAt,
+		&completedAt,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := amount.parse(); err != nil {
+		return nil, err
+	}
+
+	refund.Reason = reason.String
+	refund.ReviewedBy = int(reviewedBy.Int64)
+	refund.ReviewedAt = reviewedAt.Time
+	refund.RejectReason = rejectReason.String
+	refund.GatewayRefundID = gatewayRefundID.String
+	refund.FailureReason = failureReason.String
+	refund.ProcessingStartedAt = processingStartedAt.Time
+	refund.CompletedAt = completedAt.Time
+
+	return &refund, nil
+}
+
+func (r *refundRepository) queryRefunds(ctx context.Context, query string, args ...interface{}) ([]entity.Refund, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refunds []entity.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, *refund)
+	}
+
+	return refunds, rows.Err()
+}
+
+// Create memakai ON CONFLICT pada unique index parsial sehingga dua pengajuan bersamaan untuk
+// transaksi yang sama hanya menghasilkan satu refund
+func (r *refundRepository) Create(ctx context.Context, refund *entity.Refund) (int, error) {
+	query := `
+		INSERT INTO refunds (
+			transaction_id, event_id, user_id, amount, currency, status, source, reason,
+			reviewed_by, reviewed_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (transaction_id) WHERE status <> 'rejected' DO NOTHING
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		refund.TransactionID,
+		refund.EventID,
+		refund.UserID,
+		refund.Amount.Decimal(),
+		refund.Amount.CurrencyCode(),
+		refund.Status,
+		refund.Source,
+		nullString(refund.Reason),
+		nullInt(refund.ReviewedBy),
+		nullTime(refund.ReviewedAt),
+		refund.CreatedAt,
+		refund.UpdatedAt,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrRefundExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *refundRepository) FindByID(ctx context.Context, id int) (*entity.Refund, error) {
+	query := `
+		SELECT ` + refundColumns + `
+		FROM refunds
+		WHERE id = $1
+	`
+
+	refund, err := scanRefund(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) FindByTransactionID(ctx context.Context, transactionID int) (*entity.Refund, error) {
+	query := `
+		SELECT ` + refundColumns + `
+		FROM refunds
+		WHERE transaction_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	refund, err := scanRefund(executor(ctx, r.db).QueryRowContext(ctx, query, transactionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) FindByEventID(ctx context.Context, eventID int) ([]entity.Refund, error) {
+	query := `
+		SELECT ` + refundColumns + `
+		FROM refunds
+		WHERE event_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return r.queryRefunds(ctx, query, eventID)
+}
+
+func (r *refundRepository) FindByStatus(ctx context.Context, status entity.RefundStatus, limit int) ([]entity.Refund, error) {
+	query := `
+		SELECT ` + refundColumns + `
+		FROM refunds
+		WHERE status = $1
+		ORDER BY updated_at ASC, id ASC
+		LIMIT $2
+	`
+
+	return r.queryRefunds(ctx, query, status, limit)
+}
+
+func (r *refundRepository) UpdateStatus(ctx context.Context, id int, from, to entity.RefundStatus) error {
+	query := `UPDATE refunds SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, to, time.Now(), id, from)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+// StartProcessing memakai UPDATE kondisional sehingga refund processing yang macet hanya
+// diambil alih oleh satu worker, waktu mulai yang baru membuatnya tidak lagi dianggap macet
+func (r *refundRepository) StartProcessing(ctx context.Context, id int, staleBefore time.Time) error {
+	query := `
+		UPDATE refunds
+		SET status = 'processing', processing_started_at = $1, updated_at = $1
+		WHERE id = $2 AND (
+			status = 'approved' OR (status = 'processing' AND processing_started_at < $3)
+		)
+	`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, time.Now(), id, staleBefore)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *refundRepository) FindStaleProcessing(ctx context.Context, staleBefore time.Time, limit int) ([]entity.Refund, error) {
+	query := `
+		SELECT ` + refundColumns + `
+		FROM refunds
+		WHERE status = 'processing' AND processing_started_at < $1
+		ORDER BY processing_started_at ASC, id ASC
+		LIMIT $2
+	`
+
+	return r.queryRefunds(ctx, query, staleBefore, limit)
+}
+
+func (r *refundRepository) Update(ctx context.Context, refund *entity.Refund) error {
+	query := `
+		UPDATE refunds
+		SET amount = $1, source = $2, reviewed_by = $3, reviewed_at = $4, reject_reason = $5,
+			gateway_refund_id = $6, failure_reason = $7, completed_at = $8, updated_at = $9
+		WHERE id = $10
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		refund.Amount.Decimal(),
+		refund.Source,
+		nullInt(refund.ReviewedBy),
+		nullTime(refund.ReviewedAt),
+		nullString(refund.RejectReason),
+		nullString(refund.GatewayRefundID),
+		nullString(refund.FailureReason),
+		nullTime(refund.CompletedAt),
+		time.Now(),
+		refund.ID,
+	)
+	return err
+}
+
+// QueueCancelledEventRefunds berjalan dalam satu transaksi database. Pengajuan pembeli yang
+// belum direview disetujui dengan nominal penuh, lalu transaksi paid yang belum punya refund
+// aktif dibuatkan refund baru. Refund yang pernah ditolak tidak menghalangi refund pembatalan.
+func (r *refundRepository) QueueCancelledEventRefunds(ctx context.Context, limit int) (int, error) {
+	var queued int64
+
+	err := NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		result, err := executor(ctx, r.db).ExecContext(ctx, `
+			UPDATE refunds r
+			SET status = 'approved', source = 'event_cancelled', amount = t.total_amount,
+				reviewed_at = NOW(), updated_at = NOW()
+			FROM transactions t
+			JOIN events e ON e.id = t.event_id
+			WHERE r.transaction_id = t.id AND r.status = 'requested'
+				AND e.status = 'cancelled' AND t.status = 'paid'
+		`)
+		if err != nil {
+			return err
+		}
+		if queued, err = result.RowsAffected(); err != nil {
+			return err
+		}
+
+		result, err = executor(ctx, r.db).ExecContext(ctx, `
+			INSERT INTO refunds (
+				transaction_id, event_id, user_id, amount, currency, status, source, reason,
+				reviewed_at, created_at, updated_at
+			)
+			SELECT t.id, t.event_id, t.user_id, t.total_amount, t.currency, 'approved', 'event_cancelled',
+				'event dibatalkan oleh organizer', NOW(), NOW(), NOW()
+			FROM transactions t
+			JOIN events e ON e.id = t.event_id
+			WHERE e.status = 'cancelled' AND t.status = 'paid'
+				AND NOT EXISTS (
+					SELECT 1 FROM refunds r WHERE r.transaction_id = t.id AND r.status <> 'rejected'
+				)
+			ORDER BY t.id
+			LIMIT $1
+			ON CONFLICT (transaction_id) WHERE status <> 'rejected' DO NOTHING
+		`, limit)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		queued += inserted
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(queued), nil
+}
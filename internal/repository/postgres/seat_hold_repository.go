@@ -0,0 +1,220 @@
+//internal/repository/postgres/seat_hold_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const seatHoldColumns = `id, event_id, seat_id, user_id, transaction_id, status, expires_at, created_at, updated_at`
+
+type seatHoldRepository struct {
+	db *sql.DB
+}
+
+func NewSeatHoldRepository(db *sql.DB) *seatHoldRepository {
+	return &seatHoldRepository{
+		db: db,
+	}
+}
+
+func scanSeatHold(row rowScanner) (*entity.SeatHold, error) {
+	var hold entity.SeatHold
+	var transactionID sql.NullInt64
+
+	err := row.Scan(
+		&hold.ID,
+		&hold.EventID,
+		&hold.SeatID,
+		&hold.UserID,
+		&transactionID,
+		&hold.Status,
+		&hold.ExpiresAt,
+		&hold.CreatedAt,
+		&hold.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	hold.TransactionID = int(transactionID.Int64)
+
+	return &hold, nil
+}
+
+func (r *seatHoldRepository) queryHolds(ctx context.Context, query string, args ...interface{}) ([]entity.SeatHold, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []entity.SeatHold
+	for rows.Next() {
+		hold, err := scanSeatHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, *hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// Hold bergantung pada unique index parsial idx_seat_holds_active: hold yang kedaluwarsa pada
+// kursi yang diminta dilepas lebih dulu, lalu INSERT ... ON CONFLICT DO NOTHING memastikan dua
+// pembeli yang berebut kursi yang sama hanya satu yang berhasil. Jika jumlah baris yang masuk
+// kurang dari yang diminta, seluruh transaksi dibatalkan sehingga hold lama user tetap utuh.
+func (r *seatHoldRepository) Hold(ctx context.Context, eventID, userID int, seatIDs []int, now, expiresAt time.Time) ([]entity.SeatHold, error) {
+	var holds []entity.SeatHold
+
+	err := NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := executor(ctx, r.db).ExecContext(ctx, `
+			UPDATE seat_holds
+			SET status = 'released', updated_at = $1
+			WHERE event_id = $2 AND status = 'held'
+				AND (user_id = $3 OR (seat_id = ANY($4) AND expires_at <= $1))
+		`, now, eventID, userID, pq.Array(seatIDs))
+		if err != nil {
+			return err
+		}
+
+		holds, err = r.queryHolds(ctx, `
+			INSERT INTO seat_holds (event_id, seat_id, user_id, status, expires_at, created_at, updated_at)
+			SELECT $1, seat_id, $2, 'held', $3, $4, $4
+			FROM unnest($5::int[]) AS seat_id
+			ON CONFLICT (event_id, seat_id) WHERE status IN ('held', 'booked') DO NOTHING
+			RETURNING `+seatHoldColumns+`
+		`, eventID, userID, expiresAt, now, pq.Array(seatIDs))
+		if err != nil {
+			return err
+		}
+		if len(holds) != len(seatIDs) {
+			return repository.ErrSeatUnavailable
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r *seatHoldRepository) FindActiveByEventID(ctx context.Context, eventID int, now time.Time) ([]entity.SeatHold, error) {
+	query := `
+		SELECT ` + seatHoldColumns + `
+		FROM seat_holds
+		WHERE event_id = $1 AND (status = 'booked' OR (status = 'held' AND expires_at > $2))
+	`
+
+	return r.queryHolds(ctx, query, eventID, now)
+}
+
+func (r *seatHoldRepository) FindHeldByUser(ctx context.Context, eventID, userID int, now time.Time) ([]entity.SeatHold, error) {
+	query := `
+		SELECT ` + seatHoldColumns + `
+		FROM seat_holds
+		WHERE event_id = $1 AND user_id = $2 AND status = 'held' AND expires_at > $3
+		ORDER BY seat_id ASC
+	`
+
+	return r.queryHolds(ctx, query, eventID, userID, now)
+}
+
+func (r *seatHoldRepository) FindSeatsByTransactionID(ctx context.Context, transactionID int) ([]entity.VenueSeat, error) {
+	query := `
+		SELECT s.id, s.venue_id, s.section, s.row_label, s.seat_number, s.pos_x, s.pos_y, s.is_accessible
+		FROM seat_holds h
+		JOIN venue_seats s ON s.id = h.seat_id
+		WHERE h.transaction_id = $1 AND h.status = 'booked'
+		ORDER BY s.section ASC, s.row_label ASC, s.id ASC
+	`
+
+	return queryVenueSeats(ctx, r.db, query, transactionID)
+}
+
+// Book hanya mengubah hold yang masih held, milik user dan belum kedaluwarsa. Hold yang sudah
+// diambil alih pembeli lain setelah kedaluwarsa tidak ikut terhitung sehingga transaksi gagal.
+func (r *seatHoldRepository) Book(ctx context.Context, eventID, userID, transactionID int, seatIDs []int, now time.Time) error {
+	query := `
+		UPDATE seat_holds
+		SET status = 'booked', transaction_id = $1, updated_at = $2
+		WHERE event_id = $3 AND user_id = $4 AND seat_id = ANY($5)
+			AND status = 'held' AND expires_at > $2
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, transactionID, now, eventID, userID, pq.Array(seatIDs))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(affected) != len(seatIDs) {
+		return repository.ErrSeatHoldExpired
+	}
+
+	return nil
+}
+
+func (r *seatHoldRepository) ReleaseByUser(ctx context.Context, eventID, userID int) (int, error) {
+	query := `
+		UPDATE seat_holds
+		SET status = 'released', updated_at = $1
+		WHERE event_id = $2 AND user_id = $3 AND status = 'held'
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, time.Now(), eventID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+func (r *seatHoldRepository) ReleaseByTransactionID(ctx context.Context, transactionID int) error {
+	query := `
+		UPDATE seat_holds
+		SET status = 'released', updated_at = $1
+		WHERE transaction_id = $2 AND status = 'booked'
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, time.Now(), transactionID)
+	return err
+}
+
+func (r *seatHoldRepository) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	query := `
+		UPDATE seat_holds
+		SET status = 'released', updated_at = $1
+		WHERE status = 'held' AND expires_at <= $1
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
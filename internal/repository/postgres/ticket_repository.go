@@ -0,0 +1,285 @@
+//internal/repository/postgres/ticket_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+const ticketColumns = `id, transaction_id, event_id, ticket_type_id, user_id, ticket_code, seat_number, seat_id, seat_label, qr_nonce, status,
+			purchase_date, checked_in_at, checked_in_by, checked_in_gate, transfer_count, created_at, updated_at`
+
+type ticketRepository struct {
+	db *sql.DB
+}
+
+func NewTicketRepository(db *sql.DB) *ticketRepository {
+	return &ticketRepository{
+		db: db,
+	}
+}
+
+func scanTicket(row rowScanner) (*entity.Ticket, error) {
+	var ticket entity.Ticket
+	var checkedInAt sql.NullTime
+	var checkedInBy sql.NullInt64
+	var checkedInGate sql.NullString
+	var ticketTypeID sql.NullInt64
+	var seatID sql.NullInt64
+	var seatLabel sql.NullString
+
+	err := row.Scan(
+		&ticket.ID,
+		&ticket.TransactionID,
+		&ticket.EventID,
+		&ticketTypeID,
+		&ticket.UserID,
+		&ticket.TicketCode,
+		&ticket.SeatNumber,
+		&seatID,
+		&seatLabel,
+		&ticket.QRNonce,
+		&ticket.Status,
+		&ticket.PurchaseDate,
+		&checkedInAt,
+		&checkedInBy,
+		&checkedInGate,
+		&ticket.TransferCount,
+		&ticket.CreatedAt,
+		&ticket.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket.CheckedInAt = checkedInAt.Time
+	ticket.CheckedInBy = int(checkedInBy.Int64)
+	ticket.CheckedInGate = checkedInGate.String
+	ticket.TicketTypeID = int(ticketTypeID.Int64)
+	ticket.SeatID = int(seatID.Int64)
+	ticket.SeatLabel = seatLabel.String
+
+	return &ticket, nil
+}
+
+// CreateBatch memakai ON CONFLICT pada (transaction_id, seat_number) sehingga penerbitan ulang
+// untuk transaksi yang sama tidak menggandakan tiket. ID hanya diisi untuk tiket yang baru dibuat.
+func (r *ticketRepository) CreateBatch(ctx context.Context, tickets []*entity.Ticket) error {
+	query := `
+		INSERT INTO tickets (
+			transaction_id, event_id, ticket_type_id, user_id, ticket_code, seat_number, seat_id, seat_label, qr_nonce, status,
+			purchase_date, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW(), NOW())
+		ON CONFLICT (transaction_id, seat_number) DO NOTHING
+		RETURNING id
+	`
+
+	for _, ticket := range tickets {
+		err := executor(ctx, r.db).QueryRowContext(
+			ctx,
+			query,
+			ticket.TransactionID,
+			ticket.EventID,
+			nullInt(ticket.TicketTypeID),
+			ticket.UserID,
+			ticket.TicketCode,
+			ticket.SeatNumber,
+			nullInt(ticket.SeatID),
+			nullString(ticket.SeatLabel),
+			ticket.QRNonce,
+			ticket.Status,
+		).Scan(&ticket.ID)
+
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ticketRepository) FindByID(ctx context.Context, id int) (*entity.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE id = $1
+	`
+
+	ticket, err := scanTicket(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+func (r *ticketRepository) FindByCode(ctx context.Context, code string) (*entity.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE ticket_code = $1
+	`
+
+	ticket, err := scanTicket(executor(ctx, r.db).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+func (r *ticketRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]entity.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE transaction_id = $1
+		ORDER BY seat_number ASC
+	`
+
+	return r.queryTickets(ctx, query, transactionID)
+}
+
+func (r *ticketRepository) FindByEventID(ctx context.Context, eventID int) ([]entity.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE event_id = $1
+		ORDER BY id ASC
+	`
+
+	return r.queryTickets(ctx, query, eventID)
+}
+
+func (r *ticketRepository) FindByUserID(ctx context.Context, userID, offset, limit int) ([]entity.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE user_id = $1
+		ORDER BY purchase_date DESC, id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryTickets(ctx, query, userID, limit, offset)
+}
+
+func (r *ticketRepository) CountByUserID(ctx context.Context, userID int) (int, error) {
+	query := `SELECT COUNT(*) FROM tickets WHERE user_id = $1`
+
+	var count int
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *ticketRepository) UpdateStatusByTransactionID(ctx context.Context, transactionID int, from, to entity.TicketStatus) (int, error) {
+	query := `
+		UPDATE tickets
+		SET status = $1, updated_at = NOW()
+		WHERE transaction_id = $2 AND status = $3
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, to, transactionID, from)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// CheckIn memakai UPDATE bersyarat status = 'active' sehingga dari beberapa scan yang
+// bersamaan hanya satu yang berhasil, tanpa perlu SELECT ... FOR UPDATE terlebih dahulu
+func (r *ticketRepository) CheckIn(ctx context.Context, ticketID, staffID int, gate string) error {
+	query := `
+		UPDATE tickets
+		SET status = $1, checked_in_at = NOW(), checked_in_by = $2, checked_in_gate = $3, updated_at = NOW()
+		WHERE id = $4 AND status = $5
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, entity.TicketStatusUsed, staffID, nullString(gate), ticketID, entity.TicketStatusActive)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *ticketRepository) CheckInAt(ctx context.Context, ticketID, staffID int, gate string, scannedAt time.Time) (bool, error) {
+	query := `
+		UPDATE tickets
+		SET status = $1, checked_in_at = $2, checked_in_by = $3, checked_in_gate = $4, updated_at = NOW()
+		WHERE id = $5 AND (status = $6 OR (status = $1 AND checked_in_at > $2))
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, entity.TicketStatusUsed, scannedAt, staffID, nullString(gate), ticketID, entity.TicketStatusActive)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Transfer memindahkan tiket ke pemilik baru dengan nonce QR baru sehingga QR lama tidak bisa
+// dipakai lagi. Update bersyarat pemilik dan status active mencegah tiket yang sudah dipakai,
+// direfund atau dipindahtangankan proses lain ikut berpindah.
+func (r *ticketRepository) Transfer(ctx context.Context, ticketID, fromUserID, toUserID int, qrNonce string) error {
+	query := `
+		UPDATE tickets
+		SET user_id = $1, qr_nonce = $2, transfer_count = transfer_count + 1, updated_at = NOW()
+		WHERE id = $3 AND user_id = $4 AND status = $5
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, toUserID, qrNonce, ticketID, fromUserID, entity.TicketStatusActive)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *ticketRepository) queryTickets(ctx context.Context, query string, args ...interface{}) ([]entity.Ticket, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []entity.Ticket
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tickets = append(tickets, *ticket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
@@ -0,0 +1,67 @@
+//internal/repository/postgres/ticket_scan_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type ticketScanRepository struct {
+	db *sql.DB
+}
+
+func NewTicketScanRepository(db *sql.DB) *ticketScanRepository {
+	return &ticketScanRepository{
+		db: db,
+	}
+}
+
+// Create memakai ON CONFLICT agar perangkat bisa mengirim ulang batch yang sama tanpa
+// menggandakan log ketika respons sinkronisasi sebelumnya tidak sampai
+func (r *ticketScanRepository) Create(ctx context.Context, scan *entity.TicketScan) (bool, error) {
+	query := `
+		INSERT INTO ticket_scans (
+			event_id, ticket_id, device_id, gate, payload_hash, scanned_at, result, synced_by, synced_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (device_id, payload_hash, scanned_at) DO NOTHING
+		RETURNING id
+	`
+
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		scan.EventID,
+		nullInt(scan.TicketID),
+		scan.DeviceID,
+		nullString(scan.Gate),
+		scan.PayloadHash,
+		scan.ScannedAt,
+		scan.Result,
+		scan.SyncedBy,
+	).Scan(&scan.ID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *ticketScanRepository) CountByEventAndResult(ctx context.Context, eventID int, result entity.TicketScanResult) (int, error) {
+	query := `SELECT COUNT(*) FROM ticket_scans WHERE event_id = $1 AND result = $2`
+
+	var count int
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, eventID, result).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,150 @@
+//internal/repository/postgres/ticket_transfer_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const ticketTransferColumns = `id, ticket_id, event_id, from_user_id, to_user_id, status, responded_at, created_at, updated_at`
+
+type ticketTransferRepository struct {
+	db *sql.DB
+}
+
+func NewTicketTransferRepository(db *sql.DB) *ticketTransferRepository {
+	return &ticketTransferRepository{
+		db: db,
+	}
+}
+
+func scanTicketTransfer(row rowScanner) (*entity.TicketTransfer, error) {
+	var transfer entity.TicketTransfer
+	var respondedAt sql.NullTime
+
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.TicketID,
+		&transfer.EventID,
+		&transfer.FromUserID,
+		&transfer.ToUserID,
+		&transfer.Status,
+		&respondedAt,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.RespondedAt = respondedAt.Time
+
+	return &transfer, nil
+}
+
+func (r *ticketTransferRepository) queryTransfers(ctx context.Context, query string, args ...interface{}) ([]entity.TicketTransfer, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []entity.TicketTransfer
+	for rows.Next() {
+		transfer, err := scanTicketTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// Create memakai ON CONFLICT pada unique index parsial sehingga satu tiket hanya bisa memiliki
+// satu transfer pending walaupun pemilik mengirim dua transfer secara bersamaan
+func (r *ticketTransferRepository) Create(ctx context.Context, transfer *entity.TicketTransfer) (int, error) {
+	query := `
+		INSERT INTO ticket_transfers (ticket_id, event_id, from_user_id, to_user_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (ticket_id) WHERE status = 'pending' DO NOTHING
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		transfer.TicketID,
+		transfer.EventID,
+		transfer.FromUserID,
+		transfer.ToUserID,
+		transfer.Status,
+		transfer.CreatedAt,
+		transfer.UpdatedAt,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrTicketTransferExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *ticketTransferRepository) FindByID(ctx context.Context, id int) (*entity.TicketTransfer, error) {
+	query := `
+		SELECT ` + ticketTransferColumns + `
+		FROM ticket_transfers
+		WHERE id = $1
+	`
+
+	transfer, err := scanTicketTransfer(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (r *ticketTransferRepository) FindByTicketID(ctx context.Context, ticketID int) ([]entity.TicketTransfer, error) {
+	query := `
+		SELECT ` + ticketTransferColumns + `
+		FROM ticket_transfers
+		WHERE ticket_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryTransfers(ctx, query, ticketID)
+}
+
+func (r *ticketTransferRepository) FindPendingByUserID(ctx context.Context, userID int) ([]entity.TicketTransfer, error) {
+	query := `
+		SELECT ` + ticketTransferColumns + `
+		FROM ticket_transfers
+		WHERE status = 'pending' AND (from_user_id = $1 OR to_user_id = $1)
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return r.queryTransfers(ctx, query, userID)
+}
+
+func (r *ticketTransferRepository) UpdateStatus(ctx context.Context, id int, from, to entity.TicketTransferStatus) error {
+	query := `UPDATE ticket_transfers SET status = $1, responded_at = $2, updated_at = $2 WHERE id = $3 AND status = $4`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, to, time.Now(), id, from)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
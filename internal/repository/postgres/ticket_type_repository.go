@@ -0,0 +1,222 @@
+//internal/repository/postgres/ticket_type_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const ticketTypeColumns = `id, event_id, name, description, price, currency, quota, sold, sales_start,
+			sales_end, max_per_order, created_at, updated_at`
+
+type ticketTypeRepository struct {
+	db *sql.DB
+}
+
+func NewTicketTypeRepository(db *sql.DB) *ticketTypeRepository {
+	return &ticketTypeRepository{
+		db: db,
+	}
+}
+
+func scanTicketType(row rowScanner) (*entity.TicketType, error) {
+	var ticketType entity.TicketType
+	var description sql.NullString
+	var salesStart, salesEnd sql.NullTime
+	price := newMoneyScan(&ticketType.Price)
+
+	err := row.Scan(
+		&ticketType.ID,
+		&ticketType.EventID,
+		&ticketType.Name,
+		&description,
+		price.Amount(0),
+		price.Currency(),
+		&ticketType.Quota,
+		&ticketType.Sold,
+		&salesStart,
+		&salesEnd,
+		&ticketType.MaxPerOrder,
+		&ticketType.CreatedAt,
+		&ticketType.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := price.parse(); err != nil {
+		return nil, err
+	}
+
+	ticketType.Description = description.String
+	ticketType.SalesStart = salesStart.Time
+	ticketType.SalesEnd = salesEnd.Time
+
+	return &ticketType, nil
+}
+
+func (r *ticketTypeRepository) Create(ctx context.Context, ticketType *entity.TicketType) (int, error) {
+	query := `
+		INSERT INTO ticket_types (
+			event_id, name, description, price, currency, quota, sold, sales_start, sales_end,
+			max_per_order, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, 0, $7, $8, $9, NOW(), NOW())
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		ticketType.EventID,
+		ticketType.Name,
+		nullString(ticketType.Description),
+		ticketType.Price.Decimal(),
+		ticketType.Price.CurrencyCode(),
+		ticketType.Quota,
+		nullTime(ticketType.SalesStart),
+		nullTime(ticketType.SalesEnd),
+		ticketType.MaxPerOrder,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *ticketTypeRepository) FindByID(ctx context.Context, id int) (*entity.TicketType, error) {
+	query := `
+		SELECT ` + ticketTypeColumns + `
+		FROM ticket_types
+		WHERE id = $1
+	`
+
+	ticketType, err := scanTicketType(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ticketType, nil
+}
+
+func (r *ticketTypeRepository) FindByEventID(ctx context.Context, eventID int) ([]entity.TicketType, error) {
+	query := `
+		SELECT ` + ticketTypeColumns + `
+		FROM ticket_types
+		WHERE event_id = $1
+		ORDER BY price ASC, id ASC
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ticketTypes []entity.TicketType
+	for rows.Next() {
+		ticketType, err := scanTicketType(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		ticketTypes = append(ticketTypes, *ticketType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ticketTypes, nil
+}
+
+// Update tidak menyentuh kolom sold. Kuota yang lebih kecil dari sold ditolak oleh constraint
+// sehingga pembelian yang terjadi bersamaan dengan perubahan kuota tetap aman.
+func (r *ticketTypeRepository) Update(ctx context.Context, ticketType *entity.TicketType) error {
+	query := `
+		UPDATE ticket_types
+		SET name = $1, description = $2, price = $3, quota = $4, sales_start = $5, sales_end = $6,
+			max_per_order = $7, updated_at = NOW()
+		WHERE id = $8 AND sold <= $4
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		ticketType.Name,
+		nullString(ticketType.Description),
+		ticketType.Price.Decimal(),
+		ticketType.Quota,
+		nullTime(ticketType.SalesStart),
+		nullTime(ticketType.SalesEnd),
+		ticketType.MaxPerOrder,
+		ticketType.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrTicketTypeSoldOut
+	}
+
+	return nil
+}
+
+// Delete hanya menghapus tipe tiket yang belum pernah terjual
+func (r *ticketTypeRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM ticket_types WHERE id = $1 AND sold = 0`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *ticketTypeRepository) Reserve(ctx context.Context, id, quantity int) error {
+	query := `
+		UPDATE ticket_types
+		SET sold = sold + $1, updated_at = NOW()
+		WHERE id = $2 AND sold + $1 <= quota
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, quantity, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrTicketTypeSoldOut
+	}
+
+	return nil
+}
+
+func (r *ticketTypeRepository) Release(ctx context.Context, id, quantity int) error {
+	query := `
+		UPDATE ticket_types
+		SET sold = GREATEST(sold - $1, 0), updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, quantity, id)
+	return err
+}
@@ -0,0 +1,160 @@
+//internal/repository/postgres/token_revocation_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+// revocationSyncInterval adalah jeda maksimal pencabutan dari instance server lain terlihat di
+// cache. Pencabutan dari instance yang sama masuk ke cache begitu transaksinya commit.
+const revocationSyncInterval = 30 * time.Second
+
+// tokenRevocationRepository menyimpan pencabutan di tabel token_revocations dan menyalin entry
+// yang masih berlaku ke memori, karena IsRevoked dipanggil di setiap request terotentikasi.
+type tokenRevocationRepository struct {
+	db *sql.DB
+
+	mu          sync.RWMutex
+	revocations []entity.TokenRevocation
+	syncedAt    time.Time
+}
+
+func NewTokenRevocationRepository(db *sql.DB) *tokenRevocationRepository {
+	return &tokenRevocationRepository{
+		db: db,
+	}
+}
+
+func (r *tokenRevocationRepository) Create(ctx context.Context, revocation *entity.TokenRevocation) error {
+	query := `
+		INSERT INTO token_revocations (user_id, jti, token_version, revoked_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (jti) WHERE jti IS NOT NULL DO NOTHING
+	`
+
+	_, err := executor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		revocation.UserID,
+		nullString(revocation.JTI),
+		revocation.TokenVersion,
+		revocation.RevokedAt,
+		revocation.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Cache baru diisi setelah commit agar rollback tidak meninggalkan pencabutan yang tidak pernah tersimpan
+	entry := *revocation
+	afterCommit(ctx, func() {
+		r.mu.Lock()
+		r.revocations = append(r.revocations, entry)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *tokenRevocationRepository) IsRevoked(ctx context.Context, userID int, jti string, tokenVersion int) (bool, error) {
+	if err := r.syncIfStale(ctx); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	for i := range r.revocations {
+		revocation := &r.revocations[i]
+		if revocation.ExpiresAt.After(now) && revocation.Covers(userID, jti, tokenVersion) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *tokenRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	query := `DELETE FROM token_revocations WHERE expires_at <= $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	kept := r.revocations[:0]
+	for _, revocation := range r.revocations {
+		if revocation.ExpiresAt.After(now) {
+			kept = append(kept, revocation)
+		}
+	}
+	r.revocations = kept
+	r.mu.Unlock()
+
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+// syncIfStale memuat ulang pencabutan yang masih berlaku dari database. Lock ditahan selama query
+// agar pencabutan dari Create yang selesai di tengah sinkronisasi tidak tertimpa snapshot lama.
+// Jika database gagal diakses, error dikembalikan dan sinkronisasi dicoba lagi di request
+// berikutnya, karena cache lama bisa melewatkan pencabutan dari instance lain.
+func (r *tokenRevocationRepository) syncIfStale(ctx context.Context) error {
+	r.mu.RLock()
+	fresh := time.Since(r.syncedAt) < revocationSyncInterval
+	r.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.syncedAt) < revocationSyncInterval {
+		return nil
+	}
+
+	revocations, err := r.findActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.revocations = revocations
+	r.syncedAt = time.Now()
+	return nil
+}
+
+func (r *tokenRevocationRepository) findActive(ctx context.Context) ([]entity.TokenRevocation, error) {
+	query := `
+		SELECT id, user_id, jti, token_version, revoked_at, expires_at
+		FROM token_revocations
+		WHERE expires_at > NOW()
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revocations []entity.TokenRevocation
+	for rows.Next() {
+		var revocation entity.TokenRevocation
+		var jti sql.NullString
+		if err := rows.Scan(&revocation.ID, &revocation.UserID, &jti, &revocation.TokenVersion, &revocation.RevokedAt, &revocation.ExpiresAt); err != nil {
+			return nil, err
+		}
+		revocation.JTI = jti.String
+		revocations = append(revocations, revocation)
+	}
+
+	return revocations, rows.Err()
+}
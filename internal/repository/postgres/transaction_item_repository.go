@@ -0,0 +1,93 @@
+//internal/repository/postgres/transaction_item_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type transactionItemRepository struct {
+	db *sql.DB
+}
+
+func NewTransactionItemRepository(db *sql.DB) *transactionItemRepository {
+	return &transactionItemRepository{
+		db: db,
+	}
+}
+
+func (r *transactionItemRepository) CreateBatch(ctx context.Context, items []*entity.TransactionItem) error {
+	query := `
+		INSERT INTO transaction_items (
+			transaction_id, ticket_type_id, ticket_type_name, quantity, unit_price, subtotal, currency
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	for _, item := range items {
+		err := executor(ctx, r.db).QueryRowContext(
+			ctx,
+			query,
+			item.TransactionID,
+			item.TicketTypeID,
+			item.TicketTypeName,
+			item.Quantity,
+			item.UnitPrice.Decimal(),
+			item.Subtotal.Decimal(),
+			item.UnitPrice.CurrencyCode(),
+		).Scan(&item.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *transactionItemRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]entity.TransactionItem, error) {
+	query := `
+		SELECT id, transaction_id, ticket_type_id, ticket_type_name, quantity, unit_price, subtotal, currency
+		FROM transaction_items
+		WHERE transaction_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []entity.TransactionItem
+	for rows.Next() {
+		var item entity.TransactionItem
+		amounts := newMoneyScan(&item.UnitPrice, &item.Subtotal)
+		err := rows.Scan(
+			&item.ID,
+			&item.TransactionID,
+			&item.TicketTypeID,
+			&item.TicketTypeName,
+			&item.Quantity,
+			amounts.Amount(0),
+			amounts.Amount(1),
+			amounts.Currency(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := amounts.parse(); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
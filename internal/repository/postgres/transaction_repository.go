@@ -8,8 +8,19 @@ import (
 	"errors"
 	"time"
 	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
 )
 
+const transactionColumns = `id, user_id, event_id, transaction_code, quantity, 
+			total_amount, status, payment_method, payment_detail, payment_proof,
+			verified_at, verified_by, expires_at, promo_code_id, discount_amount,
+			subtotal_amount, service_fee_amount, platform_fee_amount, platform_fee_to_buyer, tax_amount,
+			currency, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type transactionRepository struct {
 	db *sql.DB
 }
@@ -20,29 +31,109 @@ func NewTransactionRepository(db *sql.DB) *transactionRepository {
 	}
 }
 
+func scanTransaction(row rowScanner) (*entity.Transaction, error) {
+	var transaction entity.Transaction
+	var verifiedAt sql.NullTime
+	var verifiedBy sql.NullInt64
+	var expiresAt sql.NullTime
+	var promoCodeID sql.NullInt64
+	amounts := newMoneyScan(
+		&transaction.TotalAmount,
+		&transaction.DiscountAmount,
+		&transaction.SubtotalAmount,
+		&transaction.ServiceFeeAmount,
+		&transaction.PlatformFeeAmount,
+		&transaction.TaxAmount,
+	)
+
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.UserID,
+		&transaction.EventID,
+		&transaction.TransactionCode,
+		&transaction.Quantity,
+		amounts.Amount(0),
+		&transaction.Status,
+		&transaction.PaymentMethod,
+		&transaction.PaymentDetail,
+		&transaction.PaymentProof,
+		&verifiedAt,
+		&verifiedBy,
+		&expiresAt,
+		&promoCodeID,
+		amounts.Amount(1),
+		amounts.Amount(2),
+		amounts.Amount(3),
+		amounts.Amount(4),
+		&transaction.PlatformFeeToBuyer,
+		amounts.Amount(5),
+		amounts.Currency(),
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := amounts.parse(); err != nil {
+		return nil, err
+	}
+
+	if verifiedAt.Valid {
+		transaction.VerifiedAt = verifiedAt.Time
+	}
+	if verifiedBy.Valid {
+		transaction.VerifiedBy = int(verifiedBy.Int64)
+	}
+	if expiresAt.Valid {
+		transaction.ExpiresAt = expiresAt.Time
+	}
+	if promoCodeID.Valid {
+		transaction.PromoCodeID = int(promoCodeID.Int64)
+	}
+
+	return &transaction, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t, Valid: true}
+}
+
 func (r *transactionRepository) Create(ctx context.Context, transaction *entity.Transaction) (int, error) {
 	query := `
 		INSERT INTO transactions (
 			user_id, event_id, transaction_code, quantity, total_amount, 
 			status, payment_method, payment_detail, payment_proof,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			expires_at, promo_code_id, discount_amount, subtotal_amount, service_fee_amount,
+			platform_fee_amount, platform_fee_to_buyer, tax_amount, currency, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 		RETURNING id
 	`
 
 	var id int
-	err := r.db.QueryRowContext(
+	err := executor(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		transaction.UserID,
 		transaction.EventID,
 		transaction.TransactionCode,
 		transaction.Quantity,
-		transaction.TotalAmount,
+		transaction.TotalAmount.Decimal(),
 		transaction.Status,
 		transaction.PaymentMethod,
 		transaction.PaymentDetail,
 		transaction.PaymentProof,
+		nullTime(transaction.ExpiresAt),
+		nullInt(transaction.PromoCodeID),
+		transaction.DiscountAmount.Decimal(),
+		transaction.SubtotalAmount.Decimal(),
+		transaction.ServiceFeeAmount.Decimal(),
+		transaction.PlatformFeeAmount.Decimal(),
+		transaction.PlatformFeeToBuyer,
+		transaction.TaxAmount.Decimal(),
+		transaction.TotalAmount.CurrencyCode(),
 		transaction.CreatedAt,
 		transaction.UpdatedAt,
 	).Scan(&id)
@@ -54,36 +145,50 @@ func (r *transactionRepository) Create(ctx context.Context, transaction *entity.
 	return id, nil
 }
 
+// CreateWithReservation menambah tickets_sold secara kondisional dan menyimpan transaksi
+// dalam satu transaksi database, sehingga dua pembeli tidak bisa mengambil kursi yang sama
+func (r *transactionRepository) CreateWithReservation(ctx context.Context, transaction *entity.Transaction) (int, error) {
+	var id int
+
+	err := NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		reserveQuery := `
+			UPDATE events
+			SET tickets_sold = tickets_sold + $1, updated_at = $2
+			WHERE id = $3 AND tickets_sold + $1 <= max_capacity
+		`
+
+		result, err := executor(ctx, r.db).ExecContext(ctx, reserveQuery, transaction.Quantity, time.Now(), transaction.EventID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return repository.ErrTicketSoldOut
+		}
+
+		id, err = r.Create(ctx, transaction)
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
 func (r *transactionRepository) FindByID(ctx context.Context, id int) (*entity.Transaction, error) {
 	query := `
-		SELECT id, user_id, event_id, transaction_code, quantity, 
-			total_amount, status, payment_method, payment_detail, payment_proof,
-			verified_at, verified_by, created_at, updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		WHERE id = $1
 	`
 
-	var transaction entity.Transaction
-	var verifiedAt sql.NullTime
-	var verifiedBy sql.NullInt64
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&transaction.ID,
-		&transaction.UserID,
-		&transaction.EventID,
-		&transaction.TransactionCode,
-		&transaction.Quantity,
-		&transaction.TotalAmount,
-		&transaction.Status,
-		&transaction.PaymentMethod,
-		&transaction.PaymentDetail,
-		&transaction.PaymentProof,
-		&verifiedAt,
-		&verifiedBy,
-		&transaction.CreatedAt,
-		&transaction.UpdatedAt,
-	)
-
+	transaction, err := scanTransaction(executor(ctx, r.db).QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -91,46 +196,17 @@ func (r *transactionRepository) FindByID(ctx context.Context, id int) (*entity.T
 		return nil, err
 	}
 
-	if verifiedAt.Valid {
-		transaction.VerifiedAt = verifiedAt.Time
-	}
-	if verifiedBy.Valid {
-		transaction.VerifiedBy = int(verifiedBy.Int64)
-	}
-
-	return &transaction, nil
+	return transaction, nil
 }
 
 func (r *transactionRepository) FindByCode(ctx context.Context, code string) (*entity.Transaction, error) {
 	query := `
-		SELECT id, user_id, event_id, transaction_code, quantity, 
-			total_amount, status, payment_method, payment_detail, payment_proof,
-			verified_at, verified_by, created_at, updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		WHERE transaction_code = $1
 	`
 
-	var transaction entity.Transaction
-	var verifiedAt sql.NullTime
-	var verifiedBy sql.NullInt64
-
-	err := r.db.QueryRowContext(ctx, query, code).Scan(
-		&transaction.ID,
-		&transaction.UserID,
-		&transaction.EventID,
-		&transaction.TransactionCode,
-		&transaction.Quantity,
-		&transaction.TotalAmount,
-		&transaction.Status,
-		&transaction.PaymentMethod,
-		&transaction.PaymentDetail,
-		&transaction.PaymentProof,
-		&verifiedAt,
-		&verifiedBy,
-		&transaction.CreatedAt,
-		&transaction.UpdatedAt,
-	)
-
+	transaction, err := scanTransaction(executor(ctx, r.db).QueryRowContext(ctx, query, code))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -138,28 +214,41 @@ func (r *transactionRepository) FindByCode(ctx context.Context, code string) (*e
 		return nil, err
 	}
 
-	if verifiedAt.Valid {
-		transaction.VerifiedAt = verifiedAt.Time
-	}
-	if verifiedBy.Valid {
-		transaction.VerifiedBy = int(verifiedBy.Int64)
+	return transaction, nil
+}
+
+// FindByCodeForUpdate mengunci baris transaksi sampai transaksi database selesai, sehingga
+// notifikasi pembayaran tidak bertabrakan dengan sweeper expiry maupun notifikasi lain.
+// Harus dipanggil di dalam TxManager.WithinTransaction.
+func (r *transactionRepository) FindByCodeForUpdate(ctx context.Context, code string) (*entity.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE transaction_code = $1
+		FOR UPDATE
+	`
+
+	transaction, err := scanTransaction(executor(ctx, r.db).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	return &transaction, nil
+	return transaction, nil
 }
 
 func (r *transactionRepository) FindByUserID(ctx context.Context, userID, offset, limit int) ([]entity.Transaction, error) {
 	query := `
-		SELECT id, user_id, event_id, transaction_code, quantity, 
-			total_amount, status, payment_method, payment_detail, payment_proof,
-			verified_at, verified_by, created_at, updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		WHERE user_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -167,38 +256,12 @@ func (r *transactionRepository) FindByUserID(ctx context.Context, userID, offset
 
 	var transactions []entity.Transaction
 	for rows.Next() {
-		var transaction entity.Transaction
-		var verifiedAt sql.NullTime
-		var verifiedBy sql.NullInt64
-
-		err := rows.Scan(
-			&transaction.ID,
-			&transaction.UserID,
-			&transaction.EventID,
-			&transaction.TransactionCode,
-			&transaction.Quantity,
-			&transaction.TotalAmount,
-			&transaction.Status,
-			&transaction.PaymentMethod,
-			&transaction.PaymentDetail,
-			&transaction.PaymentProof,
-			&verifiedAt,
-			&verifiedBy,
-			&transaction.CreatedAt,
-			&transaction.UpdatedAt,
-		)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		if verifiedAt.Valid {
-			transaction.VerifiedAt = verifiedAt.Time
-		}
-		if verifiedBy.Valid {
-			transaction.VerifiedBy = int(verifiedBy.Int64)
-		}
-
-		transactions = append(transactions, transaction)
+		transactions = append(transactions, *transaction)
 	}
 
 	return transactions, nil
@@ -208,7 +271,7 @@ func (r *transactionRepository) CountByUserID(ctx context.Context, userID int) (
 	query := `SELECT COUNT(*) FROM transactions WHERE user_id = $1`
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -221,36 +284,41 @@ func (r *transactionRepository) Update(ctx context.Context, transaction *entity.
 		UPDATE transactions
 		SET user_id = $1, event_id = $2, transaction_code = $3, quantity = $4, 
 			total_amount = $5, status = $6, payment_method = $7, payment_detail = $8, 
-			payment_proof = $9, verified_at = $10, verified_by = $11, updated_at = $12
-		WHERE id = $13
+			payment_proof = $9, verified_at = $10, verified_by = $11, expires_at = $12,
+			promo_code_id = $13, discount_amount = $14, subtotal_amount = $15, service_fee_amount = $16,
+			platform_fee_amount = $17, platform_fee_to_buyer = $18, tax_amount = $19, currency = $20, updated_at = $21
+		WHERE id = $22
 	`
 
-	verifiedAt := sql.NullTime{}
-	if !transaction.VerifiedAt.IsZero() {
-		verifiedAt.Time = transaction.VerifiedAt
-		verifiedAt.Valid = true
-	}
-
 	verifiedBy := sql.NullInt64{}
 	if transaction.VerifiedBy != 0 {
 		verifiedBy.Int64 = int64(transaction.VerifiedBy)
 		verifiedBy.Valid = true
 	}
 
-	_, err := r.db.ExecContext(
+	_, err := executor(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		transaction.UserID,
 		transaction.EventID,
 		transaction.TransactionCode,
 		transaction.Quantity,
-		transaction.TotalAmount,
+		transaction.TotalAmount.Decimal(),
 		transaction.Status,
 		transaction.PaymentMethod,
 		transaction.PaymentDetail,
 		transaction.PaymentProof,
-		verifiedAt,
+		nullTime(transaction.VerifiedAt),
 		verifiedBy,
+		nullTime(transaction.ExpiresAt),
+		nullInt(transaction.PromoCodeID),
+		transaction.DiscountAmount.Decimal(),
+		transaction.SubtotalAmount.Decimal(),
+		transaction.ServiceFeeAmount.Decimal(),
+		transaction.PlatformFeeAmount.Decimal(),
+		transaction.PlatformFeeToBuyer,
+		transaction.TaxAmount.Decimal(),
+		transaction.TotalAmount.CurrencyCode(),
 		time.Now(),
 		transaction.ID,
 	)
@@ -258,24 +326,153 @@ func (r *transactionRepository) Update(ctx context.Context, transaction *entity.
 	return err
 }
 
-func (r *transactionRepository) UpdateStatus(ctx context.Context, id int, status string) error {
-	query := `UPDATE transactions SET status = $1, updated_at = $2 WHERE id = $3`
-	_, err := r.db.ExecContext(ctx, query, status, time.Now(), id)
-	return err
+// UpdateStatus hanya mengubah status jika status di database masih sama dengan from,
+// sehingga dua proses yang membaca status yang sama tidak bisa sama-sama menang.
+func (r *transactionRepository) UpdateStatus(ctx context.Context, id int, from, to entity.TransactionStatus) error {
+	query := `UPDATE transactions SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, to, time.Now(), id, from)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
 }
 
 func (r *transactionRepository) UpdatePaymentProof(ctx context.Context, id int, proofURL string) error {
 	query := `UPDATE transactions SET payment_proof = $1, updated_at = $2 WHERE id = $3`
-	_, err := r.db.ExecContext(ctx, query, proofURL, time.Now(), id)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, proofURL, time.Now(), id)
+	return err
+}
+
+func (r *transactionRepository) UpdateExpiresAt(ctx context.Context, id int, expiresAt time.Time) error {
+	query := `UPDATE transactions SET expires_at = $1, updated_at = $2 WHERE id = $3`
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, nullTime(expiresAt), time.Now(), id)
 	return err
 }
 
 func (r *transactionRepository) VerifyPayment(ctx context.Context, id, verifierID int) error {
 	query := `
 		UPDATE transactions 
-		SET status = 'success', verified_at = $1, verified_by = $2, updated_at = $3
-		WHERE id = $4
+		SET status = 'paid', verified_at = $1, verified_by = $2, updated_at = $3
+		WHERE id = $4 AND status = 'waiting_verification'
 	`
-	_, err := r.db.ExecContext(ctx, query, time.Now(), verifierID, time.Now(), id)
-	return err
-}
\ No newline at end of file
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, time.Now(), verifierID, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func expectOneRow(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrStatusConflict
+	}
+	return nil
+}
+
+// ExpireOverdue mengubah transaksi pending yang melewati batas pembayaran menjadi expired.
+// SKIP LOCKED membuat beberapa instance API bisa menjalankan sweeper bersamaan tanpa
+// memproses baris yang sama dua kali.
+func (r *transactionRepository) ExpireOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Transaction, error) {
+	query := `
+		WITH overdue AS (
+			SELECT id
+			FROM transactions
+			WHERE status = 'pending' AND expires_at IS NOT NULL AND expires_at <= $1
+			ORDER BY expires_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE transactions t
+		SET status = 'expired', updated_at = $1
+		FROM overdue
+		WHERE t.id = overdue.id
+		RETURNING t.id, t.user_id, t.event_id, t.transaction_code, t.quantity, 
+			t.total_amount, t.status, t.payment_method, t.payment_detail, t.payment_proof,
+			t.verified_at, t.verified_by, t.expires_at, t.promo_code_id, t.discount_amount,
+			t.subtotal_amount, t.service_fee_amount, t.platform_fee_amount, t.platform_fee_to_buyer, t.tax_amount,
+			t.currency, t.created_at, t.updated_at
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []entity.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, *transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+func (r *transactionRepository) SumPaidRevenueByEvent(ctx context.Context, eventID int, currency string) (*entity.EventRevenue, error) {
+	query := `
+		SELECT COALESCE(SUM(subtotal_amount), 0), COALESCE(SUM(discount_amount), 0)
+		FROM transactions
+		WHERE event_id = $1 AND status = 'paid'
+	`
+
+	var subtotal, discount string
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, eventID).Scan(&subtotal, &discount); err != nil {
+		return nil, err
+	}
+
+	revenue := &entity.EventRevenue{
+		ByTicketType: make(map[int]entity.Money),
+	}
+
+	var err error
+	if revenue.Subtotal, err = entity.ParseMoney(subtotal, currency); err != nil {
+		return nil, err
+	}
+	if revenue.Discount, err = entity.ParseMoney(discount, currency); err != nil {
+		return nil, err
+	}
+
+	itemQuery := `
+		SELECT ti.ticket_type_id, SUM(ti.subtotal)
+		FROM transaction_items ti
+		JOIN transactions t ON t.id = ti.transaction_id
+		WHERE t.event_id = $1 AND t.status = 'paid'
+		GROUP BY ti.ticket_type_id
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, itemQuery, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ticketTypeID int
+		var amount string
+		if err := rows.Scan(&ticketTypeID, &amount); err != nil {
+			return nil, err
+		}
+
+		money, err := entity.ParseMoney(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		revenue.ByTicketType[ticketTypeID] = money
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revenue, nil
+}
@@ -0,0 +1,116 @@
+//internal/repository/postgres/transaction_status_history_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type transactionStatusHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewTransactionStatusHistoryRepository(db *sql.DB) *transactionStatusHistoryRepository {
+	return &transactionStatusHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *transactionStatusHistoryRepository) Create(ctx context.Context, history *entity.TransactionStatusHistory) (int, error) {
+	query := `
+		INSERT INTO transaction_status_history (
+			transaction_id, from_status, to_status, actor_type, actor_id, reason, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id
+	`
+
+	var actorID sql.NullInt64
+	if history.ActorID != 0 {
+		actorID = sql.NullInt64{Int64: int64(history.ActorID), Valid: true}
+	}
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		history.TransactionID,
+		nullString(string(history.FromStatus)),
+		history.ToStatus,
+		history.ActorType,
+		actorID,
+		nullString(history.Reason),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *transactionStatusHistoryRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]entity.TransactionStatusHistory, error) {
+	query := `
+		SELECT id, transaction_id, from_status, to_status, actor_type, actor_id, reason, created_at
+		FROM transaction_status_history
+		WHERE transaction_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories []entity.TransactionStatusHistory
+	for rows.Next() {
+		var history entity.TransactionStatusHistory
+		var fromStatus, reason sql.NullString
+		var actorID sql.NullInt64
+
+		err := rows.Scan(
+			&history.ID,
+			&history.TransactionID,
+			&fromStatus,
+			&history.ToStatus,
+			&history.ActorType,
+			&actorID,
+			&reason,
+			&history.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		history.FromStatus = entity.TransactionStatus(fromStatus.String)
+		history.ActorID = int(actorID.Int64)
+		history.Reason = reason.String
+
+		histories = append(histories, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (r *transactionStatusHistoryRepository) CountByTransition(ctx context.Context, transactionID int, from, to entity.TransactionStatus) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM transaction_status_history
+		WHERE transaction_id = $1 AND from_status = $2 AND to_status = $3
+	`
+
+	var count int
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, transactionID, from, to).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,87 @@
+//internal/repository/postgres/tx_manager.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txContextKey struct{}
+
+// txState adalah transaksi aktif beserta fungsi yang menunggu commit
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func()
+}
+
+// dbExecutor adalah method yang dimiliki bersama oleh *sql.DB dan *sql.Tx
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txManager struct {
+	db *sql.DB
+}
+
+func NewTxManager(db *sql.DB) *txManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithinTransaction menjalankan fn dalam satu *sql.Tx. Jika ctx sudah membawa transaksi,
+// fn ikut transaksi tersebut sehingga commit/rollback tetap dilakukan oleh pemanggil terluar.
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	state := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txContextKey{}, state)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, hook := range state.afterCommit {
+		hook()
+	}
+
+	return nil
+}
+
+// executor mengembalikan transaksi aktif di ctx, atau db jika tidak ada transaksi
+func executor(ctx context.Context, db *sql.DB) dbExecutor {
+	if state, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		return state.tx
+	}
+	return db
+}
+
+// afterCommit menunda fn sampai transaksi di ctx berhasil commit dan membuangnya saat rollback.
+// Tanpa transaksi, perubahan sudah tersimpan sehingga fn langsung dijalankan.
+func afterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,175 @@
+//internal/repository/postgres/user_mfa_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+)
+
+type userMFARepository struct {
+	db *sql.DB
+}
+
+func NewUserMFARepository(db *sql.DB) *userMFARepository {
+	return &userMFARepository{
+		db: db,
+	}
+}
+
+func (r *userMFARepository) FindByUserID(ctx context.Context, userID int) (*entity.UserMFA, error) {
+	query := `
+		SELECT user_id, secret_encrypted, enabled_at, last_used_step, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+
+	var mfa entity.UserMFA
+	var enabledAt sql.NullTime
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.SecretEncrypted,
+		&enabledAt,
+		&mfa.LastUsedStep,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	mfa.EnabledAt = enabledAt.Time
+
+	return &mfa, nil
+}
+
+// SavePending hanya menimpa baris yang belum aktif, sehingga setup ulang tidak bisa mengganti
+// secret 2FA yang sedang dipakai
+func (r *userMFARepository) SavePending(ctx context.Context, mfa *entity.UserMFA) error {
+	query := `
+		INSERT INTO user_mfa (user_id, secret_encrypted, last_used_step, created_at, updated_at)
+		VALUES ($1, $2, 0, $3, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret_encrypted = EXCLUDED.secret_encrypted,
+			last_used_step = 0,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at
+		WHERE user_mfa.enabled_at IS NULL
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, mfa.UserID, mfa.SecretEncrypted, mfa.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *userMFARepository) Enable(ctx context.Context, userID int, enabledAt time.Time, step int64) error {
+	query := `
+		UPDATE user_mfa
+		SET enabled_at = $1, last_used_step = $2, updated_at = $1
+		WHERE user_id = $3 AND enabled_at IS NULL
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, enabledAt, step, userID)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+// ConsumeStep memakai perbandingan di WHERE supaya dua login bersamaan dengan kode yang sama
+// tidak sama-sama lolos
+func (r *userMFARepository) ConsumeStep(ctx context.Context, userID int, step int64) error {
+	query := `
+		UPDATE user_mfa
+		SET last_used_step = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $2 AND last_used_step < $1
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, step, userID)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *userMFARepository) Delete(ctx context.Context, userID int) error {
+	if _, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *userMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	if _, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO mfa_recovery_codes (user_id, code_hash, created_at)
+		VALUES ($1, $2, $3)
+	`
+
+	now := time.Now()
+	for _, codeHash := range codeHashes {
+		if _, err := executor(ctx, r.db).ExecContext(ctx, query, userID, codeHash, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *userMFARepository) FindUnusedRecoveryCodes(ctx context.Context, userID int) ([]entity.MFARecoveryCode, error) {
+	query := `
+		SELECT id, user_id, code_hash, created_at
+		FROM mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+		ORDER BY id
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []entity.MFARecoveryCode
+	for rows.Next() {
+		var code entity.MFARecoveryCode
+		if err := rows.Scan(&code.ID, &code.UserID, &code.CodeHash, &code.CreatedAt); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, rows.Err()
+}
+
+func (r *userMFARepository) MarkRecoveryCodeUsed(ctx context.Context, id int, usedAt time.Time) error {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
@@ -27,7 +27,7 @@ func (r *userProfileRepository) Create(ctx context.Context, profile *entity.User
 	`
 
 	var id int
-	err := r.db.QueryRowContext(
+	err := executor(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
 		profile.UserID,
@@ -52,7 +52,7 @@ func (r *userProfileRepository) FindByUserID(ctx context.Context, userID int) (*
 	`
 
 	var profile entity.UserProfile
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
 		&profile.ID,
 		&profile.UserID,
 		&profile.Name,
@@ -80,7 +80,7 @@ func (r *userProfileRepository) Update(ctx context.Context, profile *entity.User
 		WHERE id = $5
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := executor(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		profile.Name,
@@ -95,6 +95,6 @@ func (r *userProfileRepository) Update(ctx context.Context, profile *entity.User
 
 func (r *userProfileRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM user_profiles WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	return err
 }
\ No newline at end of file
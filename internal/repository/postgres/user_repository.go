@@ -27,7 +27,7 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) (int, er
 	`
 
 	var id int
-	err := r.db.QueryRowContext(
+	err := executor(ctx, r.db).QueryRowContext(
 		ctx, 
 		query, 
 		user.Username, 
@@ -46,19 +46,54 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) (int, er
 
 func (r *userRepository) FindByID(ctx context.Context, id int) (*entity.User, error) {
 	query := `
-		SELECT id, username, email, password, role, is_verified, created_at, updated_at
+		SELECT id, username, email, password, role, is_verified, token_version, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 
 	var user entity.User
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.Password,
 		&user.Role,
 		&user.IsVerified,
+		&user.TokenVersion,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// FindByIDForUpdate mengunci baris user sampai transaksi database selesai, sehingga penerbitan
+// sesi tidak bertabrakan dengan IncrementTokenVersion. Harus dipanggil di dalam
+// TxManager.WithinTransaction.
+func (r *userRepository) FindByIDForUpdate(ctx context.Context, id int) (*entity.User, error) {
+	query := `
+		SELECT id, username, email, password, role, is_verified, token_version, created_at, updated_at
+		FROM users
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var user entity.User
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.IsVerified,
+		&user.TokenVersion,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -75,19 +110,20 @@ func (r *userRepository) FindByID(ctx context.Context, id int) (*entity.User, er
 
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
 	query := `
-		SELECT id, username, email, password, role, is_verified, created_at, updated_at
+		SELECT id, username, email, password, role, is_verified, token_version, created_at, updated_at
 		FROM users
 		WHERE username = $1
 	`
 
 	var user entity.User
-	err := r.db.QueryRowContext(ctx, query, username).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, username).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.Password,
 		&user.Role,
 		&user.IsVerified,
+		&user.TokenVersion,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -104,19 +140,20 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
-		SELECT id, username, email, password, role, is_verified, created_at, updated_at
+		SELECT id, username, email, password, role, is_verified, token_version, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
 	var user entity.User
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.Password,
 		&user.Role,
 		&user.IsVerified,
+		&user.TokenVersion,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -138,7 +175,7 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 		WHERE id = $6
 	`
 
-	_, err := r.db.ExecContext(
+	_, err := executor(ctx, r.db).ExecContext(
 		ctx,
 		query,
 		user.Username,
@@ -154,13 +191,30 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 
 func (r *userRepository) UpdateVerificationStatus(ctx context.Context, userID int, isVerified bool) error {
 	query := `UPDATE users SET is_verified = $1, updated_at = NOW() WHERE id = $2`
-	_, err := r.db.ExecContext(ctx, query, isVerified, userID)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, isVerified, userID)
 	return err
 }
 
+func (r *userRepository) UpdatePassword(ctx context.Context, userID int, hashedPassword string) error {
+	query := `UPDATE users SET password = $1, updated_at = NOW() WHERE id = $2`
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, hashedPassword, userID)
+	return err
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, userID int) (int, error) {
+	query := `UPDATE users SET token_version = token_version + 1, updated_at = NOW() WHERE id = $1 RETURNING token_version`
+
+	var version int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&version); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
 func (r *userRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	return err
 }
 
@@ -171,6 +225,6 @@ func (r *userRepository) CreateDefaultProfile(ctx context.Context, userID int) e
 		ON CONFLICT (user_id) DO NOTHING
 	`
 
-	_, err := r.db.ExecContext(ctx, query, userID, "Pengguna")
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, userID, "Pengguna")
 	return err
 }
\ No newline at end of file
@@ -0,0 +1,203 @@
+//internal/repository/postgres/venue_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const venueColumns = `v.id, v.owner_id, v.name, v.address, v.created_at, v.updated_at,
+			(SELECT COUNT(*) FROM venue_seats s WHERE s.venue_id = v.id)`
+
+const venueSeatColumns = `id, venue_id, section, row_label, seat_number, pos_x, pos_y, is_accessible`
+
+type venueRepository struct {
+	db *sql.DB
+}
+
+func NewVenueRepository(db *sql.DB) *venueRepository {
+	return &venueRepository{
+		db: db,
+	}
+}
+
+func scanVenue(row rowScanner) (*entity.Venue, error) {
+	var venue entity.Venue
+	var address sql.NullString
+
+	err := row.Scan(
+		&venue.ID,
+		&venue.OwnerID,
+		&venue.Name,
+		&address,
+		&venue.CreatedAt,
+		&venue.UpdatedAt,
+		&venue.SeatCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	venue.Address = address.String
+
+	return &venue, nil
+}
+
+func scanVenueSeat(row rowScanner) (*entity.VenueSeat, error) {
+	var seat entity.VenueSeat
+
+	err := row.Scan(
+		&seat.ID,
+		&seat.VenueID,
+		&seat.Section,
+		&seat.Row,
+		&seat.Number,
+		&seat.X,
+		&seat.Y,
+		&seat.Accessible,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seat, nil
+}
+
+func queryVenueSeats(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]entity.VenueSeat, error) {
+	rows, err := executor(ctx, db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seats []entity.VenueSeat
+	for rows.Next() {
+		seat, err := scanVenueSeat(rows)
+		if err != nil {
+			return nil, err
+		}
+		seats = append(seats, *seat)
+	}
+
+	return seats, rows.Err()
+}
+
+// Create menyimpan venue beserta seluruh kursinya dalam satu transaksi sehingga denah yang
+// setengah tersimpan tidak pernah terlihat
+func (r *venueRepository) Create(ctx context.Context, venue *entity.Venue, seats []*entity.VenueSeat) (int, error) {
+	err := NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		err := executor(ctx, r.db).QueryRowContext(ctx, `
+			INSERT INTO venues (owner_id, name, address, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, venue.OwnerID, venue.Name, nullString(venue.Address), venue.CreatedAt, venue.UpdatedAt).Scan(&venue.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, seat := range seats {
+			seat.VenueID = venue.ID
+			err := executor(ctx, r.db).QueryRowContext(ctx, `
+				INSERT INTO venue_seats (venue_id, section, row_label, seat_number, pos_x, pos_y, is_accessible)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				RETURNING id
+			`, seat.VenueID, seat.Section, seat.Row, seat.Number, seat.X, seat.Y, seat.Accessible).Scan(&seat.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	venue.SeatCount = len(seats)
+
+	return venue.ID, nil
+}
+
+func (r *venueRepository) FindByID(ctx context.Context, id int) (*entity.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues v
+		WHERE v.id = $1
+	`
+
+	venue, err := scanVenue(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (r *venueRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]entity.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues v
+		WHERE v.owner_id = $1
+		ORDER BY v.name ASC, v.id ASC
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var venues []entity.Venue
+	for rows.Next() {
+		venue, err := scanVenue(rows)
+		if err != nil {
+			return nil, err
+		}
+		venues = append(venues, *venue)
+	}
+
+	return venues, rows.Err()
+}
+
+func (r *venueRepository) FindSeatsByVenueID(ctx context.Context, venueID int) ([]entity.VenueSeat, error) {
+	query := `
+		SELECT ` + venueSeatColumns + `
+		FROM venue_seats
+		WHERE venue_id = $1
+		ORDER BY section ASC, row_label ASC, id ASC
+	`
+
+	return queryVenueSeats(ctx, r.db, query, venueID)
+}
+
+// Delete hanya menghapus venue yang belum dipakai event mana pun. Pengecekan dilakukan di
+// statement yang sama agar tidak berbalapan dengan penetapan venue ke event.
+func (r *venueRepository) Delete(ctx context.Context, id int) error {
+	query := `
+		DELETE FROM venues
+		WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM events WHERE venue_id = $1)
+	`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrVenueInUse
+	}
+
+	return nil
+}
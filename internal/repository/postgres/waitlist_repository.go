@@ -0,0 +1,306 @@
+//internal/repository/postgres/waitlist_repository.go
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+const waitlistColumns = `id, event_id, user_id, quantity, status, offered_at, offer_expires_at,
+			claimed_transaction_id, created_at, updated_at`
+
+type waitlistRepository struct {
+	db *sql.DB
+}
+
+func NewWaitlistRepository(db *sql.DB) *waitlistRepository {
+	return &waitlistRepository{
+		db: db,
+	}
+}
+
+func scanWaitlistEntry(row rowScanner) (*entity.WaitlistEntry, error) {
+	var entry entity.WaitlistEntry
+	var offeredAt, offerExpiresAt sql.NullTime
+	var claimedTransactionID sql.NullInt64
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.EventID,
+		&entry.UserID,
+		&entry.Quantity,
+		&entry.Status,
+		&offeredAt,
+		&offerExpiresAt,
+		&claimedTransactionID,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.OfferedAt = offeredAt.Time
+	entry.OfferExpiresAt = offerExpiresAt.Time
+	entry.ClaimedTransactionID = int(claimedTransactionID.Int64)
+
+	return &entry, nil
+}
+
+func (r *waitlistRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]entity.WaitlistEntry, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []entity.WaitlistEntry
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *waitlistRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.WaitlistEntry, error) {
+	entry, err := scanWaitlistEntry(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Create memakai ON CONFLICT pada unique index parsial sehingga dua pendaftaran bersamaan dari
+// user yang sama hanya menghasilkan satu antrean aktif
+func (r *waitlistRepository) Create(ctx context.Context, entry *entity.WaitlistEntry) (int, error) {
+	query := `
+		INSERT INTO waitlist_entries (event_id, user_id, quantity, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (event_id, user_id) WHERE status IN ('waiting', 'offered') DO NOTHING
+		RETURNING id
+	`
+
+	var id int
+	err := executor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		entry.EventID,
+		entry.UserID,
+		entry.Quantity,
+		entry.Status,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrWaitlistEntryExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *waitlistRepository) FindByID(ctx context.Context, id int) (*entity.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE id = $1
+	`
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *waitlistRepository) FindActive(ctx context.Context, eventID, userID int) (*entity.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE event_id = $1 AND user_id = $2 AND status IN ('waiting', 'offered')
+	`
+
+	return r.findOne(ctx, query, eventID, userID)
+}
+
+func (r *waitlistRepository) CountWaitingAhead(ctx context.Context, entry *entity.WaitlistEntry) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM waitlist_entries
+		WHERE event_id = $1 AND status = 'waiting' AND (created_at, id) < ($2, $3)
+	`
+
+	var count int
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, entry.EventID, entry.CreatedAt, entry.ID).Scan(&count)
+	return count, err
+}
+
+func (r *waitlistRepository) HasWaiting(ctx context.Context, eventID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM waitlist_entries WHERE event_id = $1 AND status = 'waiting')`
+
+	var exists bool
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, eventID).Scan(&exists)
+	return exists, err
+}
+
+func (r *waitlistRepository) FindEventIDsWithWaiting(ctx context.Context) ([]int, error) {
+	query := `
+		SELECT DISTINCT w.event_id
+		FROM waitlist_entries w
+		JOIN events e ON e.id = w.event_id
+		WHERE w.status = 'waiting' AND e.status = 'active' AND e.tickets_sold < e.max_capacity
+		ORDER BY w.event_id
+	`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var eventID int
+		if err := rows.Scan(&eventID); err != nil {
+			return nil, err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	return eventIDs, rows.Err()
+}
+
+func (r *waitlistRepository) UpdateStatus(ctx context.Context, id int, from, to entity.WaitlistStatus) error {
+	query := `UPDATE waitlist_entries SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, to, time.Now(), id, from)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+func (r *waitlistRepository) Claim(ctx context.Context, id, transactionID int, now time.Time) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = 'claimed', claimed_transaction_id = $1, updated_at = $2
+		WHERE id = $3 AND status = 'offered' AND offer_expires_at > $2
+	`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, transactionID, now, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(result)
+}
+
+// OfferSeats mengunci baris event lebih dulu sehingga sisa kursi yang dihitung tidak bisa
+// diambil pembeli lain sebelum penawaran tersimpan. Setiap entry minimal meminta satu kursi,
+// jadi paling banyak sisa kursi entry yang perlu dibaca.
+func (r *waitlistRepository) OfferSeats(ctx context.Context, eventID int, now, expiresAt time.Time) ([]entity.WaitlistEntry, error) {
+	var offered []entity.WaitlistEntry
+
+	err := NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		var available int
+		err := executor(ctx, r.db).QueryRowContext(ctx, `
+			SELECT max_capacity - tickets_sold
+			FROM events
+			WHERE id = $1 AND status = 'active'
+			FOR UPDATE
+		`, eventID).Scan(&available)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		if available <= 0 {
+			return nil
+		}
+
+		waiting, err := r.queryEntries(ctx, `
+			SELECT `+waitlistColumns+`
+			FROM waitlist_entries
+			WHERE event_id = $1 AND status = 'waiting'
+			ORDER BY created_at ASC, id ASC
+			LIMIT $2
+			FOR UPDATE
+		`, eventID, available)
+		if err != nil {
+			return err
+		}
+
+		reserved := 0
+		for _, entry := range waiting {
+			if entry.Quantity > available-reserved {
+				break
+			}
+
+			_, err := executor(ctx, r.db).ExecContext(ctx, `
+				UPDATE waitlist_entries
+				SET status = 'offered', offered_at = $1, offer_expires_at = $2, updated_at = $1
+				WHERE id = $3
+			`, now, expiresAt, entry.ID)
+			if err != nil {
+				return err
+			}
+
+			entry.Status = entity.WaitlistStatusOffered
+			entry.OfferedAt = now
+			entry.OfferExpiresAt = expiresAt
+			offered = append(offered, entry)
+			reserved += entry.Quantity
+		}
+
+		if reserved == 0 {
+			return nil
+		}
+
+		_, err = executor(ctx, r.db).ExecContext(ctx, `
+			UPDATE events
+			SET tickets_sold = tickets_sold + $1, updated_at = $2
+			WHERE id = $3
+		`, reserved, now, eventID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return offered, nil
+}
+
+// ExpireOffers memakai SKIP LOCKED seperti ExpireOverdue transaksi sehingga beberapa instance
+// bisa menjalankan worker waitlist bersamaan
+func (r *waitlistRepository) ExpireOffers(ctx context.Context, now time.Time, limit int) ([]entity.WaitlistEntry, error) {
+	query := `
+		WITH overdue AS (
+			SELECT id
+			FROM waitlist_entries
+			WHERE status = 'offered' AND offer_expires_at <= $1
+			ORDER BY offer_expires_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE waitlist_entries w
+		SET status = 'expired', updated_at = $1
+		FROM overdue
+		WHERE w.id = overdue.id
+		RETURNING w.id, w.event_id, w.user_id, w.quantity, w.status, w.offered_at, w.offer_expires_at,
+			w.claimed_transaction_id, w.created_at, w.updated_at
+	`
+
+	return r.queryEntries(ctx, query, now, limit)
+}
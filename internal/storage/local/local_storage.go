@@ -0,0 +1,159 @@
+//internal/storage/local/local_storage.go
+
+package local
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/storage"
+)
+
+var (
+	ErrInvalidKey       = errors.New("key file tidak valid")
+	ErrInvalidSignature = errors.New("tautan unduhan tidak valid")
+	ErrURLExpired       = errors.New("tautan unduhan sudah kedaluwarsa")
+)
+
+// Storage menyimpan file di filesystem lokal. File tidak disajikan sebagai static file,
+// melainkan lewat endpoint yang memeriksa signature dari SignedURL.
+type Storage struct {
+	baseDir    string
+	baseURL    string
+	signingKey []byte
+}
+
+func NewStorage(baseDir, baseURL, signingKey string) *Storage {
+	if baseDir == "" {
+		baseDir = "./uploads"
+	}
+
+	return &Storage{
+		baseDir:    baseDir,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		signingKey: []byte(signingKey),
+	}
+}
+
+// filePath menerjemahkan key menjadi path di dalam baseDir dan menolak key yang keluar dari baseDir
+func (s *Storage) filePath(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.baseDir, filepath.FromSlash(cleaned)), nil
+}
+
+func (s *Storage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	target, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	// Tulis ke file sementara lalu rename agar pembaca tidak pernah melihat file setengah jadi
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, storage.ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	target, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := s.filePath(key); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(ttl).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, expires))
+
+	return fmt.Sprintf("%s/%s?%s", s.baseURL, (&url.URL{Path: key}).EscapedPath(), query.Encode()), nil
+}
+
+// VerifySignature memeriksa parameter expires dan signature dari URL yang dibuat SignedURL
+func (s *Storage) VerifySignature(key, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(key, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return ErrURLExpired
+	}
+
+	return nil
+}
+
+func (s *Storage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
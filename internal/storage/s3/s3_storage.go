@@ -0,0 +1,297 @@
+//internal/storage/s3/s3_storage.go
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/storage"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type Config struct {
+	Endpoint   string // contoh: https://s3.amazonaws.com atau http://localhost:9000 untuk MinIO
+	Region     string
+	Bucket     string
+	AccessKey  string
+	SecretKey  string
+	PathStyle  bool // wajib true untuk MinIO dan kebanyakan layanan S3-compatible
+	HTTPClient *http.Client
+	Now        func() time.Time // opsional, untuk mengunci waktu saat test
+}
+
+// Storage adalah implementasi BlobStorage untuk layanan S3-compatible. Request ditandatangani
+// dengan AWS Signature Version 4 sehingga tidak membutuhkan SDK tambahan.
+type Storage struct {
+	endpoint   *url.URL
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+	pathStyle  bool
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func NewStorage(cfg Config) (*Storage, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint S3 tidak valid: %q", cfg.Endpoint)
+	}
+
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("bucket S3 harus diisi")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Storage{
+		endpoint:   endpoint,
+		region:     region,
+		bucket:     cfg.Bucket,
+		accessKey:  cfg.AccessKey,
+		secretKey:  cfg.SecretKey,
+		pathStyle:  cfg.PathStyle,
+		httpClient: httpClient,
+		now:        now,
+	}, nil
+}
+
+func (s *Storage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	req, err := s.newSignedRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = int64(len(content))
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (s *Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newSignedRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newSignedRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// SignedURL membuat presigned GET URL (query string authentication)
+func (s *Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	objectURL := s.objectURL(key)
+	now := s.now().UTC()
+	scope := s.credentialScope(now)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     signingAlgorithm,
+		"X-Amz-Credential":    s.accessKey + "/" + scope,
+		"X-Amz-Date":          now.Format(amzDateFormat),
+		"X-Amz-Expires":       strconv.Itoa(int(ttl.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	canonicalQuery := canonicalQueryString(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		objectURL.EscapedPath(),
+		canonicalQuery,
+		"host:" + objectURL.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	signature := s.signature(now, canonicalRequest)
+
+	return objectURL.String() + "?" + canonicalQuery + "&X-Amz-Signature=" + signature, nil
+}
+
+func (s *Storage) newSignedRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	objectURL := s.objectURL(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	payloadHash := emptyPayloadHash
+	if len(body) > 0 {
+		payloadHash = hashHex(body)
+	}
+
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		method,
+		objectURL.EscapedPath(),
+		"",
+		"host:" + objectURL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + now.Format(amzDateFormat) + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.accessKey, s.credentialScope(now), signedHeaders, s.signature(now, canonicalRequest),
+	))
+
+	return req, nil
+}
+
+func (s *Storage) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghubungi storage S3: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, storage.ErrObjectNotFound
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage S3 menolak request %s (HTTP %d): %s", req.Method, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return resp, nil
+}
+
+func (s *Storage) objectURL(key string) *url.URL {
+	objectURL := *s.endpoint
+	if s.pathStyle {
+		objectURL.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	} else {
+		objectURL.Host = s.bucket + "." + s.endpoint.Host
+		objectURL.Path = s.endpoint.Path + "/" + key
+	}
+	objectURL.RawPath = uriEncode(objectURL.Path, false)
+
+	return &objectURL
+}
+
+func (s *Storage) credentialScope(t time.Time) string {
+	return t.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+func (s *Storage) signature(t time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		t.Format(amzDateFormat),
+		s.credentialScope(t),
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalQueryString(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(params[key], true))
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode mengikuti aturan encoding SigV4: hanya karakter unreserved yang tidak di-encode
+func uriEncode(value string, encodeSlash bool) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			builder.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			builder.WriteByte(c)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", c)
+		}
+	}
+	return builder.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,17 @@
+//internal/storage/scanner/noop_scanner.go
+
+package scanner
+
+import "context"
+
+// NoopScanner menerima semua file. Dipakai selama belum ada layanan antivirus (misalnya ClamAV)
+// yang dipasang; cukup ganti dengan implementasi storage.VirusScanner lain di routes.
+type NoopScanner struct{}
+
+func NewNoopScanner() *NoopScanner {
+	return &NoopScanner{}
+}
+
+func (s *NoopScanner) Scan(ctx context.Context, content []byte) error {
+	return nil
+}
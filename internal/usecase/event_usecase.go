@@ -5,6 +5,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 	
 	"ticket-system/internal/domain/entity"
@@ -12,33 +13,66 @@ import (
 )
 
 type CreateEventRequest struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Location    string    `json:"location"`
-	EventDate   time.Time `json:"event_date"`
-	MaxCapacity int       `json:"max_capacity"`
-	Price       float64   `json:"price"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Location    string       `json:"location"`
+	EventDate   time.Time    `json:"event_date"`
+	MaxCapacity int          `json:"max_capacity"`
+	Price       entity.Money `json:"price"`
+	Currency    string       `json:"currency"`
+	
+	RefundPolicy   *entity.RefundPolicy   `json:"refund_policy"`
+	TransferPolicy *entity.TransferPolicy `json:"transfer_policy"`
 }
 
+// UpdateEventRequest tidak bisa mengubah mata uang event karena tipe tiket dan transaksi
+// yang sudah ada tercatat dalam mata uang tersebut
 type UpdateEventRequest struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Location    string    `json:"location"`
-	EventDate   time.Time `json:"event_date"`
-	MaxCapacity int       `json:"max_capacity"`
-	Price       float64   `json:"price"`
-	Status      string    `json:"status"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Location    string       `json:"location"`
+	EventDate   time.Time    `json:"event_date"`
+	MaxCapacity int          `json:"max_capacity"`
+	Price       entity.Money `json:"price"`
+	Status      string       `json:"status"`
+	
+	// RefundPolicy dan TransferPolicy nil berarti kebijakan event tidak diubah
+	RefundPolicy   *entity.RefundPolicy   `json:"refund_policy"`
+	TransferPolicy *entity.TransferPolicy `json:"transfer_policy"`
 }
 
 type EventSalesResponse struct {
-	EventID          int     `json:"event_id"`
-	Title            string  `json:"title"`
-	MaxCapacity      int     `json:"max_capacity"`
-	TicketsSold      int     `json:"tickets_sold"`
-	AvailableTickets int     `json:"available_tickets"`
-	Price            float64 `json:"price"`
-	TotalSales       float64 `json:"total_sales"`
-	Status           string  `json:"status"`
+	EventID          int          `json:"event_id"`
+	Title            string       `json:"title"`
+	MaxCapacity      int          `json:"max_capacity"`
+	TicketsSold      int          `json:"tickets_sold"`
+	AvailableTickets int          `json:"available_tickets"`
+	Price            entity.Money `json:"price"`
+	TotalSales       entity.Money `json:"total_sales"`
+	TotalDiscount    entity.Money `json:"total_discount"`
+	Status           string       `json:"status"`
+	
+	TicketTypes []TicketTypeSalesResponse `json:"ticket_types,omitempty"`
+}
+
+type TicketTypeSalesResponse struct {
+	TicketTypeID int          `json:"ticket_type_id"`
+	Name         string       `json:"name"`
+	Price        entity.Money `json:"price"`
+	Quota        int          `json:"quota"`
+	Sold         int          `json:"sold"`
+	Remaining    int          `json:"remaining"`
+	Revenue      entity.Money `json:"revenue"`
+}
+
+type TicketTypeRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Price       entity.Money `json:"price"`
+	Quota       int          `json:"quota"`
+	SalesStart  time.Time    `json:"sales_start"`
+	SalesEnd    time.Time    `json:"sales_end"`
+	MaxPerOrder int          `json:"max_per_order"`
 }
 
 type EventUsecase interface {
@@ -49,17 +83,28 @@ type EventUsecase interface {
 	DeleteEvent(ctx context.Context, eventID, userID int) error
 	GetEventsByOrganizer(ctx context.Context, userID, page, limit int) ([]entity.Event, int, error)
 	GetEventSales(ctx context.Context, eventID, userID int) (*EventSalesResponse, error)
+	CreateTicketType(ctx context.Context, eventID, userID int, req TicketTypeRequest) (*entity.TicketType, error)
+	GetTicketTypes(ctx context.Context, eventID int) ([]entity.TicketType, error)
+	UpdateTicketType(ctx context.Context, eventID, ticketTypeID, userID int, req TicketTypeRequest) (*entity.TicketType, error)
+	DeleteTicketType(ctx context.Context, eventID, ticketTypeID, userID int) error
 }
 
+// defaultMaxPerOrder dipakai ketika organizer tidak menentukan batas pembelian per transaksi
+const defaultMaxPerOrder = 10
+
 type eventUsecase struct {
-	eventRepo repository.EventRepository
-	userRepo  repository.UserRepository
+	eventRepo       repository.EventRepository
+	userRepo        repository.UserRepository
+	ticketTypeRepo  repository.TicketTypeRepository
+	transactionRepo repository.TransactionRepository
 }
 
-func NewEventUsecase(eventRepo repository.EventRepository, userRepo repository.UserRepository) EventUsecase {
+func NewEventUsecase(eventRepo repository.EventRepository, userRepo repository.UserRepository, ticketTypeRepo repository.TicketTypeRepository, transactionRepo repository.TransactionRepository) EventUsecase {
 	return &eventUsecase{
-		eventRepo: eventRepo,
-		userRepo:  userRepo,
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		ticketTypeRepo:  ticketTypeRepo,
+		transactionRepo: transactionRepo,
 	}
 }
 
@@ -81,6 +126,32 @@ func (u *eventUsecase) CreateEvent(ctx context.Context, userID int, req CreateEv
 		return 0, errors.New("tanggal event tidak boleh di masa lalu")
 	}
 	
+	currency := entity.NormalizeCurrency(req.Currency)
+	if !entity.IsSupportedCurrency(currency) {
+		return 0, errors.New("mata uang tidak didukung")
+	}
+	
+	price, err := priceInCurrency(req.Price, currency)
+	if err != nil {
+		return 0, err
+	}
+	
+	refundPolicy := defaultRefundPolicy
+	if req.RefundPolicy != nil {
+		if err := validateRefundPolicy(*req.RefundPolicy); err != nil {
+			return 0, err
+		}
+		refundPolicy = *req.RefundPolicy
+	}
+	
+	transferPolicy := defaultTransferPolicy
+	if req.TransferPolicy != nil {
+		if err := validateTransferPolicy(*req.TransferPolicy); err != nil {
+			return 0, err
+		}
+		transferPolicy = *req.TransferPolicy
+	}
+	
 	event := &entity.Event{
 		OwnerID:     userID,
 		Title:       req.Title,
@@ -89,10 +160,14 @@ func (u *eventUsecase) CreateEvent(ctx context.Context, userID int, req CreateEv
 		EventDate:   req.EventDate,
 		MaxCapacity: req.MaxCapacity,
 		TicketsSold: 0,
-		Price:       req.Price,
+		Price:       price,
+		Currency:    currency,
 		Status:      "active",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+		
+		RefundPolicy:   refundPolicy,
+		TransferPolicy: transferPolicy,
 	}
 	
 	eventID, err := u.eventRepo.Create(ctx, event)
@@ -141,20 +216,58 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, eventID, userID int, req
 		return errors.New("anda tidak memiliki izin untuk mengubah event ini")
 	}
 	
+	// Kapasitas event dengan denah venue selalu sama dengan jumlah kursinya
+	if event.VenueID != 0 {
+		req.MaxCapacity = event.MaxCapacity
+	}
+	
 	if req.MaxCapacity < event.TicketsSold {
 		return errors.New("kapasitas tidak boleh lebih kecil dari jumlah tiket yang sudah terjual")
 	}
 	
+	ticketTypes, err := u.ticketTypeRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	
+	if totalQuota(ticketTypes, 0) > req.MaxCapacity {
+		return errors.New("kapasitas tidak boleh lebih kecil dari total kuota tipe tiket")
+	}
+	
 	if req.Status != "" && req.Status != "active" && req.Status != "cancelled" && req.Status != "completed" {
 		return errors.New("status tidak valid")
 	}
 	
+	// Transaksi event yang dibatalkan sudah masuk antrean refund otomatis
+	if event.Status == "cancelled" && req.Status != "" && req.Status != "cancelled" {
+		return errors.New("event yang sudah dibatalkan tidak dapat diaktifkan kembali")
+	}
+	
+	price, err := priceInCurrency(req.Price, event.Currency)
+	if err != nil {
+		return err
+	}
+	
+	if req.RefundPolicy != nil {
+		if err := validateRefundPolicy(*req.RefundPolicy); err != nil {
+			return err
+		}
+		event.RefundPolicy = *req.RefundPolicy
+	}
+	
+	if req.TransferPolicy != nil {
+		if err := validateTransferPolicy(*req.TransferPolicy); err != nil {
+			return err
+		}
+		event.TransferPolicy = *req.TransferPolicy
+	}
+	
 	event.Title = req.Title
 	event.Description = req.Description
 	event.Location = req.Location
 	event.EventDate = req.EventDate
 	event.MaxCapacity = req.MaxCapacity
-	event.Price = req.Price
+	event.Price = price
 	event.UpdatedAt = time.Now()
 	
 	if req.Status != "" {
@@ -163,6 +276,10 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, eventID, userID int, req
 	
 	err = u.eventRepo.Update(ctx, event)
 	if err != nil {
+		// Tiket terjual di antara pengecekan dan update melebihi kapasitas baru
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return errors.New("kapasitas tidak boleh lebih kecil dari jumlah tiket yang sudah terjual")
+		}
 		return err
 	}
 	
@@ -221,6 +338,11 @@ func (u *eventUsecase) GetEventSales(ctx context.Context, eventID, userID int) (
 		return nil, errors.New("anda tidak memiliki izin untuk melihat data penjualan event ini")
 	}
 	
+	ticketTypes, err := u.ticketTypeRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	
 	sales := &EventSalesResponse{
 		EventID:          event.ID,
 		Title:            event.Title,
@@ -228,9 +350,297 @@ func (u *eventUsecase) GetEventSales(ctx context.Context, eventID, userID int) (
 		TicketsSold:      event.TicketsSold,
 		AvailableTickets: event.MaxCapacity - event.TicketsSold,
 		Price:            event.Price,
-		TotalSales:       float64(event.TicketsSold) * event.Price,
 		Status:           event.Status,
 	}
 	
+	// Pendapatan dihitung dari transaksi paid dengan harga saat transaksi dibuat, sehingga
+	// perubahan harga, diskon promo, dan reservasi yang belum dibayar tidak ikut mengubahnya
+	revenue, err := u.transactionRepo.SumPaidRevenueByEvent(ctx, eventID, event.Price.CurrencyCode())
+	if err != nil {
+		return nil, err
+	}
+	sales.TotalSales = revenue.Net()
+	sales.TotalDiscount = revenue.Discount
+	
+	for _, ticketType := range ticketTypes {
+		revenueByType, ok := revenue.ByTicketType[ticketType.ID]
+		if !ok {
+			revenueByType = entity.NewMoney(0, event.Price.CurrencyCode())
+		}
+		
+		sales.TicketTypes = append(sales.TicketTypes, TicketTypeSalesResponse{
+			TicketTypeID: ticketType.ID,
+			Name:         ticketType.Name,
+			Price:        ticketType.Price,
+			Quota:        ticketType.Quota,
+			Sold:         ticketType.Sold,
+			Remaining:    ticketType.Quota - ticketType.Sold,
+			Revenue:      revenueByType,
+		})
+	}
+	
 	return sales, nil
+}
+
+func (u *eventUsecase) CreateTicketType(ctx context.Context, eventID, userID int, req TicketTypeRequest) (*entity.TicketType, error) {
+	event, err := u.findOwnedEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := validateTicketTypeRequest(&req, event.Currency); err != nil {
+		return nil, err
+	}
+	
+	ticketTypes, err := u.ticketTypeRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if hasTicketTypeName(ticketTypes, req.Name, 0) {
+		return nil, errors.New("nama tipe tiket sudah digunakan di event ini")
+	}
+	
+	if totalQuota(ticketTypes, 0)+req.Quota > event.MaxCapacity {
+		return nil, errors.New("total kuota tipe tiket melebihi kapasitas event")
+	}
+	
+	now := time.Now()
+	ticketType := &entity.TicketType{
+		EventID:     eventID,
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       req.Price,
+		Quota:       req.Quota,
+		SalesStart:  req.SalesStart,
+		SalesEnd:    req.SalesEnd,
+		MaxPerOrder: req.MaxPerOrder,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	
+	ticketTypeID, err := u.ticketTypeRepo.Create(ctx, ticketType)
+	if err != nil {
+		return nil, err
+	}
+	ticketType.ID = ticketTypeID
+	
+	return ticketType, nil
+}
+
+func (u *eventUsecase) GetTicketTypes(ctx context.Context, eventID int) ([]entity.TicketType, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+	
+	return u.ticketTypeRepo.FindByEventID(ctx, eventID)
+}
+
+func (u *eventUsecase) UpdateTicketType(ctx context.Context, eventID, ticketTypeID, userID int, req TicketTypeRequest) (*entity.TicketType, error) {
+	event, err := u.findOwnedEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	ticketType, err := u.findEventTicketType(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := validateTicketTypeRequest(&req, event.Currency); err != nil {
+		return nil, err
+	}
+	
+	if req.Quota < ticketType.Sold {
+		return nil, errors.New("kuota tidak boleh lebih kecil dari jumlah tiket yang sudah terjual")
+	}
+	
+	ticketTypes, err := u.ticketTypeRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if hasTicketTypeName(ticketTypes, req.Name, ticketTypeID) {
+		return nil, errors.New("nama tipe tiket sudah digunakan di event ini")
+	}
+	
+	if totalQuota(ticketTypes, ticketTypeID)+req.Quota > event.MaxCapacity {
+		return nil, errors.New("total kuota tipe tiket melebihi kapasitas event")
+	}
+	
+	// Harga baru hanya berlaku untuk transaksi berikutnya, transaksi lama menyimpan harga di item-nya
+	ticketType.Name = req.Name
+	ticketType.Description = req.Description
+	ticketType.Price = req.Price
+	ticketType.Quota = req.Quota
+	ticketType.SalesStart = req.SalesStart
+	ticketType.SalesEnd = req.SalesEnd
+	ticketType.MaxPerOrder = req.MaxPerOrder
+	ticketType.UpdatedAt = time.Now()
+	
+	if err := u.ticketTypeRepo.Update(ctx, ticketType); err != nil {
+		if errors.Is(err, repository.ErrTicketTypeSoldOut) {
+			return nil, errors.New("kuota tidak boleh lebih kecil dari jumlah tiket yang sudah terjual")
+		}
+		return nil, err
+	}
+	
+	return ticketType, nil
+}
+
+func (u *eventUsecase) DeleteTicketType(ctx context.Context, eventID, ticketTypeID, userID int) error {
+	if _, err := u.findOwnedEvent(ctx, eventID, userID); err != nil {
+		return err
+	}
+	
+	ticketType, err := u.findEventTicketType(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return err
+	}
+	
+	if ticketType.Sold > 0 {
+		return errors.New("tipe tiket yang sudah terjual tidak dapat dihapus")
+	}
+	
+	if err := u.ticketTypeRepo.Delete(ctx, ticketTypeID); err != nil {
+		// Tiket terjual di antara pengecekan dan penghapusan
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return errors.New("tipe tiket yang sudah terjual tidak dapat dihapus")
+		}
+		return err
+	}
+	
+	return nil
+}
+
+func (u *eventUsecase) findOwnedEvent(ctx context.Context, eventID, userID int) (*entity.Event, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+	
+	if event.OwnerID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk mengelola tipe tiket event ini")
+	}
+	
+	return event, nil
+}
+
+func (u *eventUsecase) findEventTicketType(ctx context.Context, eventID, ticketTypeID int) (*entity.TicketType, error) {
+	ticketType, err := u.ticketTypeRepo.FindByID(ctx, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if ticketType == nil || ticketType.EventID != eventID {
+		return nil, errors.New("tipe tiket tidak ditemukan")
+	}
+	
+	return ticketType, nil
+}
+
+func validateTicketTypeRequest(req *TicketTypeRequest, currency string) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("nama tipe tiket harus diisi")
+	}
+	
+	if req.Price.IsNegative() {
+		return errors.New("harga tipe tiket tidak boleh negatif")
+	}
+	
+	price, err := priceInCurrency(req.Price, currency)
+	if err != nil {
+		return err
+	}
+	req.Price = price
+	
+	if req.Quota <= 0 {
+		return errors.New("kuota tipe tiket harus lebih dari 0")
+	}
+	
+	if req.MaxPerOrder < 0 {
+		return errors.New("batas pembelian per transaksi tidak boleh negatif")
+	}
+	if req.MaxPerOrder == 0 {
+		req.MaxPerOrder = defaultMaxPerOrder
+	}
+	
+	if !req.SalesStart.IsZero() && !req.SalesEnd.IsZero() && !req.SalesEnd.After(req.SalesStart) {
+		return errors.New("akhir penjualan harus setelah awal penjualan")
+	}
+	
+	return nil
+}
+
+// priceInCurrency mengisi mata uang harga dari request dengan mata uang event dan menolak
+// harga dalam mata uang lain, supaya semua harga di satu event bisa dijumlahkan
+func priceInCurrency(price entity.Money, currency string) (entity.Money, error) {
+	price = price.InCurrency(currency)
+	if price.CurrencyCode() != entity.NormalizeCurrency(currency) {
+		return entity.Money{}, errors.New("mata uang harga harus sama dengan mata uang event")
+	}
+	
+	return price, nil
+}
+
+// defaultRefundPolicy dipakai untuk event baru tanpa kebijakan refund: pembeli tidak bisa
+// mengajukan refund, tetapi pembatalan event tetap dikembalikan penuh
+var defaultRefundPolicy = entity.RefundPolicy{Allowed: false, DeadlineHours: 0, Percent: entity.OneHundredPercent}
+
+func validateRefundPolicy(policy entity.RefundPolicy) error {
+	if policy.DeadlineHours < 0 {
+		return errors.New("batas waktu refund tidak boleh negatif")
+	}
+	
+	if policy.Allowed && (policy.Percent <= 0 || policy.Percent > entity.OneHundredPercent) {
+		return errors.New("persentase refund harus lebih dari 0 dan maksimal 100")
+	}
+	
+	return nil
+}
+
+// defaultTransferPolicy dipakai untuk event baru tanpa kebijakan transfer: tiket boleh
+// dipindahtangankan sampai event dimulai tanpa batas jumlah transfer
+var defaultTransferPolicy = entity.TransferPolicy{Allowed: true, DeadlineHours: 0, MaxTransfers: 0}
+
+func validateTransferPolicy(policy entity.TransferPolicy) error {
+	if policy.DeadlineHours < 0 {
+		return errors.New("batas waktu transfer tidak boleh negatif")
+	}
+	
+	if policy.MaxTransfers < 0 {
+		return errors.New("batas jumlah transfer tidak boleh negatif")
+	}
+	
+	return nil
+}
+
+// totalQuota menjumlahkan kuota semua tipe tiket kecuali excludeID, dipakai saat tipe tersebut sedang diubah
+func totalQuota(ticketTypes []entity.TicketType, excludeID int) int {
+	total := 0
+	for _, ticketType := range ticketTypes {
+		if ticketType.ID != excludeID {
+			total += ticketType.Quota
+		}
+	}
+	return total
+}
+
+func hasTicketTypeName(ticketTypes []entity.TicketType, name string, excludeID int) bool {
+	for _, ticketType := range ticketTypes {
+		if ticketType.ID != excludeID && strings.EqualFold(ticketType.Name, name) {
+			return true
+		}
+	}
+	return false
 }
\ No newline at end of file
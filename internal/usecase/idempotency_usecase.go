@@ -0,0 +1,125 @@
+//internal/usecase/idempotency_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+// BeginIdempotencyRequest berisi sidik request yang dikirim bersama header Idempotency-Key.
+// RequestHash sudah mencakup method, path, dan body sehingga key yang sama untuk endpoint
+// lain juga dianggap request berbeda.
+type BeginIdempotencyRequest struct {
+	UserID      int
+	Key         string
+	Method      string
+	Path        string
+	RequestHash string
+}
+
+type IdempotencyUsecase interface {
+	// Begin mengunci key untuk request baru dan mengembalikan key berstatus processing. Jika
+	// key sudah selesai untuk request yang sama, key berstatus completed dikembalikan agar
+	// responsnya diputar ulang.
+	Begin(ctx context.Context, req BeginIdempotencyRequest) (*entity.IdempotencyKey, error)
+	Complete(ctx context.Context, key *entity.IdempotencyKey, responseCode int, contentType string, body []byte) error
+	// Release melepas key yang requestnya gagal agar klien bisa mencoba lagi dengan key yang sama
+	Release(ctx context.Context, key *entity.IdempotencyKey) error
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+var (
+	// ErrIdempotencyKeyMismatch dikembalikan saat key yang sama dipakai untuk request yang berbeda
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key sudah dipakai untuk request yang berbeda")
+	// ErrIdempotencyKeyInProgress dikembalikan saat request lain dengan key yang sama belum selesai
+	ErrIdempotencyKeyInProgress = errors.New("request dengan idempotency key yang sama masih diproses")
+)
+
+// idempotencyLockTimeout adalah batas waktu key processing dianggap tertinggal, misalnya karena
+// server berhenti sebelum respons tersimpan, sehingga boleh diambil alih request berikutnya
+const idempotencyLockTimeout = 5 * time.Minute
+
+type idempotencyUsecase struct {
+	idempotencyRepo repository.IdempotencyKeyRepository
+	keyTTL          time.Duration
+}
+
+func NewIdempotencyUsecase(idempotencyRepo repository.IdempotencyKeyRepository, keyTTLHours string) IdempotencyUsecase {
+	ttl, _ := strconv.Atoi(keyTTLHours)
+	if ttl <= 0 {
+		ttl = 24 // default 24 jam
+	}
+
+	return &idempotencyUsecase{
+		idempotencyRepo: idempotencyRepo,
+		keyTTL:          time.Duration(ttl) * time.Hour,
+	}
+}
+
+func (u *idempotencyUsecase) Begin(ctx context.Context, req BeginIdempotencyRequest) (*entity.IdempotencyKey, error) {
+	now := time.Now()
+	key := &entity.IdempotencyKey{
+		UserID:      req.UserID,
+		Key:         req.Key,
+		Method:      req.Method,
+		Path:        req.Path,
+		RequestHash: req.RequestHash,
+		Status:      entity.IdempotencyKeyProcessing,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(u.keyTTL),
+	}
+
+	id, err := u.idempotencyRepo.Create(ctx, key, now.Add(-idempotencyLockTimeout))
+	if err == nil {
+		key.ID = id
+		return key, nil
+	}
+	if !errors.Is(err, repository.ErrIdempotencyKeyExists) {
+		return nil, err
+	}
+
+	existing, err := u.idempotencyRepo.FindByKey(ctx, req.UserID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	// Key bisa saja dilepas request lain di antara Create dan FindByKey
+	if existing == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	if existing.RequestHash != req.RequestHash {
+		return nil, ErrIdempotencyKeyMismatch
+	}
+	if existing.Status != entity.IdempotencyKeyCompleted {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	return existing, nil
+}
+
+func (u *idempotencyUsecase) Complete(ctx context.Context, key *entity.IdempotencyKey, responseCode int, contentType string, body []byte) error {
+	key.ResponseCode = responseCode
+	key.ContentType = contentType
+	key.ResponseBody = body
+
+	if err := u.idempotencyRepo.Complete(ctx, key); err != nil {
+		return err
+	}
+
+	key.Status = entity.IdempotencyKeyCompleted
+	return nil
+}
+
+func (u *idempotencyUsecase) Release(ctx context.Context, key *entity.IdempotencyKey) error {
+	return u.idempotencyRepo.Delete(ctx, key.ID)
+}
+
+func (u *idempotencyUsecase) PurgeExpired(ctx context.Context) (int, error) {
+	return u.idempotencyRepo.DeleteExpired(ctx)
+}
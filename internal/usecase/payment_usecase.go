@@ -0,0 +1,187 @@
+//internal/usecase/payment_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/domain/repository"
+)
+
+type PaymentUsecase interface {
+	HandleNotification(ctx context.Context, payload []byte) error
+}
+
+type paymentUsecase struct {
+	transactionRepo repository.TransactionRepository
+	eventRepo       repository.EventRepository
+	paymentRepo     repository.PaymentRepository
+	historyRepo     repository.TransactionStatusHistoryRepository
+	ticketRepo      repository.TicketRepository
+	ticketTypeRepo  repository.TicketTypeRepository
+	itemRepo        repository.TransactionItemRepository
+	promoRepo       repository.PromoCodeRepository
+	seatHoldRepo    repository.SeatHoldRepository
+	refundRepo      repository.RefundRepository
+	txManager       repository.TxManager
+	paymentGateway  gateway.PaymentGateway
+}
+
+func NewPaymentUsecase(
+	transactionRepo repository.TransactionRepository,
+	eventRepo repository.EventRepository,
+	paymentRepo repository.PaymentRepository,
+	historyRepo repository.TransactionStatusHistoryRepository,
+	ticketRepo repository.TicketRepository,
+	ticketTypeRepo repository.TicketTypeRepository,
+	itemRepo repository.TransactionItemRepository,
+	promoRepo repository.PromoCodeRepository,
+	seatHoldRepo repository.SeatHoldRepository,
+	refundRepo repository.RefundRepository,
+	txManager repository.TxManager,
+	paymentGateway gateway.PaymentGateway,
+) PaymentUsecase {
+	return &paymentUsecase{
+		transactionRepo: transactionRepo,
+		eventRepo:       eventRepo,
+		paymentRepo:     paymentRepo,
+		historyRepo:     historyRepo,
+		ticketRepo:      ticketRepo,
+		ticketTypeRepo:  ticketTypeRepo,
+		itemRepo:        itemRepo,
+		promoRepo:       promoRepo,
+		seatHoldRepo:    seatHoldRepo,
+		refundRepo:      refundRepo,
+		txManager:       txManager,
+		paymentGateway:  paymentGateway,
+	}
+}
+
+// HandleNotification memproses notifikasi dari payment gateway. Gateway bisa mengirim ulang
+// notifikasi yang sama berkali-kali, jadi perubahan status hanya dilakukan jika transaksi
+// masih berada di status asal yang sesuai.
+func (u *paymentUsecase) HandleNotification(ctx context.Context, payload []byte) error {
+	notification, err := u.paymentGateway.ParseNotification(payload)
+	if err != nil {
+		return err
+	}
+
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		transaction, err := u.transactionRepo.FindByCodeForUpdate(ctx, notification.OrderID)
+		if err != nil {
+			return err
+		}
+		if transaction == nil {
+			return errors.New("transaksi tidak ditemukan")
+		}
+
+		payment, err := u.paymentRepo.FindByTransactionID(ctx, transaction.ID)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return errors.New("data pembayaran tidak ditemukan")
+		}
+
+		if !notification.GrossAmount.Equal(transaction.TotalAmount) {
+			return errors.New("nominal pembayaran tidak sesuai dengan transaksi")
+		}
+
+		payment.MidtransTransactionID = notification.GatewayTransactionID
+		payment.PaymentType = notification.PaymentType
+		payment.Status = notification.GatewayStatus
+		payment.StatusCode = notification.StatusCode
+		payment.StatusMessage = notification.StatusMessage
+		payment.PaymentTime = notification.PaymentTime
+		payment.CallbackData = notification.Payload
+
+		if err := u.paymentRepo.UpdateFromNotification(ctx, payment); err != nil {
+			return err
+		}
+
+		return u.applyPaymentResult(ctx, transaction, notification)
+	})
+}
+
+// paymentTargetStatus menerjemahkan hasil dari gateway ke status transaksi. Hasil pending
+// atau yang tidak dikenal tidak mengubah transaksi.
+var paymentTargetStatus = map[string]entity.TransactionStatus{
+	gateway.PaymentResultPaid:     entity.TransactionStatusPaid,
+	gateway.PaymentResultExpired:  entity.TransactionStatusExpired,
+	gateway.PaymentResultFailed:   entity.TransactionStatusFailed,
+	gateway.PaymentResultRefunded: entity.TransactionStatusRefunded,
+}
+
+func (u *paymentUsecase) applyPaymentResult(ctx context.Context, transaction *entity.Transaction, notification *gateway.PaymentNotification) error {
+	target, ok := paymentTargetStatus[notification.Result]
+	if !ok || transaction.Status == target {
+		// Notifikasi yang dikirim ulang berhenti di sini
+		return nil
+	}
+
+	if !transaction.Status.CanTransitionTo(target) {
+		// Transaksi yang sudah direfund pernah menerima dana, pembayaran lain hanya dikirim
+		// ulang oleh gateway
+		if target == entity.TransactionStatusPaid && transaction.Status != entity.TransactionStatusRefunded {
+			return u.queueLatePaymentRefund(ctx, transaction, notification)
+		}
+		return nil
+	}
+
+	err := changeTransactionStatus(ctx, u.transactionRepo, u.historyRepo, transaction, target, statusActor{
+		Type:   entity.StatusActorPaymentGateway,
+		Reason: "notifikasi pembayaran: " + notification.GatewayStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	if target == entity.TransactionStatusPaid {
+		return issueTickets(ctx, u.ticketRepo, u.itemRepo, u.seatHoldRepo, transaction)
+	}
+
+	// Tiket dari transaksi yang direfund tidak boleh dipakai lagi
+	if target == entity.TransactionStatusRefunded {
+		if _, err := u.ticketRepo.UpdateStatusByTransactionID(ctx, transaction.ID, entity.TicketStatusActive, entity.TicketStatusRefunded); err != nil {
+			return err
+		}
+	}
+
+	// Selain paid, semua tujuan (expired, failed, refunded) mengakhiri transaksi yang masih memegang kursi
+	return releaseReservation(ctx, u.eventRepo, u.ticketTypeRepo, u.itemRepo, u.promoRepo, u.seatHoldRepo, transaction)
+}
+
+// queueLatePaymentRefund menangani dana yang masuk setelah transaksi berakhir, misalnya
+// pembayaran yang selesai sesaat setelah sweeper expiry berjalan. Kursinya sudah dilepas, jadi
+// dana dikembalikan penuh lewat refund approved yang diproses worker refund dan terlihat oleh
+// organizer di daftar refund event.
+func (u *paymentUsecase) queueLatePaymentRefund(ctx context.Context, transaction *entity.Transaction, notification *gateway.PaymentNotification) error {
+	now := time.Now()
+	_, err := u.refundRepo.Create(ctx, &entity.Refund{
+		TransactionID: transaction.ID,
+		EventID:       transaction.EventID,
+		UserID:        transaction.UserID,
+		Amount:        notification.GrossAmount,
+		Status:        entity.RefundStatusApproved,
+		Source:        entity.RefundSourceLatePayment,
+		Reason:        fmt.Sprintf("pembayaran diterima setelah transaksi %s", transaction.Status),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		// Notifikasi yang dikirim ulang tidak membuat refund kedua
+		if errors.Is(err, repository.ErrRefundExists) {
+			return nil
+		}
+		return err
+	}
+
+	log.Printf("Pembayaran diterima untuk transaksi %s berstatus %s, refund otomatis dibuat", transaction.TransactionCode, transaction.Status)
+	return nil
+}
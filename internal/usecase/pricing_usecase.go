@@ -0,0 +1,172 @@
+//internal/usecase/pricing_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+// PricingRuleRequest dipakai untuk aturan default organizer maupun aturan khusus event.
+// Persen boleh pecahan seperti 2.5, ServiceFeePerTicket ditagihkan per kursi.
+type PricingRuleRequest struct {
+	ServiceFeePercent   entity.Percent `json:"service_fee_percent"`
+	ServiceFeePerTicket entity.Money   `json:"service_fee_per_ticket"`
+	TaxPercent          entity.Percent `json:"tax_percent"`
+	PassPlatformFee     bool           `json:"pass_platform_fee"`
+}
+
+type PricingUsecase interface {
+	GetOrganizerPricing(ctx context.Context, userID int) (*entity.PricingRule, error)
+	UpdateOrganizerPricing(ctx context.Context, userID int, req PricingRuleRequest) (*entity.PricingRule, error)
+	// GetEventPricing mengembalikan aturan yang berlaku untuk event, EventID 0 berarti event
+	// masih memakai aturan default organizer
+	GetEventPricing(ctx context.Context, eventID, userID int) (*entity.PricingRule, error)
+	UpdateEventPricing(ctx context.Context, eventID, userID int, req PricingRuleRequest) (*entity.PricingRule, error)
+	DeleteEventPricing(ctx context.Context, eventID, userID int) error
+}
+
+type pricingUsecase struct {
+	pricingRepo repository.PricingRuleRepository
+	eventRepo   repository.EventRepository
+}
+
+func NewPricingUsecase(pricingRepo repository.PricingRuleRepository, eventRepo repository.EventRepository) PricingUsecase {
+	return &pricingUsecase{
+		pricingRepo: pricingRepo,
+		eventRepo:   eventRepo,
+	}
+}
+
+func (u *pricingUsecase) GetOrganizerPricing(ctx context.Context, userID int) (*entity.PricingRule, error) {
+	rule, err := u.pricingRepo.FindDefault(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Organizer yang belum mengatur biaya tidak membebankan biaya apa pun ke pembeli
+	if rule == nil {
+		rule = &entity.PricingRule{OwnerID: userID, ServiceFeePerTicket: entity.IDR(0)}
+	}
+
+	return rule, nil
+}
+
+// UpdateOrganizerPricing menyimpan biaya per tiket dalam DefaultCurrency bila mata uang tidak
+// disebutkan. Event dengan mata uang lain perlu aturan sendiri jika memakai biaya per tiket.
+func (u *pricingUsecase) UpdateOrganizerPricing(ctx context.Context, userID int, req PricingRuleRequest) (*entity.PricingRule, error) {
+	req.ServiceFeePerTicket = req.ServiceFeePerTicket.InCurrency(entity.DefaultCurrency)
+	if err := validatePricingRuleRequest(req); err != nil {
+		return nil, err
+	}
+
+	rule := newPricingRule(userID, 0, req)
+	if err := u.pricingRepo.Upsert(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (u *pricingUsecase) GetEventPricing(ctx context.Context, eventID, userID int) (*entity.PricingRule, error) {
+	event, err := u.findOwnedEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := u.pricingRepo.FindApplicable(ctx, event.OwnerID, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule == nil {
+		rule = &entity.PricingRule{OwnerID: event.OwnerID, ServiceFeePerTicket: entity.NewMoney(0, event.Currency)}
+	}
+
+	return rule, nil
+}
+
+func (u *pricingUsecase) UpdateEventPricing(ctx context.Context, eventID, userID int, req PricingRuleRequest) (*entity.PricingRule, error) {
+	event, err := u.findOwnedEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ServiceFeePerTicket = req.ServiceFeePerTicket.InCurrency(event.Currency)
+	if req.ServiceFeePerTicket.CurrencyCode() != entity.NormalizeCurrency(event.Currency) {
+		return nil, errors.New("mata uang biaya layanan harus sama dengan mata uang event")
+	}
+
+	if err := validatePricingRuleRequest(req); err != nil {
+		return nil, err
+	}
+
+	rule := newPricingRule(event.OwnerID, event.ID, req)
+	if err := u.pricingRepo.Upsert(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteEventPricing mengembalikan event ke aturan default organizer. Transaksi yang sudah
+// dibuat tetap memakai rincian biaya yang tersimpan di transaksi.
+func (u *pricingUsecase) DeleteEventPricing(ctx context.Context, eventID, userID int) error {
+	event, err := u.findOwnedEvent(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	return u.pricingRepo.DeleteByEventID(ctx, event.ID)
+}
+
+func (u *pricingUsecase) findOwnedEvent(ctx context.Context, eventID, userID int) (*entity.Event, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+
+	if event.OwnerID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk mengatur biaya event ini")
+	}
+
+	return event, nil
+}
+
+func newPricingRule(ownerID, eventID int, req PricingRuleRequest) *entity.PricingRule {
+	now := time.Now()
+	return &entity.PricingRule{
+		OwnerID:             ownerID,
+		EventID:             eventID,
+		ServiceFeePercent:   req.ServiceFeePercent,
+		ServiceFeePerTicket: req.ServiceFeePerTicket,
+		TaxPercent:          req.TaxPercent,
+		PassPlatformFee:     req.PassPlatformFee,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+}
+
+func validatePricingRuleRequest(req PricingRuleRequest) error {
+	if req.ServiceFeePercent < 0 || req.ServiceFeePercent > entity.OneHundredPercent {
+		return errors.New("persentase biaya layanan harus antara 0 dan 100")
+	}
+
+	if req.ServiceFeePerTicket.IsNegative() {
+		return errors.New("biaya layanan per tiket tidak boleh negatif")
+	}
+
+	if req.TaxPercent < 0 || req.TaxPercent > entity.OneHundredPercent {
+		return errors.New("persentase pajak harus antara 0 dan 100")
+	}
+
+	return nil
+}
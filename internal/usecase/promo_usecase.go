@@ -0,0 +1,260 @@
+//internal/usecase/promo_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+// PromoCodeRequest dipakai untuk membuat dan mengubah kode promo. EventID 0 membuat kode
+// berlaku untuk semua event milik organizer. DiscountPercent diisi untuk tipe percentage
+// (boleh pecahan seperti 12.5), DiscountAmount untuk tipe fixed.
+type PromoCodeRequest struct {
+	EventID         int            `json:"event_id"`
+	Code            string         `json:"code"`
+	DiscountType    string         `json:"discount_type"`
+	DiscountPercent entity.Percent `json:"discount_percent"`
+	DiscountAmount  entity.Money   `json:"discount_amount"`
+	MaxUses         int            `json:"max_uses"`
+	PerUserLimit    int            `json:"per_user_limit"`
+	MinQuantity     int            `json:"min_quantity"`
+	ValidFrom       time.Time      `json:"valid_from"`
+	ValidUntil      time.Time      `json:"valid_until"`
+	IsActive        *bool          `json:"is_active"`
+}
+
+type PromoUsecase interface {
+	CreatePromoCode(ctx context.Context, userID int, req PromoCodeRequest) (*entity.PromoCode, error)
+	GetPromoCodes(ctx context.Context, userID int) ([]entity.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promoID, userID int, req PromoCodeRequest) (*entity.PromoCode, error)
+	DeactivatePromoCode(ctx context.Context, promoID, userID int) error
+}
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+type promoUsecase struct {
+	promoRepo repository.PromoCodeRepository
+	eventRepo repository.EventRepository
+}
+
+func NewPromoUsecase(promoRepo repository.PromoCodeRepository, eventRepo repository.EventRepository) PromoUsecase {
+	return &promoUsecase{
+		promoRepo: promoRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (u *promoUsecase) CreatePromoCode(ctx context.Context, userID int, req PromoCodeRequest) (*entity.PromoCode, error) {
+	if err := validatePromoCodeRequest(&req); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkPromoEvent(ctx, userID, &req); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkDuplicateCode(ctx, userID, req.Code, 0); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	promo := &entity.PromoCode{
+		OwnerID:         userID,
+		EventID:         req.EventID,
+		Code:            req.Code,
+		DiscountType:    entity.PromoDiscountType(req.DiscountType),
+		DiscountPercent: req.DiscountPercent,
+		DiscountAmount:  req.DiscountAmount,
+		MaxUses:         req.MaxUses,
+		PerUserLimit:    req.PerUserLimit,
+		MinQuantity:     req.MinQuantity,
+		ValidFrom:       req.ValidFrom,
+		ValidUntil:      req.ValidUntil,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	promoID, err := u.promoRepo.Create(ctx, promo)
+	if err != nil {
+		return nil, err
+	}
+	promo.ID = promoID
+
+	return promo, nil
+}
+
+func (u *promoUsecase) GetPromoCodes(ctx context.Context, userID int) ([]entity.PromoCode, error) {
+	return u.promoRepo.FindByOwnerID(ctx, userID)
+}
+
+func (u *promoUsecase) UpdatePromoCode(ctx context.Context, promoID, userID int, req PromoCodeRequest) (*entity.PromoCode, error) {
+	promo, err := u.findOwnedPromo(ctx, promoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePromoCodeRequest(&req); err != nil {
+		return nil, err
+	}
+
+	if req.MaxUses > 0 && req.MaxUses < promo.UsedCount {
+		return nil, errors.New("batas pemakaian tidak boleh lebih kecil dari jumlah pemakaian")
+	}
+
+	if err := u.checkPromoEvent(ctx, userID, &req); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkDuplicateCode(ctx, userID, req.Code, promo.ID); err != nil {
+		return nil, err
+	}
+
+	// Diskon yang sudah tercatat di transaksi tidak ikut berubah
+	promo.EventID = req.EventID
+	promo.Code = req.Code
+	promo.DiscountType = entity.PromoDiscountType(req.DiscountType)
+	promo.DiscountPercent = req.DiscountPercent
+	promo.DiscountAmount = req.DiscountAmount
+	promo.MaxUses = req.MaxUses
+	promo.PerUserLimit = req.PerUserLimit
+	promo.MinQuantity = req.MinQuantity
+	promo.ValidFrom = req.ValidFrom
+	promo.ValidUntil = req.ValidUntil
+	if req.IsActive != nil {
+		promo.IsActive = *req.IsActive
+	}
+	promo.UpdatedAt = time.Now()
+
+	if err := u.promoRepo.Update(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+// DeactivatePromoCode hanya menonaktifkan kode karena transaksi lama masih merujuk ke kode tersebut
+func (u *promoUsecase) DeactivatePromoCode(ctx context.Context, promoID, userID int) error {
+	promo, err := u.findOwnedPromo(ctx, promoID, userID)
+	if err != nil {
+		return err
+	}
+
+	promo.IsActive = false
+	promo.UpdatedAt = time.Now()
+
+	return u.promoRepo.Update(ctx, promo)
+}
+
+// findOwnedPromo memperlakukan kode milik organizer lain sebagai tidak ditemukan
+// supaya keberadaan kode tersebut tidak bocor
+func (u *promoUsecase) findOwnedPromo(ctx context.Context, promoID, userID int) (*entity.PromoCode, error) {
+	promo, err := u.promoRepo.FindByID(ctx, promoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if promo == nil || promo.OwnerID != userID {
+		return nil, errors.New("kode promo tidak ditemukan")
+	}
+
+	return promo, nil
+}
+
+// checkPromoEvent juga menyamakan mata uang potongan tetap dengan mata uang event. Kode untuk
+// semua event memakai mata uang dari request, atau DefaultCurrency bila kosong.
+func (u *promoUsecase) checkPromoEvent(ctx context.Context, userID int, req *PromoCodeRequest) error {
+	if req.EventID == 0 {
+		req.DiscountAmount = req.DiscountAmount.InCurrency(entity.DefaultCurrency)
+		return nil
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	if event == nil {
+		return errors.New("event tidak ditemukan")
+	}
+
+	if event.OwnerID != userID {
+		return errors.New("anda tidak memiliki izin untuk membuat kode promo di event ini")
+	}
+
+	req.DiscountAmount = req.DiscountAmount.InCurrency(event.Currency)
+	if req.DiscountAmount.CurrencyCode() != entity.NormalizeCurrency(event.Currency) {
+		return errors.New("mata uang diskon harus sama dengan mata uang event")
+	}
+
+	return nil
+}
+
+func (u *promoUsecase) checkDuplicateCode(ctx context.Context, userID int, code string, excludeID int) error {
+	promos, err := u.promoRepo.FindByOwnerID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, promo := range promos {
+		if promo.ID != excludeID && strings.EqualFold(promo.Code, code) {
+			return errors.New("kode promo sudah digunakan")
+		}
+	}
+
+	return nil
+}
+
+func validatePromoCodeRequest(req *PromoCodeRequest) error {
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	if req.Code == "" {
+		return errors.New("kode promo harus diisi")
+	}
+
+	if !promoCodePattern.MatchString(req.Code) {
+		return errors.New("kode promo hanya boleh berisi 3-50 huruf, angka, tanda hubung, atau garis bawah")
+	}
+
+	// Hanya satu jenis nilai yang disimpan sesuai tipe diskon
+	switch entity.PromoDiscountType(req.DiscountType) {
+	case entity.PromoDiscountPercentage:
+		if req.DiscountPercent <= 0 {
+			return errors.New("nilai diskon harus lebih dari 0")
+		}
+		if req.DiscountPercent > entity.OneHundredPercent {
+			return errors.New("diskon persentase tidak boleh lebih dari 100")
+		}
+		req.DiscountAmount = entity.Money{Currency: req.DiscountAmount.Currency}
+	case entity.PromoDiscountFixed:
+		if !req.DiscountAmount.IsPositive() {
+			return errors.New("nilai diskon harus lebih dari 0")
+		}
+		req.DiscountPercent = 0
+	default:
+		return errors.New("tipe diskon tidak valid")
+	}
+
+	if req.MaxUses < 0 || req.PerUserLimit < 0 {
+		return errors.New("batas pemakaian tidak boleh negatif")
+	}
+
+	if req.MinQuantity < 0 {
+		return errors.New("minimum jumlah tiket tidak boleh negatif")
+	}
+	if req.MinQuantity == 0 {
+		req.MinQuantity = 1
+	}
+
+	if !req.ValidFrom.IsZero() && !req.ValidUntil.IsZero() && !req.ValidUntil.After(req.ValidFrom) {
+		return errors.New("akhir masa berlaku harus setelah awal masa berlaku")
+	}
+
+	return nil
+}
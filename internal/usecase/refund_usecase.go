@@ -0,0 +1,482 @@
+//internal/usecase/refund_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/domain/repository"
+)
+
+type RequestRefundRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ApproveRefundRequest dengan Amount nil menyetujui nominal yang tercatat di pengajuan
+type ApproveRefundRequest struct {
+	Amount *entity.Money `json:"amount"`
+}
+
+type RejectRefundRequest struct {
+	Reason string `json:"reason"`
+}
+
+type RefundUsecase interface {
+	RequestRefund(ctx context.Context, userID, transactionID int, req RequestRefundRequest) (*entity.Refund, error)
+	GetTransactionRefund(ctx context.Context, userID, transactionID int) (*entity.Refund, error)
+	GetEventRefunds(ctx context.Context, organizerID, eventID int) ([]entity.Refund, error)
+	ApproveRefund(ctx context.Context, organizerID, refundID int, req ApproveRefundRequest) (*entity.Refund, error)
+	RejectRefund(ctx context.Context, organizerID, refundID int, req RejectRefundRequest) (*entity.Refund, error)
+	ProcessRefunds(ctx context.Context) (int, error)
+}
+
+// refundBatchSize membatasi jumlah refund yang diantrekan dan diproses dalam satu putaran worker
+const refundBatchSize = 100
+
+// refundProcessingTimeout adalah batas refund berstatus processing dianggap macet, misalnya karena
+// server berhenti saat menunggu payment gateway, sehingga diproses ulang oleh worker
+const refundProcessingTimeout = 10 * time.Minute
+
+type refundUsecase struct {
+	refundRepo      repository.RefundRepository
+	transactionRepo repository.TransactionRepository
+	eventRepo       repository.EventRepository
+	historyRepo     repository.TransactionStatusHistoryRepository
+	ticketRepo      repository.TicketRepository
+	ticketTypeRepo  repository.TicketTypeRepository
+	itemRepo        repository.TransactionItemRepository
+	promoRepo       repository.PromoCodeRepository
+	seatHoldRepo    repository.SeatHoldRepository
+	txManager       repository.TxManager
+	paymentGateway  gateway.PaymentGateway
+}
+
+func NewRefundUsecase(
+	refundRepo repository.RefundRepository,
+	transactionRepo repository.TransactionRepository,
+	eventRepo repository.EventRepository,
+	historyRepo repository.TransactionStatusHistoryRepository,
+	ticketRepo repository.TicketRepository,
+	ticketTypeRepo repository.TicketTypeRepository,
+	itemRepo repository.TransactionItemRepository,
+	promoRepo repository.PromoCodeRepository,
+	seatHoldRepo repository.SeatHoldRepository,
+	txManager repository.TxManager,
+	paymentGateway gateway.PaymentGateway,
+) RefundUsecase {
+	return &refundUsecase{
+		refundRepo:      refundRepo,
+		transactionRepo: transactionRepo,
+		eventRepo:       eventRepo,
+		historyRepo:     historyRepo,
+		ticketRepo:      ticketRepo,
+		ticketTypeRepo:  ticketTypeRepo,
+		itemRepo:        itemRepo,
+		promoRepo:       promoRepo,
+		seatHoldRepo:    seatHoldRepo,
+		txManager:       txManager,
+		paymentGateway:  paymentGateway,
+	}
+}
+
+// RequestRefund membuat pengajuan refund pembeli sesuai kebijakan refund event. Nominalnya
+// dihitung dari persentase kebijakan dan masih bisa diubah organizer saat menyetujui.
+func (u *refundUsecase) RequestRefund(ctx context.Context, userID, transactionID int, req RequestRefundRequest) (*entity.Refund, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("alasan refund harus diisi")
+	}
+
+	transaction, err := u.findOwnedTransaction(ctx, userID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Status != entity.TransactionStatusPaid {
+		return nil, errors.New("hanya transaksi dengan status paid yang dapat direfund")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, transaction.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, errors.New("event terkait tidak ditemukan")
+	}
+
+	if event.Status == "cancelled" {
+		return nil, errors.New("event dibatalkan, refund akan diproses otomatis")
+	}
+
+	if !event.RefundPolicy.Allowed {
+		return nil, errors.New("event ini tidak menerima pengajuan refund")
+	}
+
+	now := time.Now()
+	if now.After(event.RefundPolicy.Deadline(event.EventDate)) {
+		return nil, errors.New("batas waktu pengajuan refund sudah lewat")
+	}
+
+	tickets, err := u.ticketRepo.FindByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ticket := range tickets {
+		if ticket.Status == entity.TicketStatusUsed {
+			return nil, errors.New("transaksi dengan tiket yang sudah digunakan tidak dapat direfund")
+		}
+		// Dana refund kembali ke pembeli, bukan ke pemegang tiket hasil transfer
+		if ticket.TransferCount > 0 {
+			return nil, errors.New("transaksi dengan tiket yang sudah dipindahtangankan tidak dapat direfund")
+		}
+	}
+
+	refund := &entity.Refund{
+		TransactionID: transaction.ID,
+		EventID:       transaction.EventID,
+		UserID:        userID,
+		Amount:        event.RefundPolicy.Amount(transaction.TotalAmount),
+		Status:        entity.RefundStatusRequested,
+		Source:        entity.RefundSourceBuyer,
+		Reason:        reason,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	refund.ID, err = u.refundRepo.Create(ctx, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (u *refundUsecase) GetTransactionRefund(ctx context.Context, userID, transactionID int) (*entity.Refund, error) {
+	if _, err := u.findOwnedTransaction(ctx, userID, transactionID); err != nil {
+		return nil, err
+	}
+
+	refund, err := u.refundRepo.FindByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if refund == nil {
+		return nil, errors.New("refund tidak ditemukan")
+	}
+
+	return refund, nil
+}
+
+func (u *refundUsecase) GetEventRefunds(ctx context.Context, organizerID, eventID int) ([]entity.Refund, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+	if event.OwnerID != organizerID {
+		return nil, errors.New("anda tidak memiliki izin untuk mengelola refund event ini")
+	}
+
+	return u.refundRepo.FindByEventID(ctx, eventID)
+}
+
+// ApproveRefund menyetujui pengajuan refund lalu langsung memprosesnya. Refund yang gagal di
+// payment gateway bisa disetujui ulang untuk dicoba lagi. Kegagalan gateway tidak dikembalikan
+// sebagai error, melainkan tercatat di status dan FailureReason refund.
+func (u *refundUsecase) ApproveRefund(ctx context.Context, organizerID, refundID int, req ApproveRefundRequest) (*entity.Refund, error) {
+	refund, transaction, err := u.findOwnedRefund(ctx, organizerID, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !refund.Status.CanTransitionTo(entity.RefundStatusApproved) {
+		return nil, errors.New("hanya refund berstatus requested atau failed yang dapat disetujui")
+	}
+
+	if req.Amount != nil {
+		amount := req.Amount.InCurrency(transaction.TotalAmount.CurrencyCode())
+		if !amount.SameCurrency(transaction.TotalAmount) {
+			return nil, errors.New("mata uang refund harus sama dengan mata uang transaksi")
+		}
+		if !amount.IsPositive() || amount.Cmp(transaction.TotalAmount) > 0 {
+			return nil, errors.New("nominal refund harus lebih dari 0 dan tidak melebihi total transaksi")
+		}
+		refund.Amount = amount
+	}
+
+	from := refund.Status
+	refund.ReviewedBy = organizerID
+	refund.ReviewedAt = time.Now()
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.refundRepo.Update(ctx, refund); err != nil {
+			return err
+		}
+		return u.refundRepo.UpdateStatus(ctx, refund.ID, from, entity.RefundStatusApproved)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return nil, errors.New("refund sedang diproses, silakan muat ulang")
+		}
+		return nil, err
+	}
+	refund.Status = entity.RefundStatusApproved
+
+	if err := u.processRefund(ctx, refund); err != nil && !errors.Is(err, repository.ErrStatusConflict) {
+		return nil, err
+	}
+
+	return u.refundRepo.FindByID(ctx, refund.ID)
+}
+
+func (u *refundUsecase) RejectRefund(ctx context.Context, organizerID, refundID int, req RejectRefundRequest) (*entity.Refund, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("alasan penolakan harus diisi")
+	}
+
+	refund, _, err := u.findOwnedRefund(ctx, organizerID, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.Status != entity.RefundStatusRequested {
+		return nil, errors.New("hanya refund berstatus requested yang dapat ditolak")
+	}
+
+	refund.ReviewedBy = organizerID
+	refund.ReviewedAt = time.Now()
+	refund.RejectReason = reason
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.refundRepo.Update(ctx, refund); err != nil {
+			return err
+		}
+		return u.refundRepo.UpdateStatus(ctx, refund.ID, entity.RefundStatusRequested, entity.RefundStatusRejected)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return nil, errors.New("refund sedang diproses, silakan muat ulang")
+		}
+		return nil, err
+	}
+	refund.Status = entity.RefundStatusRejected
+
+	return refund, nil
+}
+
+// ProcessRefunds dijalankan worker secara berkala. Transaksi paid milik event yang dibatalkan
+// diantrekan sebagai refund penuh, lalu semua refund approved dan refund processing yang macet
+// diproses. Mengembalikan jumlah refund yang selesai pada putaran ini.
+func (u *refundUsecase) ProcessRefunds(ctx context.Context) (int, error) {
+	for {
+		queued, err := u.refundRepo.QueueCancelledEventRefunds(ctx, refundBatchSize)
+		if err != nil {
+			return 0, err
+		}
+		if queued > 0 {
+			log.Printf("%d refund pembatalan event masuk antrean", queued)
+		}
+		if queued < refundBatchSize {
+			break
+		}
+	}
+
+	completed, err := u.processRefundBatches(ctx, func(ctx context.Context) ([]entity.Refund, error) {
+		return u.refundRepo.FindByStatus(ctx, entity.RefundStatusApproved, refundBatchSize)
+	})
+	if err != nil {
+		return completed, err
+	}
+
+	// Refund yang diklaim ulang mendapat waktu mulai baru sehingga tidak terambil lagi di putaran ini
+	staleBefore := time.Now().Add(-refundProcessingTimeout)
+	redriven, err := u.processRefundBatches(ctx, func(ctx context.Context) ([]entity.Refund, error) {
+		return u.refundRepo.FindStaleProcessing(ctx, staleBefore, refundBatchSize)
+	})
+
+	return completed + redriven, err
+}
+
+// processRefundBatches memproses refund dari find per batch sampai habis dan mengembalikan
+// jumlah refund yang selesai
+func (u *refundUsecase) processRefundBatches(ctx context.Context, find func(ctx context.Context) ([]entity.Refund, error)) (int, error) {
+	completed := 0
+	for {
+		refunds, err := find(ctx)
+		if err != nil {
+			return completed, err
+		}
+
+		for i := range refunds {
+			refund := &refunds[i]
+			if err := u.processRefund(ctx, refund); err != nil {
+				// Refund sudah diambil proses lain
+				if errors.Is(err, repository.ErrStatusConflict) {
+					continue
+				}
+				return completed, err
+			}
+			if refund.Status == entity.RefundStatusCompleted {
+				completed++
+			}
+		}
+
+		if len(refunds) < refundBatchSize {
+			return completed, nil
+		}
+	}
+}
+
+// processRefund mengklaim refund approved (atau processing yang macet) menjadi processing supaya
+// hanya satu proses yang memanggil payment gateway, lalu menyelesaikannya. RefundKey dibuat dari
+// ID refund sehingga percobaan ulang setelah timeout tidak mengembalikan dana dua kali.
+func (u *refundUsecase) processRefund(ctx context.Context, refund *entity.Refund) error {
+	if err := u.refundRepo.StartProcessing(ctx, refund.ID, time.Now().Add(-refundProcessingTimeout)); err != nil {
+		return err
+	}
+	refund.Status = entity.RefundStatusProcessing
+
+	transaction, err := u.transactionRepo.FindByID(ctx, refund.TransactionID)
+	if err != nil {
+		return err
+	}
+	if transaction == nil {
+		return errors.New("transaksi tidak ditemukan")
+	}
+
+	// Transaksi yang sudah refunded lewat notifikasi gateway tidak perlu direfund lagi. Pembayaran
+	// terlambat tetap dikembalikan walau transaksinya sudah expired atau gagal.
+	latePayment := refund.Source == entity.RefundSourceLatePayment
+	if transaction.PaymentMethod == "midtrans" && (transaction.Status == entity.TransactionStatusPaid || latePayment) {
+		result, err := u.paymentGateway.Refund(ctx, gateway.RefundRequest{
+			OrderID:   transaction.TransactionCode,
+			RefundKey: fmt.Sprintf("REFUND-%d", refund.ID),
+			Amount:    refund.Amount.Amount,
+			Reason:    refund.Reason,
+		})
+		if err != nil {
+			return u.failRefund(ctx, refund, err)
+		}
+		refund.GatewayRefundID = result.GatewayRefundID
+	}
+
+	return u.completeRefund(ctx, refund, transaction.TransactionCode)
+}
+
+func (u *refundUsecase) failRefund(ctx context.Context, refund *entity.Refund, cause error) error {
+	log.Printf("Refund %d untuk transaksi %d gagal: %v", refund.ID, refund.TransactionID, cause)
+
+	refund.FailureReason = cause.Error()
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.refundRepo.Update(ctx, refund); err != nil {
+			return err
+		}
+		return u.refundRepo.UpdateStatus(ctx, refund.ID, entity.RefundStatusProcessing, entity.RefundStatusFailed)
+	})
+	if err != nil {
+		return err
+	}
+	refund.Status = entity.RefundStatusFailed
+
+	return nil
+}
+
+// completeRefund mengunci transaksi agar tidak berbalapan dengan notifikasi refund dari gateway,
+// lalu menandai transaksi dan tiketnya refunded dan mengembalikan kursinya ke event
+func (u *refundUsecase) completeRefund(ctx context.Context, refund *entity.Refund, transactionCode string) error {
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		transaction, err := u.transactionRepo.FindByCodeForUpdate(ctx, transactionCode)
+		if err != nil {
+			return err
+		}
+		if transaction == nil {
+			return errors.New("transaksi tidak ditemukan")
+		}
+
+		// Transaksi dengan pembayaran terlambat sudah berakhir dan kursinya sudah dilepas
+		if transaction.Status != entity.TransactionStatusRefunded && refund.Source != entity.RefundSourceLatePayment {
+			actor := statusActor{Type: entity.StatusActorOrganizer, ID: refund.ReviewedBy, Reason: "refund disetujui organizer"}
+			if refund.Source == entity.RefundSourceEventCancelled {
+				actor = statusActor{Type: entity.StatusActorSystem, Reason: "event dibatalkan"}
+			}
+
+			if err := changeTransactionStatus(ctx, u.transactionRepo, u.historyRepo, transaction, entity.TransactionStatusRefunded, actor); err != nil {
+				return err
+			}
+
+			if _, err := u.ticketRepo.UpdateStatusByTransactionID(ctx, transaction.ID, entity.TicketStatusActive, entity.TicketStatusRefunded); err != nil {
+				return err
+			}
+
+			if err := releaseReservation(ctx, u.eventRepo, u.ticketTypeRepo, u.itemRepo, u.promoRepo, u.seatHoldRepo, transaction); err != nil {
+				return err
+			}
+		}
+
+		refund.FailureReason = ""
+		refund.CompletedAt = time.Now()
+		if err := u.refundRepo.Update(ctx, refund); err != nil {
+			return err
+		}
+		return u.refundRepo.UpdateStatus(ctx, refund.ID, entity.RefundStatusProcessing, entity.RefundStatusCompleted)
+	})
+	if err != nil {
+		return err
+	}
+	refund.Status = entity.RefundStatusCompleted
+
+	return nil
+}
+
+func (u *refundUsecase) findOwnedTransaction(ctx context.Context, userID, transactionID int) (*entity.Transaction, error) {
+	transaction, err := u.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, errors.New("transaksi tidak ditemukan")
+	}
+	if transaction.UserID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk transaksi ini")
+	}
+
+	return transaction, nil
+}
+
+func (u *refundUsecase) findOwnedRefund(ctx context.Context, organizerID, refundID int) (*entity.Refund, *entity.Transaction, error) {
+	refund, err := u.refundRepo.FindByID(ctx, refundID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if refund == nil {
+		return nil, nil, errors.New("refund tidak ditemukan")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, refund.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if event == nil || event.OwnerID != organizerID {
+		return nil, nil, errors.New("anda tidak memiliki izin untuk mengelola refund event ini")
+	}
+
+	transaction, err := u.transactionRepo.FindByID(ctx, refund.TransactionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if transaction == nil {
+		return nil, nil, errors.New("transaksi tidak ditemukan")
+	}
+
+	return refund, transaction, nil
+}
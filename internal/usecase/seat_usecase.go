@@ -0,0 +1,262 @@
+//internal/usecase/seat_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+// Status kursi pada peta kursi event
+const (
+	SeatStatusAvailable = "available"
+	SeatStatusHeld      = "held"
+	SeatStatusBooked    = "booked"
+)
+
+type HoldSeatsRequest struct {
+	SeatIDs []int `json:"seat_ids"`
+}
+
+type SeatStatusResponse struct {
+	ID         int     `json:"id"`
+	Section    string  `json:"section"`
+	Row        string  `json:"row"`
+	Number     string  `json:"number"`
+	Label      string  `json:"label"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Accessible bool    `json:"accessible"`
+	Status     string  `json:"status"`
+}
+
+type SeatMapResponse struct {
+	EventID   int                  `json:"event_id"`
+	VenueID   int                  `json:"venue_id"`
+	VenueName string               `json:"venue_name"`
+	Available int                  `json:"available"`
+	Seats     []SeatStatusResponse `json:"seats"`
+}
+
+// SeatHoldResponse adalah kursi yang sedang dikunci pembeli, SeatIDs dikirim ulang di
+// CreateTransactionRequest sebelum ExpiresAt
+type SeatHoldResponse struct {
+	EventID   int       `json:"event_id"`
+	SeatIDs   []int     `json:"seat_ids"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type SeatUsecase interface {
+	GetSeatMap(ctx context.Context, eventID int) (*SeatMapResponse, error)
+	// HoldSeats mengganti hold pembeli di event dengan kursi yang diminta
+	HoldSeats(ctx context.Context, userID, eventID int, req HoldSeatsRequest) (*SeatHoldResponse, error)
+	GetSeatHold(ctx context.Context, userID, eventID int) (*SeatHoldResponse, error)
+	ReleaseSeatHold(ctx context.Context, userID, eventID int) error
+	ExpireSeatHolds(ctx context.Context) (int, error)
+}
+
+// maxSeatsPerHold membatasi jumlah kursi yang bisa dikunci satu pembeli dalam satu event
+const maxSeatsPerHold = 10
+
+type seatUsecase struct {
+	seatHoldRepo repository.SeatHoldRepository
+	venueRepo    repository.VenueRepository
+	eventRepo    repository.EventRepository
+	holdTTL      time.Duration
+}
+
+func NewSeatUsecase(
+	seatHoldRepo repository.SeatHoldRepository,
+	venueRepo repository.VenueRepository,
+	eventRepo repository.EventRepository,
+	holdMinutes string,
+) SeatUsecase {
+	ttl, _ := strconv.Atoi(holdMinutes)
+	if ttl <= 0 {
+		ttl = 10 // default 10 menit
+	}
+
+	return &seatUsecase{
+		seatHoldRepo: seatHoldRepo,
+		venueRepo:    venueRepo,
+		eventRepo:    eventRepo,
+		holdTTL:      time.Duration(ttl) * time.Minute,
+	}
+}
+
+func (u *seatUsecase) GetSeatMap(ctx context.Context, eventID int) (*SeatMapResponse, error) {
+	event, err := u.findSeatedEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err := u.venueRepo.FindByID(ctx, event.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	if venue == nil {
+		return nil, errors.New("venue tidak ditemukan")
+	}
+
+	seats, err := u.venueRepo.FindSeatsByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := u.seatHoldRepo.FindActiveByEventID(ctx, event.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[int]string, len(holds))
+	for _, hold := range holds {
+		statuses[hold.SeatID] = string(hold.Status)
+	}
+
+	response := &SeatMapResponse{
+		EventID:   event.ID,
+		VenueID:   venue.ID,
+		VenueName: venue.Name,
+		Seats:     make([]SeatStatusResponse, 0, len(seats)),
+	}
+	for _, seat := range seats {
+		status, ok := statuses[seat.ID]
+		if !ok {
+			status = SeatStatusAvailable
+			response.Available++
+		}
+
+		response.Seats = append(response.Seats, SeatStatusResponse{
+			ID:         seat.ID,
+			Section:    seat.Section,
+			Row:        seat.Row,
+			Number:     seat.Number,
+			Label:      seat.Label(),
+			X:          seat.X,
+			Y:          seat.Y,
+			Accessible: seat.Accessible,
+			Status:     status,
+		})
+	}
+
+	return response, nil
+}
+
+func (u *seatUsecase) HoldSeats(ctx context.Context, userID, eventID int, req HoldSeatsRequest) (*SeatHoldResponse, error) {
+	if len(req.SeatIDs) == 0 {
+		return nil, errors.New("kursi harus dipilih untuk event dengan denah venue")
+	}
+
+	if len(req.SeatIDs) > maxSeatsPerHold {
+		return nil, errors.New("jumlah kursi yang dipilih melebihi batas per pembeli")
+	}
+
+	event, err := u.findSeatedEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status != "active" {
+		return nil, errors.New("event tidak aktif")
+	}
+
+	seats, err := u.venueRepo.FindSeatsByVenueID(ctx, event.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	venueSeats := make(map[int]bool, len(seats))
+	for _, seat := range seats {
+		venueSeats[seat.ID] = true
+	}
+
+	requested := make(map[int]bool, len(req.SeatIDs))
+	for _, seatID := range req.SeatIDs {
+		if requested[seatID] {
+			return nil, errors.New("kursi yang dipilih tidak boleh duplikat")
+		}
+		if !venueSeats[seatID] {
+			return nil, errors.New("kursi tidak ditemukan di denah venue event")
+		}
+		requested[seatID] = true
+	}
+
+	// Keputusan akhir ada di repository karena pembeli lain bisa memilih kursi yang sama bersamaan
+	now := time.Now()
+	holds, err := u.seatHoldRepo.Hold(ctx, event.ID, userID, req.SeatIDs, now, now.Add(u.holdTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return toSeatHoldResponse(event.ID, holds), nil
+}
+
+func (u *seatUsecase) GetSeatHold(ctx context.Context, userID, eventID int) (*SeatHoldResponse, error) {
+	holds, err := u.seatHoldRepo.FindHeldByUser(ctx, eventID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(holds) == 0 {
+		return nil, errors.New("anda tidak sedang memegang kursi di event ini")
+	}
+
+	return toSeatHoldResponse(eventID, holds), nil
+}
+
+func (u *seatUsecase) ReleaseSeatHold(ctx context.Context, userID, eventID int) error {
+	released, err := u.seatHoldRepo.ReleaseByUser(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	if released == 0 {
+		return errors.New("anda tidak sedang memegang kursi di event ini")
+	}
+
+	return nil
+}
+
+// ExpireSeatHolds melepas kursi yang hold-nya kedaluwarsa agar tampil tersedia di peta kursi.
+// Hold kedaluwarsa sudah tidak menghalangi pembeli lain meski belum dilepas worker.
+func (u *seatUsecase) ExpireSeatHolds(ctx context.Context) (int, error) {
+	return u.seatHoldRepo.ExpireHolds(ctx, time.Now())
+}
+
+func (u *seatUsecase) findSeatedEvent(ctx context.Context, eventID int) (*entity.Event, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+
+	if event.VenueID == 0 {
+		return nil, errors.New("event tidak memiliki kursi bernomor")
+	}
+
+	return event, nil
+}
+
+func toSeatHoldResponse(eventID int, holds []entity.SeatHold) *SeatHoldResponse {
+	response := &SeatHoldResponse{
+		EventID: eventID,
+		SeatIDs: make([]int, 0, len(holds)),
+	}
+
+	for _, hold := range holds {
+		response.SeatIDs = append(response.SeatIDs, hold.SeatID)
+		if response.ExpiresAt.IsZero() || hold.ExpiresAt.Before(response.ExpiresAt) {
+			response.ExpiresAt = hold.ExpiresAt
+		}
+	}
+
+	return response
+}
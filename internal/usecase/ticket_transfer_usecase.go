@@ -0,0 +1,473 @@
+//internal/usecase/ticket_transfer_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/pkg/utils"
+)
+
+// InitiateTransferRequest berisi email atau username penerima tiket
+type InitiateTransferRequest struct {
+	Recipient string `json:"recipient"`
+}
+
+type TicketTransferResponse struct {
+	ID           int        `json:"id"`
+	TicketID     int        `json:"ticket_id"`
+	TicketCode   string     `json:"ticket_code"`
+	EventID      int        `json:"event_id"`
+	EventTitle   string     `json:"event_title"`
+	FromUserID   int        `json:"from_user_id"`
+	FromUsername string     `json:"from_username"`
+	ToUserID     int        `json:"to_user_id"`
+	ToUsername   string     `json:"to_username"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RespondedAt  *time.Time `json:"responded_at,omitempty"`
+}
+
+type TicketTransferUsecase interface {
+	InitiateTransfer(ctx context.Context, userID int, code string, req InitiateTransferRequest) (*TicketTransferResponse, error)
+	GetPendingTransfers(ctx context.Context, userID int) ([]TicketTransferResponse, error)
+	GetTicketTransfers(ctx context.Context, userID int, code string) ([]TicketTransferResponse, error)
+	AcceptTransfer(ctx context.Context, userID, transferID int) (*TicketTransferResponse, error)
+	DeclineTransfer(ctx context.Context, userID, transferID int) (*TicketTransferResponse, error)
+	CancelTransfer(ctx context.Context, userID, transferID int) (*TicketTransferResponse, error)
+}
+
+type ticketTransferUsecase struct {
+	transferRepo repository.TicketTransferRepository
+	ticketRepo   repository.TicketRepository
+	eventRepo    repository.EventRepository
+	userRepo     repository.UserRepository
+	refundRepo   repository.RefundRepository
+	txManager    repository.TxManager
+	smtpConfig   utils.SMTPConfig
+}
+
+func NewTicketTransferUsecase(
+	transferRepo repository.TicketTransferRepository,
+	ticketRepo repository.TicketRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	refundRepo repository.RefundRepository,
+	txManager repository.TxManager,
+	smtpConfig utils.SMTPConfig,
+) TicketTransferUsecase {
+	return &ticketTransferUsecase{
+		transferRepo: transferRepo,
+		ticketRepo:   ticketRepo,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		refundRepo:   refundRepo,
+		txManager:    txManager,
+		smtpConfig:   smtpConfig,
+	}
+}
+
+// InitiateTransfer membuat transfer pending. Tiket tetap milik pengirim dan QR lama tetap
+// berlaku sampai penerima menerima transfer.
+func (u *ticketTransferUsecase) InitiateTransfer(ctx context.Context, userID int, code string, req InitiateTransferRequest) (*TicketTransferResponse, error) {
+	recipientKey := strings.TrimSpace(req.Recipient)
+	if recipientKey == "" {
+		return nil, errors.New("penerima transfer harus diisi")
+	}
+
+	ticket, err := u.ticketRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, errors.New("tiket tidak ditemukan")
+	}
+
+	if ticket.UserID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk memindahtangankan tiket ini")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, ticket.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkTransferable(ctx, ticket, event, time.Now()); err != nil {
+		return nil, err
+	}
+
+	recipient, err := u.findRecipient(ctx, recipientKey)
+	if err != nil {
+		return nil, err
+	}
+	if recipient == nil {
+		return nil, errors.New("penerima transfer tidak ditemukan")
+	}
+
+	if recipient.ID == userID {
+		return nil, errors.New("tiket tidak dapat dipindahtangankan ke akun sendiri")
+	}
+
+	now := time.Now()
+	transfer := &entity.TicketTransfer{
+		TicketID:   ticket.ID,
+		EventID:    ticket.EventID,
+		FromUserID: userID,
+		ToUserID:   recipient.ID,
+		Status:     entity.TicketTransferStatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	transferID, err := u.transferRepo.Create(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	transfer.ID = transferID
+
+	response, err := u.toTransferResponse(ctx, transfer, ticket, event, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	go u.sendTransferRequestEmail(recipient.Email, response)
+
+	return response, nil
+}
+
+// GetPendingTransfers mengembalikan transfer yang menunggu konfirmasi, baik yang dikirim
+// maupun yang diterima user
+func (u *ticketTransferUsecase) GetPendingTransfers(ctx context.Context, userID int) ([]TicketTransferResponse, error) {
+	transfers, err := u.transferRepo.FindPendingByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toTransferResponses(ctx, transfers, nil, nil)
+}
+
+// GetTicketTransfers mengembalikan rantai kepemilikan tiket termasuk transfer yang ditolak
+// atau dibatalkan. Hanya pemilik tiket saat ini dan organizer event yang boleh melihatnya.
+func (u *ticketTransferUsecase) GetTicketTransfers(ctx context.Context, userID int, code string) ([]TicketTransferResponse, error) {
+	ticket, err := u.ticketRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, errors.New("tiket tidak ditemukan")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, ticket.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.UserID != userID && (event == nil || event.OwnerID != userID) {
+		return nil, errors.New("anda tidak memiliki izin untuk melihat tiket ini")
+	}
+
+	transfers, err := u.transferRepo.FindByTicketID(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.toTransferResponses(ctx, transfers, ticket, event)
+}
+
+// AcceptTransfer memindahkan tiket ke penerima dan mengganti nonce QR sehingga QR yang
+// dipegang pengirim tidak bisa dipakai lagi. Aturan transfer event dicek ulang karena
+// kebijakan maupun status tiket bisa berubah sejak transfer dikirim.
+func (u *ticketTransferUsecase) AcceptTransfer(ctx context.Context, userID, transferID int) (*TicketTransferResponse, error) {
+	transfer, err := u.findPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToUserID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk transfer tiket ini")
+	}
+
+	ticket, err := u.ticketRepo.FindByID(ctx, transfer.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, errors.New("tiket tidak ditemukan")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, ticket.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkTransferable(ctx, ticket, event, time.Now()); err != nil {
+		return nil, err
+	}
+
+	nonce, err := generateQRNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.transferRepo.UpdateStatus(ctx, transfer.ID, entity.TicketTransferStatusPending, entity.TicketTransferStatusAccepted); err != nil {
+			return err
+		}
+
+		return u.ticketRepo.Transfer(ctx, ticket.ID, transfer.FromUserID, transfer.ToUserID, nonce)
+	})
+	if errors.Is(err, repository.ErrStatusConflict) {
+		return nil, errors.New("status tiket atau transfer sudah berubah, silakan muat ulang")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Tiket %s dipindahtangankan dari pengguna %d ke pengguna %d", ticket.TicketCode, transfer.FromUserID, transfer.ToUserID)
+
+	return u.reloadTransferResponse(ctx, transfer.ID, ticket, event)
+}
+
+func (u *ticketTransferUsecase) DeclineTransfer(ctx context.Context, userID, transferID int) (*TicketTransferResponse, error) {
+	transfer, err := u.findPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToUserID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk transfer tiket ini")
+	}
+
+	return u.closeTransfer(ctx, transfer, entity.TicketTransferStatusDeclined)
+}
+
+func (u *ticketTransferUsecase) CancelTransfer(ctx context.Context, userID, transferID int) (*TicketTransferResponse, error) {
+	transfer, err := u.findPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.FromUserID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk transfer tiket ini")
+	}
+
+	return u.closeTransfer(ctx, transfer, entity.TicketTransferStatusCancelled)
+}
+
+func (u *ticketTransferUsecase) closeTransfer(ctx context.Context, transfer *entity.TicketTransfer, status entity.TicketTransferStatus) (*TicketTransferResponse, error) {
+	err := u.transferRepo.UpdateStatus(ctx, transfer.ID, entity.TicketTransferStatusPending, status)
+	if errors.Is(err, repository.ErrStatusConflict) {
+		return nil, errors.New("transfer tiket sudah diproses")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return u.reloadTransferResponse(ctx, transfer.ID, nil, nil)
+}
+
+func (u *ticketTransferUsecase) findPendingTransfer(ctx context.Context, transferID int) (*entity.TicketTransfer, error) {
+	transfer, err := u.transferRepo.FindByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, errors.New("transfer tiket tidak ditemukan")
+	}
+
+	if transfer.Status != entity.TicketTransferStatusPending {
+		return nil, errors.New("transfer tiket sudah diproses")
+	}
+
+	return transfer, nil
+}
+
+// checkTransferable menerapkan kebijakan transfer event. Tiket dari transaksi yang sedang
+// diajukan refund ikut ditolak agar dana tidak dikembalikan ke pembeli untuk tiket yang sudah
+// dipegang orang lain.
+func (u *ticketTransferUsecase) checkTransferable(ctx context.Context, ticket *entity.Ticket, event *entity.Event, now time.Time) error {
+	if event == nil {
+		return errors.New("event terkait tidak ditemukan")
+	}
+
+	if ticket.Status != entity.TicketStatusActive {
+		return errors.New("hanya tiket aktif yang dapat dipindahtangankan")
+	}
+
+	if event.Status != "active" {
+		return errors.New("tiket untuk event yang tidak aktif tidak dapat dipindahtangankan")
+	}
+
+	policy := event.TransferPolicy
+	if !policy.Allowed {
+		return errors.New("event ini tidak mengizinkan transfer tiket")
+	}
+
+	if now.After(policy.Deadline(event.EventDate)) {
+		return errors.New("batas waktu transfer tiket sudah lewat")
+	}
+
+	if policy.LimitReached(ticket.TransferCount) {
+		return errors.New("tiket sudah mencapai batas jumlah transfer")
+	}
+
+	refund, err := u.refundRepo.FindByTransactionID(ctx, ticket.TransactionID)
+	if err != nil {
+		return err
+	}
+	if refund != nil && refund.Status != entity.RefundStatusRejected {
+		return errors.New("tiket dengan pengajuan refund tidak dapat dipindahtangankan")
+	}
+
+	return nil
+}
+
+// findRecipient mencari penerima dengan aturan yang sama seperti login: email jika formatnya
+// valid, selain itu username
+func (u *ticketTransferUsecase) findRecipient(ctx context.Context, recipient string) (*entity.User, error) {
+	if utils.ValidateEmail(recipient) == nil {
+		return u.userRepo.FindByEmail(ctx, recipient)
+	}
+
+	return u.userRepo.FindByUsername(ctx, recipient)
+}
+
+func (u *ticketTransferUsecase) reloadTransferResponse(ctx context.Context, transferID int, ticket *entity.Ticket, event *entity.Event) (*TicketTransferResponse, error) {
+	transfer, err := u.transferRepo.FindByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, errors.New("transfer tiket tidak ditemukan")
+	}
+
+	return u.toTransferResponse(ctx, transfer, ticket, event, nil)
+}
+
+// toTransferResponses memakai ticket dan event yang sudah dimuat jika semua transfer berasal
+// dari tiket yang sama, selain itu tiket, event dan user dimuat sekali per ID
+func (u *ticketTransferUsecase) toTransferResponses(ctx context.Context, transfers []entity.TicketTransfer, ticket *entity.Ticket, event *entity.Event) ([]TicketTransferResponse, error) {
+	usernames := make(map[int]string)
+	responses := make([]TicketTransferResponse, 0, len(transfers))
+	for i := range transfers {
+		response, err := u.toTransferResponse(ctx, &transfers[i], ticket, event, usernames)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+
+	return responses, nil
+}
+
+func (u *ticketTransferUsecase) toTransferResponse(ctx context.Context, transfer *entity.TicketTransfer, ticket *entity.Ticket, event *entity.Event, usernames map[int]string) (*TicketTransferResponse, error) {
+	var err error
+	if ticket == nil || ticket.ID != transfer.TicketID {
+		ticket, err = u.ticketRepo.FindByID(ctx, transfer.TicketID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if event == nil || event.ID != transfer.EventID {
+		event, err = u.eventRepo.FindByID(ctx, transfer.EventID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if usernames == nil {
+		usernames = make(map[int]string)
+	}
+
+	fromUsername, err := u.username(ctx, transfer.FromUserID, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	toUsername, err := u.username(ctx, transfer.ToUserID, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TicketTransferResponse{
+		ID:           transfer.ID,
+		TicketID:     transfer.TicketID,
+		EventID:      transfer.EventID,
+		FromUserID:   transfer.FromUserID,
+		FromUsername: fromUsername,
+		ToUserID:     transfer.ToUserID,
+		ToUsername:   toUsername,
+		Status:       string(transfer.Status),
+		CreatedAt:    transfer.CreatedAt,
+	}
+
+	if ticket != nil {
+		response.TicketCode = ticket.TicketCode
+	}
+
+	if event != nil {
+		response.EventTitle = event.Title
+	}
+
+	if !transfer.RespondedAt.IsZero() {
+		respondedAt := transfer.RespondedAt
+		response.RespondedAt = &respondedAt
+	}
+
+	return response, nil
+}
+
+func (u *ticketTransferUsecase) username(ctx context.Context, userID int, usernames map[int]string) (string, error) {
+	if username, ok := usernames[userID]; ok {
+		return username, nil
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var username string
+	if user != nil {
+		username = user.Username
+	}
+	usernames[userID] = username
+
+	return username, nil
+}
+
+func (u *ticketTransferUsecase) sendTransferRequestEmail(email string, transfer *TicketTransferResponse) {
+	templateData := map[string]interface{}{
+		"Username":     transfer.ToUsername,
+		"FromUsername": transfer.FromUsername,
+		"EventTitle":   transfer.EventTitle,
+		"TicketCode":   transfer.TicketCode,
+		"TransferID":   transfer.ID,
+		"Year":         time.Now().Year(),
+	}
+
+	body, err := utils.ParseTemplate("templates/email/ticket_transfer.html", templateData)
+	if err != nil {
+		log.Printf("Gagal parse template email: %v", err)
+		return
+	}
+
+	emailData := utils.EmailData{
+		To:      []string{email},
+		Subject: "Transfer Tiket - " + transfer.EventTitle,
+		Body:    body,
+	}
+
+	if err := utils.SendEmail(u.smtpConfig, emailData); err != nil {
+		log.Printf("Gagal mengirim email transfer tiket: %v", err)
+	} else {
+		log.Printf("Email transfer tiket berhasil dikirim ke: %s", email)
+	}
+}
@@ -0,0 +1,738 @@
+//internal/usecase/ticket_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/pkg/utils"
+)
+
+// ticketQRSize adalah lebar/tinggi gambar QR dalam pixel
+const ticketQRSize = 512
+
+type TicketResponse struct {
+	ID            int       `json:"id"`
+	TicketCode    string    `json:"ticket_code"`
+	TransactionID int       `json:"transaction_id"`
+	EventID       int       `json:"event_id"`
+	TicketTypeID  int       `json:"ticket_type_id,omitempty"`
+	EventTitle    string    `json:"event_title"`
+	EventDate     time.Time `json:"event_date"`
+	Location      string    `json:"location"`
+	SeatNumber    int       `json:"seat_number"`
+	SeatLabel     string    `json:"seat_label,omitempty"`
+	Status        string    `json:"status"`
+	PurchaseDate  time.Time `json:"purchase_date"`
+	CheckedInAt   time.Time `json:"checked_in_at,omitempty"`
+	CheckedInGate string    `json:"checked_in_gate,omitempty"`
+	QRPayload     string    `json:"qr_payload,omitempty"`
+}
+
+type CheckInRequest struct {
+	QRPayload string `json:"qr_payload"`
+	Gate      string `json:"gate"`
+}
+
+// CheckInManifest adalah isi manifest yang ditandatangani. Field dibuat singkat karena
+// manifest event besar bisa berisi puluhan ribu tiket.
+type CheckInManifest struct {
+	EventID     int                     `json:"event_id"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Tickets     []CheckInManifestTicket `json:"tickets"`
+}
+
+type CheckInManifestTicket struct {
+	TicketID  int    `json:"id"`
+	NonceHash string `json:"h"`
+	Status    string `json:"s"`
+}
+
+type CheckInManifestResponse struct {
+	EventID     int       `json:"event_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	TicketCount int       `json:"ticket_count"`
+	Payload     string    `json:"payload"`   // JSON CheckInManifest dalam base64url
+	Signature   string    `json:"signature"` // Ed25519 atas byte payload yang sudah di-decode
+}
+
+// CheckInPublicKeyResponse adalah public key untuk memverifikasi manifest check-in
+type CheckInPublicKeyResponse struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"` // public key Ed25519 dalam base64url
+}
+
+type CheckInScan struct {
+	QRPayload string    `json:"qr_payload"`
+	Gate      string    `json:"gate"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+type CheckInSyncRequest struct {
+	DeviceID string        `json:"device_id"`
+	Scans    []CheckInScan `json:"scans"`
+}
+
+type CheckInScanResult struct {
+	Index          int       `json:"index"`
+	TicketCode     string    `json:"ticket_code,omitempty"`
+	Result         string    `json:"result"`
+	FirstScannedAt time.Time `json:"first_scanned_at,omitempty"`
+	FirstGate      string    `json:"first_gate,omitempty"`
+}
+
+type CheckInSyncResponse struct {
+	Results    []CheckInScanResult `json:"results"`
+	Accepted   int                 `json:"accepted"`
+	Duplicates int                 `json:"duplicates"`
+	Rejected   int                 `json:"rejected"`
+	Attendance AttendanceSummary   `json:"attendance"`
+}
+
+type AttendanceSummary struct {
+	TotalTickets   int `json:"total_tickets"`
+	CheckedIn      int `json:"checked_in"`
+	NotCheckedIn   int `json:"not_checked_in"`
+	DuplicateScans int `json:"duplicate_scans"`
+}
+
+type AttendeeResponse struct {
+	TicketCode  string    `json:"ticket_code"`
+	SeatNumber  int       `json:"seat_number"`
+	SeatLabel   string    `json:"seat_label,omitempty"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+	Gate        string    `json:"gate,omitempty"`
+}
+
+type AttendanceResponse struct {
+	EventID   int                `json:"event_id"`
+	Summary   AttendanceSummary  `json:"summary"`
+	Attendees []AttendeeResponse `json:"attendees"`
+}
+
+// Batas jumlah scan per sinkronisasi agar satu request tidak menahan koneksi database terlalu lama
+const maxCheckInSyncScans = 1000
+
+// scanResultAlreadySynced hanya muncul di respons sinkronisasi, tidak disimpan di ticket_scans
+const scanResultAlreadySynced = "already_synced"
+
+var errScanAlreadySynced = errors.New("scan sudah pernah disinkronkan")
+
+var errManifestKeyMissing = errors.New("manifest check-in offline belum dikonfigurasi")
+
+// ErrTicketWrongEvent dikembalikan saat QR yang dipindai milik tiket event lain
+var ErrTicketWrongEvent = errors.New("tiket bukan untuk event ini")
+
+type TicketUsecase interface {
+	GetUserTickets(ctx context.Context, userID, page, limit int) ([]TicketResponse, int, error)
+	GetTicketByCode(ctx context.Context, userID int, code string) (*TicketResponse, error)
+	GetTicketQR(ctx context.Context, userID int, code string) ([]byte, error)
+	CheckIn(ctx context.Context, organizerID, eventID int, req CheckInRequest) (*TicketResponse, error)
+	GetCheckInManifest(ctx context.Context, organizerID, eventID int) (*CheckInManifestResponse, error)
+	GetCheckInPublicKey(ctx context.Context) (*CheckInPublicKeyResponse, error)
+	SyncCheckIns(ctx context.Context, organizerID, eventID int, req CheckInSyncRequest) (*CheckInSyncResponse, error)
+	GetAttendance(ctx context.Context, organizerID, eventID int) (*AttendanceResponse, error)
+}
+
+type ticketUsecase struct {
+	ticketRepo     repository.TicketRepository
+	ticketScanRepo repository.TicketScanRepository
+	eventRepo      repository.EventRepository
+	txManager      repository.TxManager
+	qrSecret       string
+	manifestKey    ed25519.PrivateKey
+}
+
+// manifestKey boleh nil jika manifest check-in offline tidak dikonfigurasi
+func NewTicketUsecase(
+	ticketRepo repository.TicketRepository,
+	ticketScanRepo repository.TicketScanRepository,
+	eventRepo repository.EventRepository,
+	txManager repository.TxManager,
+	qrSecret string,
+	manifestKey ed25519.PrivateKey,
+) TicketUsecase {
+	return &ticketUsecase{
+		ticketRepo:     ticketRepo,
+		ticketScanRepo: ticketScanRepo,
+		eventRepo:      eventRepo,
+		txManager:      txManager,
+		qrSecret:       qrSecret,
+		manifestKey:    manifestKey,
+	}
+}
+
+func (u *ticketUsecase) GetUserTickets(ctx context.Context, userID, page, limit int) ([]TicketResponse, int, error) {
+	offset := (page - 1) * limit
+
+	tickets, err := u.ticketRepo.FindByUserID(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := u.ticketRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Satu transaksi menghasilkan beberapa tiket untuk event yang sama, jadi event cukup dimuat sekali
+	events := make(map[int]*entity.Event)
+	responses := make([]TicketResponse, 0, len(tickets))
+	for i := range tickets {
+		event, ok := events[tickets[i].EventID]
+		if !ok {
+			event, err = u.eventRepo.FindByID(ctx, tickets[i].EventID)
+			if err != nil {
+				return nil, 0, err
+			}
+			events[tickets[i].EventID] = event
+		}
+
+		response := toTicketResponse(&tickets[i], event)
+		response.QRPayload = u.qrPayload(&tickets[i])
+		responses = append(responses, *response)
+	}
+
+	return responses, total, nil
+}
+
+func (u *ticketUsecase) GetTicketByCode(ctx context.Context, userID int, code string) (*TicketResponse, error) {
+	ticket, err := u.ticketRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket == nil {
+		return nil, errors.New("tiket tidak ditemukan")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, ticket.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Selain pemilik tiket, hanya organizer pemilik event yang boleh melihat tiket
+	if ticket.UserID != userID && (event == nil || event.OwnerID != userID) {
+		return nil, errors.New("anda tidak memiliki izin untuk melihat tiket ini")
+	}
+
+	response := toTicketResponse(ticket, event)
+	// Isi QR hanya diberikan ke pemegang tiket
+	if ticket.UserID == userID {
+		response.QRPayload = u.qrPayload(ticket)
+	}
+
+	return response, nil
+}
+
+func (u *ticketUsecase) GetTicketQR(ctx context.Context, userID int, code string) ([]byte, error) {
+	ticket, err := u.ticketRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket == nil {
+		return nil, errors.New("tiket tidak ditemukan")
+	}
+
+	if ticket.UserID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk melihat tiket ini")
+	}
+
+	return qrcode.Encode(u.qrPayload(ticket), qrcode.Medium, ticketQRSize)
+}
+
+// CheckIn memvalidasi QR yang dipindai petugas di pintu masuk lalu menandai tiket sebagai used.
+// Untuk penolakan karena status tiket (sudah dipakai, refund, batal), detail tiket tetap
+// dikembalikan bersama error agar petugas bisa melihat kapan tiket tersebut dipakai.
+func (u *ticketUsecase) CheckIn(ctx context.Context, organizerID, eventID int, req CheckInRequest) (*TicketResponse, error) {
+	if strings.TrimSpace(req.QRPayload) == "" {
+		return nil, errors.New("qr tiket harus diisi")
+	}
+
+	event, err := u.findCheckInEvent(ctx, organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := u.findScannedTicket(ctx, eventID, req.QRPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ticketStatusError(ticket.Status); err != nil {
+		return toTicketResponse(ticket, event), err
+	}
+
+	if err := u.ticketRepo.CheckIn(ctx, ticket.ID, organizerID, strings.TrimSpace(req.Gate)); err != nil {
+		if !errors.Is(err, repository.ErrStatusConflict) {
+			return nil, err
+		}
+
+		// Kalah dari scan lain yang berjalan bersamaan, muat ulang untuk status terbaru
+		ticket, err = u.ticketRepo.FindByID(ctx, ticket.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		statusErr := ticketStatusError(ticket.Status)
+		if statusErr == nil {
+			statusErr = errors.New("tiket sudah digunakan")
+		}
+		return toTicketResponse(ticket, event), statusErr
+	}
+
+	ticket, err = u.ticketRepo.FindByID(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketResponse(ticket, event), nil
+}
+
+// GetCheckInManifest mengekspor daftar tiket event untuk validasi offline. Manifest hanya berisi
+// hash nonce, jadi perangkat bisa mencocokkan QR tanpa memegang secret penandatangan QR.
+func (u *ticketUsecase) GetCheckInManifest(ctx context.Context, organizerID, eventID int) (*CheckInManifestResponse, error) {
+	if u.manifestKey == nil {
+		return nil, errManifestKeyMissing
+	}
+
+	if _, err := u.findCheckInEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+
+	tickets, err := u.ticketRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := CheckInManifest{
+		EventID:     eventID,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Tickets:     make([]CheckInManifestTicket, 0, len(tickets)),
+	}
+	for _, ticket := range tickets {
+		manifest.Tickets = append(manifest.Tickets, CheckInManifestTicket{
+			TicketID:  ticket.ID,
+			NonceHash: utils.TicketNonceHash(ticket.QRNonce),
+			Status:    string(ticket.Status),
+		})
+	}
+
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckInManifestResponse{
+		EventID:     eventID,
+		GeneratedAt: manifest.GeneratedAt,
+		TicketCount: len(manifest.Tickets),
+		Payload:     base64.RawURLEncoding.EncodeToString(payload),
+		Signature:   utils.SignTicketManifest(payload, u.manifestKey),
+	}, nil
+}
+
+// GetCheckInPublicKey mengembalikan public key manifest untuk disimpan di perangkat check-in
+// sebelum acara, sehingga manifest yang diunduh kemudian diverifikasi dengan key yang sudah dipercaya
+func (u *ticketUsecase) GetCheckInPublicKey(ctx context.Context) (*CheckInPublicKeyResponse, error) {
+	if u.manifestKey == nil {
+		return nil, errManifestKeyMissing
+	}
+
+	return &CheckInPublicKeyResponse{
+		Algorithm: "Ed25519",
+		PublicKey: utils.TicketManifestPublicKey(u.manifestKey),
+	}, nil
+}
+
+// SyncCheckIns memproses log scan dari perangkat offline. Scan diproses berurutan menurut waktu
+// scan sehingga ketika tiket yang sama dipindai di dua gerbang, scan paling awal yang dicatat
+// sebagai check-in dan sisanya dilaporkan sebagai duplikat. Batch yang dikirim ulang aman
+// karena setiap scan hanya dicatat sekali.
+func (u *ticketUsecase) SyncCheckIns(ctx context.Context, organizerID, eventID int, req CheckInSyncRequest) (*CheckInSyncResponse, error) {
+	req.DeviceID = strings.TrimSpace(req.DeviceID)
+	if req.DeviceID == "" {
+		return nil, errors.New("device id harus diisi")
+	}
+
+	if len(req.Scans) == 0 {
+		return nil, errors.New("data scan tidak boleh kosong")
+	}
+
+	if len(req.Scans) > maxCheckInSyncScans {
+		return nil, errors.New("jumlah scan melebihi batas per sinkronisasi")
+	}
+
+	if _, err := u.findCheckInEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+
+	order := make([]int, len(req.Scans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return req.Scans[order[a]].ScannedAt.Before(req.Scans[order[b]].ScannedAt)
+	})
+
+	response := &CheckInSyncResponse{Results: make([]CheckInScanResult, len(req.Scans))}
+	for _, i := range order {
+		result, err := u.syncScan(ctx, organizerID, eventID, req.DeviceID, req.Scans[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Index = i
+		response.Results[i] = *result
+
+		switch result.Result {
+		case string(entity.TicketScanResultCheckedIn):
+			response.Accepted++
+		case string(entity.TicketScanResultDuplicate):
+			response.Duplicates++
+		case scanResultAlreadySynced:
+		default:
+			response.Rejected++
+		}
+	}
+
+	summary, _, err := u.attendance(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	response.Attendance = *summary
+
+	return response, nil
+}
+
+func (u *ticketUsecase) syncScan(ctx context.Context, organizerID, eventID int, deviceID string, scan CheckInScan) (*CheckInScanResult, error) {
+	result := &CheckInScanResult{}
+	gate := strings.TrimSpace(scan.Gate)
+
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		record := &entity.TicketScan{
+			EventID:     eventID,
+			DeviceID:    deviceID,
+			Gate:        gate,
+			PayloadHash: hashScanPayload(scan.QRPayload),
+			ScannedAt:   scan.ScannedAt,
+			SyncedBy:    organizerID,
+		}
+
+		ticket, err := u.findScannedTicket(ctx, eventID, scan.QRPayload)
+		switch {
+		case scan.ScannedAt.IsZero():
+			record.Result = entity.TicketScanResultInvalid
+		case err == nil:
+			record.TicketID = ticket.ID
+			result.TicketCode = ticket.TicketCode
+			record.Result, err = u.applyOfflineCheckIn(ctx, organizerID, ticket, gate, scan.ScannedAt)
+			if err != nil {
+				return err
+			}
+			if record.Result == entity.TicketScanResultDuplicate {
+				if ticket, err = u.ticketRepo.FindByID(ctx, ticket.ID); err != nil {
+					return err
+				}
+				result.FirstScannedAt = ticket.CheckedInAt
+				result.FirstGate = ticket.CheckedInGate
+			}
+		case errors.Is(err, ErrTicketWrongEvent):
+			record.Result = entity.TicketScanResultWrongEvent
+		case errors.Is(err, utils.ErrInvalidTicketQR):
+			record.Result = entity.TicketScanResultInvalid
+		default:
+			return err
+		}
+		result.Result = string(record.Result)
+
+		created, err := u.ticketScanRepo.Create(ctx, record)
+		if err != nil {
+			return err
+		}
+		// Batalkan perubahan tiket, scan ini sudah diproses pada sinkronisasi sebelumnya
+		if !created {
+			return errScanAlreadySynced
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errScanAlreadySynced) {
+		return &CheckInScanResult{TicketCode: result.TicketCode, Result: scanResultAlreadySynced}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (u *ticketUsecase) applyOfflineCheckIn(ctx context.Context, organizerID int, ticket *entity.Ticket, gate string, scannedAt time.Time) (entity.TicketScanResult, error) {
+	if ticket.Status != entity.TicketStatusActive && ticket.Status != entity.TicketStatusUsed {
+		return entity.TicketScanResultNotActive, nil
+	}
+
+	first, err := u.ticketRepo.CheckInAt(ctx, ticket.ID, organizerID, gate, scannedAt)
+	if err != nil {
+		return "", err
+	}
+
+	if !first {
+		return entity.TicketScanResultDuplicate, nil
+	}
+
+	// Untuk tiket yang sudah used, berhasil di sini berarti scan ini lebih awal dari check-in
+	// yang tercatat sehingga waktu dan gerbang masuk diganti dengan milik scan ini
+	return entity.TicketScanResultCheckedIn, nil
+}
+
+func (u *ticketUsecase) GetAttendance(ctx context.Context, organizerID, eventID int) (*AttendanceResponse, error) {
+	if _, err := u.findCheckInEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+
+	summary, tickets, err := u.attendance(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendees := make([]AttendeeResponse, 0, summary.CheckedIn)
+	for _, ticket := range tickets {
+		if ticket.Status != entity.TicketStatusUsed {
+			continue
+		}
+		attendees = append(attendees, AttendeeResponse{
+			TicketCode:  ticket.TicketCode,
+			SeatNumber:  ticket.SeatNumber,
+			SeatLabel:   ticket.SeatLabel,
+			CheckedInAt: ticket.CheckedInAt,
+			Gate:        ticket.CheckedInGate,
+		})
+	}
+
+	return &AttendanceResponse{
+		EventID:   eventID,
+		Summary:   *summary,
+		Attendees: attendees,
+	}, nil
+}
+
+// attendance menghitung rekap kehadiran. Tiket yang di-refund atau dibatalkan tidak dihitung.
+func (u *ticketUsecase) attendance(ctx context.Context, eventID int) (*AttendanceSummary, []entity.Ticket, error) {
+	tickets, err := u.ticketRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	duplicates, err := u.ticketScanRepo.CountByEventAndResult(ctx, eventID, entity.TicketScanResultDuplicate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := &AttendanceSummary{DuplicateScans: duplicates}
+	for _, ticket := range tickets {
+		switch ticket.Status {
+		case entity.TicketStatusActive:
+			summary.TotalTickets++
+			summary.NotCheckedIn++
+		case entity.TicketStatusUsed:
+			summary.TotalTickets++
+			summary.CheckedIn++
+		}
+	}
+
+	return summary, tickets, nil
+}
+
+func (u *ticketUsecase) findCheckInEvent(ctx context.Context, organizerID, eventID int) (*entity.Event, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+
+	if event.OwnerID != organizerID {
+		return nil, errors.New("anda tidak memiliki izin untuk melakukan check-in di event ini")
+	}
+
+	return event, nil
+}
+
+// findScannedTicket memverifikasi signature QR dan mencocokkannya dengan tiket di database
+func (u *ticketUsecase) findScannedTicket(ctx context.Context, eventID int, qrPayload string) (*entity.Ticket, error) {
+	claim, err := utils.ParseTicketQRPayload(qrPayload, u.qrSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.EventID != eventID {
+		return nil, ErrTicketWrongEvent
+	}
+
+	ticket, err := u.ticketRepo.FindByID(ctx, claim.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nonce yang tidak cocok berarti QR sudah diganti (misalnya setelah tiket dipindahtangankan)
+	if ticket == nil || ticket.EventID != claim.EventID || ticket.QRNonce != claim.Nonce {
+		return nil, utils.ErrInvalidTicketQR
+	}
+
+	return ticket, nil
+}
+
+func hashScanPayload(payload string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(payload)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ticketStatusError mengembalikan alasan tiket tidak bisa dipakai masuk, atau nil jika masih aktif
+func ticketStatusError(status entity.TicketStatus) error {
+	switch status {
+	case entity.TicketStatusActive:
+		return nil
+	case entity.TicketStatusUsed:
+		return errors.New("tiket sudah digunakan")
+	case entity.TicketStatusRefunded:
+		return errors.New("tiket sudah di-refund")
+	default:
+		return errors.New("tiket sudah dibatalkan")
+	}
+}
+
+func (u *ticketUsecase) qrPayload(ticket *entity.Ticket) string {
+	return utils.GenerateTicketQRPayload(ticket.ID, ticket.EventID, ticket.QRNonce, u.qrSecret)
+}
+
+func toTicketResponse(ticket *entity.Ticket, event *entity.Event) *TicketResponse {
+	response := &TicketResponse{
+		ID:            ticket.ID,
+		TicketCode:    ticket.TicketCode,
+		TransactionID: ticket.TransactionID,
+		EventID:       ticket.EventID,
+		TicketTypeID:  ticket.TicketTypeID,
+		SeatNumber:    ticket.SeatNumber,
+		SeatLabel:     ticket.SeatLabel,
+		Status:        string(ticket.Status),
+		PurchaseDate:  ticket.PurchaseDate,
+		CheckedInAt:   ticket.CheckedInAt,
+		CheckedInGate: ticket.CheckedInGate,
+	}
+
+	if event != nil {
+		response.EventTitle = event.Title
+		response.EventDate = event.EventDate
+		response.Location = event.Location
+	}
+
+	return response
+}
+
+// issueTickets menerbitkan satu tiket per kursi untuk transaksi yang baru saja dibayar.
+// Kursi sudah dihitung di tickets_sold sejak transaksi dibuat, jadi di sini tidak ada
+// perubahan kapasitas event. Dipanggil di dalam transaksi database yang sama dengan
+// perubahan status ke paid.
+func issueTickets(
+	ctx context.Context,
+	ticketRepo repository.TicketRepository,
+	itemRepo repository.TransactionItemRepository,
+	seatHoldRepo repository.SeatHoldRepository,
+	transaction *entity.Transaction,
+) error {
+	items, err := itemRepo.FindByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	// Event dengan denah venue memiliki satu kursi bernomor per tiket yang dipesan saat checkout
+	seats, err := seatHoldRepo.FindSeatsByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	// Nomor kursi diurutkan mengikuti urutan item, misalnya 2 VIP lalu 3 Regular menjadi kursi 1-2 VIP
+	// dan 3-5 Regular. Transaksi tanpa item (event tanpa tipe tiket) tidak memiliki tipe.
+	seatTypes := make([]int, 0, transaction.Quantity)
+	for _, item := range items {
+		for i := 0; i < item.Quantity; i++ {
+			seatTypes = append(seatTypes, item.TicketTypeID)
+		}
+	}
+
+	tickets := make([]*entity.Ticket, 0, transaction.Quantity)
+	for seat := 1; seat <= transaction.Quantity; seat++ {
+		code, err := generateTicketCode()
+		if err != nil {
+			return err
+		}
+
+		nonce, err := generateQRNonce()
+		if err != nil {
+			return err
+		}
+
+		var ticketTypeID int
+		if seat <= len(seatTypes) {
+			ticketTypeID = seatTypes[seat-1]
+		}
+
+		ticket := &entity.Ticket{
+			TransactionID: transaction.ID,
+			EventID:       transaction.EventID,
+			TicketTypeID:  ticketTypeID,
+			UserID:        transaction.UserID,
+			TicketCode:    code,
+			SeatNumber:    seat,
+			QRNonce:       nonce,
+			Status:        entity.TicketStatusActive,
+		}
+		if seat <= len(seats) {
+			ticket.SeatID = seats[seat-1].ID
+			ticket.SeatLabel = seats[seat-1].Label()
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return ticketRepo.CreateBatch(ctx, tickets)
+}
+
+// generateTicketCode menghasilkan kode 120 bit dari crypto/rand agar tidak bisa ditebak
+func generateTicketCode() (string, error) {
+	b := make([]byte, 15)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "TKT-" + base32.StdEncoding.EncodeToString(b), nil
+}
+
+// generateQRNonce diganti setiap kali QR tiket perlu dibatalkan tanpa mengubah kode tiket
+func generateQRNonce() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,37 @@
+//internal/usecase/token_revocation_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticket-system/internal/domain/repository"
+	"ticket-system/pkg/utils"
+)
+
+type TokenRevocationUsecase interface {
+	// IsRevoked mengecek apakah access token sudah dicabut lewat logout, logout dari semua
+	// perangkat, atau penggantian password
+	IsRevoked(ctx context.Context, claims *utils.JWTClaim) (bool, error)
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+type tokenRevocationUsecase struct {
+	tokenRevocationRepo repository.TokenRevocationRepository
+}
+
+func NewTokenRevocationUsecase(tokenRevocationRepo repository.TokenRevocationRepository) TokenRevocationUsecase {
+	return &tokenRevocationUsecase{
+		tokenRevocationRepo: tokenRevocationRepo,
+	}
+}
+
+func (u *tokenRevocationUsecase) IsRevoked(ctx context.Context, claims *utils.JWTClaim) (bool, error) {
+	return u.tokenRevocationRepo.IsRevoked(ctx, claims.UserID, claims.ID, claims.TokenVersion)
+}
+
+// PurgeExpired menghapus pencabutan yang token terdampaknya sudah kedaluwarsa dengan sendirinya
+func (u *tokenRevocationUsecase) PurgeExpired(ctx context.Context) (int, error) {
+	return u.tokenRevocationRepo.DeleteExpired(ctx, time.Now())
+}
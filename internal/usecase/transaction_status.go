@@ -0,0 +1,98 @@
+//internal/usecase/transaction_status.go
+
+package usecase
+
+import (
+	"context"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+// statusActor menjelaskan siapa yang mengubah status dan alasannya untuk dicatat di riwayat
+type statusActor struct {
+	Type   string
+	ID     int
+	Reason string
+}
+
+// changeTransactionStatus memvalidasi perubahan status terhadap tabel transisi, menulisnya
+// secara kondisional, lalu mencatat riwayatnya. Panggil di dalam TxManager.WithinTransaction
+// agar update dan riwayat tersimpan bersama.
+func changeTransactionStatus(
+	ctx context.Context,
+	transactionRepo repository.TransactionRepository,
+	historyRepo repository.TransactionStatusHistoryRepository,
+	transaction *entity.Transaction,
+	to entity.TransactionStatus,
+	actor statusActor,
+) error {
+	from := transaction.Status
+	if !from.CanTransitionTo(to) {
+		return entity.ErrInvalidStatusTransition
+	}
+
+	if err := transactionRepo.UpdateStatus(ctx, transaction.ID, from, to); err != nil {
+		return err
+	}
+	transaction.Status = to
+
+	return recordStatusHistory(ctx, historyRepo, transaction.ID, from, to, actor)
+}
+
+func recordStatusHistory(
+	ctx context.Context,
+	historyRepo repository.TransactionStatusHistoryRepository,
+	transactionID int,
+	from, to entity.TransactionStatus,
+	actor statusActor,
+) error {
+	_, err := historyRepo.Create(ctx, &entity.TransactionStatusHistory{
+		TransactionID: transactionID,
+		FromStatus:    from,
+		ToStatus:      to,
+		ActorType:     actor.Type,
+		ActorID:       actor.ID,
+		Reason:        actor.Reason,
+	})
+	return err
+}
+
+// releaseReservation mengembalikan kursi yang dipegang transaksi ke kapasitas event dan ke kuota
+// setiap tipe tiket yang dibelinya, lalu melepas kursi bernomor dan kode promo yang dipakai. Panggil di dalam
+// transaksi database yang sama dengan perubahan status yang mengakhiri reservasi
+// (cancelled, expired, failed, rejected, refunded).
+func releaseReservation(
+	ctx context.Context,
+	eventRepo repository.EventRepository,
+	ticketTypeRepo repository.TicketTypeRepository,
+	itemRepo repository.TransactionItemRepository,
+	promoRepo repository.PromoCodeRepository,
+	seatHoldRepo repository.SeatHoldRepository,
+	transaction *entity.Transaction,
+) error {
+	if err := eventRepo.UpdateTicketsSold(ctx, transaction.EventID, -transaction.Quantity); err != nil {
+		return err
+	}
+
+	items, err := itemRepo.FindByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := ticketTypeRepo.Release(ctx, item.TicketTypeID, item.Quantity); err != nil {
+			return err
+		}
+	}
+
+	if err := seatHoldRepo.ReleaseByTransactionID(ctx, transaction.ID); err != nil {
+		return err
+	}
+
+	if transaction.PromoCodeID != 0 {
+		return promoRepo.Release(ctx, transaction.ID)
+	}
+
+	return nil
+}
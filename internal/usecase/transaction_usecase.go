@@ -6,36 +6,100 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 	
 	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
 	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/domain/storage"
 	"ticket-system/pkg/utils"
 )
 
 type CreateTransactionRequest struct {
-	EventID       int    `json:"event_id"`
-	Quantity      int    `json:"quantity"`
-	PaymentMethod string `json:"payment_method"`
+	EventID       int                      `json:"event_id"`
+	Quantity      int                      `json:"quantity"`
+	Items         []TransactionItemRequest `json:"items,omitempty"`
+	PromoCode     string                   `json:"promo_code,omitempty"`
+	PaymentMethod string                   `json:"payment_method"`
+	// WaitlistEntryID diisi untuk mengklaim penawaran waitlist, jumlah tiket harus sama
+	// dengan jumlah pada penawaran
+	WaitlistEntryID int `json:"waitlist_entry_id,omitempty"`
+	// SeatIDs wajib untuk event dengan denah venue dan harus sudah di-hold oleh pembeli,
+	// jumlah tiket dihitung dari jumlah kursi
+	SeatIDs []int `json:"seat_ids,omitempty"`
+}
+
+// TransactionItemRequest wajib dipakai untuk event yang memiliki tipe tiket, Quantity di
+// CreateTransactionRequest diabaikan dan dihitung ulang dari jumlah item
+type TransactionItemRequest struct {
+	TicketTypeID int `json:"ticket_type_id"`
+	Quantity     int `json:"quantity"`
+}
+
+type TransactionItemResponse struct {
+	TicketTypeID   int          `json:"ticket_type_id"`
+	TicketTypeName string       `json:"ticket_type_name"`
+	Quantity       int          `json:"quantity"`
+	UnitPrice      entity.Money `json:"unit_price"`
+	Subtotal       entity.Money `json:"subtotal"`
 }
 
 type TransactionResponse struct {
-	ID              int       `json:"id"`
-	TransactionCode string    `json:"transaction_code"`
-	EventID         int       `json:"event_id"`
-	EventTitle      string    `json:"event_title"`
-	Quantity        int       `json:"quantity"`
-	TotalAmount     float64   `json:"total_amount"`
-	Status          string    `json:"status"`
-	PaymentMethod   string    `json:"payment_method"`
-	PaymentDetail   string    `json:"payment_detail"`
-	PaymentProof    string    `json:"payment_proof,omitempty"`
-	CreatedAt       time.Time `json:"created_at"`
+	ID              int           `json:"id"`
+	TransactionCode string        `json:"transaction_code"`
+	EventID         int           `json:"event_id"`
+	EventTitle      string        `json:"event_title"`
+	Quantity        int           `json:"quantity"`
+	TotalAmount     entity.Money  `json:"total_amount"`
+	DiscountAmount  *entity.Money `json:"discount_amount,omitempty"`
+	PromoCode       string        `json:"promo_code,omitempty"`
+	Status          string        `json:"status"`
+	PaymentMethod   string        `json:"payment_method"`
+	PaymentDetail   string        `json:"payment_detail"`
+	PaymentProof    string        `json:"payment_proof,omitempty"`
+	PaymentProofURL string        `json:"payment_proof_url,omitempty"`
+	SnapToken       string        `json:"snap_token,omitempty"`
+	RedirectURL     string        `json:"redirect_url,omitempty"`
+	ExpiresAt       time.Time     `json:"expires_at"`
+	CreatedAt       time.Time     `json:"created_at"`
+
+	Pricing       PriceBreakdownResponse    `json:"pricing"`
+	Items         []TransactionItemResponse `json:"items,omitempty"`
+	StatusHistory []StatusHistoryResponse   `json:"status_history,omitempty"`
+}
+
+// PriceBreakdownResponse merinci total yang dibayar pembeli. Komisi platform yang ditanggung
+// organizer tidak ditampilkan karena tidak menambah tagihan pembeli.
+type PriceBreakdownResponse struct {
+	Subtotal    entity.Money  `json:"subtotal"`
+	Discount    entity.Money  `json:"discount"`
+	ServiceFee  entity.Money  `json:"service_fee"`
+	PlatformFee *entity.Money `json:"platform_fee,omitempty"`
+	Tax         entity.Money  `json:"tax"`
+	Total       entity.Money  `json:"total"`
+}
+
+type StatusHistoryResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorType  string    `json:"actor_type"`
+	ActorID    int       `json:"actor_id,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type UploadPaymentProofRequest struct {
-	TransactionID string `json:"transaction_id"`
-	ProofURL      string `json:"proof_url"`
+	TransactionID string
+	FileName      string
+	Content       []byte
+}
+
+type RejectPaymentRequest struct {
+	Reason string `json:"reason"`
 }
 
 type TransactionUsecase interface {
@@ -46,23 +110,117 @@ type TransactionUsecase interface {
 	UploadPaymentProof(ctx context.Context, userID int, req UploadPaymentProofRequest) error
 	CancelTransaction(ctx context.Context, userID int, transactionID int) error
 	VerifyPayment(ctx context.Context, organizerID int, transactionID int) error
+	RejectPayment(ctx context.Context, organizerID int, transactionID int, req RejectPaymentRequest) error
+	ExpirePendingTransactions(ctx context.Context) (int, error)
+}
+
+// expiryBatchSize membatasi jumlah transaksi yang dikunci dalam satu putaran sweeper
+const expiryBatchSize = 100
+
+// proofContentTypes memetakan tipe file bukti pembayaran yang diterima ke ekstensi file di storage
+var proofContentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
 }
 
 type transactionUsecase struct {
 	transactionRepo repository.TransactionRepository
 	eventRepo       repository.EventRepository
 	userRepo        repository.UserRepository
+	paymentRepo     repository.PaymentRepository
+	historyRepo     repository.TransactionStatusHistoryRepository
+	ticketRepo      repository.TicketRepository
+	ticketTypeRepo  repository.TicketTypeRepository
+	itemRepo        repository.TransactionItemRepository
+	promoRepo       repository.PromoCodeRepository
+	pricingRepo     repository.PricingRuleRepository
+	waitlistRepo    repository.WaitlistRepository
+	seatHoldRepo    repository.SeatHoldRepository
+	txManager       repository.TxManager
+	paymentGateway  gateway.PaymentGateway
+	blobStorage     storage.BlobStorage
+	virusScanner    storage.VirusScanner
+	paymentDeadline time.Duration
+	maxRejections   int
+	maxProofSize    int
+	proofURLTTL     time.Duration
+	platformFee     entity.Percent
+	smtpConfig      utils.SMTPConfig
 }
 
 func NewTransactionUsecase(
 	transactionRepo repository.TransactionRepository,
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
+	paymentRepo repository.PaymentRepository,
+	historyRepo repository.TransactionStatusHistoryRepository,
+	ticketRepo repository.TicketRepository,
+	ticketTypeRepo repository.TicketTypeRepository,
+	itemRepo repository.TransactionItemRepository,
+	promoRepo repository.PromoCodeRepository,
+	pricingRepo repository.PricingRuleRepository,
+	waitlistRepo repository.WaitlistRepository,
+	seatHoldRepo repository.SeatHoldRepository,
+	txManager repository.TxManager,
+	paymentGateway gateway.PaymentGateway,
+	blobStorage storage.BlobStorage,
+	virusScanner storage.VirusScanner,
+	paymentDeadline string,
+	maxRejections string,
+	maxProofSizeKB string,
+	proofURLTTL string,
+	platformFeePercent string,
+	smtpConfig utils.SMTPConfig,
 ) TransactionUsecase {
+	deadline, _ := strconv.Atoi(paymentDeadline)
+	if deadline <= 0 {
+		deadline = 60 // default 60 menit
+	}
+
+	rejections, _ := strconv.Atoi(maxRejections)
+	if rejections <= 0 {
+		rejections = 3 // default 3 kali penolakan
+	}
+
+	proofSize, _ := strconv.Atoi(maxProofSizeKB)
+	if proofSize <= 0 {
+		proofSize = 2048 // default 2 MB
+	}
+
+	urlTTL, _ := strconv.Atoi(proofURLTTL)
+	if urlTTL <= 0 {
+		urlTTL = 15 // default 15 menit
+	}
+
+	platformFee, err := entity.ParsePercent(platformFeePercent)
+	if err != nil || platformFee > entity.OneHundredPercent {
+		platformFee = 0 // default tanpa komisi platform
+	}
+
 	return &transactionUsecase{
 		transactionRepo: transactionRepo,
 		eventRepo:       eventRepo,
 		userRepo:        userRepo,
+		paymentRepo:     paymentRepo,
+		historyRepo:     historyRepo,
+		ticketRepo:      ticketRepo,
+		ticketTypeRepo:  ticketTypeRepo,
+		itemRepo:        itemRepo,
+		promoRepo:       promoRepo,
+		pricingRepo:     pricingRepo,
+		waitlistRepo:    waitlistRepo,
+		seatHoldRepo:    seatHoldRepo,
+		txManager:       txManager,
+		paymentGateway:  paymentGateway,
+		blobStorage:     blobStorage,
+		virusScanner:    virusScanner,
+		paymentDeadline: time.Duration(deadline) * time.Minute,
+		maxRejections:   rejections,
+		maxProofSize:    proofSize * 1024,
+		proofURLTTL:     time.Duration(urlTTL) * time.Minute,
+		platformFee:     platformFee,
+		smtpConfig:      smtpConfig,
 	}
 }
 
@@ -87,8 +245,50 @@ func (u *transactionUsecase) CreateTransaction(ctx context.Context, userID int,
 		return nil, errors.New("event tidak aktif")
 	}
 
-	if event.TicketsSold + req.Quantity > event.MaxCapacity {
-		return nil, errors.New("jumlah tiket yang diminta melebihi kapasitas")
+	now := time.Now()
+
+	items, err := u.buildTransactionItems(ctx, event, req.Items, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) > 0 {
+		req.Quantity = 0
+		for _, item := range items {
+			req.Quantity += item.Quantity
+		}
+	}
+
+	// Event dengan denah venue menjual kursi bernomor, jumlah tiket mengikuti kursi yang dipilih
+	if event.VenueID != 0 {
+		if err := validateSeatSelection(req.SeatIDs, len(items) > 0, req.Quantity); err != nil {
+			return nil, err
+		}
+		req.Quantity = len(req.SeatIDs)
+	} else if len(req.SeatIDs) > 0 {
+		return nil, errors.New("event tidak memiliki kursi bernomor")
+	}
+
+	// Kursi penawaran waitlist sudah dipesan saat penawaran dibuat. Pembelian biasa dianggap
+	// habis selama masih ada antrean supaya kursi yang dilepas tidak mendahului antrean.
+	var waitlistEntry *entity.WaitlistEntry
+	if req.WaitlistEntryID != 0 {
+		waitlistEntry, err = u.findClaimableWaitlistEntry(ctx, userID, req, now)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if event.TicketsSold + req.Quantity > event.MaxCapacity {
+			return nil, errors.New("jumlah tiket yang diminta melebihi kapasitas")
+		}
+
+		hasWaiting, err := u.waitlistRepo.HasWaiting(ctx, event.ID)
+		if err != nil {
+			return nil, err
+		}
+		if hasWaiting {
+			return nil, errors.New("jumlah tiket yang diminta melebihi kapasitas")
+		}
 	}
 
 	if req.Quantity <= 0 {
@@ -103,15 +303,49 @@ func (u *transactionUsecase) CreateTransaction(ctx context.Context, userID int,
 		"bank_transfer": true,
 		"qris":          true,
 		"ewallet":       true,
+		"midtrans":      true,
 	}
 
 	if !validPaymentMethods[req.PaymentMethod] {
 		return nil, errors.New("metode pembayaran tidak valid")
 	}
 
-	totalAmount := float64(req.Quantity) * event.Price
+	if req.PaymentMethod == "midtrans" && event.Price.CurrencyCode() != "IDR" {
+		return nil, errors.New("pembayaran midtrans hanya mendukung mata uang IDR")
+	}
 
-	transactionCode := fmt.Sprintf("TRX-%s-%d", time.Now().Format("20060102"), utils.GenerateRandomNumber(6))
+	// Total dihitung setelah jumlah tiket divalidasi sehingga perkalian harga tidak pernah
+	// memakai jumlah yang melebihi kapasitas event
+	subtotal := event.Price.Mul(int64(req.Quantity))
+	if len(items) > 0 {
+		subtotal = entity.NewMoney(0, event.Price.CurrencyCode())
+		for _, item := range items {
+			subtotal = subtotal.Add(item.Subtotal)
+		}
+	}
+
+	var promo *entity.PromoCode
+	discountAmount := entity.NewMoney(0, subtotal.CurrencyCode())
+	if req.PromoCode != "" {
+		promo, err = u.findApplicablePromo(ctx, event, req.PromoCode, req.Quantity, now)
+		if err != nil {
+			return nil, err
+		}
+		discountAmount = promo.Discount(subtotal)
+	}
+
+	// Aturan khusus event menggantikan aturan default organizer
+	rule, err := u.pricingRepo.FindApplicable(ctx, event.OwnerID, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !rule.AppliesTo(subtotal.CurrencyCode()) {
+		return nil, errors.New("mata uang biaya layanan tidak sesuai dengan mata uang event")
+	}
+
+	pricing := entity.CalculatePrice(subtotal, discountAmount, req.Quantity, rule, u.platformFee)
+
+	transactionCode := fmt.Sprintf("TRX-%s-%s", time.Now().Format("20060102"), utils.GenerateRandomNumber(6))
 
 	var paymentDetail string
 	switch req.PaymentMethod {
@@ -121,6 +355,8 @@ func (u *transactionUsecase) CreateTransaction(ctx context.Context, userID int,
 		paymentDetail = "Silakan scan QRIS yang tersedia"
 	case "ewallet":
 		paymentDetail = "Silakan bayar melalui e-wallet yang terdaftar"
+	case "midtrans":
+		paymentDetail = "Silakan selesaikan pembayaran melalui halaman Midtrans"
 	}
 
 	transaction := &entity.Transaction{
@@ -128,58 +364,419 @@ func (u *transactionUsecase) CreateTransaction(ctx context.Context, userID int,
 		EventID:         req.EventID,
 		TransactionCode: transactionCode,
 		Quantity:        req.Quantity,
-		TotalAmount:     totalAmount,
-		Status:          "pending",
+		TotalAmount:     pricing.Total,
+		Status:          entity.TransactionStatusPending,
 		PaymentMethod:   req.PaymentMethod,
 		PaymentDetail:   paymentDetail,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		ExpiresAt:       now.Add(u.paymentDeadline),
+		DiscountAmount:  pricing.Discount,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+
+		SubtotalAmount:     pricing.Subtotal,
+		ServiceFeeAmount:   pricing.ServiceFee,
+		PlatformFeeAmount:  pricing.PlatformFee,
+		PlatformFeeToBuyer: pricing.PlatformFeeToBuyer,
+		TaxAmount:          pricing.Tax,
+	}
+	if promo != nil {
+		transaction.PromoCodeID = promo.ID
 	}
 
-	transactionID, err := u.transactionRepo.Create(ctx, transaction)
+	// Pengecekan kapasitas di atas hanya untuk gagal lebih cepat, keputusan akhir
+	// ada di reservasi atomik karena pembeli lain bisa membeli di saat yang sama
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if waitlistEntry != nil {
+			transactionID, err := u.transactionRepo.Create(ctx, transaction)
+			if err != nil {
+				return err
+			}
+			transaction.ID = transactionID
+
+			// Klaim gagal jika penawaran kedaluwarsa atau dibatalkan setelah pengecekan awal
+			if err := u.waitlistRepo.Claim(ctx, waitlistEntry.ID, transaction.ID, now); err != nil {
+				if errors.Is(err, repository.ErrStatusConflict) {
+					return errors.New("penawaran waitlist tidak ditemukan atau sudah tidak berlaku")
+				}
+				return err
+			}
+		} else {
+			transactionID, err := u.transactionRepo.CreateWithReservation(ctx, transaction)
+			if err != nil {
+				return err
+			}
+			transaction.ID = transactionID
+		}
+
+		if len(items) > 0 {
+			for _, item := range items {
+				if err := u.ticketTypeRepo.Reserve(ctx, item.TicketTypeID, item.Quantity); err != nil {
+					return err
+				}
+				item.TransactionID = transaction.ID
+			}
+
+			if err := u.itemRepo.CreateBatch(ctx, items); err != nil {
+				return err
+			}
+		}
+
+		// Hold yang kedaluwarsa bisa sudah diambil pembeli lain, jadi kepemilikan kursi diputuskan
+		// di sini. Kursi lain yang masih di-hold pembeli tapi tidak dibeli langsung dilepas.
+		if len(req.SeatIDs) > 0 {
+			if err := u.seatHoldRepo.Book(ctx, event.ID, userID, transaction.ID, req.SeatIDs, now); err != nil {
+				return err
+			}
+			if _, err := u.seatHoldRepo.ReleaseByUser(ctx, event.ID, userID); err != nil {
+				return err
+			}
+		}
+
+		// Batas pemakaian kode diputuskan di sini, bukan di pengecekan awal, karena pembeli lain
+		// bisa memakai kode yang sama di saat bersamaan
+		if promo != nil {
+			err := u.promoRepo.Redeem(ctx, &entity.PromoRedemption{
+				PromoCodeID:    promo.ID,
+				TransactionID:  transaction.ID,
+				UserID:         userID,
+				DiscountAmount: discountAmount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return recordStatusHistory(ctx, u.historyRepo, transaction.ID, "", entity.TransactionStatusPending, statusActor{
+			Type: entity.StatusActorUser,
+			ID:   userID,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = u.eventRepo.UpdateTicketsSold(ctx, req.EventID, req.Quantity)
+	response := toTransactionResponse(transaction, event.Title)
+	if promo != nil {
+		response.PromoCode = promo.Code
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, toTransactionItemResponse(item))
+	}
+
+	if transaction.PaymentMethod == "midtrans" {
+		payment, err := u.createMidtransPayment(ctx, transaction, event, user, items, promo)
+		if err != nil {
+			return nil, err
+		}
+		response.SnapToken = payment.SnapToken
+		response.RedirectURL = payment.RedirectURL
+	}
+
+	return response, nil
+}
+
+// validateSeatSelection memastikan kursi dipilih tanpa duplikat. Jika pembeli juga memilih tipe
+// tiket, jumlah tiket per tipe harus sama dengan jumlah kursi.
+func validateSeatSelection(seatIDs []int, hasItems bool, itemQuantity int) error {
+	if len(seatIDs) == 0 {
+		return errors.New("kursi harus dipilih untuk event dengan denah venue")
+	}
+
+	seen := make(map[int]bool, len(seatIDs))
+	for _, seatID := range seatIDs {
+		if seen[seatID] {
+			return errors.New("kursi yang dipilih tidak boleh duplikat")
+		}
+		seen[seatID] = true
+	}
+
+	if hasItems && itemQuantity != len(seatIDs) {
+		return errors.New("jumlah tiket harus sama dengan jumlah kursi yang dipilih")
+	}
+
+	return nil
+}
+
+// findClaimableWaitlistEntry memastikan penawaran waitlist milik pembeli, untuk event yang sama,
+// masih berlaku, dan jumlah tiketnya sama dengan kursi yang sudah dipesan
+func (u *transactionUsecase) findClaimableWaitlistEntry(ctx context.Context, userID int, req CreateTransactionRequest, now time.Time) (*entity.WaitlistEntry, error) {
+	entry, err := u.waitlistRepo.FindByID(ctx, req.WaitlistEntryID)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &TransactionResponse{
-		ID:              transactionID,
-		TransactionCode: transactionCode,
-		EventID:         event.ID,
-		EventTitle:      event.Title,
-		Quantity:        req.Quantity,
-		TotalAmount:     totalAmount,
-		Status:          "pending",
-		PaymentMethod:   req.PaymentMethod,
-		PaymentDetail:   paymentDetail,
-		CreatedAt:       transaction.CreatedAt,
+	if entry == nil || entry.UserID != userID || entry.EventID != req.EventID ||
+		entry.Status != entity.WaitlistStatusOffered || !entry.OfferExpiresAt.After(now) {
+		return nil, errors.New("penawaran waitlist tidak ditemukan atau sudah tidak berlaku")
 	}
 
-	return response, nil
+	if req.Quantity != entry.Quantity {
+		return nil, errors.New("jumlah tiket harus sama dengan jumlah pada penawaran waitlist")
+	}
+
+	return entry, nil
 }
 
-func (u *transactionUsecase) GetTransactionByID(ctx context.Context, userID int, transactionID int) (*TransactionResponse, error) {
-	transaction, err := u.transactionRepo.FindByID(ctx, transactionID)
+// buildTransactionItems memvalidasi pilihan tipe tiket dan menyalin harga saat ini ke item transaksi.
+// Event tanpa tipe tiket tetap memakai Quantity dan Price event seperti sebelumnya.
+func (u *transactionUsecase) buildTransactionItems(ctx context.Context, event *entity.Event, requested []TransactionItemRequest, now time.Time) ([]*entity.TransactionItem, error) {
+	ticketTypes, err := u.ticketTypeRepo.FindByEventID(ctx, event.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if transaction == nil {
-		return nil, errors.New("transaksi tidak ditemukan")
+	if len(requested) == 0 {
+		if len(ticketTypes) > 0 {
+			return nil, errors.New("tipe tiket harus dipilih")
+		}
+		return nil, nil
 	}
 
-	if transaction.UserID != userID {
-		user, err := u.userRepo.FindByID(ctx, userID)
-		if err != nil {
-			return nil, err
+	typesByID := make(map[int]entity.TicketType, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		typesByID[ticketType.ID] = ticketType
+	}
+
+	// Tipe yang sama bisa dikirim lebih dari sekali, jumlahnya digabung supaya batas per transaksi tetap berlaku
+	quantities := make(map[int]int)
+	order := make([]int, 0, len(requested))
+	for _, item := range requested {
+		if item.Quantity <= 0 {
+			return nil, errors.New("jumlah tiket harus lebih dari 0")
+		}
+		if _, ok := typesByID[item.TicketTypeID]; !ok {
+			return nil, errors.New("tipe tiket tidak ditemukan")
+		}
+		if _, seen := quantities[item.TicketTypeID]; !seen {
+			order = append(order, item.TicketTypeID)
+		}
+		quantities[item.TicketTypeID] += item.Quantity
+	}
+
+	items := make([]*entity.TransactionItem, 0, len(order))
+	for _, ticketTypeID := range order {
+		ticketType := typesByID[ticketTypeID]
+		quantity := quantities[ticketTypeID]
+
+		if !ticketType.OnSale(now) {
+			return nil, errors.New("tipe tiket tidak sedang dijual")
+		}
+
+		if quantity > ticketType.MaxPerOrder {
+			return nil, errors.New("jumlah tiket melebihi batas pembelian per transaksi")
+		}
+
+		if ticketType.Sold+quantity > ticketType.Quota {
+			return nil, repository.ErrTicketTypeSoldOut
+		}
+
+		items = append(items, &entity.TransactionItem{
+			TicketTypeID:   ticketType.ID,
+			TicketTypeName: ticketType.Name,
+			Quantity:       quantity,
+			UnitPrice:      ticketType.Price,
+			Subtotal:       ticketType.Price.Mul(int64(quantity)),
+		})
+	}
+
+	return items, nil
+}
+
+// findApplicablePromo mencari kode promo yang berlaku untuk event dan memeriksa syaratnya.
+// Batas total dan per user hanya dicek sekilas di sini, keputusan akhirnya ada di Redeem.
+func (u *transactionUsecase) findApplicablePromo(ctx context.Context, event *entity.Event, code string, quantity int, now time.Time) (*entity.PromoCode, error) {
+	promo, err := u.promoRepo.FindApplicable(ctx, strings.TrimSpace(code), event.ID, event.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Potongan tetap dalam mata uang lain tidak bisa dikurangkan dari total transaksi
+	if promo == nil || !promo.IsActive || !promo.AppliesTo(event.Price.CurrencyCode()) {
+		return nil, errors.New("kode promo tidak valid")
+	}
+
+	if !promo.ValidAt(now) {
+		return nil, errors.New("kode promo tidak berlaku saat ini")
+	}
+
+	if quantity < promo.MinQuantity {
+		return nil, errors.New("jumlah tiket belum memenuhi minimum kode promo")
+	}
+
+	if promo.MaxUses > 0 && promo.UsedCount >= promo.MaxUses {
+		return nil, repository.ErrPromoCodeExhausted
+	}
+
+	return promo, nil
+}
+
+// createMidtransPayment membuat sesi Snap untuk transaksi yang kursinya sudah direservasi.
+// Jika gateway gagal, transaksi ditandai failed dan kursinya dikembalikan supaya tidak
+// tertahan sampai batas pembayaran habis.
+// Transaksi Midtrans selalu dalam IDR, jadi Amount (rupiah penuh) bisa dikirim apa adanya.
+func (u *transactionUsecase) createMidtransPayment(ctx context.Context, transaction *entity.Transaction, event *entity.Event, user *entity.User, items []*entity.TransactionItem, promo *entity.PromoCode) (*entity.Payment, error) {
+	paymentItems := []gateway.PaymentItem{
+		{
+			ID:       strconv.Itoa(event.ID),
+			Name:     event.Title,
+			Price:    event.Price.Amount,
+			Quantity: transaction.Quantity,
+		},
+	}
+	if len(items) > 0 {
+		paymentItems = make([]gateway.PaymentItem, 0, len(items))
+		for _, item := range items {
+			paymentItems = append(paymentItems, gateway.PaymentItem{
+				ID:       fmt.Sprintf("%d-%d", event.ID, item.TicketTypeID),
+				Name:     fmt.Sprintf("%s - %s", event.Title, item.TicketTypeName),
+				Price:    item.UnitPrice.Amount,
+				Quantity: item.Quantity,
+			})
 		}
-		if user == nil || user.Role != "organizer" {
-			return nil, errors.New("anda tidak memiliki izin untuk melihat transaksi ini")
+	}
+
+	// Midtrans mewajibkan jumlah rincian item sama dengan gross_amount, jadi diskon dikirim
+	// sebagai item bernilai negatif
+	if promo != nil && transaction.DiscountAmount.IsPositive() {
+		paymentItems = append(paymentItems, gateway.PaymentItem{
+			ID:       "PROMO-" + promo.Code,
+			Name:     "Diskon " + promo.Code,
+			Price:    -transaction.DiscountAmount.Amount,
+			Quantity: 1,
+		})
+	}
+
+	fees := []struct {
+		id, name string
+		amount   entity.Money
+	}{
+		{"SERVICE-FEE", "Biaya Layanan", transaction.ServiceFeeAmount},
+		{"PLATFORM-FEE", "Biaya Platform", transaction.PlatformFeeAmount},
+		{"TAX", "PPN", transaction.TaxAmount},
+	}
+	for _, fee := range fees {
+		if !fee.amount.IsPositive() || (fee.id == "PLATFORM-FEE" && !transaction.PlatformFeeToBuyer) {
+			continue
+		}
+		paymentItems = append(paymentItems, gateway.PaymentItem{
+			ID:       fee.id,
+			Name:     fee.name,
+			Price:    fee.amount.Amount,
+			Quantity: 1,
+		})
+	}
+
+	session, err := u.paymentGateway.CreatePayment(ctx, gateway.PaymentRequest{
+		OrderID:     transaction.TransactionCode,
+		GrossAmount: transaction.TotalAmount.Amount,
+		Items:       paymentItems,
+		Customer: gateway.PaymentCustomer{
+			FirstName: user.Username,
+			Email:     user.Email,
+		},
+		ExpiryMinutes: int(u.paymentDeadline / time.Minute),
+	})
+	if err != nil {
+		log.Printf("Gagal membuat pembayaran Midtrans untuk transaksi %s: %v", transaction.TransactionCode, err)
+
+		releaseErr := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			err := changeTransactionStatus(ctx, u.transactionRepo, u.historyRepo, transaction, entity.TransactionStatusFailed, statusActor{
+				Type:   entity.StatusActorSystem,
+				Reason: "gagal membuat pembayaran di payment gateway",
+			})
+			if err != nil {
+				return err
+			}
+
+			return releaseReservation(ctx, u.eventRepo, u.ticketTypeRepo, u.itemRepo, u.promoRepo, u.seatHoldRepo, transaction)
+		})
+		if releaseErr != nil {
+			log.Printf("Gagal mengembalikan kursi transaksi %s: %v", transaction.TransactionCode, releaseErr)
 		}
+
+		return nil, errors.New("gagal membuat pembayaran, silakan coba lagi")
+	}
+
+	payment := &entity.Payment{
+		TransactionID: transaction.ID,
+		PaymentType:   "snap",
+		Amount:        transaction.TotalAmount,
+		Status:        "pending",
+		ExpiryTime:    transaction.ExpiresAt,
+		SnapToken:     session.Token,
+		RedirectURL:   session.RedirectURL,
+	}
+
+	paymentID, err := u.paymentRepo.Create(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+	payment.ID = paymentID
+
+	return payment, nil
+}
+
+// attachPayment melengkapi response dengan token dan URL pembayaran Midtrans yang tersimpan
+func (u *transactionUsecase) attachPayment(ctx context.Context, response *TransactionResponse) error {
+	if response.PaymentMethod != "midtrans" {
+		return nil
+	}
+
+	payment, err := u.paymentRepo.FindByTransactionID(ctx, response.ID)
+	if err != nil {
+		return err
+	}
+	if payment != nil {
+		response.SnapToken = payment.SnapToken
+		response.RedirectURL = payment.RedirectURL
+	}
+
+	return nil
+}
+
+// attachItems melengkapi response dengan rincian tipe tiket yang dibeli
+func (u *transactionUsecase) attachItems(ctx context.Context, response *TransactionResponse) error {
+	items, err := u.itemRepo.FindByTransactionID(ctx, response.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		response.Items = append(response.Items, toTransactionItemResponse(&items[i]))
+	}
+
+	return nil
+}
+
+// attachProofURL membuat tautan unduhan sementara untuk bukti pembayaran yang disimpan di storage
+func (u *transactionUsecase) attachProofURL(ctx context.Context, response *TransactionResponse) error {
+	if response.PaymentProof == "" {
+		return nil
+	}
+
+	// Transaksi lama masih menyimpan URL yang ditempel langsung oleh pembeli
+	if strings.HasPrefix(response.PaymentProof, "http://") || strings.HasPrefix(response.PaymentProof, "https://") {
+		response.PaymentProofURL = response.PaymentProof
+		return nil
+	}
+
+	proofURL, err := u.blobStorage.SignedURL(ctx, response.PaymentProof, u.proofURLTTL)
+	if err != nil {
+		return err
+	}
+	response.PaymentProofURL = proofURL
+
+	return nil
+}
+
+func (u *transactionUsecase) GetTransactionByID(ctx context.Context, userID int, transactionID int) (*TransactionResponse, error) {
+	transaction, err := u.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction == nil {
+		return nil, errors.New("transaksi tidak ditemukan")
 	}
 
 	event, err := u.eventRepo.FindByID(ctx, transaction.EventID)
@@ -190,18 +787,39 @@ func (u *transactionUsecase) GetTransactionByID(ctx context.Context, userID int,
 		return nil, errors.New("event terkait tidak ditemukan")
 	}
 
-	response := &TransactionResponse{
-		ID:              transaction.ID,
-		TransactionCode: transaction.TransactionCode,
-		EventID:         event.ID,
-		EventTitle:      event.Title,
-		Quantity:        transaction.Quantity,
-		TotalAmount:     transaction.TotalAmount,
-		Status:          transaction.Status,
-		PaymentMethod:   transaction.PaymentMethod,
-		PaymentDetail:   transaction.PaymentDetail,
-		PaymentProof:    transaction.PaymentProof,
-		CreatedAt:       transaction.CreatedAt,
+	// Selain pembeli, hanya organizer pemilik event yang boleh melihat transaksi
+	if transaction.UserID != userID && event.OwnerID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk melihat transaksi ini")
+	}
+
+	response := toTransactionResponse(transaction, event.Title)
+	if err := u.attachPayment(ctx, response); err != nil {
+		return nil, err
+	}
+
+	if err := u.attachItems(ctx, response); err != nil {
+		return nil, err
+	}
+
+	if event.OwnerID == userID {
+		if err := u.attachProofURL(ctx, response); err != nil {
+			return nil, err
+		}
+	}
+
+	histories, err := u.historyRepo.FindByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, history := range histories {
+		response.StatusHistory = append(response.StatusHistory, StatusHistoryResponse{
+			FromStatus: string(history.FromStatus),
+			ToStatus:   string(history.ToStatus),
+			ActorType:  history.ActorType,
+			ActorID:    history.ActorID,
+			Reason:     history.Reason,
+			CreatedAt:  history.CreatedAt,
+		})
 	}
 
 	return response, nil
@@ -217,16 +835,6 @@ func (u *transactionUsecase) GetTransactionByCode(ctx context.Context, userID in
 		return nil, errors.New("transaksi tidak ditemukan")
 	}
 
-	if transaction.UserID != userID {
-		user, err := u.userRepo.FindByID(ctx, userID)
-		if err != nil {
-			return nil, err
-		}
-		if user == nil || user.Role != "organizer" {
-			return nil, errors.New("anda tidak memiliki izin untuk melihat transaksi ini")
-		}
-	}
-
 	event, err := u.eventRepo.FindByID(ctx, transaction.EventID)
 	if err != nil {
 		return nil, err
@@ -235,18 +843,24 @@ func (u *transactionUsecase) GetTransactionByCode(ctx context.Context, userID in
 		return nil, errors.New("event terkait tidak ditemukan")
 	}
 
-	response := &TransactionResponse{
-		ID:              transaction.ID,
-		TransactionCode: transaction.TransactionCode,
-		EventID:         event.ID,
-		EventTitle:      event.Title,
-		Quantity:        transaction.Quantity,
-		TotalAmount:     transaction.TotalAmount,
-		Status:          transaction.Status,
-		PaymentMethod:   transaction.PaymentMethod,
-		PaymentDetail:   transaction.PaymentDetail,
-		PaymentProof:    transaction.PaymentProof,
-		CreatedAt:       transaction.CreatedAt,
+	// Selain pembeli, hanya organizer pemilik event yang boleh melihat transaksi
+	if transaction.UserID != userID && event.OwnerID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk melihat transaksi ini")
+	}
+
+	response := toTransactionResponse(transaction, event.Title)
+	if err := u.attachPayment(ctx, response); err != nil {
+		return nil, err
+	}
+
+	if err := u.attachItems(ctx, response); err != nil {
+		return nil, err
+	}
+
+	if event.OwnerID == userID {
+		if err := u.attachProofURL(ctx, response); err != nil {
+			return nil, err
+		}
 	}
 
 	return response, nil
@@ -276,21 +890,12 @@ func (u *transactionUsecase) GetUserTransactions(ctx context.Context, userID, pa
 			eventTitle = event.Title
 		}
 
-		response := TransactionResponse{
-			ID:              transaction.ID,
-			TransactionCode: transaction.TransactionCode,
-			EventID:         transaction.EventID,
-			EventTitle:      eventTitle,
-			Quantity:        transaction.Quantity,
-			TotalAmount:     transaction.TotalAmount,
-			Status:          transaction.Status,
-			PaymentMethod:   transaction.PaymentMethod,
-			PaymentDetail:   transaction.PaymentDetail,
-			PaymentProof:    transaction.PaymentProof,
-			CreatedAt:       transaction.CreatedAt,
+		response := toTransactionResponse(&transaction, eventTitle)
+		if err := u.attachPayment(ctx, response); err != nil {
+			return nil, 0, err
 		}
 
-		responses = append(responses, response)
+		responses = append(responses, *response)
 	}
 
 	return responses, total, nil
@@ -315,20 +920,56 @@ func (u *transactionUsecase) UploadPaymentProof(ctx context.Context, userID int,
 		return errors.New("anda tidak memiliki izin untuk transaksi ini")
 	}
 
-	if transaction.Status != "pending" {
+	if !transaction.Status.CanTransitionTo(entity.TransactionStatusWaitingVerification) {
 		return errors.New("bukti pembayaran hanya dapat diunggah untuk transaksi dengan status pending")
 	}
 
-	if req.ProofURL == "" {
-		return errors.New("URL bukti pembayaran tidak boleh kosong")
+	if len(req.Content) == 0 {
+		return errors.New("file bukti pembayaran tidak boleh kosong")
+	}
+
+	if len(req.Content) > u.maxProofSize {
+		return errors.New("ukuran file bukti pembayaran melebihi batas")
+	}
+
+	// Tipe file ditentukan dari isinya, bukan dari nama file atau header yang dikirim klien
+	contentType := http.DetectContentType(req.Content)
+	extension, ok := proofContentTypes[contentType]
+	if !ok {
+		return errors.New("tipe file bukti pembayaran harus JPG, PNG, atau PDF")
+	}
+
+	if err := u.virusScanner.Scan(ctx, req.Content); err != nil {
+		if errors.Is(err, storage.ErrInfectedFile) {
+			return errors.New("file bukti pembayaran terdeteksi mengandung virus")
+		}
+		return err
 	}
 
-	err = u.transactionRepo.UpdatePaymentProof(ctx, transactionID, req.ProofURL)
+	key := fmt.Sprintf("payment-proofs/%d/%d%s", transaction.ID, time.Now().UnixNano(), extension)
+	if err := u.blobStorage.Put(ctx, key, req.Content, contentType); err != nil {
+		return err
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.transactionRepo.UpdatePaymentProof(ctx, transactionID, key); err != nil {
+			return err
+		}
+
+		return changeTransactionStatus(ctx, u.transactionRepo, u.historyRepo, transaction, entity.TransactionStatusWaitingVerification, statusActor{
+			Type: entity.StatusActorUser,
+			ID:   userID,
+		})
+	})
 	if err != nil {
+		// File yang tidak jadi tercatat di transaksi tidak perlu disimpan
+		if deleteErr := u.blobStorage.Delete(ctx, key); deleteErr != nil {
+			log.Printf("Gagal menghapus bukti pembayaran %s: %v", key, deleteErr)
+		}
 		return err
 	}
 
-	return u.transactionRepo.UpdateStatus(ctx, transactionID, "waiting_verification")
+	return nil
 }
 
 func (u *transactionUsecase) CancelTransaction(ctx context.Context, userID int, transactionID int) error {
@@ -345,16 +986,22 @@ func (u *transactionUsecase) CancelTransaction(ctx context.Context, userID int,
 		return errors.New("anda tidak memiliki izin untuk transaksi ini")
 	}
 
-	if transaction.Status != "pending" {
+	if !transaction.Status.CanTransitionTo(entity.TransactionStatusCancelled) {
 		return errors.New("hanya transaksi dengan status pending yang dapat dibatalkan")
 	}
 
-	err = u.transactionRepo.UpdateStatus(ctx, transactionID, "cancelled")
-	if err != nil {
-		return err
-	}
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := changeTransactionStatus(ctx, u.transactionRepo, u.historyRepo, transaction, entity.TransactionStatusCancelled, statusActor{
+			Type:   entity.StatusActorUser,
+			ID:     userID,
+			Reason: "dibatalkan oleh pembeli",
+		})
+		if err != nil {
+			return err
+		}
 
-	return u.eventRepo.UpdateTicketsSold(ctx, transaction.EventID, -transaction.Quantity)
+		return releaseReservation(ctx, u.eventRepo, u.ticketTypeRepo, u.itemRepo, u.promoRepo, u.seatHoldRepo, transaction)
+	})
 }
 
 func (u *transactionUsecase) VerifyPayment(ctx context.Context, organizerID int, transactionID int) error {
@@ -376,9 +1023,245 @@ func (u *transactionUsecase) VerifyPayment(ctx context.Context, organizerID int,
 		return errors.New("transaksi tidak ditemukan")
 	}
 
-	if transaction.Status != "waiting_verification" {
+	event, err := u.eventRepo.FindByID(ctx, transaction.EventID)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return errors.New("event terkait tidak ditemukan")
+	}
+	if event.OwnerID != organizerID {
+		return errors.New("anda tidak memiliki izin untuk memverifikasi pembayaran transaksi ini")
+	}
+
+	if transaction.Status != entity.TransactionStatusWaitingVerification {
 		return errors.New("hanya transaksi dengan status menunggu verifikasi yang dapat diverifikasi")
 	}
 
-	return u.transactionRepo.VerifyPayment(ctx, transactionID, organizerID)
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.transactionRepo.VerifyPayment(ctx, transactionID, organizerID); err != nil {
+			return err
+		}
+
+		err := recordStatusHistory(ctx, u.historyRepo, transactionID, transaction.Status, entity.TransactionStatusPaid, statusActor{
+			Type:   entity.StatusActorOrganizer,
+			ID:     organizerID,
+			Reason: "bukti pembayaran diverifikasi",
+		})
+		if err != nil {
+			return err
+		}
+
+		return issueTickets(ctx, u.ticketRepo, u.itemRepo, u.seatHoldRepo, transaction)
+	})
+}
+
+// RejectPayment menolak bukti pembayaran. Transaksi dikembalikan ke pending agar pembeli bisa
+// mengunggah bukti baru, kecuali sudah ditolak sebanyak maxRejections kali sehingga menjadi
+// rejected dan kursinya dikembalikan ke event.
+func (u *transactionUsecase) RejectPayment(ctx context.Context, organizerID int, transactionID int, req RejectPaymentRequest) error {
+	organizer, err := u.userRepo.FindByID(ctx, organizerID)
+	if err != nil {
+		return err
+	}
+
+	if organizer == nil || organizer.Role != "organizer" {
+		return errors.New("hanya organizer yang dapat menolak pembayaran")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return errors.New("alasan penolakan harus diisi")
+	}
+
+	transaction, err := u.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if transaction == nil {
+		return errors.New("transaksi tidak ditemukan")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, transaction.EventID)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return errors.New("event terkait tidak ditemukan")
+	}
+	if event.OwnerID != organizerID {
+		return errors.New("anda tidak memiliki izin untuk menolak pembayaran transaksi ini")
+	}
+
+	if transaction.Status != entity.TransactionStatusWaitingVerification {
+		return errors.New("hanya transaksi dengan status menunggu verifikasi yang dapat ditolak")
+	}
+
+	buyer, err := u.userRepo.FindByID(ctx, transaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	var remaining int
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		rejections, err := u.historyRepo.CountByTransition(ctx, transaction.ID, entity.TransactionStatusWaitingVerification, entity.TransactionStatusPending)
+		if err != nil {
+			return err
+		}
+
+		remaining = u.maxRejections - rejections - 1
+		target := entity.TransactionStatusPending
+		if remaining <= 0 {
+			target = entity.TransactionStatusRejected
+		}
+
+		err = changeTransactionStatus(ctx, u.transactionRepo, u.historyRepo, transaction, target, statusActor{
+			Type:   entity.StatusActorOrganizer,
+			ID:     organizerID,
+			Reason: reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if target == entity.TransactionStatusRejected {
+			return releaseReservation(ctx, u.eventRepo, u.ticketTypeRepo, u.itemRepo, u.promoRepo, u.seatHoldRepo, transaction)
+		}
+
+		// Batas pembayaran dihitung ulang supaya sweeper tidak langsung meng-expire transaksi
+		// yang sudah lama menunggu verifikasi
+		transaction.ExpiresAt = time.Now().Add(u.paymentDeadline)
+		return u.transactionRepo.UpdateExpiresAt(ctx, transaction.ID, transaction.ExpiresAt)
+	})
+	if err != nil {
+		return err
+	}
+
+	if buyer != nil {
+		go u.sendPaymentRejectedEmail(buyer.Username, buyer.Email, transaction, reason, remaining)
+	}
+
+	return nil
+}
+
+// ExpirePendingTransactions mengubah transaksi pending yang melewati batas pembayaran menjadi
+// expired dan mengembalikan kursinya ke event. Aman dijalankan dari beberapa instance sekaligus.
+func (u *transactionUsecase) ExpirePendingTransactions(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		var expired []entity.Transaction
+		err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			expired, err = u.transactionRepo.ExpireOverdue(ctx, time.Now(), expiryBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, transaction := range expired {
+				if err := releaseReservation(ctx, u.eventRepo, u.ticketTypeRepo, u.itemRepo, u.promoRepo, u.seatHoldRepo, &transaction); err != nil {
+					return err
+				}
+
+				err := recordStatusHistory(ctx, u.historyRepo, transaction.ID, entity.TransactionStatusPending, entity.TransactionStatusExpired, statusActor{
+					Type:   entity.StatusActorSystem,
+					Reason: "batas waktu pembayaran habis",
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		for _, transaction := range expired {
+			log.Printf("Transaksi %s kedaluwarsa, %d kursi dikembalikan ke event %d", transaction.TransactionCode, transaction.Quantity, transaction.EventID)
+		}
+
+		total += len(expired)
+		if len(expired) < expiryBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (u *transactionUsecase) sendPaymentRejectedEmail(username, email string, transaction *entity.Transaction, reason string, remaining int) {
+	templateData := map[string]interface{}{
+		"Username":        username,
+		"TransactionCode": transaction.TransactionCode,
+		"Reason":          reason,
+		"CanResubmit":     transaction.Status == entity.TransactionStatusPending,
+		"Remaining":       remaining,
+		"ExpiresAt":       transaction.ExpiresAt.Format("02 Jan 2006 15:04"),
+		"Year":            time.Now().Year(),
+	}
+
+	body, err := utils.ParseTemplate("templates/email/payment_rejected.html", templateData)
+	if err != nil {
+		log.Printf("Gagal parse template email: %v", err)
+		return
+	}
+
+	emailData := utils.EmailData{
+		To:      []string{email},
+		Subject: "Pembayaran Ditolak - " + transaction.TransactionCode,
+		Body:    body,
+	}
+
+	if err := utils.SendEmail(u.smtpConfig, emailData); err != nil {
+		log.Printf("Gagal mengirim email penolakan pembayaran: %v", err)
+	} else {
+		log.Printf("Email penolakan pembayaran berhasil dikirim ke: %s", email)
+	}
+}
+
+func toTransactionResponse(transaction *entity.Transaction, eventTitle string) *TransactionResponse {
+	response := &TransactionResponse{
+		ID:              transaction.ID,
+		TransactionCode: transaction.TransactionCode,
+		EventID:         transaction.EventID,
+		EventTitle:      eventTitle,
+		Quantity:        transaction.Quantity,
+		TotalAmount:     transaction.TotalAmount,
+		Status:          string(transaction.Status),
+		PaymentMethod:   transaction.PaymentMethod,
+		PaymentDetail:   transaction.PaymentDetail,
+		PaymentProof:    transaction.PaymentProof,
+		ExpiresAt:       transaction.ExpiresAt,
+		CreatedAt:       transaction.CreatedAt,
+	}
+
+	if transaction.DiscountAmount.IsPositive() {
+		discount := transaction.DiscountAmount
+		response.DiscountAmount = &discount
+	}
+
+	response.Pricing = PriceBreakdownResponse{
+		Subtotal:   transaction.SubtotalAmount,
+		Discount:   transaction.DiscountAmount,
+		ServiceFee: transaction.ServiceFeeAmount,
+		Tax:        transaction.TaxAmount,
+		Total:      transaction.TotalAmount,
+	}
+	if transaction.PlatformFeeToBuyer {
+		platformFee := transaction.PlatformFeeAmount
+		response.Pricing.PlatformFee = &platformFee
+	}
+
+	return response
+}
+
+func toTransactionItemResponse(item *entity.TransactionItem) TransactionItemResponse {
+	return TransactionItemResponse{
+		TicketTypeID:   item.TicketTypeID,
+		TicketTypeName: item.TicketTypeName,
+		Quantity:       item.Quantity,
+		UnitPrice:      item.UnitPrice,
+		Subtotal:       item.Subtotal,
+	}
 }
\ No newline at end of file
@@ -0,0 +1,414 @@
+//internal/usecase/user_mfa_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/pkg/utils"
+)
+
+const (
+	// mfaIssuer tampil sebagai nama akun di aplikasi authenticator
+	mfaIssuer         = "Sistem Tiket Event"
+	mfaQRSize         = 256
+	recoveryCodeCount = 10
+)
+
+type LoginMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type DisableMFARequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// MFASetupResponse berisi secret dalam tiga bentuk: teks untuk input manual, URI otpauth:// dan
+// QR code PNG (data URI base64) dari URI yang sama
+type MFASetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+	QRCode     string `json:"qr_code"`
+}
+
+// MFARecoveryCodesResponse berisi recovery code dalam bentuk teks. Kode hanya ditampilkan sekali,
+// yang disimpan hanya hash-nya.
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (u *userUsecase) LoginMFA(ctx context.Context, req LoginMFARequest) (*LoginResponse, error) {
+	claims, err := utils.ValidateToken(req.MFAToken, u.jwtSecret)
+	if err != nil || claims.TokenType != utils.TokenTypeMFAChallenge || claims.ID == "" {
+		return nil, errors.New("token mfa tidak valid")
+	}
+
+	// Challenge yang sudah ditukar dicabut, begitu juga challenge yang terbit sebelum reset password
+	revoked, err := u.tokenRevocationRepo.IsRevoked(ctx, claims.UserID, claims.ID, claims.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		return nil, errors.New("token mfa tidak valid")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("token mfa tidak valid")
+	}
+
+	mfa, err := u.userMFARepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa == nil || !mfa.Enabled() {
+		return nil, errors.New("token mfa tidak valid")
+	}
+
+	if err := u.verifyMFACode(ctx, mfa, req.Code, true); err != nil {
+		return nil, err
+	}
+
+	err = u.tokenRevocationRepo.Create(ctx, &entity.TokenRevocation{
+		UserID:    user.ID,
+		JTI:       claims.ID,
+		RevokedAt: time.Now(),
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.issueSession(ctx, user, "", true)
+}
+
+func (u *userUsecase) GetMFAStatus(ctx context.Context, userID int) (*MFAStatusResponse, error) {
+	mfa, err := u.userMFARepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa == nil || !mfa.Enabled() {
+		return &MFAStatusResponse{Enabled: false}, nil
+	}
+
+	codes, err := u.userMFARepo.FindUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabledAt := mfa.EnabledAt
+	return &MFAStatusResponse{
+		Enabled:                true,
+		EnabledAt:              &enabledAt,
+		RecoveryCodesRemaining: len(codes),
+	}, nil
+}
+
+func (u *userUsecase) SetupMFA(ctx context.Context, userID int) (*MFASetupResponse, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("user tidak ditemukan")
+	}
+
+	existing, err := u.userMFARepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil && existing.Enabled() {
+		return nil, errors.New("2fa sudah aktif")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := utils.EncryptSecret(secret, u.mfaSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.userMFARepo.SavePending(ctx, &entity.UserMFA{
+		UserID:          userID,
+		SecretEncrypted: encrypted,
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		// Setup lain sudah dikonfirmasi lebih dulu
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return nil, errors.New("2fa sudah aktif")
+		}
+		return nil, err
+	}
+
+	uri := utils.TOTPProvisioningURI(mfaIssuer, user.Email, secret)
+
+	png, err := qrcode.Encode(uri, qrcode.Medium, mfaQRSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFASetupResponse{
+		Secret:     secret,
+		OTPAuthURL: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// EnableMFA mengonfirmasi setup dengan kode pertama dari aplikasi authenticator. Semua sesi lama
+// dicabut sehingga setiap sesi yang tersisa dibuka lewat 2FA dan user perlu login kembali.
+func (u *userUsecase) EnableMFA(ctx context.Context, userID int, req MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	mfa, err := u.userMFARepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa == nil {
+		return nil, errors.New("2fa belum di-setup")
+	}
+
+	if mfa.Enabled() {
+		return nil, errors.New("2fa sudah aktif")
+	}
+
+	secret, err := utils.DecryptSecret(mfa.SecretEncrypted, u.mfaSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	step, ok := utils.ValidateTOTPCode(secret, req.Code, now)
+	if !ok {
+		return nil, errors.New("kode 2fa tidak valid")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.userMFARepo.Enable(ctx, userID, now, step); err != nil {
+			return err
+		}
+
+		return u.userMFARepo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return nil, errors.New("2fa sudah aktif")
+		}
+		return nil, err
+	}
+
+	if err := u.revokeAllSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("2FA user %d diaktifkan", userID)
+
+	return &MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (u *userUsecase) DisableMFA(ctx context.Context, userID int, req DisableMFARequest) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("user tidak ditemukan")
+	}
+
+	match, err := utils.VerifyPassword(req.Password, user.Password)
+	if err != nil {
+		return err
+	}
+
+	if !match {
+		return errors.New("password saat ini salah")
+	}
+
+	mfa, err := u.userMFARepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if mfa == nil || !mfa.Enabled() {
+		return errors.New("2fa belum aktif")
+	}
+
+	if err := u.verifyMFACode(ctx, mfa, req.Code, true); err != nil {
+		return err
+	}
+
+	// Delete menghapus secret dan recovery code dalam dua query, keduanya harus terhapus bersamaan
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return u.userMFARepo.Delete(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("2FA user %d dinonaktifkan", userID)
+
+	return nil
+}
+
+// RegenerateRecoveryCodes mengganti semua recovery code. Hanya kode TOTP yang diterima agar
+// recovery code yang bocor tidak bisa dipakai membuat kode baru.
+func (u *userUsecase) RegenerateRecoveryCodes(ctx context.Context, userID int, req MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	mfa, err := u.userMFARepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa == nil || !mfa.Enabled() {
+		return nil, errors.New("2fa belum aktif")
+	}
+
+	if err := u.verifyMFACode(ctx, mfa, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return u.userMFARepo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// issueMFAChallenge menerbitkan token langkah pertama login. Token ini bukan access token sehingga
+// ditolak AuthenticateJWT dan hanya bisa ditukar di LoginMFA.
+func (u *userUsecase) issueMFAChallenge(user *entity.User) (*LoginResponse, error) {
+	token, err := utils.GenerateJWT(user.ID, user.Username, user.Email, user.Role, utils.TokenTypeMFAChallenge, false, user.TokenVersion, u.jwtSecret, u.mfaChallengeExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		Role:        user.Role,
+		Username:    user.Username,
+	}, nil
+}
+
+// verifyMFACode menerima kode TOTP yang time step-nya belum pernah dipakai, atau recovery code
+// yang belum dipakai jika allowRecovery true. Kode yang cocok langsung ditandai terpakai.
+func (u *userUsecase) verifyMFACode(ctx context.Context, mfa *entity.UserMFA, code string, allowRecovery bool) error {
+	invalid := errors.New("kode 2fa tidak valid")
+	code = strings.TrimSpace(code)
+
+	secret, err := utils.DecryptSecret(mfa.SecretEncrypted, u.mfaSecretKey)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := utils.ValidateTOTPCode(secret, code, time.Now()); ok {
+		if step <= mfa.LastUsedStep {
+			return invalid
+		}
+
+		if err := u.userMFARepo.ConsumeStep(ctx, mfa.UserID, step); err != nil {
+			if errors.Is(err, repository.ErrStatusConflict) {
+				return invalid
+			}
+			return err
+		}
+		return nil
+	}
+
+	if !allowRecovery || code == "" {
+		return invalid
+	}
+
+	codes, err := u.userMFARepo.FindUnusedRecoveryCodes(ctx, mfa.UserID)
+	if err != nil {
+		return err
+	}
+
+	code = strings.ToLower(code)
+	for _, recovery := range codes {
+		match, err := utils.VerifyPassword(code, recovery.CodeHash)
+		if err != nil {
+			return err
+		}
+
+		if !match {
+			continue
+		}
+
+		if err := u.userMFARepo.MarkRecoveryCodeUsed(ctx, recovery.ID, time.Now()); err != nil {
+			if errors.Is(err, repository.ErrStatusConflict) {
+				return invalid
+			}
+			return err
+		}
+
+		log.Printf("Recovery code 2FA user %d dipakai, tersisa %d", mfa.UserID, len(codes)-1)
+		return nil
+	}
+
+	return invalid
+}
+
+// generateRecoveryCodes mengembalikan recovery code dalam bentuk teks untuk ditampilkan ke user
+// beserta hash argon2 untuk disimpan
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		codes[i] = utils.GenerateRecoveryCode()
+
+		hash, err := utils.GeneratePassword(codes[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		hashes[i] = hash
+	}
+
+	return codes, hashes, nil
+}
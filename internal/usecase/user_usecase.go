@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 	
 	"ticket-system/internal/domain/entity"
@@ -28,16 +29,66 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+// LoginResponse untuk akun ber-2FA hanya berisi MFARequired dan MFAToken, access token dan
+// refresh token baru diberikan setelah kode 2FA diverifikasi di LoginMFA
 type LoginResponse struct {
-	Token        string `json:"token"`
-	RefreshToken string `json:"refresh_token"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 	Role         string `json:"role"`
 	Username     string `json:"username"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token          string `json:"token"`
+	Password       string `json:"password"`
+	RetypePassword string `json:"retype_password"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+	RetypePassword  string `json:"retype_password"`
+}
+
+// LogoutRequest boleh menyertakan refresh token sesi yang sama agar ikut dicabut
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 type UserUsecase interface {
 	Register(ctx context.Context, req RegisterRequest) (int, error)
 	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+	// RefreshToken menukar refresh token dengan access token dan refresh token baru
+	RefreshToken(ctx context.Context, req RefreshTokenRequest) (*LoginResponse, error)
+	// Logout mencabut access token yang sedang dipakai beserta refresh token sesinya
+	Logout(ctx context.Context, claims *utils.JWTClaim, req LogoutRequest) error
+	// LogoutAll mencabut semua access token dan refresh token milik user
+	LogoutAll(ctx context.Context, userID int) error
+	// ForgotPassword mengirim tautan reset password. Email yang tidak terdaftar tidak menghasilkan
+	// error agar respons tidak membocorkan akun mana yang ada.
+	ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, userID int, req ChangePasswordRequest) error
+	// LoginMFA menyelesaikan login akun ber-2FA dengan challenge token dari Login dan kode TOTP
+	// atau recovery code
+	LoginMFA(ctx context.Context, req LoginMFARequest) (*LoginResponse, error)
+	GetMFAStatus(ctx context.Context, userID int) (*MFAStatusResponse, error)
+	// SetupMFA membuat secret TOTP baru yang belum aktif sampai dikonfirmasi lewat EnableMFA
+	SetupMFA(ctx context.Context, userID int) (*MFASetupResponse, error)
+	EnableMFA(ctx context.Context, userID int, req MFACodeRequest) (*MFARecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID int, req DisableMFARequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int, req MFACodeRequest) (*MFARecoveryCodesResponse, error)
 	GetByID(ctx context.Context, id int) (*entity.User, error)
 	VerifyEmail(ctx context.Context, token string) error
 	ResendVerificationEmail(ctx context.Context, email string) error
@@ -48,12 +99,26 @@ type userUsecase struct {
 	userRepo              repository.UserRepository
 	userProfileRepo       repository.UserProfileRepository
 	emailVerificationRepo repository.EmailVerificationRepository
+	refreshTokenRepo      repository.RefreshTokenRepository
+	tokenRevocationRepo   repository.TokenRevocationRepository
+	passwordResetRepo     repository.PasswordResetRepository
+	userMFARepo           repository.UserMFARepository
+	txManager             repository.TxManager
 	jwtSecret             string
-	tokenExpiry           int
+	accessTokenExpiry     time.Duration
+	refreshTokenExpiry    time.Duration
+	passwordResetExpiry   time.Duration
+	mfaChallengeExpiry    time.Duration
 	smtpConfig            utils.SMTPConfig
 	appURL                string
+	passwordResetURL      string
+	mfaSecretKey          string
 }
 
+// passwordResetCooldown adalah jeda minimal antar email reset password untuk akun yang sama.
+// Permintaan di dalam jeda ini diabaikan tanpa error agar kotak masuk user tidak dibanjiri.
+const passwordResetCooldown = time.Minute
+
 func (u *userUsecase) UpdateProfile(ctx context.Context, userID int, name, gender, address, phoneNumber string) error {
 	existingProfile, err := u.userProfileRepo.FindByUserID(ctx, userID)
 	if err != nil {
@@ -81,24 +146,68 @@ func NewUserUsecase(
 	userRepo repository.UserRepository,
 	userProfileRepo repository.UserProfileRepository,
 	emailVerificationRepo repository.EmailVerificationRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	tokenRevocationRepo repository.TokenRevocationRepository,
+	passwordResetRepo repository.PasswordResetRepository,
+	userMFARepo repository.UserMFARepository,
+	txManager repository.TxManager,
 	jwtSecret string,
-	tokenExpiry string,
+	accessTokenMinutes string,
+	refreshTokenHours string,
+	passwordResetMinutes string,
+	mfaChallengeMinutes string,
 	smtpConfig utils.SMTPConfig,
 	appURL string,
+	passwordResetURL string,
+	mfaSecretKey string,
 ) UserUsecase {
-	expiry, _ := strconv.Atoi(tokenExpiry)
-	if expiry == 0 {
-		expiry = 24 // default 24 jam
+	accessExpiry, _ := strconv.Atoi(accessTokenMinutes)
+	if accessExpiry <= 0 {
+		accessExpiry = 15 // default 15 menit
+	}
+	
+	refreshExpiry, _ := strconv.Atoi(refreshTokenHours)
+	if refreshExpiry <= 0 {
+		refreshExpiry = 720 // default 30 hari
+	}
+	
+	resetExpiry, _ := strconv.Atoi(passwordResetMinutes)
+	if resetExpiry <= 0 {
+		resetExpiry = 30 // default 30 menit
+	}
+	
+	// Tanpa halaman frontend khusus, tautan reset diarahkan ke host aplikasi
+	if passwordResetURL == "" {
+		passwordResetURL = strings.TrimRight(appURL, "/") + "/reset-password"
+	}
+	
+	challengeExpiry, _ := strconv.Atoi(mfaChallengeMinutes)
+	if challengeExpiry <= 0 {
+		challengeExpiry = 5 // default 5 menit
+	}
+	
+	if mfaSecretKey == "" {
+		mfaSecretKey = jwtSecret
 	}
 	
 	return &userUsecase{
 		userRepo:              userRepo,
 		userProfileRepo:       userProfileRepo,
 		emailVerificationRepo: emailVerificationRepo,
+		refreshTokenRepo:      refreshTokenRepo,
+		tokenRevocationRepo:   tokenRevocationRepo,
+		passwordResetRepo:     passwordResetRepo,
+		userMFARepo:           userMFARepo,
+		txManager:             txManager,
 		jwtSecret:             jwtSecret,
-		tokenExpiry:           expiry,
+		accessTokenExpiry:     time.Duration(accessExpiry) * time.Minute,
+		refreshTokenExpiry:    time.Duration(refreshExpiry) * time.Hour,
+		passwordResetExpiry:   time.Duration(resetExpiry) * time.Minute,
+		mfaChallengeExpiry:    time.Duration(challengeExpiry) * time.Minute,
 		smtpConfig:            smtpConfig,
 		appURL:                appURL,
+		passwordResetURL:      passwordResetURL,
+		mfaSecretKey:          mfaSecretKey,
 	}
 }
 
@@ -154,22 +263,27 @@ func (u *userUsecase) Register(ctx context.Context, req RegisterRequest) (int, e
 		UpdatedAt:  time.Now(),
 	}
 	
-	userID, err := u.userRepo.Create(ctx, user)
-	if err != nil {
-		return 0, err
-	}
-	
 	token := utils.GenerateRandomString(64)
 	expiredAt := time.Now().Add(24 * time.Hour)
 	
-	verification := &entity.EmailVerification{
-		UserID:    userID,
-		Token:     token,
-		ExpiredAt: expiredAt,
-		CreatedAt: time.Now(),
-	}
-	
-	_, err = u.emailVerificationRepo.Create(ctx, verification)
+	var userID int
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		userID, err = u.userRepo.Create(ctx, user)
+		if err != nil {
+			return err
+		}
+		
+		verification := &entity.EmailVerification{
+			UserID:    userID,
+			Token:     token,
+			ExpiredAt: expiredAt,
+			CreatedAt: time.Now(),
+		}
+		
+		_, err = u.emailVerificationRepo.Create(ctx, verification)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -210,12 +324,310 @@ func (u *userUsecase) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 		return nil, errors.New("email belum diverifikasi, silakan periksa email Anda")
 	}
 	
-	token, err := utils.GenerateJWT(user.ID, user.Username, user.Email, user.Role, u.jwtSecret, u.tokenExpiry)
+	mfa, err := u.userMFARepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if mfa != nil && mfa.Enabled() {
+		return u.issueMFAChallenge(user)
+	}
+	
+	return u.issueSession(ctx, user, "", false)
+}
+
+func (u *userUsecase) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*LoginResponse, error) {
+	stored, err := u.refreshTokenRepo.FindByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	
+	if stored == nil || stored.Revoked() {
+		return nil, errors.New("refresh token tidak valid")
+	}
+	
+	now := time.Now()
+	
+	// Token yang sudah ditukar tidak pernah dikirim lagi oleh klien yang sah, artinya token bocor
+	// dan dipakai pihak lain. Seluruh sesi dicabut agar pemegang token baru ikut keluar.
+	if stored.Rotated() {
+		return nil, u.revokeReusedFamily(ctx, stored, now)
+	}
+	
+	if now.After(stored.ExpiresAt) {
+		return nil, errors.New("refresh token sudah kedaluwarsa")
+	}
+	
+	// Mengaktifkan 2FA mencabut semua sesi, jadi sesi yang masih bisa di-refresh milik user ber-2FA
+	// pasti dibuka lewat LoginMFA
+	mfa, err := u.userMFARepo.FindByUserID(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	mfaVerified := mfa != nil && mfa.Enabled()
+	
+	var response *LoginResponse
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Baris user dikunci sebelum rotasi agar logout dari semua perangkat yang berjalan bersamaan
+		// menunggu token baru tersimpan lalu ikut mencabutnya, atau selesai lebih dulu sehingga
+		// MarkRotated menolak token yang sudah dicabut
+		user, err := u.userRepo.FindByIDForUpdate(ctx, stored.UserID)
+		if err != nil {
+			return err
+		}
+		
+		if user == nil {
+			return errors.New("refresh token tidak valid")
+		}
+		
+		if err := u.refreshTokenRepo.MarkRotated(ctx, stored.ID, now); err != nil {
+			return err
+		}
+		
+		response, err = u.issueSession(ctx, user, stored.FamilyID, mfaVerified)
+		return err
+	})
+	if err != nil {
+		// Request lain sudah menukar atau mencabut token yang sama lebih dulu
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return nil, u.revokeReusedFamily(ctx, stored, now)
+		}
+		return nil, err
+	}
+	
+	return response, nil
+}
+
+func (u *userUsecase) Logout(ctx context.Context, claims *utils.JWTClaim, req LogoutRequest) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return errors.New("token tidak valid")
+	}
+	
+	now := time.Now()
+	
+	if req.RefreshToken != "" {
+		stored, err := u.refreshTokenRepo.FindByHash(ctx, utils.HashToken(req.RefreshToken))
+		if err != nil {
+			return err
+		}
+		
+		// Refresh token milik user lain diabaikan agar logout tidak bisa mencabut sesi orang lain
+		if stored != nil && stored.UserID == claims.UserID {
+			if err := u.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID, now); err != nil {
+				return err
+			}
+		}
+	}
+	
+	return u.tokenRevocationRepo.Create(ctx, &entity.TokenRevocation{
+		UserID:    claims.UserID,
+		JTI:       claims.ID,
+		RevokedAt: now,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+}
+
+func (u *userUsecase) LogoutAll(ctx context.Context, userID int) error {
+	return u.revokeAllSessions(ctx, userID)
+}
+
+func (u *userUsecase) ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error {
+	if err := utils.ValidateEmail(req.Email); err != nil {
+		return err
+	}
+	
+	user, err := u.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	
+	if user == nil {
+		log.Printf("Permintaan reset password untuk email yang tidak terdaftar diabaikan")
+		return nil
+	}
+	
+	latest, err := u.passwordResetRepo.FindLatestByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now()
+	if latest != nil && now.Sub(latest.CreatedAt) < passwordResetCooldown {
+		log.Printf("Permintaan reset password user %d diabaikan karena masih dalam jeda", user.ID)
+		return nil
+	}
+	
+	token := utils.GenerateRandomString(64)
+	
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.passwordResetRepo.DeleteUnusedByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		
+		_, err := u.passwordResetRepo.Create(ctx, &entity.PasswordReset{
+			UserID:    user.ID,
+			TokenHash: utils.HashToken(token),
+			ExpiredAt: now.Add(u.passwordResetExpiry),
+			CreatedAt: now,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	
+	go u.sendPasswordResetEmail(user.Username, user.Email, token)
+	
+	return nil
+}
+
+func (u *userUsecase) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	if err := utils.ValidatePassword(req.Password); err != nil {
+		return err
+	}
+	
+	if req.Password != req.RetypePassword {
+		return errors.New("password dan retype password tidak cocok")
+	}
+	
+	reset, err := u.passwordResetRepo.FindByTokenHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	
+	if reset == nil || reset.Used() {
+		return errors.New("token reset password tidak valid")
+	}
+	
+	now := time.Now()
+	if now.After(reset.ExpiredAt) {
+		return errors.New("token reset password sudah kedaluwarsa")
+	}
+	
+	hashedPassword, err := utils.GeneratePassword(req.Password)
+	if err != nil {
+		return err
+	}
+	
+	// Password baru hanya tersimpan bersama pencabutan semua sesi, agar sesi milik pihak yang
+	// mengetahui password lama tidak bertahan jika pencabutan gagal
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.passwordResetRepo.MarkUsed(ctx, reset.ID, now); err != nil {
+			return err
+		}
+		
+		if err := u.userRepo.UpdatePassword(ctx, reset.UserID, hashedPassword); err != nil {
+			return err
+		}
+		
+		return u.revokeAllSessions(ctx, reset.UserID)
+	})
+	if err != nil {
+		// Token yang sama sudah dipakai request lain lebih dulu
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return errors.New("token reset password tidak valid")
+		}
+		return err
+	}
+	
+	return nil
+}
+
+func (u *userUsecase) ChangePassword(ctx context.Context, userID int, req ChangePasswordRequest) error {
+	if err := utils.ValidatePassword(req.NewPassword); err != nil {
+		return err
+	}
+	
+	if req.NewPassword != req.RetypePassword {
+		return errors.New("password dan retype password tidak cocok")
+	}
+	
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	
+	if user == nil {
+		return errors.New("user tidak ditemukan")
+	}
+	
+	match, err := utils.VerifyPassword(req.CurrentPassword, user.Password)
+	if err != nil {
+		return err
+	}
+	
+	if !match {
+		return errors.New("password saat ini salah")
+	}
+	
+	if req.NewPassword == req.CurrentPassword {
+		return errors.New("password baru harus berbeda dari password saat ini")
+	}
+	
+	hashedPassword, err := utils.GeneratePassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+	
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+			return err
+		}
+		
+		return u.revokeAllSessions(ctx, userID)
+	})
+}
+
+// revokeAllSessions mencabut semua refresh token user lalu semua access token yang terbit sebelum
+// saat ini dengan menaikkan versi token user. Dipanggil untuk logout dari semua perangkat dan
+// setiap kali password berubah.
+func (u *userUsecase) revokeAllSessions(ctx context.Context, userID int) error {
+	now := time.Now()
+	
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		tokenVersion, err := u.userRepo.IncrementTokenVersion(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		if err := u.refreshTokenRepo.RevokeByUserID(ctx, userID, now); err != nil {
+			return err
+		}
+		
+		// Access token terakhir dengan versi lama kedaluwarsa paling lambat setelah accessTokenExpiry,
+		// setelah itu entry pencabutan tidak diperlukan lagi
+		return u.tokenRevocationRepo.Create(ctx, &entity.TokenRevocation{
+			UserID:       userID,
+			TokenVersion: tokenVersion,
+			RevokedAt:    now,
+			ExpiresAt:    now.Add(u.accessTokenExpiry),
+		})
+	})
+}
+
+// issueSession menerbitkan access token dan refresh token baru. familyID kosong berarti sesi
+// login baru, selain itu refresh token baru melanjutkan family sesi yang sedang dirotasi.
+// mfaVerified menandai sesi yang dibuka lewat verifikasi 2FA.
+func (u *userUsecase) issueSession(ctx context.Context, user *entity.User, familyID string, mfaVerified bool) (*LoginResponse, error) {
+	token, err := utils.GenerateJWT(user.ID, user.Username, user.Email, user.Role, utils.TokenTypeAccess, mfaVerified, user.TokenVersion, u.jwtSecret, u.accessTokenExpiry)
 	if err != nil {
 		return nil, err
 	}
 	
-	refreshToken, err := utils.GenerateJWT(user.ID, user.Username, user.Email, user.Role, u.jwtSecret, u.tokenExpiry*2)
+	if familyID == "" {
+		familyID = utils.GenerateRandomString(32)
+	}
+	
+	refreshToken := utils.GenerateRandomString(64)
+	now := time.Now()
+	
+	_, err = u.refreshTokenRepo.Create(ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: now.Add(u.refreshTokenExpiry),
+		CreatedAt: now,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -223,6 +635,7 @@ func (u *userUsecase) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 	response := &LoginResponse{
 		Token:        token,
 		RefreshToken: refreshToken,
+		ExpiresIn:    int(u.accessTokenExpiry.Seconds()),
 		Role:         user.Role,
 		Username:     user.Username,
 	}
@@ -230,6 +643,16 @@ func (u *userUsecase) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 	return response, nil
 }
 
+func (u *userUsecase) revokeReusedFamily(ctx context.Context, stored *entity.RefreshToken, now time.Time) error {
+	log.Printf("Refresh token family %s milik user %d dipakai ulang, sesi dicabut", stored.FamilyID, stored.UserID)
+	
+	if err := u.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID, now); err != nil {
+		return err
+	}
+	
+	return errors.New("refresh token sudah pernah dipakai, silakan login kembali")
+}
+
 func (u *userUsecase) GetByID(ctx context.Context, id int) (*entity.User, error) {
 	return u.userRepo.FindByID(ctx, id)
 }
@@ -276,15 +699,17 @@ func (u *userUsecase) VerifyEmail(ctx context.Context, token string) error {
 		return errors.New("token verifikasi sudah kedaluwarsa")
 	}
 	
-	if err := u.userRepo.UpdateVerificationStatus(ctx, verification.UserID, true); err != nil {
-		return err
-	}
-	
-	if err := u.userRepo.CreateDefaultProfile(ctx, verification.UserID); err != nil {
-		return err
-	}
-	
-	return u.emailVerificationRepo.Delete(ctx, verification.ID)
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.UpdateVerificationStatus(ctx, verification.UserID, true); err != nil {
+			return err
+		}
+		
+		if err := u.userRepo.CreateDefaultProfile(ctx, verification.UserID); err != nil {
+			return err
+		}
+		
+		return u.emailVerificationRepo.Delete(ctx, verification.ID)
+	})
 }
 
 func (u *userUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
@@ -301,22 +726,24 @@ func (u *userUsecase) ResendVerificationEmail(ctx context.Context, email string)
 		return errors.New("email sudah diverifikasi")
 	}
 	
-	err = u.emailVerificationRepo.DeleteByUserID(ctx, user.ID)
-	if err != nil {
-		return err
-	}
-	
 	token := utils.GenerateRandomString(64)
 	expiredAt := time.Now().Add(24 * time.Hour)
 	
-	verification := &entity.EmailVerification{
-		UserID:    user.ID,
-		Token:     token,
-		ExpiredAt: expiredAt,
-		CreatedAt: time.Now(),
-	}
-	
-	_, err = u.emailVerificationRepo.Create(ctx, verification)
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.emailVerificationRepo.DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		
+		verification := &entity.EmailVerification{
+			UserID:    user.ID,
+			Token:     token,
+			ExpiredAt: expiredAt,
+			CreatedAt: time.Now(),
+		}
+		
+		_, err := u.emailVerificationRepo.Create(ctx, verification)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -351,4 +778,32 @@ func (u *userUsecase) sendVerificationEmail(username, email, token string) {
 	} else {
 		log.Printf("Email verifikasi berhasil dikirim ke: %s", email)
 	}
-}
\ No newline at end of file
+}
+
+func (u *userUsecase) sendPasswordResetEmail(username, email, token string) {
+	resetLink := fmt.Sprintf("%s?token=%s", u.passwordResetURL, token)
+	templateData := map[string]interface{}{
+		"Username":      username,
+		"ResetLink":     resetLink,
+		"ExpiryMinutes": int(u.passwordResetExpiry.Minutes()),
+		"Year":          time.Now().Year(),
+	}
+	
+	body, err := utils.ParseTemplate("templates/email/password_reset.html", templateData)
+	if err != nil {
+		log.Printf("Gagal parse template email: %v", err)
+		return
+	}
+	
+	emailData := utils.EmailData{
+		To:      []string{email},
+		Subject: "Reset Password - Sistem Tiket Event",
+		Body:    body,
+	}
+	
+	if err := utils.SendEmail(u.smtpConfig, emailData); err != nil {
+		log.Printf("Gagal mengirim email reset password: %v", err)
+	} else {
+		log.Printf("Email reset password berhasil dikirim ke: %s", email)
+	}
+}
@@ -0,0 +1,257 @@
+//internal/usecase/venue_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+)
+
+// CreateVenueRequest menyusun denah per section, lalu per baris, lalu per kursi. Nomor kursi
+// harus unik di dalam baris yang sama.
+type CreateVenueRequest struct {
+	Name     string                `json:"name"`
+	Address  string                `json:"address"`
+	Sections []VenueSectionRequest `json:"sections"`
+}
+
+type VenueSectionRequest struct {
+	Name string            `json:"name"`
+	Rows []VenueRowRequest `json:"rows"`
+}
+
+type VenueRowRequest struct {
+	Label string             `json:"label"`
+	Seats []VenueSeatRequest `json:"seats"`
+}
+
+type VenueSeatRequest struct {
+	Number     string  `json:"number"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Accessible bool    `json:"accessible"`
+}
+
+type AssignVenueRequest struct {
+	VenueID int `json:"venue_id"`
+}
+
+type VenueDetailResponse struct {
+	Venue *entity.Venue      `json:"venue"`
+	Seats []entity.VenueSeat `json:"seats"`
+}
+
+type VenueUsecase interface {
+	CreateVenue(ctx context.Context, userID int, req CreateVenueRequest) (*VenueDetailResponse, error)
+	GetVenues(ctx context.Context, userID int) ([]entity.Venue, error)
+	GetVenue(ctx context.Context, venueID, userID int) (*VenueDetailResponse, error)
+	DeleteVenue(ctx context.Context, venueID, userID int) error
+	// AssignVenue memasang denah venue ke event yang belum memiliki penjualan. Kapasitas event
+	// berubah menjadi jumlah kursi venue.
+	AssignVenue(ctx context.Context, eventID, userID int, req AssignVenueRequest) (*entity.Event, error)
+}
+
+// maxVenueSeats membatasi ukuran denah agar satu request tidak menahan koneksi database terlalu lama
+const maxVenueSeats = 20000
+
+type venueUsecase struct {
+	venueRepo      repository.VenueRepository
+	eventRepo      repository.EventRepository
+	ticketTypeRepo repository.TicketTypeRepository
+}
+
+func NewVenueUsecase(
+	venueRepo repository.VenueRepository,
+	eventRepo repository.EventRepository,
+	ticketTypeRepo repository.TicketTypeRepository,
+) VenueUsecase {
+	return &venueUsecase{
+		venueRepo:      venueRepo,
+		eventRepo:      eventRepo,
+		ticketTypeRepo: ticketTypeRepo,
+	}
+}
+
+func (u *venueUsecase) CreateVenue(ctx context.Context, userID int, req CreateVenueRequest) (*VenueDetailResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, errors.New("nama venue harus diisi")
+	}
+
+	seats, err := buildVenueSeats(req.Sections)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	venue := &entity.Venue{
+		OwnerID:   userID,
+		Name:      req.Name,
+		Address:   strings.TrimSpace(req.Address),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	venueID, err := u.venueRepo.Create(ctx, venue, seats)
+	if err != nil {
+		return nil, err
+	}
+	venue.ID = venueID
+	venue.SeatCount = len(seats)
+
+	response := &VenueDetailResponse{Venue: venue, Seats: make([]entity.VenueSeat, 0, len(seats))}
+	for _, seat := range seats {
+		response.Seats = append(response.Seats, *seat)
+	}
+
+	return response, nil
+}
+
+func (u *venueUsecase) GetVenues(ctx context.Context, userID int) ([]entity.Venue, error) {
+	return u.venueRepo.FindByOwnerID(ctx, userID)
+}
+
+func (u *venueUsecase) GetVenue(ctx context.Context, venueID, userID int) (*VenueDetailResponse, error) {
+	venue, err := u.findOwnedVenue(ctx, venueID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := u.venueRepo.FindSeatsByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VenueDetailResponse{Venue: venue, Seats: seats}, nil
+}
+
+func (u *venueUsecase) DeleteVenue(ctx context.Context, venueID, userID int) error {
+	venue, err := u.findOwnedVenue(ctx, venueID, userID)
+	if err != nil {
+		return err
+	}
+
+	return u.venueRepo.Delete(ctx, venue.ID)
+}
+
+func (u *venueUsecase) AssignVenue(ctx context.Context, eventID, userID int, req AssignVenueRequest) (*entity.Event, error) {
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+
+	if event.OwnerID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk mengatur venue event ini")
+	}
+
+	// Kursi yang sudah dipesan terikat ke denah lama, jadi denah hanya bisa diganti sebelum ada penjualan
+	if event.TicketsSold > 0 {
+		return nil, errors.New("venue tidak dapat diubah setelah tiket terjual")
+	}
+
+	venue, err := u.findOwnedVenue(ctx, req.VenueID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypes, err := u.ticketTypeRepo.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if totalQuota(ticketTypes, 0) > venue.SeatCount {
+		return nil, errors.New("total kuota tipe tiket melebihi jumlah kursi venue")
+	}
+
+	if err := u.eventRepo.AssignVenue(ctx, event.ID, venue.ID, venue.SeatCount); err != nil {
+		// Tiket terjual di antara pengecekan dan penggantian venue
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return nil, errors.New("venue tidak dapat diubah setelah tiket terjual")
+		}
+		return nil, err
+	}
+
+	event.VenueID = venue.ID
+	event.MaxCapacity = venue.SeatCount
+
+	return event, nil
+}
+
+func (u *venueUsecase) findOwnedVenue(ctx context.Context, venueID, userID int) (*entity.Venue, error) {
+	venue, err := u.venueRepo.FindByID(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if venue == nil {
+		return nil, errors.New("venue tidak ditemukan")
+	}
+
+	if venue.OwnerID != userID {
+		return nil, errors.New("anda tidak memiliki izin untuk mengelola venue ini")
+	}
+
+	return venue, nil
+}
+
+// buildVenueSeats meratakan denah bertingkat menjadi daftar kursi sesuai urutan di request
+func buildVenueSeats(sections []VenueSectionRequest) ([]*entity.VenueSeat, error) {
+	var seats []*entity.VenueSeat
+	seen := make(map[[3]string]bool)
+
+	for _, section := range sections {
+		sectionName := strings.TrimSpace(section.Name)
+		if sectionName == "" {
+			return nil, errors.New("nama section harus diisi")
+		}
+
+		for _, row := range section.Rows {
+			rowLabel := strings.TrimSpace(row.Label)
+			if rowLabel == "" {
+				return nil, errors.New("label baris harus diisi")
+			}
+
+			for _, seat := range row.Seats {
+				number := strings.TrimSpace(seat.Number)
+				if number == "" {
+					return nil, errors.New("nomor kursi harus diisi")
+				}
+
+				venueSeat := &entity.VenueSeat{
+					Section:    sectionName,
+					Row:        rowLabel,
+					Number:     number,
+					X:          seat.X,
+					Y:          seat.Y,
+					Accessible: seat.Accessible,
+				}
+
+				key := [3]string{sectionName, rowLabel, number}
+				if seen[key] {
+					return nil, errors.New("nomor kursi tidak boleh duplikat dalam satu baris")
+				}
+				seen[key] = true
+
+				seats = append(seats, venueSeat)
+				if len(seats) > maxVenueSeats {
+					return nil, errors.New("jumlah kursi venue melebihi batas maksimal")
+				}
+			}
+		}
+	}
+
+	if len(seats) == 0 {
+		return nil, errors.New("venue harus memiliki minimal satu kursi")
+	}
+
+	return seats, nil
+}
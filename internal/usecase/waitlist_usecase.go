@@ -0,0 +1,291 @@
+//internal/usecase/waitlist_usecase.go
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/pkg/utils"
+)
+
+type JoinWaitlistRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+// WaitlistResponse menampilkan Position hanya selama entry masih menunggu, dimulai dari 1
+type WaitlistResponse struct {
+	ID             int        `json:"id"`
+	EventID        int        `json:"event_id"`
+	Quantity       int        `json:"quantity"`
+	Status         string     `json:"status"`
+	Position       int        `json:"position,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type WaitlistUsecase interface {
+	JoinWaitlist(ctx context.Context, userID, eventID int, req JoinWaitlistRequest) (*WaitlistResponse, error)
+	GetWaitlistEntry(ctx context.Context, userID, eventID int) (*WaitlistResponse, error)
+	LeaveWaitlist(ctx context.Context, userID, eventID int) error
+	ProcessWaitlist(ctx context.Context) (int, error)
+}
+
+// waitlistBatchSize membatasi jumlah penawaran kedaluwarsa yang dikunci dalam satu putaran worker
+const waitlistBatchSize = 100
+
+type waitlistUsecase struct {
+	waitlistRepo repository.WaitlistRepository
+	eventRepo    repository.EventRepository
+	userRepo     repository.UserRepository
+	txManager    repository.TxManager
+	offerTTL     time.Duration
+	smtpConfig   utils.SMTPConfig
+}
+
+func NewWaitlistUsecase(
+	waitlistRepo repository.WaitlistRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	txManager repository.TxManager,
+	offerMinutes string,
+	smtpConfig utils.SMTPConfig,
+) WaitlistUsecase {
+	ttl, _ := strconv.Atoi(offerMinutes)
+	if ttl <= 0 {
+		ttl = 30 // default 30 menit
+	}
+
+	return &waitlistUsecase{
+		waitlistRepo: waitlistRepo,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		txManager:    txManager,
+		offerTTL:     time.Duration(ttl) * time.Minute,
+		smtpConfig:   smtpConfig,
+	}
+}
+
+func (u *waitlistUsecase) JoinWaitlist(ctx context.Context, userID, eventID int, req JoinWaitlistRequest) (*WaitlistResponse, error) {
+	if req.Quantity <= 0 {
+		return nil, errors.New("jumlah tiket harus lebih dari 0")
+	}
+
+	event, err := u.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, errors.New("event tidak ditemukan")
+	}
+
+	if event.Status != "active" {
+		return nil, errors.New("event tidak aktif")
+	}
+
+	if req.Quantity > event.MaxCapacity {
+		return nil, errors.New("jumlah tiket melebihi kapasitas event")
+	}
+
+	// Selama masih ada antrean, kursi kosong menjadi milik antrean sehingga pendaftar baru
+	// tetap masuk ke belakang antrean
+	hasWaiting, err := u.waitlistRepo.HasWaiting(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasWaiting && event.TicketsSold+req.Quantity <= event.MaxCapacity {
+		return nil, errors.New("tiket masih tersedia, silakan langsung membeli")
+	}
+
+	now := time.Now()
+	entry := &entity.WaitlistEntry{
+		EventID:   event.ID,
+		UserID:    userID,
+		Quantity:  req.Quantity,
+		Status:    entity.WaitlistStatusWaiting,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	entryID, err := u.waitlistRepo.Create(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = entryID
+
+	return u.toWaitlistResponse(ctx, entry)
+}
+
+func (u *waitlistUsecase) GetWaitlistEntry(ctx context.Context, userID, eventID int) (*WaitlistResponse, error) {
+	entry, err := u.waitlistRepo.FindActive(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, errors.New("anda tidak terdaftar di waitlist event ini")
+	}
+
+	return u.toWaitlistResponse(ctx, entry)
+}
+
+// LeaveWaitlist membatalkan antrean user. Jika user sedang memegang penawaran, kursinya
+// dikembalikan ke event dan ditawarkan ke antrean berikutnya pada putaran worker selanjutnya.
+func (u *waitlistUsecase) LeaveWaitlist(ctx context.Context, userID, eventID int) error {
+	entry, err := u.waitlistRepo.FindActive(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return errors.New("anda tidak terdaftar di waitlist event ini")
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.waitlistRepo.UpdateStatus(ctx, entry.ID, entry.Status, entity.WaitlistStatusCancelled); err != nil {
+			return err
+		}
+
+		if entry.Status == entity.WaitlistStatusOffered {
+			return u.eventRepo.UpdateTicketsSold(ctx, entry.EventID, -entry.Quantity)
+		}
+		return nil
+	})
+	if errors.Is(err, repository.ErrStatusConflict) {
+		return errors.New("status waitlist sudah berubah, silakan muat ulang")
+	}
+
+	return err
+}
+
+// ProcessWaitlist mengembalikan kursi dari penawaran yang tidak diklaim, lalu menawarkan kursi
+// kosong setiap event ke antrean berikutnya. Kursi yang dilepas transaksi batal, kedaluwarsa,
+// refund, maupun penambahan kapasitas event ikut ditawarkan di sini. Mengembalikan jumlah
+// penawaran baru.
+func (u *waitlistUsecase) ProcessWaitlist(ctx context.Context) (int, error) {
+	for {
+		var expired []entity.WaitlistEntry
+		err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			expired, err = u.waitlistRepo.ExpireOffers(ctx, time.Now(), waitlistBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range expired {
+				if err := u.eventRepo.UpdateTicketsSold(ctx, entry.EventID, -entry.Quantity); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		for _, entry := range expired {
+			log.Printf("Penawaran waitlist %d kedaluwarsa, %d kursi dikembalikan ke event %d", entry.ID, entry.Quantity, entry.EventID)
+		}
+
+		if len(expired) < waitlistBatchSize {
+			break
+		}
+	}
+
+	eventIDs, err := u.waitlistRepo.FindEventIDsWithWaiting(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, eventID := range eventIDs {
+		now := time.Now()
+		offered, err := u.waitlistRepo.OfferSeats(ctx, eventID, now, now.Add(u.offerTTL))
+		if err != nil {
+			return total, err
+		}
+		if len(offered) == 0 {
+			continue
+		}
+		total += len(offered)
+
+		event, err := u.eventRepo.FindByID(ctx, eventID)
+		if err != nil {
+			log.Printf("Gagal mengambil event %d untuk email waitlist: %v", eventID, err)
+			continue
+		}
+
+		for _, entry := range offered {
+			u.notifyOffer(ctx, event, entry)
+		}
+	}
+
+	return total, nil
+}
+
+func (u *waitlistUsecase) notifyOffer(ctx context.Context, event *entity.Event, entry entity.WaitlistEntry) {
+	user, err := u.userRepo.FindByID(ctx, entry.UserID)
+	if err != nil || user == nil {
+		log.Printf("Gagal mengambil pengguna %d untuk email waitlist: %v", entry.UserID, err)
+		return
+	}
+
+	go u.sendWaitlistOfferEmail(user.Username, user.Email, event, entry)
+}
+
+func (u *waitlistUsecase) sendWaitlistOfferEmail(username, email string, event *entity.Event, entry entity.WaitlistEntry) {
+	templateData := map[string]interface{}{
+		"Username":        username,
+		"EventTitle":      event.Title,
+		"EventID":         event.ID,
+		"Quantity":        entry.Quantity,
+		"WaitlistEntryID": entry.ID,
+		"ExpiresAt":       entry.OfferExpiresAt.Format("02 Jan 2006 15:04"),
+		"Year":            time.Now().Year(),
+	}
+
+	body, err := utils.ParseTemplate("templates/email/waitlist_offer.html", templateData)
+	if err != nil {
+		log.Printf("Gagal parse template email: %v", err)
+		return
+	}
+
+	emailData := utils.EmailData{
+		To:      []string{email},
+		Subject: "Tiket Tersedia - " + event.Title,
+		Body:    body,
+	}
+
+	if err := utils.SendEmail(u.smtpConfig, emailData); err != nil {
+		log.Printf("Gagal mengirim email penawaran waitlist: %v", err)
+	} else {
+		log.Printf("Email penawaran waitlist berhasil dikirim ke: %s", email)
+	}
+}
+
+func (u *waitlistUsecase) toWaitlistResponse(ctx context.Context, entry *entity.WaitlistEntry) (*WaitlistResponse, error) {
+	response := &WaitlistResponse{
+		ID:        entry.ID,
+		EventID:   entry.EventID,
+		Quantity:  entry.Quantity,
+		Status:    string(entry.Status),
+		CreatedAt: entry.CreatedAt,
+	}
+
+	switch entry.Status {
+	case entity.WaitlistStatusWaiting:
+		ahead, err := u.waitlistRepo.CountWaitingAhead(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		response.Position = ahead + 1
+	case entity.WaitlistStatusOffered:
+		expiresAt := entry.OfferExpiresAt
+		response.OfferExpiresAt = &expiresAt
+	}
+
+	return response, nil
+}
@@ -0,0 +1,57 @@
+//internal/worker/idempotency_key_cleanup_worker.go
+
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/usecase"
+)
+
+// IdempotencyKeyCleanupWorker menghapus idempotency key yang sudah melewati masa simpannya.
+// Key kedaluwarsa tidak lagi diputar ulang meskipun belum dihapus, jadi worker ini hanya
+// menjaga ukuran tabel.
+type IdempotencyKeyCleanupWorker struct {
+	idempotencyUsecase usecase.IdempotencyUsecase
+	interval           time.Duration
+}
+
+func NewIdempotencyKeyCleanupWorker(idempotencyUsecase usecase.IdempotencyUsecase, intervalSeconds string) *IdempotencyKeyCleanupWorker {
+	interval, _ := strconv.Atoi(intervalSeconds)
+	if interval <= 0 {
+		interval = 3600 // default 1 jam
+	}
+
+	return &IdempotencyKeyCleanupWorker{
+		idempotencyUsecase: idempotencyUsecase,
+		interval:           time.Duration(interval) * time.Second,
+	}
+}
+
+// Start menghapus idempotency key kedaluwarsa secara berkala sampai ctx dibatalkan
+func (w *IdempotencyKeyCleanupWorker) Start(ctx context.Context) {
+	log.Printf("Worker pembersih idempotency key berjalan setiap %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Worker pembersih idempotency key dihentikan")
+			return
+		case <-ticker.C:
+			deleted, err := w.idempotencyUsecase.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("Gagal menghapus idempotency key kedaluwarsa: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("%d idempotency key kedaluwarsa dihapus", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+//internal/worker/refund_worker.go
+
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/usecase"
+)
+
+// RefundWorker mengantrekan refund untuk event yang dibatalkan dan memproses refund yang sudah
+// disetujui, termasuk yang tertunda karena proses sebelumnya berhenti di tengah jalan
+type RefundWorker struct {
+	refundUsecase usecase.RefundUsecase
+	interval      time.Duration
+}
+
+func NewRefundWorker(refundUsecase usecase.RefundUsecase, intervalSeconds string) *RefundWorker {
+	interval, _ := strconv.Atoi(intervalSeconds)
+	if interval <= 0 {
+		interval = 60 // default 60 detik
+	}
+
+	return &RefundWorker{
+		refundUsecase: refundUsecase,
+		interval:      time.Duration(interval) * time.Second,
+	}
+}
+
+// Start menjalankan pemrosesan refund secara berkala sampai ctx dibatalkan
+func (w *RefundWorker) Start(ctx context.Context) {
+	log.Printf("Worker refund berjalan setiap %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Worker refund dihentikan")
+			return
+		case <-ticker.C:
+			completed, err := w.refundUsecase.ProcessRefunds(ctx)
+			if err != nil {
+				log.Printf("Gagal memproses refund: %v", err)
+				continue
+			}
+			if completed > 0 {
+				log.Printf("%d refund selesai diproses", completed)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+//internal/worker/seat_hold_worker.go
+
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/usecase"
+)
+
+// SeatHoldWorker melepas hold kursi bernomor yang melewati batas waktu checkout
+type SeatHoldWorker struct {
+	seatUsecase usecase.SeatUsecase
+	interval    time.Duration
+}
+
+func NewSeatHoldWorker(seatUsecase usecase.SeatUsecase, intervalSeconds string) *SeatHoldWorker {
+	interval, _ := strconv.Atoi(intervalSeconds)
+	if interval <= 0 {
+		interval = 30 // default 30 detik
+	}
+
+	return &SeatHoldWorker{
+		seatUsecase: seatUsecase,
+		interval:    time.Duration(interval) * time.Second,
+	}
+}
+
+// Start melepas hold kursi kedaluwarsa secara berkala sampai ctx dibatalkan
+func (w *SeatHoldWorker) Start(ctx context.Context) {
+	log.Printf("Worker hold kursi berjalan setiap %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Worker hold kursi dihentikan")
+			return
+		case <-ticker.C:
+			released, err := w.seatUsecase.ExpireSeatHolds(ctx)
+			if err != nil {
+				log.Printf("Gagal melepas hold kursi kedaluwarsa: %v", err)
+				continue
+			}
+			if released > 0 {
+				log.Printf("%d hold kursi kedaluwarsa dilepas", released)
+			}
+		}
+	}
+}
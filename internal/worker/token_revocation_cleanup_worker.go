@@ -0,0 +1,57 @@
+//internal/worker/token_revocation_cleanup_worker.go
+
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/usecase"
+)
+
+// TokenRevocationCleanupWorker menghapus pencabutan token yang access token terdampaknya sudah
+// kedaluwarsa. Token kedaluwarsa sudah ditolak saat validasi JWT, jadi worker ini hanya menjaga
+// ukuran tabel dan cache pencabutan.
+type TokenRevocationCleanupWorker struct {
+	tokenRevocationUsecase usecase.TokenRevocationUsecase
+	interval               time.Duration
+}
+
+func NewTokenRevocationCleanupWorker(tokenRevocationUsecase usecase.TokenRevocationUsecase, intervalSeconds string) *TokenRevocationCleanupWorker {
+	interval, _ := strconv.Atoi(intervalSeconds)
+	if interval <= 0 {
+		interval = 3600 // default 1 jam
+	}
+
+	return &TokenRevocationCleanupWorker{
+		tokenRevocationUsecase: tokenRevocationUsecase,
+		interval:               time.Duration(interval) * time.Second,
+	}
+}
+
+// Start menghapus pencabutan token kedaluwarsa secara berkala sampai ctx dibatalkan
+func (w *TokenRevocationCleanupWorker) Start(ctx context.Context) {
+	log.Printf("Worker pembersih pencabutan token berjalan setiap %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Worker pembersih pencabutan token dihentikan")
+			return
+		case <-ticker.C:
+			deleted, err := w.tokenRevocationUsecase.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("Gagal menghapus pencabutan token kedaluwarsa: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("%d pencabutan token kedaluwarsa dihapus", deleted)
+			}
+		}
+	}
+}
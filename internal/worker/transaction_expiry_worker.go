@@ -0,0 +1,54 @@
+//internal/worker/transaction_expiry_worker.go
+
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/usecase"
+)
+
+type TransactionExpiryWorker struct {
+	transactionUsecase usecase.TransactionUsecase
+	interval           time.Duration
+}
+
+func NewTransactionExpiryWorker(transactionUsecase usecase.TransactionUsecase, intervalSeconds string) *TransactionExpiryWorker {
+	interval, _ := strconv.Atoi(intervalSeconds)
+	if interval <= 0 {
+		interval = 60 // default 60 detik
+	}
+
+	return &TransactionExpiryWorker{
+		transactionUsecase: transactionUsecase,
+		interval:           time.Duration(interval) * time.Second,
+	}
+}
+
+// Start menjalankan sweeper secara berkala sampai ctx dibatalkan
+func (w *TransactionExpiryWorker) Start(ctx context.Context) {
+	log.Printf("Worker kedaluwarsa transaksi berjalan setiap %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Worker kedaluwarsa transaksi dihentikan")
+			return
+		case <-ticker.C:
+			expired, err := w.transactionUsecase.ExpirePendingTransactions(ctx)
+			if err != nil {
+				log.Printf("Gagal memproses transaksi kedaluwarsa: %v", err)
+				continue
+			}
+			if expired > 0 {
+				log.Printf("%d transaksi pending ditandai expired", expired)
+			}
+		}
+	}
+}
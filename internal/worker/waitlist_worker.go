@@ -0,0 +1,57 @@
+//internal/worker/waitlist_worker.go
+
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"ticket-system/internal/usecase"
+)
+
+// WaitlistWorker mengembalikan kursi dari penawaran waitlist yang tidak diklaim dan menawarkan
+// kursi kosong ke antrean berikutnya, termasuk kursi dari transaksi yang batal, kedaluwarsa,
+// atau direfund
+type WaitlistWorker struct {
+	waitlistUsecase usecase.WaitlistUsecase
+	interval        time.Duration
+}
+
+func NewWaitlistWorker(waitlistUsecase usecase.WaitlistUsecase, intervalSeconds string) *WaitlistWorker {
+	interval, _ := strconv.Atoi(intervalSeconds)
+	if interval <= 0 {
+		interval = 30 // default 30 detik
+	}
+
+	return &WaitlistWorker{
+		waitlistUsecase: waitlistUsecase,
+		interval:        time.Duration(interval) * time.Second,
+	}
+}
+
+// Start memproses waitlist secara berkala sampai ctx dibatalkan
+func (w *WaitlistWorker) Start(ctx context.Context) {
+	log.Printf("Worker waitlist berjalan setiap %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Worker waitlist dihentikan")
+			return
+		case <-ticker.C:
+			offered, err := w.waitlistUsecase.ProcessWaitlist(ctx)
+			if err != nil {
+				log.Printf("Gagal memproses waitlist: %v", err)
+				continue
+			}
+			if offered > 0 {
+				log.Printf("%d penawaran waitlist dikirim", offered)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+-- migrations/alter_idempotency_keys.sql
+
+-- Upgrade untuk database yang dibuat sebelum header Idempotency-Key didukung.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'processing',
+    response_code INTEGER,
+    response_body BYTEA,
+    content_type VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    UNIQUE (user_id, idempotency_key),
+    CHECK (status IN ('processing', 'completed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys(expires_at);
+
+COMMIT;
@@ -0,0 +1,37 @@
+-- migrations/alter_money_columns.sql
+
+-- Upgrade untuk database yang dibuat sebelum nominal uang memakai satuan terkecil.
+-- DECIMAL(10, 2) hanya menampung sampai 99.999.999,99 sehingga total transaksi rupiah
+-- yang besar ditolak database. Semua kolom nominal dilebarkan ke DECIMAL(18, 2) dan
+-- setiap tabel yang menyimpan nominal mendapat kolom currency (data lama dianggap IDR).
+-- Jalankan setelah script upgrade untuk tipe tiket dan kode promo (lihat urutan di README)
+-- karena kolom nominal tabel-tabel tersebut dibuat di sana.
+
+BEGIN;
+
+ALTER TABLE IF EXISTS events ALTER COLUMN price TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS events ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE IF EXISTS ticket_types ALTER COLUMN price TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS ticket_types ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE IF EXISTS promo_codes ALTER COLUMN discount_value TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS promo_codes ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE IF EXISTS orders ALTER COLUMN total_amount TYPE DECIMAL(18, 2);
+
+ALTER TABLE IF EXISTS transactions ALTER COLUMN total_amount TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS transactions ALTER COLUMN discount_amount TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS transactions ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE IF EXISTS promo_redemptions ALTER COLUMN discount_amount TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS promo_redemptions ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE IF EXISTS transaction_items ALTER COLUMN unit_price TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS transaction_items ALTER COLUMN subtotal TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS transaction_items ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE IF EXISTS payments ALTER COLUMN amount TYPE DECIMAL(18, 2);
+ALTER TABLE IF EXISTS payments ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+COMMIT;
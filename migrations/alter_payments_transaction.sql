@@ -0,0 +1,9 @@
+-- migrations/alter_payments_transaction.sql
+
+-- Upgrade untuk database yang dibuat sebelum pembayaran Midtrans untuk transaksi langsung.
+
+BEGIN;
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS transaction_id INTEGER UNIQUE REFERENCES transactions(id);
+
+COMMIT;
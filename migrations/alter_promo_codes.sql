@@ -0,0 +1,46 @@
+-- migrations/alter_promo_codes.sql
+
+-- Upgrade untuk database yang dibuat sebelum kode promo. Transaksi lama tidak memiliki diskon.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    event_id INTEGER REFERENCES events(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value DECIMAL(10, 2) NOT NULL,
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    used_count INTEGER NOT NULL DEFAULT 0,
+    per_user_limit INTEGER NOT NULL DEFAULT 0,
+    min_quantity INTEGER NOT NULL DEFAULT 1,
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (owner_id, code),
+    CHECK (discount_type IN ('percentage', 'fixed')),
+    CHECK (discount_value > 0),
+    CHECK (max_uses = 0 OR used_count <= max_uses)
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS promo_code_id INTEGER REFERENCES promo_codes(id);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id SERIAL PRIMARY KEY,
+    promo_code_id INTEGER NOT NULL REFERENCES promo_codes(id),
+    transaction_id INTEGER UNIQUE NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    discount_amount DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_codes_owner ON promo_codes(owner_id);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_user ON promo_redemptions(promo_code_id, user_id) WHERE status = 'active';
+
+COMMIT;
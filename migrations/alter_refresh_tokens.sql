@@ -0,0 +1,21 @@
+-- migrations/alter_refresh_tokens.sql
+
+-- Upgrade untuk database yang dibuat sebelum refresh token opaque dengan rotasi didukung.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    rotated_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);
+
+COMMIT;
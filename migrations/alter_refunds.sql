@@ -0,0 +1,47 @@
+-- migrations/alter_refunds.sql
+
+-- Upgrade untuk database yang dibuat sebelum fitur refund. Event lama tidak mengizinkan
+-- refund atas permintaan pembeli sampai organizer mengatur kebijakannya.
+
+BEGIN;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS refund_allowed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS refund_deadline_hours INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS refund_percent DECIMAL(5, 2) NOT NULL DEFAULT 100;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    amount DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    status VARCHAR(20) NOT NULL DEFAULT 'requested',
+    source VARCHAR(20) NOT NULL,
+    reason TEXT,
+    reviewed_by INTEGER REFERENCES users(id),
+    reviewed_at TIMESTAMP,
+    reject_reason TEXT,
+    gateway_refund_id VARCHAR(100),
+    failure_reason TEXT,
+    processing_started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount >= 0),
+    CHECK (status IN ('requested', 'approved', 'rejected', 'processing', 'completed', 'failed')),
+    CHECK (source IN ('buyer', 'event_cancelled', 'late_payment'))
+);
+
+-- Refund yang dibuat sebelum processing_started_at ada memakai updated_at sebagai waktu mulai
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS processing_started_at TIMESTAMP;
+UPDATE refunds SET processing_started_at = updated_at WHERE status = 'processing' AND processing_started_at IS NULL;
+
+-- Refund otomatis untuk pembayaran yang masuk setelah transaksi berakhir
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_source_check;
+ALTER TABLE refunds ADD CONSTRAINT refunds_source_check CHECK (source IN ('buyer', 'event_cancelled', 'late_payment'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refunds_transaction_open ON refunds(transaction_id) WHERE status <> 'rejected';
+CREATE INDEX IF NOT EXISTS idx_refunds_event_status ON refunds(event_id, status);
+
+COMMIT;
@@ -0,0 +1,53 @@
+-- migrations/alter_reserved_seating.sql
+
+-- Upgrade untuk database yang dibuat sebelum fitur kursi bernomor. Event lama tetap memakai
+-- kapasitas umum (venue_id kosong) sampai organizer memasang denah venue.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS venues (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR(200) NOT NULL,
+    address TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS venue_seats (
+    id SERIAL PRIMARY KEY,
+    venue_id INTEGER NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    section VARCHAR(50) NOT NULL,
+    row_label VARCHAR(20) NOT NULL,
+    seat_number VARCHAR(20) NOT NULL,
+    pos_x DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pos_y DOUBLE PRECISION NOT NULL DEFAULT 0,
+    is_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (venue_id, section, row_label, seat_number)
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id INTEGER REFERENCES venues(id);
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_id INTEGER REFERENCES venue_seats(id);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_label VARCHAR(100);
+
+CREATE TABLE IF NOT EXISTS seat_holds (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    seat_id INTEGER NOT NULL REFERENCES venue_seats(id),
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transaction_id INTEGER REFERENCES transactions(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('held', 'booked', 'released'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_venues_owner ON venues(owner_id);
+CREATE INDEX IF NOT EXISTS idx_events_venue ON events(venue_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seat_holds_active ON seat_holds(event_id, seat_id) WHERE status IN ('held', 'booked');
+CREATE INDEX IF NOT EXISTS idx_seat_holds_expiry ON seat_holds(expires_at) WHERE status = 'held';
+CREATE INDEX IF NOT EXISTS idx_seat_holds_transaction ON seat_holds(transaction_id) WHERE status = 'booked';
+
+COMMIT;
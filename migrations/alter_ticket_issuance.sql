@@ -0,0 +1,43 @@
+-- migrations/alter_ticket_issuance.sql
+
+-- Upgrade untuk database yang dibuat sebelum penerbitan tiket per kursi. Trigger
+-- update_tickets_sold harus dihapus karena tickets_sold sudah dihitung aplikasi saat transaksi
+-- dibuat, jika dibiarkan setiap tiket yang terbit menambah tickets_sold untuk kedua kalinya.
+-- Transaksi paid dan refunded yang sudah ada diterbitkan tiketnya di sini.
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_update_tickets_sold ON tickets;
+DROP FUNCTION IF EXISTS update_tickets_sold();
+
+-- Aplikasi lama tidak pernah mengisi tabel tickets. Baris yang diisi manual tidak memiliki
+-- transaksi sehingga tidak bisa dipindahkan ke layout baru secara otomatis.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'tickets' AND column_name = 'transaction_id'
+    ) AND EXISTS (SELECT 1 FROM tickets) THEN
+        RAISE EXCEPTION 'tabel tickets berisi tiket tanpa transaksi, pindahkan atau hapus sebelum upgrade';
+    END IF;
+END
+$$;
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS transaction_id INTEGER NOT NULL REFERENCES transactions(id);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS ticket_code VARCHAR(64) UNIQUE NOT NULL;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_number INTEGER NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tickets_transaction_id_seat_number_key ON tickets(transaction_id, seat_number);
+CREATE INDEX IF NOT EXISTS idx_tickets_transaction ON tickets(transaction_id);
+
+INSERT INTO tickets (transaction_id, event_id, user_id, ticket_code, seat_number, status)
+SELECT t.id, t.event_id, t.user_id,
+       'TKT-' || upper(substr(replace(gen_random_uuid()::text, '-', ''), 1, 24)),
+       seat.n,
+       CASE WHEN t.status = 'refunded' THEN 'refunded' ELSE 'active' END
+FROM transactions t
+CROSS JOIN LATERAL generate_series(1, t.quantity) AS seat(n)
+WHERE t.status IN ('paid', 'refunded')
+  AND NOT EXISTS (SELECT 1 FROM tickets existing WHERE existing.transaction_id = t.id);
+
+COMMIT;
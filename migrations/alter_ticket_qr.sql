@@ -0,0 +1,17 @@
+-- migrations/alter_ticket_qr.sql
+
+-- Upgrade untuk database yang dibuat sebelum QR tiket dan check-in. Tiket lama mendapat
+-- nonce acak sehingga QR-nya langsung bisa dipakai.
+
+BEGIN;
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS qr_nonce VARCHAR(32);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS checked_in_by INTEGER REFERENCES users(id);
+
+UPDATE tickets SET qr_nonce = substr(replace(gen_random_uuid()::text, '-', ''), 1, 16)
+WHERE qr_nonce IS NULL;
+
+ALTER TABLE tickets ALTER COLUMN qr_nonce SET NOT NULL;
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- migrations/alter_ticket_scans.sql
+
+-- Upgrade untuk database yang dibuat sebelum check-in offline.
+
+BEGIN;
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS checked_in_gate VARCHAR(50);
+
+CREATE TABLE IF NOT EXISTS ticket_scans (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id),
+    ticket_id INTEGER REFERENCES tickets(id),
+    device_id VARCHAR(100) NOT NULL,
+    gate VARCHAR(50),
+    payload_hash VARCHAR(64) NOT NULL,
+    scanned_at TIMESTAMP NOT NULL,
+    result VARCHAR(20) NOT NULL,
+    synced_by INTEGER REFERENCES users(id),
+    synced_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (device_id, payload_hash, scanned_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_scans_event ON ticket_scans(event_id);
+
+COMMIT;
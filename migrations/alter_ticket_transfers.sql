@@ -0,0 +1,33 @@
+-- migrations/alter_ticket_transfers.sql
+
+-- Upgrade untuk database yang dibuat sebelum fitur transfer tiket. Event lama mengizinkan
+-- transfer tanpa batas waktu dan jumlah sampai organizer mengatur kebijakannya.
+
+BEGIN;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS transfer_allowed BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS transfer_deadline_hours INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS transfer_max_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS transfer_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS ticket_transfers (
+    id SERIAL PRIMARY KEY,
+    ticket_id INTEGER NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id),
+    from_user_id INTEGER NOT NULL REFERENCES users(id),
+    to_user_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_user_id <> to_user_id),
+    CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_transfers_pending ON ticket_transfers(ticket_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_ticket ON ticket_transfers(ticket_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_from_pending ON ticket_transfers(from_user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_to_pending ON ticket_transfers(to_user_id) WHERE status = 'pending';
+
+COMMIT;
@@ -0,0 +1,43 @@
+-- migrations/alter_ticket_types.sql
+
+-- Upgrade untuk database yang dibuat sebelum tipe tiket. Event lama tetap memakai harga
+-- dan kapasitas event sampai organizer menambahkan tipe tiket.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS ticket_types (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    price DECIMAL(10, 2) NOT NULL,
+    quota INTEGER NOT NULL,
+    sold INTEGER NOT NULL DEFAULT 0,
+    sales_start TIMESTAMP,
+    sales_end TIMESTAMP,
+    max_per_order INTEGER NOT NULL DEFAULT 10,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id, name),
+    CHECK (sold <= quota),
+    CHECK (quota > 0),
+    CHECK (price >= 0),
+    CHECK (max_per_order > 0)
+);
+
+CREATE TABLE IF NOT EXISTS transaction_items (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    ticket_type_id INTEGER NOT NULL REFERENCES ticket_types(id),
+    ticket_type_name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL,
+    subtotal DECIMAL(10, 2) NOT NULL
+);
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS ticket_type_id INTEGER REFERENCES ticket_types(id);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_types_event ON ticket_types(event_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_items_transaction ON transaction_items(transaction_id);
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- migrations/alter_token_revocations.sql
+
+-- Upgrade untuk database yang dibuat sebelum logout dan pencabutan token didukung.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    jti VARCHAR(64),
+    token_version INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE token_revocations ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 0;
+
+-- Pencabutan seluruh sesi yang dibuat sebelum versi token ada tetap mencabut token lama user
+UPDATE users SET token_version = 1
+WHERE token_version = 0
+  AND id IN (SELECT user_id FROM token_revocations WHERE jti IS NULL AND expires_at > NOW());
+UPDATE token_revocations SET token_version = 1 WHERE jti IS NULL AND token_version = 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_token_revocations_jti ON token_revocations(jti) WHERE jti IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_token_revocations_expires ON token_revocations(expires_at);
+
+COMMIT;
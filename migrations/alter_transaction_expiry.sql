@@ -0,0 +1,15 @@
+-- migrations/alter_transaction_expiry.sql
+
+-- Upgrade untuk database yang dibuat sebelum batas waktu pembayaran. Transaksi pending lama
+-- diberi batas waktu 60 menit (default PAYMENT_DEADLINE_MINUTES) dari waktu dibuat.
+
+BEGIN;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+UPDATE transactions SET expires_at = created_at + INTERVAL '60 minutes'
+WHERE status = 'pending' AND expires_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_pending_expiry ON transactions(expires_at) WHERE status = 'pending';
+
+COMMIT;
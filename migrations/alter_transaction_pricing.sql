@@ -0,0 +1,34 @@
+-- migrations/alter_transaction_pricing.sql
+
+-- Upgrade untuk database yang dibuat sebelum rincian biaya layanan dan PPN. Transaksi lama
+-- tidak memiliki biaya tambahan sehingga subtotal diisi ulang dari total + diskon.
+
+BEGIN;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS subtotal_amount DECIMAL(18, 2) NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS service_fee_amount DECIMAL(18, 2) NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS platform_fee_amount DECIMAL(18, 2) NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS platform_fee_to_buyer BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tax_amount DECIMAL(18, 2) NOT NULL DEFAULT 0;
+
+UPDATE transactions SET subtotal_amount = total_amount + discount_amount WHERE subtotal_amount = 0;
+
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    event_id INTEGER UNIQUE REFERENCES events(id) ON DELETE CASCADE,
+    service_fee_percent DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    service_fee_per_ticket DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    tax_percent DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    pass_platform_fee BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (service_fee_percent BETWEEN 0 AND 100),
+    CHECK (tax_percent BETWEEN 0 AND 100),
+    CHECK (service_fee_per_ticket >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pricing_rules_owner_default ON pricing_rules(owner_id) WHERE event_id IS NULL;
+
+COMMIT;
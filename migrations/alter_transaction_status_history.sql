@@ -0,0 +1,21 @@
+-- migrations/alter_transaction_status_history.sql
+
+-- Upgrade untuk database yang dibuat sebelum riwayat status transaksi. Transaksi lama tidak
+-- memiliki riwayat, pencatatan dimulai dari perubahan status berikutnya.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS transaction_status_history (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id INTEGER REFERENCES users(id),
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_status_history_transaction ON transaction_status_history(transaction_id);
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- migrations/alter_waitlist.sql
+
+-- Upgrade untuk database yang dibuat sebelum fitur waitlist.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    offered_at TIMESTAMP,
+    offer_expires_at TIMESTAMP,
+    claimed_transaction_id INTEGER REFERENCES transactions(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (quantity > 0),
+    CHECK (status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_active ON waitlist_entries(event_id, user_id) WHERE status IN ('waiting', 'offered');
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_queue ON waitlist_entries(event_id, created_at, id) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_offer_expiry ON waitlist_entries(offer_expires_at) WHERE status = 'offered';
+
+COMMIT;
@@ -9,6 +9,7 @@ DROP FUNCTION IF EXISTS update_tickets_sold();
 DROP INDEX IF EXISTS idx_events_owner;
 DROP INDEX IF EXISTS idx_tickets_event;
 DROP INDEX IF EXISTS idx_tickets_user;
+DROP INDEX IF EXISTS idx_tickets_transaction;
 DROP INDEX IF EXISTS idx_orders_user;
 DROP INDEX IF EXISTS idx_orders_event;
 DROP INDEX IF EXISTS idx_payments_order;
@@ -24,12 +25,60 @@ DROP INDEX IF EXISTS idx_transactions_user;
 DROP INDEX IF EXISTS idx_transactions_event;
 DROP INDEX IF EXISTS idx_transactions_code;
 DROP INDEX IF EXISTS idx_transactions_status;
+DROP INDEX IF EXISTS idx_transactions_pending_expiry;
+DROP INDEX IF EXISTS idx_transaction_status_history_transaction;
+DROP INDEX IF EXISTS idx_ticket_scans_event;
+DROP INDEX IF EXISTS idx_ticket_types_event;
+DROP INDEX IF EXISTS idx_transaction_items_transaction;
+DROP INDEX IF EXISTS idx_promo_codes_owner;
+DROP INDEX IF EXISTS idx_promo_redemptions_user;
+DROP INDEX IF EXISTS idx_pricing_rules_owner_default;
+DROP INDEX IF EXISTS idx_refunds_transaction_open;
+DROP INDEX IF EXISTS idx_refunds_event_status;
+DROP INDEX IF EXISTS idx_idempotency_keys_expires;
+DROP INDEX IF EXISTS idx_waitlist_entries_active;
+DROP INDEX IF EXISTS idx_waitlist_entries_queue;
+DROP INDEX IF EXISTS idx_waitlist_entries_offer_expiry;
+DROP INDEX IF EXISTS idx_ticket_transfers_pending;
+DROP INDEX IF EXISTS idx_ticket_transfers_ticket;
+DROP INDEX IF EXISTS idx_ticket_transfers_from_pending;
+DROP INDEX IF EXISTS idx_ticket_transfers_to_pending;
+DROP INDEX IF EXISTS idx_venues_owner;
+DROP INDEX IF EXISTS idx_events_venue;
+DROP INDEX IF EXISTS idx_seat_holds_active;
+DROP INDEX IF EXISTS idx_seat_holds_expiry;
+DROP INDEX IF EXISTS idx_seat_holds_transaction;
+DROP INDEX IF EXISTS idx_refresh_tokens_family;
+DROP INDEX IF EXISTS idx_refresh_tokens_user;
+DROP INDEX IF EXISTS idx_token_revocations_jti;
+DROP INDEX IF EXISTS idx_token_revocations_expires;
+DROP INDEX IF EXISTS idx_password_resets_user;
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_user;
 
-DROP TABLE IF EXISTS transactions CASCADE;
+DROP TABLE IF EXISTS ticket_scans CASCADE;
+DROP TABLE IF EXISTS ticket_transfers CASCADE;
+DROP TABLE IF EXISTS idempotency_keys CASCADE;
+DROP TABLE IF EXISTS seat_holds CASCADE;
+DROP TABLE IF EXISTS waitlist_entries CASCADE;
+DROP TABLE IF EXISTS transaction_items CASCADE;
+DROP TABLE IF EXISTS refunds CASCADE;
+DROP TABLE IF EXISTS promo_redemptions CASCADE;
+DROP TABLE IF EXISTS transaction_status_history CASCADE;
 DROP TABLE IF EXISTS payments CASCADE;
+DROP TABLE IF EXISTS transactions CASCADE;
 DROP TABLE IF EXISTS orders CASCADE;
 DROP TABLE IF EXISTS tickets CASCADE;
+DROP TABLE IF EXISTS ticket_types CASCADE;
+DROP TABLE IF EXISTS promo_codes CASCADE;
+DROP TABLE IF EXISTS pricing_rules CASCADE;
 DROP TABLE IF EXISTS events CASCADE;
+DROP TABLE IF EXISTS venue_seats CASCADE;
+DROP TABLE IF EXISTS venues CASCADE;
+DROP TABLE IF EXISTS mfa_recovery_codes CASCADE;
+DROP TABLE IF EXISTS user_mfa CASCADE;
+DROP TABLE IF EXISTS password_resets CASCADE;
+DROP TABLE IF EXISTS token_revocations CASCADE;
+DROP TABLE IF EXISTS refresh_tokens CASCADE;
 DROP TABLE IF EXISTS user_profiles CASCADE;
 DROP TABLE IF EXISTS users CASCADE;
 DROP TABLE IF EXISTS email_verifications CASCADE;
\ No newline at end of file
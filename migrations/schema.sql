@@ -8,6 +8,7 @@ CREATE TABLE users (
     password VARCHAR(255) NOT NULL,
     role VARCHAR(20) DEFAULT 'user',
     is_verified BOOLEAN DEFAULT FALSE,
+    token_version INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -33,7 +34,82 @@ CREATE TABLE email_verifications (
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
--- Events
+-- Refresh Tokens (hanya hash SHA-256 yang disimpan, satu family per sesi login)
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    rotated_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Token Revocations (jti kosong berarti semua access token user dengan versi di bawah token_version)
+CREATE TABLE token_revocations (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    jti VARCHAR(64),
+    token_version INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Password Resets (hanya hash SHA-256 token yang disimpan, used_at terisi setelah dipakai)
+CREATE TABLE password_resets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expired_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- User MFA (secret TOTP terenkripsi AES-GCM, enabled_at kosong berarti setup belum dikonfirmasi)
+CREATE TABLE user_mfa (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_encrypted TEXT NOT NULL,
+    enabled_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- MFA Recovery Codes (hash argon2, used_at terisi setelah dipakai)
+CREATE TABLE mfa_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Venues (denah tempat duduk milik organizer, dipakai ulang oleh beberapa event)
+CREATE TABLE venues (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR(200) NOT NULL,
+    address TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Venue Seats. pos_x dan pos_y adalah posisi kursi pada denah untuk digambar oleh klien.
+CREATE TABLE venue_seats (
+    id SERIAL PRIMARY KEY,
+    venue_id INTEGER NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    section VARCHAR(50) NOT NULL,
+    row_label VARCHAR(20) NOT NULL,
+    seat_number VARCHAR(20) NOT NULL,
+    pos_x DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pos_y DOUBLE PRECISION NOT NULL DEFAULT 0,
+    is_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (venue_id, section, row_label, seat_number)
+);
+
+-- Events. Nominal uang disimpan dalam satuan utama dengan currency di kolom terpisah,
+-- aplikasi mengubahnya ke satuan terkecil (rupiah penuh untuk IDR).
 CREATE TABLE events (
     id SERIAL PRIMARY KEY,
     owner_id INTEGER REFERENCES users(id),
@@ -43,21 +119,88 @@ CREATE TABLE events (
     event_date TIMESTAMP NOT NULL,
     max_capacity INTEGER NOT NULL,
     tickets_sold INTEGER DEFAULT 0,
-    price DECIMAL(10, 2) NOT NULL,
+    price DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
     status VARCHAR(20) DEFAULT 'active',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    refund_allowed BOOLEAN NOT NULL DEFAULT FALSE,
+    refund_deadline_hours INTEGER NOT NULL DEFAULT 0,
+    refund_percent DECIMAL(5, 2) NOT NULL DEFAULT 100,
+    CHECK (refund_deadline_hours >= 0),
+    transfer_allowed BOOLEAN NOT NULL DEFAULT TRUE,
+    transfer_deadline_hours INTEGER NOT NULL DEFAULT 0,
+    transfer_max_count INTEGER NOT NULL DEFAULT 0,
+    venue_id INTEGER REFERENCES venues(id),
+    CHECK (refund_percent BETWEEN 0 AND 100),
+    CHECK (transfer_deadline_hours >= 0),
+    CHECK (transfer_max_count >= 0)
 );
 
--- Tickets
-CREATE TABLE tickets (
+-- Ticket Types (VIP, Regular, Early Bird, dst.). sold dihitung saat transaksi dibuat,
+-- sama seperti events.tickets_sold, jadi kuota ikut terkunci selama menunggu pembayaran.
+CREATE TABLE ticket_types (
     id SERIAL PRIMARY KEY,
-    event_id INTEGER REFERENCES events(id),
-    user_id INTEGER REFERENCES users(id),
-    purchase_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    status VARCHAR(20) DEFAULT 'active',
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    price DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    quota INTEGER NOT NULL,
+    sold INTEGER NOT NULL DEFAULT 0,
+    sales_start TIMESTAMP,
+    sales_end TIMESTAMP,
+    max_per_order INTEGER NOT NULL DEFAULT 10,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id, name),
+    CHECK (sold <= quota),
+    CHECK (quota > 0),
+    CHECK (price >= 0),
+    CHECK (max_per_order > 0)
+);
+
+-- Promo Codes. event_id kosong berarti kode berlaku untuk semua event milik organizer.
+-- max_uses dan per_user_limit bernilai 0 berarti tidak dibatasi.
+CREATE TABLE promo_codes (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    event_id INTEGER REFERENCES events(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    used_count INTEGER NOT NULL DEFAULT 0,
+    per_user_limit INTEGER NOT NULL DEFAULT 0,
+    min_quantity INTEGER NOT NULL DEFAULT 1,
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (owner_id, code),
+    CHECK (discount_type IN ('percentage', 'fixed')),
+    CHECK (discount_value > 0),
+    CHECK (max_uses = 0 OR used_count <= max_uses)
+);
+
+-- Pricing Rules (biaya layanan dan PPN yang dibebankan organizer ke pembeli). event_id kosong
+-- berarti aturan default organizer, aturan khusus event menggantikan default secara utuh.
+CREATE TABLE pricing_rules (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    event_id INTEGER UNIQUE REFERENCES events(id) ON DELETE CASCADE,
+    service_fee_percent DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    service_fee_per_ticket DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    tax_percent DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    pass_platform_fee BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (service_fee_percent BETWEEN 0 AND 100),
+    CHECK (tax_percent BETWEEN 0 AND 100),
+    CHECK (service_fee_per_ticket >= 0)
 );
 
 -- Orders
@@ -66,20 +209,219 @@ CREATE TABLE orders (
     user_id INTEGER REFERENCES users(id),
     event_id INTEGER REFERENCES events(id),
     order_number VARCHAR(50) UNIQUE NOT NULL,
-    total_amount DECIMAL(10, 2) NOT NULL,
+    total_amount DECIMAL(18, 2) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Transactions (New Table for Direct Transactions without Midtrans)
+CREATE TABLE transactions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id),
+    event_id INTEGER REFERENCES events(id),
+    transaction_code VARCHAR(50) UNIQUE NOT NULL,
     quantity INTEGER NOT NULL DEFAULT 1,
+    total_amount DECIMAL(18, 2) NOT NULL,
     status VARCHAR(20) DEFAULT 'pending',
+    payment_method VARCHAR(50) NOT NULL,
+    payment_detail TEXT,
+    payment_proof TEXT,
+    verified_at TIMESTAMP,
+    verified_by INTEGER REFERENCES users(id),
+    expires_at TIMESTAMP,
+    promo_code_id INTEGER REFERENCES promo_codes(id),
+    discount_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    subtotal_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    service_fee_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    platform_fee_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    platform_fee_to_buyer BOOLEAN NOT NULL DEFAULT FALSE,
+    tax_amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Promo Redemptions (satu baris per transaksi yang memakai kode promo). Baris berstatus
+-- released tidak dihitung lagi untuk batas pemakaian per user.
+CREATE TABLE promo_redemptions (
+    id SERIAL PRIMARY KEY,
+    promo_code_id INTEGER NOT NULL REFERENCES promo_codes(id),
+    transaction_id INTEGER UNIQUE NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    discount_amount DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP
+);
+
+-- Refunds (pengembalian dana per transaksi). Hanya satu refund yang belum ditolak per
+-- transaksi, refund yang gagal di payment gateway dipakai ulang saat dicoba lagi.
+CREATE TABLE refunds (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    amount DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    status VARCHAR(20) NOT NULL DEFAULT 'requested',
+    source VARCHAR(20) NOT NULL,
+    reason TEXT,
+    reviewed_by INTEGER REFERENCES users(id),
+    reviewed_at TIMESTAMP,
+    reject_reason TEXT,
+    gateway_refund_id VARCHAR(100),
+    failure_reason TEXT,
+    processing_started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount >= 0),
+    CHECK (status IN ('requested', 'approved', 'rejected', 'processing', 'completed', 'failed')),
+    CHECK (source IN ('buyer', 'event_cancelled', 'late_payment'))
+);
+
+-- Waitlist Entries (antrean FIFO untuk event yang kapasitasnya habis). Entry offered
+-- memegang kursi di events.tickets_sold sampai offer_expires_at.
+CREATE TABLE waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    offered_at TIMESTAMP,
+    offer_expires_at TIMESTAMP,
+    claimed_transaction_id INTEGER REFERENCES transactions(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (quantity > 0),
+    CHECK (status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled'))
+);
+
+-- Seat Holds (kunci kursi bernomor per event). Hold held berlaku sampai expires_at selama
+-- pembeli checkout, hold booked milik transaksi yang belum batal. Unique index parsial
+-- idx_seat_holds_active memastikan satu kursi hanya ditempati satu hold.
+CREATE TABLE seat_holds (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    seat_id INTEGER NOT NULL REFERENCES venue_seats(id),
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transaction_id INTEGER REFERENCES transactions(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('held', 'booked', 'released'))
+);
+
+-- Idempotency Keys (respons pertama untuk header Idempotency-Key, disimpan per user
+-- sampai expires_at agar retry dari klien tidak membuat transaksi ganda)
+CREATE TABLE idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'processing',
+    response_code INTEGER,
+    response_body BYTEA,
+    content_type VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    UNIQUE (user_id, idempotency_key),
+    CHECK (status IN ('processing', 'completed'))
+);
+
+-- Transaction Items (rincian tipe tiket yang dibeli dalam satu transaksi)
+CREATE TABLE transaction_items (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    ticket_type_id INTEGER NOT NULL REFERENCES ticket_types(id),
+    ticket_type_name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(18, 2) NOT NULL,
+    subtotal DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR'
+);
+
+-- Tickets (satu baris per kursi dari transaksi yang sudah dibayar)
+CREATE TABLE tickets (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    event_id INTEGER REFERENCES events(id),
+    user_id INTEGER REFERENCES users(id),
+    ticket_code VARCHAR(64) UNIQUE NOT NULL,
+    ticket_type_id INTEGER REFERENCES ticket_types(id),
+    seat_number INTEGER NOT NULL,
+    seat_id INTEGER REFERENCES venue_seats(id),
+    seat_label VARCHAR(100),
+    qr_nonce VARCHAR(32) NOT NULL,
+    purchase_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(20) DEFAULT 'active',
+    checked_in_at TIMESTAMP,
+    checked_in_by INTEGER REFERENCES users(id),
+    checked_in_gate VARCHAR(50),
+    transfer_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (transaction_id, seat_number)
+);
+
+-- Ticket Transfers (rantai kepemilikan tiket). tickets.user_id selalu pemilik terakhir,
+-- transfer accepted mencatat setiap perpindahan dari pembeli pertama.
+CREATE TABLE ticket_transfers (
+    id SERIAL PRIMARY KEY,
+    ticket_id INTEGER NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id),
+    from_user_id INTEGER NOT NULL REFERENCES users(id),
+    to_user_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_user_id <> to_user_id),
+    CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled'))
+);
+
+-- Ticket Scans (log scan dari perangkat check-in offline)
+CREATE TABLE ticket_scans (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id),
+    ticket_id INTEGER REFERENCES tickets(id),
+    device_id VARCHAR(100) NOT NULL,
+    gate VARCHAR(50),
+    payload_hash VARCHAR(64) NOT NULL,
+    scanned_at TIMESTAMP NOT NULL,
+    result VARCHAR(20) NOT NULL,
+    synced_by INTEGER REFERENCES users(id),
+    synced_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (device_id, payload_hash, scanned_at)
+);
+
+-- Transaction Status History
+CREATE TABLE transaction_status_history (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id INTEGER REFERENCES users(id),
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Payments
 CREATE TABLE payments (
     id SERIAL PRIMARY KEY,
     order_id INTEGER REFERENCES orders(id),
+    transaction_id INTEGER UNIQUE REFERENCES transactions(id),
     midtrans_transaction_id VARCHAR(100) UNIQUE,
     payment_type VARCHAR(50),
-    amount DECIMAL(10, 2) NOT NULL,
+    amount DECIMAL(18, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
     status VARCHAR(20) DEFAULT 'pending',
     midtrans_status_code VARCHAR(10),
     midtrans_status_message TEXT,
@@ -92,24 +434,6 @@ CREATE TABLE payments (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
--- Transactions (New Table for Direct Transactions without Midtrans)
-CREATE TABLE transactions (
-    id SERIAL PRIMARY KEY,
-    user_id INTEGER REFERENCES users(id),
-    event_id INTEGER REFERENCES events(id),
-    transaction_code VARCHAR(50) UNIQUE NOT NULL,
-    quantity INTEGER NOT NULL DEFAULT 1,
-    total_amount DECIMAL(10, 2) NOT NULL,
-    status VARCHAR(20) DEFAULT 'pending',
-    payment_method VARCHAR(50) NOT NULL,
-    payment_detail TEXT,
-    payment_proof TEXT,
-    verified_at TIMESTAMP,
-    verified_by INTEGER REFERENCES users(id),
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
-
 -- Indexes
 CREATE INDEX idx_user_profiles_user_id ON user_profiles(user_id);
 CREATE INDEX idx_email_verifications_token ON email_verifications(token);
@@ -117,6 +441,7 @@ CREATE INDEX idx_email_verifications_user_id ON email_verifications(user_id);
 CREATE INDEX idx_events_owner ON events(owner_id);
 CREATE INDEX idx_tickets_event ON tickets(event_id);
 CREATE INDEX idx_tickets_user ON tickets(user_id);
+CREATE INDEX idx_tickets_transaction ON tickets(transaction_id);
 CREATE INDEX idx_orders_user ON orders(user_id);
 CREATE INDEX idx_orders_event ON orders(event_id);
 CREATE INDEX idx_payments_order ON payments(order_id);
@@ -132,6 +457,35 @@ CREATE INDEX idx_transactions_user ON transactions(user_id);
 CREATE INDEX idx_transactions_event ON transactions(event_id);
 CREATE INDEX idx_transactions_code ON transactions(transaction_code);
 CREATE INDEX idx_transactions_status ON transactions(status);
+CREATE INDEX idx_transactions_pending_expiry ON transactions(expires_at) WHERE status = 'pending';
+CREATE INDEX idx_transaction_status_history_transaction ON transaction_status_history(transaction_id);
+CREATE INDEX idx_ticket_scans_event ON ticket_scans(event_id);
+CREATE INDEX idx_ticket_types_event ON ticket_types(event_id);
+CREATE INDEX idx_transaction_items_transaction ON transaction_items(transaction_id);
+CREATE INDEX idx_promo_codes_owner ON promo_codes(owner_id);
+CREATE UNIQUE INDEX idx_refunds_transaction_open ON refunds(transaction_id) WHERE status <> 'rejected';
+CREATE INDEX idx_refunds_event_status ON refunds(event_id, status);
+CREATE UNIQUE INDEX idx_pricing_rules_owner_default ON pricing_rules(owner_id) WHERE event_id IS NULL;
+CREATE INDEX idx_promo_redemptions_user ON promo_redemptions(promo_code_id, user_id) WHERE status = 'active';
+CREATE INDEX idx_idempotency_keys_expires ON idempotency_keys(expires_at);
+CREATE UNIQUE INDEX idx_waitlist_entries_active ON waitlist_entries(event_id, user_id) WHERE status IN ('waiting', 'offered');
+CREATE INDEX idx_waitlist_entries_queue ON waitlist_entries(event_id, created_at, id) WHERE status = 'waiting';
+CREATE INDEX idx_waitlist_entries_offer_expiry ON waitlist_entries(offer_expires_at) WHERE status = 'offered';
+CREATE UNIQUE INDEX idx_ticket_transfers_pending ON ticket_transfers(ticket_id) WHERE status = 'pending';
+CREATE INDEX idx_ticket_transfers_ticket ON ticket_transfers(ticket_id, created_at);
+CREATE INDEX idx_ticket_transfers_from_pending ON ticket_transfers(from_user_id) WHERE status = 'pending';
+CREATE INDEX idx_ticket_transfers_to_pending ON ticket_transfers(to_user_id) WHERE status = 'pending';
+CREATE INDEX idx_venues_owner ON venues(owner_id);
+CREATE INDEX idx_events_venue ON events(venue_id);
+CREATE UNIQUE INDEX idx_seat_holds_active ON seat_holds(event_id, seat_id) WHERE status IN ('held', 'booked');
+CREATE INDEX idx_seat_holds_expiry ON seat_holds(expires_at) WHERE status = 'held';
+CREATE INDEX idx_seat_holds_transaction ON seat_holds(transaction_id) WHERE status = 'booked';
+CREATE INDEX idx_refresh_tokens_family ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_user ON refresh_tokens(user_id);
+CREATE UNIQUE INDEX idx_token_revocations_jti ON token_revocations(jti) WHERE jti IS NOT NULL;
+CREATE INDEX idx_token_revocations_expires ON token_revocations(expires_at);
+CREATE INDEX idx_password_resets_user ON password_resets(user_id, created_at);
+CREATE INDEX idx_mfa_recovery_codes_user ON mfa_recovery_codes(user_id);
 
 -- Constraints
 ALTER TABLE events ADD CONSTRAINT check_capacity CHECK (tickets_sold <= max_capacity);
@@ -140,20 +494,6 @@ ALTER TABLE events ADD CONSTRAINT check_capacity_positive CHECK (max_capacity >
 ALTER TABLE transactions ADD CONSTRAINT check_transaction_quantity CHECK (quantity > 0);
 ALTER TABLE transactions ADD CONSTRAINT check_transaction_amount CHECK (total_amount >= 0);
 
--- Triggers
-CREATE OR REPLACE FUNCTION update_tickets_sold() RETURNS TRIGGER AS $update_tickets_sold$
-BEGIN
-    IF NEW.status = 'active' AND (TG_OP = 'INSERT' OR OLD.status != 'active') THEN
-        UPDATE events SET tickets_sold = tickets_sold + 1 WHERE id = NEW.event_id;
-    ELSIF OLD.status = 'active' AND NEW.status != 'active' THEN
-        UPDATE events SET tickets_sold = tickets_sold - 1 WHERE id = NEW.event_id;
-    END IF;
-    RETURN NEW;
-END;
-$update_tickets_sold$ LANGUAGE plpgsql;
-
-
-CREATE TRIGGER trigger_update_tickets_sold
-AFTER INSERT OR UPDATE ON tickets
-FOR EACH ROW
-EXECUTE FUNCTION update_tickets_sold();
\ No newline at end of file
+-- events.tickets_sold dihitung oleh aplikasi saat transaksi dibuat (kursi dipesan) dan
+-- dikurangi saat transaksi batal/kedaluwarsa. Penerbitan tiket tidak mengubahnya lagi, jadi
+-- trigger update_tickets_sold yang dulu menghitung ulang dari tabel tickets sudah dihapus.
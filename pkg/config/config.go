@@ -10,16 +10,72 @@ import (
 )
 
 type Config struct {
-	DBHost      string
-	DBPort      string
-	DBUser      string
-	DBPassword  string
-	DBName      string
-	DBSSLMode   string
-	ServerPort  string
-	JWTSecret   string
-	TokenExpiry string
-	AppEnv      string
+	DBHost     string
+	DBPort     string
+	DBUser     string
+	DBPassword string
+	DBName     string
+	DBSSLMode  string
+	ServerPort string
+	JWTSecret  string
+	AppEnv     string
+	
+	// Auth Settings
+	AccessTokenExpiry              string
+	RefreshTokenExpiry             string
+	TokenRevocationCleanupInterval string
+	PasswordResetExpiry            string
+	PasswordResetURL               string
+	PasswordResetRateLimit         string
+	PasswordResetRateWindow        string
+	OrganizerMFARequired           string
+	MFAChallengeExpiry             string
+	MFASecretKey                   string
+	MFARateLimit                   string
+	MFARateWindow                  string
+	
+	// Transaction Settings
+	PaymentDeadline      string
+	ExpirySweepInterval  string
+	MaxPaymentRejections string
+	PlatformFeePercent   string
+	RefundSweepInterval  string
+	
+	// Waitlist Settings
+	WaitlistOfferTTL      string
+	WaitlistSweepInterval string
+	
+	// Seating Settings
+	SeatHoldTTL           string
+	SeatHoldSweepInterval string
+	
+	// Idempotency Settings
+	IdempotencyKeyTTL          string
+	IdempotencyCleanupInterval string
+	
+	// Payment Proof Settings
+	MaxPaymentProofSize string
+	PaymentProofURLTTL  string
+	
+	// Ticket Settings
+	TicketQRSecret           string
+	TicketManifestSigningKey string
+	
+	// Storage Settings
+	StorageDriver     string
+	StorageLocalDir   string
+	StorageSigningKey string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKey       string
+	S3SecretKey       string
+	S3PathStyle       string
+	
+	// Midtrans Settings
+	MidtransServerKey   string
+	MidtransClientKey   string
+	MidtransEnvironment string
 	
 	// SMTP Settings
 	SMTPHost     string
@@ -36,16 +92,72 @@ func LoadConfig() *Config {
 	}
 
 	config := &Config{
-		DBHost:      getEnv("DB_HOST", ""),
-		DBPort:      getEnv("DB_PORT", ""),
-		DBUser:      getEnv("DB_USER", ""),
-		DBPassword:  getEnv("DB_PASSWORD", ""),
-		DBName:      getEnv("DB_NAME", ""),
-		DBSSLMode:   getEnv("DB_SSLMODE", ""),
-		ServerPort:  getEnv("SERVER_PORT", ""),
-		JWTSecret:   getEnv("JWT_SECRET", "rahasia_aku_kamu_dan_jwt"),
-		TokenExpiry: getEnv("TOKEN_EXPIRY", "24"),
-		AppEnv:      getEnv("APP_ENV", "development"),
+		DBHost:     getEnv("DB_HOST", ""),
+		DBPort:     getEnv("DB_PORT", ""),
+		DBUser:     getEnv("DB_USER", ""),
+		DBPassword: getEnv("DB_PASSWORD", ""),
+		DBName:     getEnv("DB_NAME", ""),
+		DBSSLMode:  getEnv("DB_SSLMODE", ""),
+		ServerPort: getEnv("SERVER_PORT", ""),
+		JWTSecret:  getEnv("JWT_SECRET", "rahasia_aku_kamu_dan_jwt"),
+		AppEnv:     getEnv("APP_ENV", "development"),
+		
+		// Auth Settings
+		AccessTokenExpiry:              getEnv("ACCESS_TOKEN_EXPIRY_MINUTES", "15"),
+		RefreshTokenExpiry:             getEnv("REFRESH_TOKEN_EXPIRY_HOURS", "720"),
+		TokenRevocationCleanupInterval: getEnv("TOKEN_REVOCATION_CLEANUP_INTERVAL_SECONDS", "3600"),
+		PasswordResetExpiry:            getEnv("PASSWORD_RESET_EXPIRY_MINUTES", "30"),
+		PasswordResetURL:               getEnv("PASSWORD_RESET_URL", ""),
+		PasswordResetRateLimit:         getEnv("PASSWORD_RESET_RATE_LIMIT", "5"),
+		PasswordResetRateWindow:        getEnv("PASSWORD_RESET_RATE_WINDOW_MINUTES", "15"),
+		OrganizerMFARequired:           getEnv("ORGANIZER_MFA_REQUIRED", "false"),
+		MFAChallengeExpiry:             getEnv("MFA_CHALLENGE_EXPIRY_MINUTES", "5"),
+		MFASecretKey:                   getEnv("MFA_SECRET_KEY", ""),
+		MFARateLimit:                   getEnv("MFA_RATE_LIMIT", "10"),
+		MFARateWindow:                  getEnv("MFA_RATE_WINDOW_MINUTES", "15"),
+		
+		// Transaction Settings
+		PaymentDeadline:      getEnv("PAYMENT_DEADLINE_MINUTES", "60"),
+		ExpirySweepInterval:  getEnv("TRANSACTION_EXPIRY_INTERVAL_SECONDS", "60"),
+		MaxPaymentRejections: getEnv("MAX_PAYMENT_REJECTIONS", "3"),
+		PlatformFeePercent:   getEnv("PLATFORM_FEE_PERCENT", "0"),
+		RefundSweepInterval:  getEnv("REFUND_SWEEP_INTERVAL_SECONDS", "60"),
+		
+		// Waitlist Settings
+		WaitlistOfferTTL:      getEnv("WAITLIST_OFFER_MINUTES", "30"),
+		WaitlistSweepInterval: getEnv("WAITLIST_SWEEP_INTERVAL_SECONDS", "30"),
+		
+		// Seating Settings
+		SeatHoldTTL:           getEnv("SEAT_HOLD_MINUTES", "10"),
+		SeatHoldSweepInterval: getEnv("SEAT_HOLD_SWEEP_INTERVAL_SECONDS", "30"),
+		
+		// Idempotency Settings
+		IdempotencyKeyTTL:          getEnv("IDEMPOTENCY_KEY_TTL_HOURS", "24"),
+		IdempotencyCleanupInterval: getEnv("IDEMPOTENCY_CLEANUP_INTERVAL_SECONDS", "3600"),
+		
+		// Payment Proof Settings
+		MaxPaymentProofSize: getEnv("MAX_PAYMENT_PROOF_SIZE_KB", "2048"),
+		PaymentProofURLTTL:  getEnv("PAYMENT_PROOF_URL_TTL_MINUTES", "15"),
+		
+		// Ticket Settings
+		TicketQRSecret:           getEnv("TICKET_QR_SECRET", ""),
+		TicketManifestSigningKey: getEnv("TICKET_MANIFEST_SIGNING_KEY", ""),
+		
+		// Storage Settings
+		StorageDriver:     getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:   getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		StorageSigningKey: getEnv("STORAGE_SIGNING_KEY", ""),
+		S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3AccessKey:       getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:       getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:       getEnv("S3_PATH_STYLE", "true"),
+		
+		// Midtrans Settings
+		MidtransServerKey:   getEnv("MIDTRANS_SERVER_KEY", ""),
+		MidtransClientKey:   getEnv("MIDTRANS_CLIENT_KEY", ""),
+		MidtransEnvironment: getEnv("MIDTRANS_ENVIRONMENT", "sandbox"),
 		
 		// SMTP Settings
 		SMTPHost:     getEnv("SMTP_HOST", ""),
@@ -4,8 +4,10 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -98,4 +100,11 @@ func decodeHash(encodedHash string) (*params, []byte, []byte, error) {
 	p.keyLength = uint32(len(hash))
 
 	return p, salt, hash, nil
-}
\ No newline at end of file
+}
+
+// HashToken menghasilkan hash SHA-256 (hex) untuk token acak yang disimpan di database.
+// Token acak sudah cukup panjang sehingga tidak perlu di-hash dengan argon2.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -10,24 +10,45 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// TokenTypeAccess menandai JWT yang boleh dipakai mengakses endpoint terproteksi
+	TokenTypeAccess = "access"
+	// TokenTypeMFAChallenge menandai JWT berumur pendek dari langkah pertama login akun ber-2FA,
+	// hanya bisa ditukar di /api/login/mfa
+	TokenTypeMFAChallenge = "mfa_challenge"
+)
+
 type JWTClaim struct {
-	UserID   int    `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
+	// MFA bernilai true jika sesi token ini dibuka lewat verifikasi 2FA
+	MFA bool `json:"mfa,omitempty"`
+	// TokenVersion adalah versi token user saat token terbit, lihat entity.TokenRevocation
+	TokenVersion int `json:"token_version,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID int, username, email, role, jwtSecret string, expHour int) (string, error) {
+// GenerateJWT menerbitkan token bertanda tangan HS256. mfa menandai sesi yang sudah melewati
+// verifikasi 2FA dan hanya relevan untuk access token. tokenVersion diambil dari versi token user
+// saat ini agar token ikut dicabut ketika semua sesi user dicabut.
+func GenerateJWT(userID int, username, email, role, tokenType string, mfa bool, tokenVersion int, jwtSecret string, expiry time.Duration) (string, error) {
 	claims := JWTClaim{
-		UserID:   userID,
-		Username: username,
-		Email:    email,
-		Role:     role,
+		UserID:       userID,
+		Username:     username,
+		Email:        email,
+		Role:         role,
+		TokenType:    tokenType,
+		MFA:          mfa,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// jti unik per token supaya satu access token bisa dicabut saat logout
+			ID:        GenerateRandomString(32),
 			Issuer:    "ticket-system",
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expHour) * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
 		},
 	}
 
@@ -4,11 +4,22 @@ package utils
 
 import (
 	"crypto/rand"
+	"encoding/base32"
 	"encoding/base64"
+	"strings"
 )
 
 func GenerateRandomString(length int) string {
 	b := make([]byte, length)
 	rand.Read(b)
 	return base64.URLEncoding.EncodeToString(b)[:length]
-}
\ No newline at end of file
+}
+
+// GenerateRecoveryCode menghasilkan kode cadangan 2FA berformat xxxx-xxxx dari alfabet base32
+// huruf kecil supaya mudah diketik ulang
+func GenerateRecoveryCode() string {
+	b := make([]byte, 5)
+	rand.Read(b)
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:4] + "-" + code[4:]
+}
@@ -18,6 +18,10 @@ const (
 	ErrorCodeEmailNotVerified     = "AUTH005" // Email belum diverifikasi
 	ErrorCodeEmailAlreadyVerified = "AUTH006" // Email sudah diverifikasi
 	ErrorCodeVerificationExpired  = "AUTH007" // Token verifikasi sudah kadaluarsa
+	ErrorCodeRefreshTokenReused   = "AUTH008" // Refresh token yang sudah ditukar dipakai lagi, seluruh sesi dicabut
+	ErrorCodeTokenRevoked         = "AUTH009" // Access token sudah dicabut lewat logout atau ganti password
+	ErrorCodeMFAInvalid           = "AUTH010" // Kode 2FA atau recovery code salah
+	ErrorCodeMFARequired          = "AUTH011" // Organizer wajib mengaktifkan dan login dengan 2FA
 
 	// Error codes - Validation
 	ErrorCodeInvalidInput         = "VAL001" // Input tidak valid secara umum
@@ -26,17 +30,22 @@ const (
 	ErrorCodePasswordMismatch     = "VAL004" // Password dan konfirmasi tidak cocok
 	ErrorCodeDuplicateUsername    = "VAL005" // Username sudah digunakan
 	ErrorCodeDuplicateEmail       = "VAL006" // Email sudah digunakan
+	ErrorCodeFileTooLarge         = "VAL007" // Ukuran file melebihi batas
+	ErrorCodeUnsupportedFileType  = "VAL008" // Tipe file tidak didukung
+	ErrorCodeInfectedFile         = "VAL009" // File terdeteksi mengandung virus
 
 	// Error codes - Resource
 	ErrorCodeResourceNotFound     = "RES001" // Resource tidak ditemukan
 	ErrorCodeResourceAlreadyExist = "RES002" // Resource sudah ada
 	ErrorCodeResourceLimit        = "RES003" // Melebihi batas resource
+	ErrorCodeTooManyRequests      = "RES004" // Terlalu banyak request dalam satu jendela waktu
 
 	// Error codes - Server
 	ErrorCodeServerError          = "SRV001" // Error server internal
 	ErrorCodeDatabaseError        = "SRV002" // Error database
 	ErrorCodeExternalServiceError = "SRV003" // Error layanan eksternal
 	ErrorCodeMailServiceError     = "SRV004" // Error layanan email
+	ErrorCodeServiceUnavailable   = "SRV005" // Fitur belum dikonfigurasi di server
 	
 	// Error codes - Event
 	ErrorCodeEventNotFound        = "EVT001" // Event tidak ditemukan
@@ -52,6 +61,55 @@ const (
 	ErrorCodeTicketAlreadySold    = "TKT002" // Tiket sudah terjual
 	ErrorCodeTicketSoldOut        = "TKT003" // Tiket sudah habis
 	ErrorCodeTicketInvalidQuantity = "TKT004" // Jumlah tiket tidak valid
+	ErrorCodeTicketInvalidQR      = "TKT005" // QR tiket tidak valid atau signature tidak cocok
+	ErrorCodeTicketWrongEvent     = "TKT006" // Tiket bukan untuk event yang sedang check-in
+	ErrorCodeTicketAlreadyUsed    = "TKT007" // Tiket sudah dipakai check-in
+	ErrorCodeTicketNotActive      = "TKT008" // Tiket sudah di-refund atau dibatalkan
+	ErrorCodeTicketTypeNotFound   = "TKT009" // Tipe tiket tidak ditemukan di event ini
+	ErrorCodeTicketTypeNotOnSale  = "TKT010" // Tipe tiket di luar periode penjualan
+	ErrorCodeTicketTypeLimit      = "TKT011" // Jumlah melebihi batas pembelian per transaksi tipe tiket
+	ErrorCodeTicketTypeRequired   = "TKT012" // Event memiliki tipe tiket tetapi item tidak dipilih
+
+	// Error codes - Promo
+	ErrorCodePromoNotFound        = "PRM001" // Kode promo tidak ditemukan atau tidak berlaku untuk event ini
+	ErrorCodePromoNotApplicable   = "PRM002" // Kode promo di luar masa berlaku atau syarat minimum tidak terpenuhi
+	ErrorCodePromoExhausted       = "PRM003" // Batas pemakaian total atau per user sudah tercapai
+
+	// Error codes - Transaction
+	ErrorCodeTransactionAccessDenied = "TRX001" // Bukan pembeli maupun organizer pemilik event transaksi ini
+	ErrorCodeTransactionOwnership    = "TRX002" // Organizer bukan pemilik event dari transaksi yang dikelola
+
+	// Error codes - Refund
+	ErrorCodeRefundNotFound   = "RFD001" // Refund tidak ditemukan
+	ErrorCodeRefundNotAllowed = "RFD002" // Kebijakan refund, batas waktu atau status tidak mengizinkan refund
+	ErrorCodeRefundExists     = "RFD003" // Transaksi sudah memiliki pengajuan refund yang belum ditolak
+	ErrorCodeRefundConflict   = "RFD004" // Refund sudah diubah atau diproses oleh proses lain
+
+	// Error codes - Idempotency
+	ErrorCodeIdempotencyKeyMismatch   = "IDM001" // Idempotency key dipakai ulang dengan request yang berbeda
+	ErrorCodeIdempotencyKeyInProgress = "IDM002" // Request pertama dengan idempotency key yang sama belum selesai
+
+	// Error codes - Waitlist
+	ErrorCodeWaitlistNotFound     = "WTL001" // User tidak memiliki antrean aktif di event
+	ErrorCodeWaitlistExists       = "WTL002" // User sudah memiliki antrean aktif di event
+	ErrorCodeWaitlistNotNeeded    = "WTL003" // Tiket masih tersedia sehingga tidak perlu antre
+	ErrorCodeWaitlistOfferInvalid = "WTL004" // Penawaran waitlist tidak berlaku atau jumlah tiket berbeda
+
+	// Error codes - Transfer Tiket
+	ErrorCodeTicketTransferNotFound     = "TRF001" // Transfer tiket tidak ditemukan
+	ErrorCodeTicketTransferNotAllowed   = "TRF002" // Tiket tidak memenuhi kebijakan transfer event
+	ErrorCodeTicketTransferExists       = "TRF003" // Tiket masih memiliki transfer yang menunggu konfirmasi
+	ErrorCodeTicketTransferConflict     = "TRF004" // Transfer sudah diproses atau tiket sudah berubah
+	ErrorCodeTicketTransferRecipient    = "TRF005" // Penerima transfer tidak ditemukan atau tidak valid
+
+	// Error codes - Venue & Kursi
+	ErrorCodeVenueNotFound     = "VEN001" // Venue tidak ditemukan
+	ErrorCodeVenueOwnership    = "VEN002" // Tidak memiliki izin untuk mengelola venue ini
+	ErrorCodeVenueInUse        = "VEN003" // Venue masih dipakai event atau event sudah memiliki penjualan
+	ErrorCodeSeatNotFound      = "SEA001" // Kursi tidak ada di denah venue event atau event tanpa kursi bernomor
+	ErrorCodeSeatUnavailable   = "SEA002" // Kursi sedang dipegang atau sudah dipesan pembeli lain
+	ErrorCodeSeatHoldExpired   = "SEA003" // Hold kursi tidak ditemukan atau sudah kedaluwarsa
+	ErrorCodeSeatSelection     = "SEA004" // Pilihan kursi tidak valid (kosong, duplikat atau melebihi batas)
 )
 
 // APIResponse adalah struktur standar untuk semua respons API
@@ -0,0 +1,59 @@
+//pkg/utils/ticket_manifest.go
+
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// ParseTicketManifestKey membaca seed Ed25519 32 byte dalam base64 dari konfigurasi. Key ini
+// terpisah dari secret QR tiket, sehingga manifest dan QR tidak ikut terbongkar jika salah satunya
+// bocor. Nilai kosong mengembalikan nil, artinya manifest check-in offline tidak diaktifkan.
+func ParseTicketManifestKey(encoded string) (ed25519.PrivateKey, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, errors.New("kunci manifest harus berupa 32 byte dalam base64")
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// SignTicketManifest mengembalikan signature Ed25519 dalam base64url tanpa padding
+func SignTicketManifest(payload []byte, key ed25519.PrivateKey) string {
+	return base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, payload))
+}
+
+// TicketManifestPublicKey mengembalikan public key dalam base64url tanpa padding. Perangkat
+// check-in menyimpannya sekali saat disiapkan, bukan dari manifest yang akan diverifikasi.
+func TicketManifestPublicKey(key ed25519.PrivateKey) string {
+	return base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+func VerifyTicketManifest(payload []byte, signature, publicKey string) bool {
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return false
+	}
+
+	return ed25519.Verify(ed25519.PublicKey(key), payload, sig)
+}
+
+// TicketNonceHash adalah sidik nonce QR yang dimuat di manifest. Perangkat offline mencocokkan
+// hash dari nonce di QR yang dipindai dengan nilai ini, jadi nonce aslinya tidak ikut tersebar.
+func TicketNonceHash(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,67 @@
+//pkg/utils/ticket_qr.go
+
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ticketQRVersion ditaruh di depan payload supaya format bisa diganti tanpa
+// membuat scanner salah membaca QR lama
+const ticketQRVersion = "TQ1"
+
+// ErrInvalidTicketQR dikembalikan untuk payload QR yang rusak atau signature-nya tidak cocok
+var ErrInvalidTicketQR = errors.New("qr tiket tidak valid")
+
+type TicketQRClaim struct {
+	TicketID int
+	EventID  int
+	Nonce    string
+}
+
+// GenerateTicketQRPayload menghasilkan isi QR berbentuk TQ1.<ticket_id>.<event_id>.<nonce>.<signature>.
+// Signature adalah HMAC-SHA256 dari bagian sebelumnya, jadi payload tetap pendek dan QR mudah dipindai.
+func GenerateTicketQRPayload(ticketID, eventID int, nonce, secret string) string {
+	body := fmt.Sprintf("%s.%d.%d.%s", ticketQRVersion, ticketID, eventID, nonce)
+	return body + "." + signTicketQR(body, secret)
+}
+
+func ParseTicketQRPayload(payload, secret string) (*TicketQRClaim, error) {
+	parts := strings.Split(strings.TrimSpace(payload), ".")
+	if len(parts) != 5 || parts[0] != ticketQRVersion {
+		return nil, ErrInvalidTicketQR
+	}
+
+	body := strings.Join(parts[:4], ".")
+	if !hmac.Equal([]byte(parts[4]), []byte(signTicketQR(body, secret))) {
+		return nil, ErrInvalidTicketQR
+	}
+
+	ticketID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, ErrInvalidTicketQR
+	}
+
+	eventID, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, ErrInvalidTicketQR
+	}
+
+	return &TicketQRClaim{
+		TicketID: ticketID,
+		EventID:  eventID,
+		Nonce:    parts[3],
+	}, nil
+}
+
+func signTicketQR(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,123 @@
+//test/entity/money_test.go
+
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+)
+
+func TestMoneyLargeIDRTotals(t *testing.T) {
+	// 9.500 kursi x Rp 1.250.000.000 jauh di atas batas kolom DECIMAL(10, 2) yang lama
+	price := entity.IDR(1_250_000_000)
+	total := price.Mul(9_500)
+	assert.Equal(t, entity.IDR(11_875_000_000_000), total)
+	assert.Equal(t, "11875000000000", total.Decimal())
+
+	// Penjumlahan berulang tidak bergeser seperti float64
+	sum := entity.IDR(0)
+	for i := 0; i < 1_000_000; i++ {
+		sum = sum.Add(entity.IDR(1_999_999))
+	}
+	assert.Equal(t, entity.IDR(1_999_999_000_000), sum)
+
+	assert.Panics(t, func() { entity.IDR(1 << 62).Mul(4) })
+	assert.Panics(t, func() { entity.IDR(1000).Add(entity.NewMoney(1000, "USD")) })
+}
+
+func TestMoneyPercentRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   entity.Money
+		rate     entity.Percent
+		expected entity.Money
+	}{
+		{"bulat", entity.IDR(300000), 2000, entity.IDR(60000)},
+		{"pecahan persen", entity.IDR(333333), 1250, entity.IDR(41667)}, // 41666,625
+		{"tepat setengah dibulatkan ke atas", entity.IDR(250005), 1000, entity.IDR(25001)},
+		{"di bawah setengah dibulatkan ke bawah", entity.IDR(250004), 1000, entity.IDR(25000)},
+		{"persen sangat kecil", entity.IDR(99), 1, entity.IDR(0)},
+		{"nominal besar", entity.IDR(9_876_543_210_987), 1575, entity.IDR(1_555_555_555_730)},      // ...730,4525
+		{"mata uang dua desimal", entity.NewMoney(1999, "USD"), 1500, entity.NewMoney(300, "USD")}, // 299,85 sen
+		{"negatif menjauhi nol", entity.IDR(-250005), 1000, entity.IDR(-25001)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.amount.Percent(tt.rate))
+		})
+	}
+}
+
+func TestPromoDiscountFractionalPercent(t *testing.T) {
+	promo := &entity.PromoCode{DiscountType: entity.PromoDiscountPercentage, DiscountPercent: 1250}
+	assert.Equal(t, entity.IDR(41667), promo.Discount(entity.IDR(333333)))
+
+	// Potongan tetap tidak boleh melebihi subtotal
+	fixed := &entity.PromoCode{DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(500000)}
+	assert.Equal(t, entity.IDR(200000), fixed.Discount(entity.IDR(200000)))
+	assert.True(t, fixed.AppliesTo("IDR"))
+	assert.False(t, fixed.AppliesTo("USD"))
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		expected entity.Money
+	}{
+		{"150000.00", "IDR", entity.IDR(150000)},
+		{"99999.50", "IDR", entity.IDR(100000)},
+		{"99999.49", "IDR", entity.IDR(99999)},
+		{"12345678901234.00", "idr", entity.IDR(12345678901234)},
+		{"10.5", "USD", entity.NewMoney(1050, "USD")},
+		{"0.01", "USD", entity.NewMoney(1, "USD")},
+		{"-20.00", "", entity.IDR(-20)},
+	}
+
+	for _, tt := range tests {
+		money, err := entity.ParseMoney(tt.value, tt.currency)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, money, tt.value)
+	}
+
+	_, err := entity.ParseMoney("1e5", "IDR")
+	assert.ErrorIs(t, err, entity.ErrInvalidMoney)
+
+	_, err = entity.ParseMoney("100", "XYZ")
+	assert.ErrorIs(t, err, entity.ErrUnsupportedCurrency)
+
+	assert.Equal(t, "10.50", entity.NewMoney(1050, "USD").Decimal())
+	assert.Equal(t, "-0.05", entity.NewMoney(-5, "USD").Decimal())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(entity.Money{Amount: 250000})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":250000,"currency":"IDR"}`, string(data))
+
+	var request struct {
+		Price    entity.Money   `json:"price"`
+		Discount entity.Money   `json:"discount"`
+		Rate     entity.Percent `json:"rate"`
+	}
+	err = json.Unmarshal([]byte(`{"price":250000,"discount":{"amount":1050,"currency":"usd"},"rate":12.5}`), &request)
+	require.NoError(t, err)
+	assert.Equal(t, entity.Money{Amount: 250000}, request.Price)
+	assert.Equal(t, entity.IDR(250000), request.Price.InCurrency("IDR"))
+	assert.Equal(t, entity.NewMoney(1050, "USD"), request.Discount)
+	assert.Equal(t, entity.Percent(1250), request.Rate)
+
+	// Nominal berpecahan di bawah satuan terkecil ditolak, bukan dibulatkan diam-diam
+	assert.Error(t, json.Unmarshal([]byte(`{"price":2500.5}`), &request))
+	assert.Error(t, json.Unmarshal([]byte(`{"rate":12.345}`), &request))
+
+	data, err = json.Marshal(entity.Percent(1250))
+	require.NoError(t, err)
+	assert.Equal(t, "12.5", string(data))
+}
@@ -0,0 +1,80 @@
+//test/entity/pricing_rule_test.go
+
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ticket-system/internal/domain/entity"
+)
+
+func TestCalculatePrice(t *testing.T) {
+	t.Run("Tanpa Aturan", func(t *testing.T) {
+		breakdown := entity.CalculatePrice(entity.IDR(500000), entity.IDR(50000), 2, nil, 250)
+
+		assert.Equal(t, entity.IDR(450000), breakdown.Total)
+		assert.Equal(t, entity.IDR(0), breakdown.ServiceFee)
+		assert.Equal(t, entity.IDR(0), breakdown.Tax)
+		// Komisi platform tetap dicatat untuk laporan keuangan meski ditanggung organizer
+		assert.Equal(t, entity.IDR(11250), breakdown.PlatformFee)
+		assert.False(t, breakdown.PlatformFeeToBuyer)
+	})
+
+	t.Run("Biaya Dan Pajak Dibebankan Ke Pembeli", func(t *testing.T) {
+		rule := &entity.PricingRule{
+			ServiceFeePercent:   500,
+			ServiceFeePerTicket: entity.IDR(2500),
+			TaxPercent:          1100,
+			PassPlatformFee:     true,
+		}
+
+		breakdown := entity.CalculatePrice(entity.IDR(500000), entity.IDR(0), 2, rule, 250)
+
+		assert.Equal(t, entity.IDR(500000), breakdown.Subtotal)
+		assert.Equal(t, entity.IDR(30000), breakdown.ServiceFee)
+		assert.Equal(t, entity.IDR(12500), breakdown.PlatformFee)
+		assert.True(t, breakdown.PlatformFeeToBuyer)
+		assert.Equal(t, entity.IDR(59675), breakdown.Tax) // 11% dari 542.500
+		assert.Equal(t, entity.IDR(602175), breakdown.Total)
+	})
+
+	t.Run("Pembulatan Per Komponen", func(t *testing.T) {
+		rule := &entity.PricingRule{ServiceFeePercent: 350, TaxPercent: 1100}
+
+		breakdown := entity.CalculatePrice(entity.IDR(333333), entity.IDR(41667), 3, rule, 200)
+
+		assert.Equal(t, entity.IDR(10208), breakdown.ServiceFee) // 10.208,31
+		assert.Equal(t, entity.IDR(5833), breakdown.PlatformFee) // 5.833,32
+		assert.Equal(t, entity.IDR(33206), breakdown.Tax)        // 11% dari 301.874
+		assert.Equal(t, entity.IDR(335080), breakdown.Total)
+
+		// Total selalu sama dengan jumlah komponen yang ditagihkan
+		sum := breakdown.Subtotal.Sub(breakdown.Discount).Add(breakdown.ServiceFee).Add(breakdown.Tax)
+		assert.Equal(t, sum, breakdown.Total)
+	})
+
+	t.Run("Mata Uang Dua Desimal", func(t *testing.T) {
+		rule := &entity.PricingRule{ServiceFeePerTicket: entity.NewMoney(150, "USD"), TaxPercent: 900}
+
+		breakdown := entity.CalculatePrice(entity.NewMoney(4999, "USD"), entity.Money{}, 1, rule, 0)
+
+		assert.Equal(t, entity.NewMoney(150, "USD"), breakdown.ServiceFee)
+		assert.Equal(t, entity.NewMoney(463, "USD"), breakdown.Tax) // 9% dari 51,49 = 4,6341
+		assert.Equal(t, entity.NewMoney(5612, "USD"), breakdown.Total)
+		assert.Equal(t, entity.NewMoney(0, "USD"), breakdown.Discount)
+	})
+}
+
+func TestPricingRuleAppliesTo(t *testing.T) {
+	var rule *entity.PricingRule
+	assert.True(t, rule.AppliesTo("USD"))
+
+	rule = &entity.PricingRule{ServiceFeePercent: 500}
+	assert.True(t, rule.AppliesTo("USD"))
+
+	rule.ServiceFeePerTicket = entity.IDR(2500)
+	assert.True(t, rule.AppliesTo("idr"))
+	assert.False(t, rule.AppliesTo("USD"))
+}
@@ -0,0 +1,61 @@
+//test/entity/refund_test.go
+
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ticket-system/internal/domain/entity"
+)
+
+func TestRefundStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from    entity.RefundStatus
+		to      entity.RefundStatus
+		allowed bool
+	}{
+		{entity.RefundStatusRequested, entity.RefundStatusApproved, true},
+		{entity.RefundStatusRequested, entity.RefundStatusRejected, true},
+		{entity.RefundStatusApproved, entity.RefundStatusProcessing, true},
+		{entity.RefundStatusProcessing, entity.RefundStatusCompleted, true},
+		{entity.RefundStatusProcessing, entity.RefundStatusFailed, true},
+		{entity.RefundStatusFailed, entity.RefundStatusApproved, true},
+
+		{entity.RefundStatusRequested, entity.RefundStatusCompleted, false},
+		{entity.RefundStatusApproved, entity.RefundStatusCompleted, false},
+		{entity.RefundStatusApproved, entity.RefundStatusRejected, false},
+		{entity.RefundStatusRejected, entity.RefundStatusApproved, false},
+		{entity.RefundStatusCompleted, entity.RefundStatusProcessing, false},
+		{entity.RefundStatusFailed, entity.RefundStatusProcessing, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" -> "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestRefundPolicy(t *testing.T) {
+	eventDate := time.Date(2025, 6, 1, 19, 0, 0, 0, time.UTC)
+
+	t.Run("Deadline", func(t *testing.T) {
+		policy := entity.RefundPolicy{Allowed: true, DeadlineHours: 48, Percent: entity.OneHundredPercent}
+		assert.Equal(t, time.Date(2025, 5, 30, 19, 0, 0, 0, time.UTC), policy.Deadline(eventDate))
+
+		policy.DeadlineHours = 0
+		assert.Equal(t, eventDate, policy.Deadline(eventDate))
+	})
+
+	t.Run("Partial Amount", func(t *testing.T) {
+		percent, err := entity.ParsePercent("75")
+		assert.NoError(t, err)
+
+		policy := entity.RefundPolicy{Allowed: true, Percent: percent}
+		assert.True(t, policy.Amount(entity.IDR(550001)).Equal(entity.IDR(412501)))
+		assert.True(t, policy.Amount(entity.NewMoney(1999, "USD")).Equal(entity.NewMoney(1499, "USD")))
+	})
+}
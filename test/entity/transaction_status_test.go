@@ -0,0 +1,58 @@
+//test/entity/transaction_status_test.go
+
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ticket-system/internal/domain/entity"
+)
+
+func TestTransactionStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from    entity.TransactionStatus
+		to      entity.TransactionStatus
+		allowed bool
+	}{
+		{entity.TransactionStatusPending, entity.TransactionStatusWaitingVerification, true},
+		{entity.TransactionStatusPending, entity.TransactionStatusPaid, true},
+		{entity.TransactionStatusPending, entity.TransactionStatusCancelled, true},
+		{entity.TransactionStatusPending, entity.TransactionStatusExpired, true},
+		{entity.TransactionStatusPending, entity.TransactionStatusFailed, true},
+		{entity.TransactionStatusWaitingVerification, entity.TransactionStatusPaid, true},
+		{entity.TransactionStatusWaitingVerification, entity.TransactionStatusPending, true},
+		{entity.TransactionStatusWaitingVerification, entity.TransactionStatusRejected, true},
+		{entity.TransactionStatusPaid, entity.TransactionStatusRefunded, true},
+
+		{entity.TransactionStatusPending, entity.TransactionStatusRefunded, false},
+		{entity.TransactionStatusWaitingVerification, entity.TransactionStatusCancelled, false},
+		{entity.TransactionStatusPaid, entity.TransactionStatusCancelled, false},
+		{entity.TransactionStatusCancelled, entity.TransactionStatusPaid, false},
+		{entity.TransactionStatusExpired, entity.TransactionStatusPaid, false},
+		{entity.TransactionStatusFailed, entity.TransactionStatusPending, false},
+		{entity.TransactionStatusRefunded, entity.TransactionStatusPaid, false},
+		{entity.TransactionStatusRejected, entity.TransactionStatusPending, false},
+		{entity.TransactionStatusPending, entity.TransactionStatusRejected, false},
+		{entity.TransactionStatusPending, entity.TransactionStatusPending, false},
+		{entity.TransactionStatus("success"), entity.TransactionStatusRefunded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" -> "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestTransactionStatusIsFinal(t *testing.T) {
+	assert.False(t, entity.TransactionStatusPending.IsFinal())
+	assert.False(t, entity.TransactionStatusWaitingVerification.IsFinal())
+	assert.False(t, entity.TransactionStatusPaid.IsFinal())
+	assert.True(t, entity.TransactionStatusCancelled.IsFinal())
+	assert.True(t, entity.TransactionStatusExpired.IsFinal())
+	assert.True(t, entity.TransactionStatusFailed.IsFinal())
+	assert.True(t, entity.TransactionStatusRefunded.IsFinal())
+	assert.True(t, entity.TransactionStatusRejected.IsFinal())
+}
@@ -0,0 +1,98 @@
+//test/gateway/midtrans_notification_test.go
+
+package gateway_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/gateway/midtrans"
+)
+
+// Fixture notifikasi ditandatangani dengan server key di bawah ini
+const fixtureServerKey = "SB-Mid-server-test"
+
+func loadNotificationFixture(t *testing.T, name string) []byte {
+	payload, err := os.ReadFile(filepath.Join("..", "fixtures", "midtrans", name+".json"))
+	require.NoError(t, err)
+	return payload
+}
+
+func TestSnapClientParseNotification(t *testing.T) {
+	client := midtrans.NewClient(midtrans.Config{ServerKey: fixtureServerKey})
+
+	tests := []struct {
+		fixture       string
+		gatewayStatus string
+		result        string
+	}{
+		{"settlement", "settlement", gateway.PaymentResultPaid},
+		{"capture_challenge", "capture", gateway.PaymentResultPending},
+		{"expire", "expire", gateway.PaymentResultExpired},
+		{"deny", "deny", gateway.PaymentResultFailed},
+		{"refund", "refund", gateway.PaymentResultRefunded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			payload := loadNotificationFixture(t, tt.fixture)
+
+			notification, err := client.ParseNotification(payload)
+
+			require.NoError(t, err)
+			assert.Equal(t, "TRX-20250101-123456", notification.OrderID)
+			assert.Equal(t, "9aed5972-5b6a-401e-894b-a32c91ed1a3a", notification.GatewayTransactionID)
+			assert.Equal(t, entity.IDR(500000), notification.GrossAmount)
+			assert.Equal(t, tt.gatewayStatus, notification.GatewayStatus)
+			assert.Equal(t, tt.result, notification.Result)
+			assert.Equal(t, payload, notification.Payload)
+		})
+	}
+
+	t.Run("Settlement Time In WIB", func(t *testing.T) {
+		notification, err := client.ParseNotification(loadNotificationFixture(t, "settlement"))
+
+		require.NoError(t, err)
+		assert.True(t, time.Date(2025, 1, 1, 3, 20, 2, 0, time.UTC).Equal(notification.PaymentTime))
+	})
+
+	t.Run("Tampered Amount", func(t *testing.T) {
+		payload := strings.Replace(string(loadNotificationFixture(t, "settlement")), `"500000.00"`, `"1000.00"`, 1)
+
+		notification, err := client.ParseNotification([]byte(payload))
+
+		assert.ErrorIs(t, err, gateway.ErrInvalidSignature)
+		assert.Nil(t, notification)
+	})
+
+	t.Run("Wrong Server Key", func(t *testing.T) {
+		otherClient := midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-lain"})
+
+		notification, err := otherClient.ParseNotification(loadNotificationFixture(t, "settlement"))
+
+		assert.ErrorIs(t, err, gateway.ErrInvalidSignature)
+		assert.Nil(t, notification)
+	})
+
+	t.Run("Missing Signature", func(t *testing.T) {
+		notification, err := client.ParseNotification([]byte(`{"order_id":"TRX-20250101-123456","status_code":"200","gross_amount":"500000.00"}`))
+
+		assert.ErrorIs(t, err, gateway.ErrInvalidSignature)
+		assert.Nil(t, notification)
+	})
+
+	t.Run("Malformed Payload", func(t *testing.T) {
+		notification, err := client.ParseNotification([]byte(`bukan json`))
+
+		assert.ErrorIs(t, err, gateway.ErrInvalidNotification)
+		assert.Nil(t, notification)
+	})
+}
@@ -0,0 +1,97 @@
+//test/gateway/midtrans_refund_client_test.go
+
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/gateway/midtrans"
+)
+
+// newFakeRefundServer meniru endpoint POST /v2/{order_id}/refund milik Core API Midtrans
+func newFakeRefundServer(t *testing.T, handler func(w http.ResponseWriter, body map[string]interface{})) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v2/TRX-20250101-123456/refund", r.URL.Path)
+
+		username, _, ok := r.BasicAuth()
+		if !ok || username != "SB-Mid-server-test" {
+			w.Write([]byte(`{"status_code":"401","status_message":"Access denied, please check client or server key"}`))
+			return
+		}
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, body)
+	}))
+
+	t.Cleanup(server.Close)
+	return server
+}
+
+func sampleRefundRequest() gateway.RefundRequest {
+	return gateway.RefundRequest{
+		OrderID:   "TRX-20250101-123456",
+		RefundKey: "REFUND-7",
+		Amount:    375000,
+		Reason:    "tidak bisa hadir",
+	}
+}
+
+func TestMidtransClientRefund(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var received map[string]interface{}
+		server := newFakeRefundServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+			received = body
+			w.Write([]byte(`{"status_code":"200","status_message":"Success, refund request is approved","refund_chargeback_id":4211,"refund_amount":"375000.00","refund_key":"REFUND-7","transaction_status":"partial_refund"}`))
+		})
+
+		client := midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test", APIURL: server.URL})
+		result, err := client.Refund(context.Background(), sampleRefundRequest())
+
+		require.NoError(t, err)
+		assert.Equal(t, "4211", result.GatewayRefundID)
+		assert.Equal(t, int64(375000), result.Amount)
+
+		assert.Equal(t, "REFUND-7", received["refund_key"])
+		assert.Equal(t, float64(375000), received["amount"])
+		assert.Equal(t, "tidak bisa hadir", received["reason"])
+	})
+
+	t.Run("Rejected In Body", func(t *testing.T) {
+		server := newFakeRefundServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+			w.Write([]byte(`{"status_code":"412","status_message":"Merchant cannot modify the status of the transaction"}`))
+		})
+
+		client := midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test", APIURL: server.URL})
+		result, err := client.Refund(context.Background(), sampleRefundRequest())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "412")
+		assert.Contains(t, err.Error(), "cannot modify")
+	})
+
+	t.Run("Unauthorized Server Key", func(t *testing.T) {
+		server := newFakeRefundServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+			t.Fatal("request dengan server key salah tidak boleh diproses")
+		})
+
+		client := midtrans.NewClient(midtrans.Config{ServerKey: "", APIURL: server.URL})
+		result, err := client.Refund(context.Background(), sampleRefundRequest())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "401")
+	})
+}
@@ -0,0 +1,121 @@
+//test/gateway/midtrans_snap_client_test.go
+
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/gateway/midtrans"
+)
+
+// newFakeSnapServer meniru endpoint POST /snap/v1/transactions milik Midtrans
+func newFakeSnapServer(t *testing.T, handler func(w http.ResponseWriter, body map[string]interface{})) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/snap/v1/transactions", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "SB-Mid-server-test" || password != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error_messages":["Access denied due to unauthorized transaction, please check client or server key"]}`))
+			return
+		}
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, body)
+	}))
+
+	t.Cleanup(server.Close)
+	return server
+}
+
+func samplePaymentRequest() gateway.PaymentRequest {
+	return gateway.PaymentRequest{
+		OrderID:     "TRX-20250101-123456",
+		GrossAmount: 500000,
+		Items: []gateway.PaymentItem{
+			{ID: "1", Name: strings.Repeat("Konser Musik ", 6), Price: 250000, Quantity: 2},
+		},
+		Customer: gateway.PaymentCustomer{
+			FirstName: "testuser",
+			Email:     "user@example.com",
+		},
+		ExpiryMinutes: 60,
+	}
+}
+
+func TestSnapClientCreatePayment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var received map[string]interface{}
+		server := newFakeSnapServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+			received = body
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token":"snap-token-123","redirect_url":"https://app.sandbox.midtrans.com/snap/v2/vtweb/snap-token-123"}`))
+		})
+
+		client := midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test", SnapURL: server.URL})
+		session, err := client.CreatePayment(context.Background(), samplePaymentRequest())
+
+		require.NoError(t, err)
+		assert.Equal(t, "snap-token-123", session.Token)
+		assert.Equal(t, "https://app.sandbox.midtrans.com/snap/v2/vtweb/snap-token-123", session.RedirectURL)
+
+		details := received["transaction_details"].(map[string]interface{})
+		assert.Equal(t, "TRX-20250101-123456", details["order_id"])
+		assert.Equal(t, float64(500000), details["gross_amount"])
+
+		items := received["item_details"].([]interface{})
+		require.Len(t, items, 1)
+		item := items[0].(map[string]interface{})
+		assert.Len(t, item["name"], 50)
+		assert.Equal(t, float64(250000), item["price"])
+		assert.Equal(t, float64(2), item["quantity"])
+
+		customer := received["customer_details"].(map[string]interface{})
+		assert.Equal(t, "user@example.com", customer["email"])
+
+		expiry := received["expiry"].(map[string]interface{})
+		assert.Equal(t, "minutes", expiry["unit"])
+		assert.Equal(t, float64(60), expiry["duration"])
+	})
+
+	t.Run("Unauthorized Server Key", func(t *testing.T) {
+		server := newFakeSnapServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+			t.Fatal("request dengan server key salah tidak boleh diproses")
+		})
+
+		client := midtrans.NewClient(midtrans.Config{ServerKey: "wrong-key", SnapURL: server.URL})
+		session, err := client.CreatePayment(context.Background(), samplePaymentRequest())
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+		assert.Contains(t, err.Error(), "unauthorized")
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		server := newFakeSnapServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_messages":["transaction_details.order_id sudah digunakan"]}`))
+		})
+
+		client := midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test", SnapURL: server.URL})
+		session, err := client.CreatePayment(context.Background(), samplePaymentRequest())
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+		assert.Contains(t, err.Error(), "order_id sudah digunakan")
+	})
+}
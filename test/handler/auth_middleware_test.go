@@ -0,0 +1,128 @@
+//test/handler/auth_middleware_test.go
+
+package handler_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/delivery/http/middleware"
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+const authTestSecret = "rahasia_test_auth"
+
+func newAuthTestApp(tokenRevocationRepo *mocks.FakeTokenRevocationRepository) *fiber.App {
+	authMiddleware := middleware.NewAuthMiddleware(authTestSecret, usecase.NewTokenRevocationUsecase(tokenRevocationRepo), false)
+
+	app := fiber.New()
+	app.Get("/profile", authMiddleware.AuthenticateJWT(), func(c *fiber.Ctx) error {
+		return utils.SuccessResponse(c, "OK", nil)
+	})
+
+	return app
+}
+
+func sendWithBearer(t *testing.T, app *fiber.App, token string) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, "/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	tokenRevocationRepo := &mocks.FakeTokenRevocationRepository{}
+	app := newAuthTestApp(tokenRevocationRepo)
+
+	t.Run("Access token", func(t *testing.T) {
+		token, err := utils.GenerateJWT(1, "budi", "budi@example.com", "user", utils.TokenTypeAccess, false, 0, authTestSecret, time.Minute)
+		require.NoError(t, err)
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Token without access type", func(t *testing.T) {
+		// JWT lama yang dulu dipakai sebagai refresh token tidak memiliki token_type
+		token, err := utils.GenerateJWT(1, "budi", "budi@example.com", "user", "", false, 0, authTestSecret, time.Hour)
+		require.NoError(t, err)
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("MFA challenge token", func(t *testing.T) {
+		token, err := utils.GenerateJWT(1, "budi", "budi@example.com", "organizer", utils.TokenTypeMFAChallenge, false, 0, authTestSecret, time.Minute)
+		require.NoError(t, err)
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Opaque refresh token", func(t *testing.T) {
+		resp := sendWithBearer(t, app, utils.GenerateRandomString(64))
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+	t.Run("Revoked access token", func(t *testing.T) {
+		token, err := utils.GenerateJWT(1, "budi", "budi@example.com", "user", utils.TokenTypeAccess, false, 0, authTestSecret, time.Minute)
+		require.NoError(t, err)
+		claims, err := utils.ValidateToken(token, authTestSecret)
+		require.NoError(t, err)
+
+		tokenRevocationRepo.Create(context.Background(), &entity.TokenRevocation{
+			UserID: 1, JTI: claims.ID, RevokedAt: time.Now(), ExpiresAt: claims.ExpiresAt.Time,
+		})
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), utils.ErrorCodeTokenRevoked)
+	})
+}
+
+func TestRoleCheckOrganizerMFA(t *testing.T) {
+	authMiddleware := middleware.NewAuthMiddleware(authTestSecret, usecase.NewTokenRevocationUsecase(&mocks.FakeTokenRevocationRepository{}), true)
+
+	app := fiber.New()
+	app.Get("/profile", authMiddleware.AuthenticateJWT(), authMiddleware.RoleCheck([]string{"organizer", "user"}), func(c *fiber.Ctx) error {
+		return utils.SuccessResponse(c, "OK", nil)
+	})
+
+	t.Run("Organizer without MFA", func(t *testing.T) {
+		token, err := utils.GenerateJWT(2, "siti", "siti@example.com", "organizer", utils.TokenTypeAccess, false, 0, authTestSecret, time.Minute)
+		require.NoError(t, err)
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), utils.ErrorCodeMFARequired)
+	})
+
+	t.Run("Organizer with MFA", func(t *testing.T) {
+		token, err := utils.GenerateJWT(2, "siti", "siti@example.com", "organizer", utils.TokenTypeAccess, true, 0, authTestSecret, time.Minute)
+		require.NoError(t, err)
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Regular user is not affected", func(t *testing.T) {
+		token, err := utils.GenerateJWT(1, "budi", "budi@example.com", "user", utils.TokenTypeAccess, false, 0, authTestSecret, time.Minute)
+		require.NoError(t, err)
+
+		resp := sendWithBearer(t, app, token)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+}
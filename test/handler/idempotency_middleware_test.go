@@ -0,0 +1,175 @@
+//test/handler/idempotency_middleware_test.go
+
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/delivery/http/middleware"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func newIdempotencyTestApp(calls *int) *fiber.App {
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(
+		usecase.NewIdempotencyUsecase(&mocks.FakeIdempotencyKeyRepository{}, "24"),
+	)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", &utils.JWTClaim{UserID: 1, Username: "buyer", Role: "user"})
+		return c.Next()
+	})
+	app.Use(idempotencyMiddleware.Handle())
+
+	app.Post("/transactions", func(c *fiber.Ctx) error {
+		*calls++
+		return utils.CreatedResponse(c, "Transaksi berhasil dibuat", fiber.Map{"call": *calls})
+	})
+	app.Post("/transactions/proof", func(c *fiber.Ctx) error {
+		*calls++
+		if _, err := c.FormFile("payment_proof"); err != nil {
+			return utils.ErrorResponse(c, utils.ErrorCodeInvalidInput, "File bukti pembayaran diperlukan", fiber.StatusBadRequest)
+		}
+		return utils.SuccessResponse(c, "Bukti pembayaran berhasil diupload", fiber.Map{"call": *calls})
+	})
+	app.Put("/transactions/:id/cancel", func(c *fiber.Ctx) error {
+		*calls++
+		return utils.ServerError(c, "Gagal membatalkan transaksi")
+	})
+
+	return app
+}
+
+func sendIdempotent(t *testing.T, app *fiber.App, method, path, key, body string) (*http.Response, string) {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, string(respBody)
+}
+
+// sendProofUpload mengirim multipart dengan boundary acak baru di setiap panggilan, sama seperti
+// klien yang mengulang upload
+func sendProofUpload(t *testing.T, app *fiber.App, key, transactionID string, file []byte) (*http.Response, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("transaction_id", transactionID))
+	part, err := writer.CreateFormFile("payment_proof", "bukti.png")
+	require.NoError(t, err)
+	_, err = part.Write(file)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/transactions/proof", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, string(respBody)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("Retry replays original response", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		first, firstBody := sendIdempotent(t, app, http.MethodPost, "/transactions", "abc", `{"event_id":1}`)
+		assert.Equal(t, fiber.StatusCreated, first.StatusCode)
+		assert.Empty(t, first.Header.Get(middleware.IdempotentReplayedHeader))
+
+		retry, retryBody := sendIdempotent(t, app, http.MethodPost, "/transactions", "abc", `{"event_id":1}`)
+		assert.Equal(t, fiber.StatusCreated, retry.StatusCode)
+		assert.Equal(t, "true", retry.Header.Get(middleware.IdempotentReplayedHeader))
+		assert.Equal(t, first.Header.Get("Content-Type"), retry.Header.Get("Content-Type"))
+		assert.Equal(t, firstBody, retryBody)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Same key with different body", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		sendIdempotent(t, app, http.MethodPost, "/transactions", "abc", `{"event_id":1}`)
+		resp, body := sendIdempotent(t, app, http.MethodPost, "/transactions", "abc", `{"event_id":2}`)
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Contains(t, body, utils.ErrorCodeIdempotencyKeyMismatch)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Multipart retry with new boundary replays response", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		first, firstBody := sendProofUpload(t, app, "upload", "1", []byte("png-bytes"))
+		retry, retryBody := sendProofUpload(t, app, "upload", "1", []byte("png-bytes"))
+
+		assert.Equal(t, fiber.StatusOK, first.StatusCode)
+		assert.Equal(t, fiber.StatusOK, retry.StatusCode)
+		assert.Equal(t, "true", retry.Header.Get(middleware.IdempotentReplayedHeader))
+		assert.Equal(t, firstBody, retryBody)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Multipart with different file", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		sendProofUpload(t, app, "upload", "1", []byte("png-bytes"))
+		resp, body := sendProofUpload(t, app, "upload", "1", []byte("png-lain"))
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Contains(t, body, utils.ErrorCodeIdempotencyKeyMismatch)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Server error is not stored", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		first, _ := sendIdempotent(t, app, http.MethodPut, "/transactions/1/cancel", "abc", "")
+		retry, _ := sendIdempotent(t, app, http.MethodPut, "/transactions/1/cancel", "abc", "")
+
+		assert.Equal(t, fiber.StatusInternalServerError, first.StatusCode)
+		assert.Equal(t, fiber.StatusInternalServerError, retry.StatusCode)
+		assert.Empty(t, retry.Header.Get(middleware.IdempotentReplayedHeader))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Requests without key are not deduplicated", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		sendIdempotent(t, app, http.MethodPost, "/transactions", "", `{"event_id":1}`)
+		sendIdempotent(t, app, http.MethodPost, "/transactions", "", `{"event_id":1}`)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Key too long", func(t *testing.T) {
+		calls := 0
+		app := newIdempotencyTestApp(&calls)
+
+		resp, _ := sendIdempotent(t, app, http.MethodPost, "/transactions", string(bytes.Repeat([]byte("a"), 256)), `{}`)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, 0, calls)
+	})
+}
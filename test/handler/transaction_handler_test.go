@@ -6,7 +6,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"testing"
@@ -221,8 +223,8 @@ func (m *MockTransactionRepository) Update(ctx context.Context, transaction *ent
 	return args.Error(0)
 }
 
-func (m *MockTransactionRepository) UpdateStatus(ctx context.Context, id int, status string) error {
-	args := m.Called(ctx, id, status)
+func (m *MockTransactionRepository) UpdateStatus(ctx context.Context, id int, from, to entity.TransactionStatus) error {
+	args := m.Called(ctx, id, from, to)
 	return args.Error(0)
 }
 
@@ -284,57 +286,14 @@ func (m *MockTransactionUsecase) VerifyPayment(ctx context.Context, organizerID,
 	return args.Error(0)
 }
 
-type MockUserUsecase struct {
-	mock.Mock
-}
-
-func (m *MockUserUsecase) Register(ctx context.Context, req usecase.RegisterRequest) (int, error) {
-	args := m.Called(ctx, req)
-	return args.Int(0), args.Error(1)
-}
-
-func (m *MockUserUsecase) Login(ctx context.Context, req usecase.LoginRequest) (*usecase.LoginResponse, error) {
-	args := m.Called(ctx, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
-}
-
-func (m *MockUserUsecase) GetByID(ctx context.Context, id int) (*entity.User, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.User), args.Error(1)
-}
-
-func (m *MockUserUsecase) VerifyEmail(ctx context.Context, token string) error {
-	args := m.Called(ctx, token)
-	return args.Error(0)
-}
-
-func (m *MockUserUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
-	args := m.Called(ctx, email)
+func (m *MockTransactionUsecase) RejectPayment(ctx context.Context, organizerID, transactionID int, req usecase.RejectPaymentRequest) error {
+	args := m.Called(ctx, organizerID, transactionID, req)
 	return args.Error(0)
 }
 
-func (m *MockUserUsecase) UpdateProfile(ctx context.Context, userID int, name, gender, address, phoneNumber string) error {
-	args := m.Called(ctx, userID, name, gender, address, phoneNumber)
-	return args.Error(0)
-}
-
-func setupUserHandlerTest() (*fiber.App, *MockUserUsecase) {
-	mockUserUsecase := new(MockUserUsecase)
-	app := fiber.New()
-	
-	userHandler := handler.NewUserHandler(mockUserUsecase)
-	
-	app.Post("/api/register", userHandler.Register)
-	app.Post("/api/login", userHandler.Login)
-	app.Get("/api/verify-email", userHandler.VerifyEmail)
-	
-	return app, mockUserUsecase
+func (m *MockTransactionUsecase) ExpirePendingTransactions(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
 }
 
 func setupTransactionHandlerTest() (*fiber.App, *MockTransactionUsecase) {
@@ -386,74 +345,6 @@ func setupTransactionHandlerTest() (*fiber.App, *MockTransactionUsecase) {
 	return app, mockUsecase
 }
 
-func TestUserFlow(t *testing.T) {
-	app, mockUsecase := setupUserHandlerTest()
-	
-	t.Run("Register User", func(t *testing.T) {
-		mockUsecase.On("Register", mock.Anything, mock.AnythingOfType("usecase.RegisterRequest")).Return(1, nil)
-		
-		reqBody := map[string]interface{}{
-			"username":        "testuser",
-			"email":           "testuser@example.com",
-			"password":        "password123",
-			"retype_password": "password123",
-			"role":            "user",
-		}
-		
-		jsonBody, _ := json.Marshal(reqBody)
-		
-		req, _ := http.NewRequest("POST", "/api/register", bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
-		
-		resp, err := app.Test(req)
-		assert.NoError(t, err)
-		
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		
-		assert.Equal(t, http.StatusCreated, resp.StatusCode)
-		
-		var result map[string]interface{}
-		json.Unmarshal(bodyBytes, &result)
-		
-		assert.Equal(t, true, result["status"])
-	})
-	
-	t.Run("Login User", func(t *testing.T) {
-		loginResp := &usecase.LoginResponse{
-			Token:        "dummy_token_123456",
-			RefreshToken: "dummy_refresh_token_123456",
-			Role:         "user",
-			Username:     "testuser",
-		}
-		
-		mockUsecase.On("Login", mock.Anything, mock.AnythingOfType("usecase.LoginRequest")).Return(loginResp, nil)
-		
-		reqBody := map[string]interface{}{
-			"username": "testuser",
-			"password": "password123",
-		}
-		
-		jsonBody, _ := json.Marshal(reqBody)
-		
-		req, _ := http.NewRequest("POST", "/api/login", bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
-		
-		resp, err := app.Test(req)
-		assert.NoError(t, err)
-		
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		
-		var result map[string]interface{}
-		json.Unmarshal(bodyBytes, &result)
-		
-		assert.Equal(t, true, result["status"])
-		data := result["data"].(map[string]interface{})
-		assert.NotEmpty(t, data["token"])
-	})
-}
-
 func TestTransactionFlow(t *testing.T) {
 	app, mockUsecase := setupTransactionHandlerTest()
 	
@@ -465,7 +356,7 @@ func TestTransactionFlow(t *testing.T) {
 			EventID:         1,
 			EventTitle:      "Konser Musik",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Silakan transfer ke Bank BCA 1234567890 a/n Ticket System",
@@ -502,17 +393,19 @@ func TestTransactionFlow(t *testing.T) {
 	})
 	
 	t.Run("Upload Payment Proof", func(t *testing.T) {
-		mockUsecase.On("UploadPaymentProof", mock.Anything, mock.Anything, mock.AnythingOfType("usecase.UploadPaymentProofRequest")).Return(nil)
-		
-		reqBody := map[string]interface{}{
-			"transaction_id": strconv.Itoa(transactionID),
-			"proof_url":      "https://example.com/bukti-transfer.jpg",
-		}
+		mockUsecase.On("UploadPaymentProof", mock.Anything, mock.Anything, mock.MatchedBy(func(req usecase.UploadPaymentProofRequest) bool {
+			return req.TransactionID == strconv.Itoa(transactionID) && req.FileName == "bukti-transfer.png" && len(req.Content) == 8
+		})).Return(nil)
 		
-		jsonBody, _ := json.Marshal(reqBody)
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("transaction_id", strconv.Itoa(transactionID))
+		part, _ := writer.CreateFormFile("proof", "bukti-transfer.png")
+		part.Write([]byte("\x89PNG\r\n\x1a\n"))
+		writer.Close()
 		
-		req, _ := http.NewRequest("POST", "/api/transactions/proof", bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("POST", "/api/transactions/proof", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
 		
 		resp, err := app.Test(req)
 		assert.NoError(t, err)
@@ -552,7 +445,7 @@ func TestTransactionFlow(t *testing.T) {
 			EventID:         1,
 			EventTitle:      "Konser Musik",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "success",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Silakan transfer ke Bank BCA 1234567890 a/n Ticket System",
@@ -579,4 +472,71 @@ func TestTransactionFlow(t *testing.T) {
 		data := result["data"].(map[string]interface{})
 		assert.Equal(t, "success", data["status"])
 	})
-}
\ No newline at end of file
+}
+
+func TestCrossOrganizerAccess(t *testing.T) {
+	app, mockUsecase := setupTransactionHandlerTest()
+	
+	t.Run("Verify Payment Of Another Organizer's Event", func(t *testing.T) {
+		mockUsecase.On("VerifyPayment", mock.Anything, 2, 7).Return(errors.New("anda tidak memiliki izin untuk memverifikasi pembayaran transaksi ini")).Once()
+		
+		req, _ := http.NewRequest("PUT", "/api/organizer/transactions/7/verify", nil)
+		
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		
+		var result map[string]interface{}
+		json.Unmarshal(bodyBytes, &result)
+		
+		assert.Equal(t, false, result["status"])
+		assert.Equal(t, utils.ErrorCodeTransactionOwnership, result["status_code"])
+	})
+	
+	t.Run("View Transaction Of Another Organizer's Event", func(t *testing.T) {
+		mockUsecase.On("GetTransactionByID", mock.Anything, 1, 7).Return(nil, errors.New("anda tidak memiliki izin untuk melihat transaksi ini")).Once()
+		
+		req, _ := http.NewRequest("GET", "/api/transactions/7", nil)
+		
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		
+		var result map[string]interface{}
+		json.Unmarshal(bodyBytes, &result)
+		
+		assert.Equal(t, false, result["status"])
+		assert.Equal(t, utils.ErrorCodeTransactionAccessDenied, result["status_code"])
+	})
+}
+
+func TestUploadPaymentProofWithoutFile(t *testing.T) {
+	app, mockUsecase := setupTransactionHandlerTest()
+	
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("transaction_id", "1")
+	writer.Close()
+	
+	req, _ := http.NewRequest("POST", "/api/transactions/proof", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	
+	var result map[string]interface{}
+	json.Unmarshal(bodyBytes, &result)
+	
+	assert.Equal(t, false, result["status"])
+	mockUsecase.AssertNotCalled(t, "UploadPaymentProof", mock.Anything, mock.Anything, mock.Anything)
+}
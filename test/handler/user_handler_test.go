@@ -0,0 +1,284 @@
+//test/handler/user_handler_test.go
+
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/delivery/http/handler"
+	"ticket-system/internal/delivery/http/middleware"
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+type MockUserUsecase struct {
+	mock.Mock
+}
+
+func (m *MockUserUsecase) Register(ctx context.Context, req usecase.RegisterRequest) (int, error) {
+	args := m.Called(ctx, req)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserUsecase) Login(ctx context.Context, req usecase.LoginRequest) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) RefreshToken(ctx context.Context, req usecase.RefreshTokenRequest) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) Logout(ctx context.Context, claims *utils.JWTClaim, req usecase.LogoutRequest) error {
+	args := m.Called(ctx, claims, req)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) LogoutAll(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) ForgotPassword(ctx context.Context, req usecase.ForgotPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) ResetPassword(ctx context.Context, req usecase.ResetPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) ChangePassword(ctx context.Context, userID int, req usecase.ChangePasswordRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) LoginMFA(ctx context.Context, req usecase.LoginMFARequest) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) GetMFAStatus(ctx context.Context, userID int) (*usecase.MFAStatusResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MFAStatusResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) SetupMFA(ctx context.Context, userID int) (*usecase.MFASetupResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MFASetupResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) EnableMFA(ctx context.Context, userID int, req usecase.MFACodeRequest) (*usecase.MFARecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) DisableMFA(ctx context.Context, userID int, req usecase.DisableMFARequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) RegenerateRecoveryCodes(ctx context.Context, userID int, req usecase.MFACodeRequest) (*usecase.MFARecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockUserUsecase) GetByID(ctx context.Context, id int) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUsecase) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) UpdateProfile(ctx context.Context, userID int, name, gender, address, phoneNumber string) error {
+	args := m.Called(ctx, userID, name, gender, address, phoneNumber)
+	return args.Error(0)
+}
+
+func setupUserHandlerTest() (*fiber.App, *MockUserUsecase) {
+	mockUserUsecase := new(MockUserUsecase)
+	app := fiber.New()
+	
+	userHandler := handler.NewUserHandler(mockUserUsecase)
+	
+	app.Post("/api/register", userHandler.Register)
+	app.Post("/api/login", userHandler.Login)
+	app.Post("/api/auth/refresh", userHandler.RefreshToken)
+	app.Get("/api/verify-email", userHandler.VerifyEmail)
+	app.Post("/api/forgot-password", middleware.NewRateLimitMiddleware("2", "15").Limit(), userHandler.ForgotPassword)
+	
+	return app, mockUserUsecase
+}
+
+func TestUserFlow(t *testing.T) {
+	app, mockUsecase := setupUserHandlerTest()
+	
+	t.Run("Register User", func(t *testing.T) {
+		mockUsecase.On("Register", mock.Anything, mock.AnythingOfType("usecase.RegisterRequest")).Return(1, nil)
+		
+		reqBody := map[string]interface{}{
+			"username":        "testuser",
+			"email":           "testuser@example.com",
+			"password":        "password123",
+			"retype_password": "password123",
+			"role":            "user",
+		}
+		
+		jsonBody, _ := json.Marshal(reqBody)
+		
+		req, _ := http.NewRequest("POST", "/api/register", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		
+		var result map[string]interface{}
+		json.Unmarshal(bodyBytes, &result)
+		
+		assert.Equal(t, true, result["status"])
+	})
+	
+	t.Run("Login User", func(t *testing.T) {
+		loginResp := &usecase.LoginResponse{
+			Token:        "dummy_token_123456",
+			RefreshToken: "dummy_refresh_token_123456",
+			Role:         "user",
+			Username:     "testuser",
+		}
+		
+		mockUsecase.On("Login", mock.Anything, mock.AnythingOfType("usecase.LoginRequest")).Return(loginResp, nil)
+		
+		reqBody := map[string]interface{}{
+			"username": "testuser",
+			"password": "password123",
+		}
+		
+		jsonBody, _ := json.Marshal(reqBody)
+		
+		req, _ := http.NewRequest("POST", "/api/login", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		
+		var result map[string]interface{}
+		json.Unmarshal(bodyBytes, &result)
+		
+		assert.Equal(t, true, result["status"])
+		data := result["data"].(map[string]interface{})
+		assert.NotEmpty(t, data["token"])
+	})
+	
+	t.Run("Refresh Token Reused", func(t *testing.T) {
+		mockUsecase.On("RefreshToken", mock.Anything, usecase.RefreshTokenRequest{RefreshToken: "stolen_refresh_token"}).
+			Return(nil, errors.New("refresh token sudah pernah dipakai, silakan login kembali"))
+		
+		jsonBody, _ := json.Marshal(map[string]interface{}{"refresh_token": "stolen_refresh_token"})
+		
+		req, _ := http.NewRequest("POST", "/api/auth/refresh", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		
+		var result map[string]interface{}
+		json.Unmarshal(bodyBytes, &result)
+		
+		assert.Equal(t, utils.ErrorCodeRefreshTokenReused, result["status_code"])
+	})
+}
+
+func TestForgotPasswordHandler(t *testing.T) {
+	app, mockUsecase := setupUserHandlerTest()
+	
+	mockUsecase.On("ForgotPassword", mock.Anything, mock.Anything).Return(nil)
+	
+	send := func() (*http.Response, map[string]interface{}) {
+		jsonBody, _ := json.Marshal(map[string]interface{}{"email": "siapa@example.com"})
+		
+		req, _ := http.NewRequest("POST", "/api/forgot-password", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		
+		var result map[string]interface{}
+		json.Unmarshal(bodyBytes, &result)
+		return resp, result
+	}
+	
+	t.Run("Generic Response", func(t *testing.T) {
+		resp, result := send()
+		
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "Jika email terdaftar, tautan reset password sudah dikirim", result["message"])
+	})
+	
+	t.Run("Rate Limited", func(t *testing.T) {
+		send()
+		resp, result := send()
+		
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, utils.ErrorCodeTooManyRequests, result["status_code"])
+		mockUsecase.AssertNumberOfCalls(t, "ForgotPassword", 2)
+	})
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+//test/mocks/gateway_mocks.go
+
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/gateway"
+)
+
+type MockPaymentGateway struct {
+	mock.Mock
+}
+
+func (m *MockPaymentGateway) CreatePayment(ctx context.Context, req gateway.PaymentRequest) (*gateway.PaymentSession, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gateway.PaymentSession), args.Error(1)
+}
+
+func (m *MockPaymentGateway) ParseNotification(payload []byte) (*gateway.PaymentNotification, error) {
+	args := m.Called(payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gateway.PaymentNotification), args.Error(1)
+}
+
+func (m *MockPaymentGateway) Refund(ctx context.Context, req gateway.RefundRequest) (*gateway.RefundResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gateway.RefundResult), args.Error(1)
+}
+
+// FakePaymentGateway mencatat setiap refund yang diminta. RefundErr membuat semua refund gagal.
+type FakePaymentGateway struct {
+	Refunds   []gateway.RefundRequest
+	RefundErr error
+}
+
+func (f *FakePaymentGateway) CreatePayment(ctx context.Context, req gateway.PaymentRequest) (*gateway.PaymentSession, error) {
+	return &gateway.PaymentSession{Token: "fake-token-" + req.OrderID, RedirectURL: "https://payment.test/" + req.OrderID}, nil
+}
+
+func (f *FakePaymentGateway) ParseNotification(payload []byte) (*gateway.PaymentNotification, error) {
+	return nil, gateway.ErrInvalidNotification
+}
+
+func (f *FakePaymentGateway) Refund(ctx context.Context, req gateway.RefundRequest) (*gateway.RefundResult, error) {
+	if f.RefundErr != nil {
+		return nil, f.RefundErr
+	}
+	f.Refunds = append(f.Refunds, req)
+	return &gateway.RefundResult{GatewayRefundID: fmt.Sprintf("RF-%d", len(f.Refunds)), Amount: req.Amount}, nil
+}
@@ -4,10 +4,14 @@ package mocks
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 	
 	"github.com/stretchr/testify/mock"
 	
 	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
 )
 
 type MockUserRepository struct {
@@ -27,6 +31,14 @@ func (m *MockUserRepository) FindByID(ctx context.Context, id int) (*entity.User
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserRepository) FindByIDForUpdate(ctx context.Context, id int) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
 func (m *MockUserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
 	args := m.Called(ctx, username)
 	if args.Get(0) == nil {
@@ -58,6 +70,16 @@ func (m *MockUserRepository) UpdateVerificationStatus(ctx context.Context, userI
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, userID int, hashedPassword string) error {
+	args := m.Called(ctx, userID, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) IncrementTokenVersion(ctx context.Context, userID int) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockUserRepository) CreateDefaultProfile(ctx context.Context, userID int) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -115,6 +137,11 @@ func (m *MockEventRepository) UpdateTicketsSold(ctx context.Context, eventID, qu
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) AssignVenue(ctx context.Context, eventID, venueID, capacity int) error {
+	args := m.Called(ctx, eventID, venueID, capacity)
+	return args.Error(0)
+}
+
 type MockTransactionRepository struct {
 	mock.Mock
 }
@@ -124,6 +151,11 @@ func (m *MockTransactionRepository) Create(ctx context.Context, transaction *ent
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockTransactionRepository) CreateWithReservation(ctx context.Context, transaction *entity.Transaction) (int, error) {
+	args := m.Called(ctx, transaction)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockTransactionRepository) FindByID(ctx context.Context, id int) (*entity.Transaction, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -140,6 +172,14 @@ func (m *MockTransactionRepository) FindByCode(ctx context.Context, code string)
 	return args.Get(0).(*entity.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) FindByCodeForUpdate(ctx context.Context, code string) (*entity.Transaction, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transaction), args.Error(1)
+}
+
 func (m *MockTransactionRepository) FindByUserID(ctx context.Context, userID, offset, limit int) ([]entity.Transaction, error) {
 	args := m.Called(ctx, userID, offset, limit)
 	if args.Get(0) == nil {
@@ -158,8 +198,8 @@ func (m *MockTransactionRepository) Update(ctx context.Context, transaction *ent
 	return args.Error(0)
 }
 
-func (m *MockTransactionRepository) UpdateStatus(ctx context.Context, id int, status string) error {
-	args := m.Called(ctx, id, status)
+func (m *MockTransactionRepository) UpdateStatus(ctx context.Context, id int, from, to entity.TransactionStatus) error {
+	args := m.Called(ctx, id, from, to)
 	return args.Error(0)
 }
 
@@ -168,7 +208,1715 @@ func (m *MockTransactionRepository) UpdatePaymentProof(ctx context.Context, id i
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) UpdateExpiresAt(ctx context.Context, id int, expiresAt time.Time) error {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Error(0)
+}
+
 func (m *MockTransactionRepository) VerifyPayment(ctx context.Context, id, verifierID int) error {
 	args := m.Called(ctx, id, verifierID)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+
+func (m *MockTransactionRepository) ExpireOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Transaction, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumPaidRevenueByEvent(ctx context.Context, eventID int, currency string) (*entity.EventRevenue, error) {
+	args := m.Called(ctx, eventID, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EventRevenue), args.Error(1)
+}
+
+// FakeTxManager langsung menjalankan fn dengan ctx yang sama agar ekspektasi mock tetap cocok,
+// lalu mencatat apakah transaksi akan di-commit atau di-rollback. Pemanggilan bersarang ikut
+// dihitung walau pada TxManager sungguhan bergabung dengan transaksi terluar.
+type FakeTxManager struct {
+	mu         sync.Mutex
+	Committed  int
+	RolledBack int
+}
+
+func (m *FakeTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.RolledBack++
+	} else {
+		m.Committed++
+	}
+	return err
+}
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *entity.Payment) (int, error) {
+	args := m.Called(ctx, payment)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPaymentRepository) FindByTransactionID(ctx context.Context, transactionID int) (*entity.Payment, error) {
+	args := m.Called(ctx, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) UpdateFromNotification(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+// FakeTransactionStatusHistoryRepository menyimpan riwayat status di memori agar test bisa
+// memeriksa perubahan yang tercatat tanpa menulis ekspektasi untuk setiap insert
+type FakeTransactionStatusHistoryRepository struct {
+	mu        sync.Mutex
+	Histories []entity.TransactionStatusHistory
+}
+
+func (r *FakeTransactionStatusHistoryRepository) Create(ctx context.Context, history *entity.TransactionStatusHistory) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history.ID = len(r.Histories) + 1
+	history.CreatedAt = time.Now()
+	r.Histories = append(r.Histories, *history)
+	return history.ID, nil
+}
+
+func (r *FakeTransactionStatusHistoryRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]entity.TransactionStatusHistory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var histories []entity.TransactionStatusHistory
+	for _, history := range r.Histories {
+		if history.TransactionID == transactionID {
+			histories = append(histories, history)
+		}
+	}
+	return histories, nil
+}
+
+func (r *FakeTransactionStatusHistoryRepository) CountByTransition(ctx context.Context, transactionID int, from, to entity.TransactionStatus) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, history := range r.Histories {
+		if history.TransactionID == transactionID && history.FromStatus == from && history.ToStatus == to {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Last mengembalikan riwayat terakhir yang dicatat, atau nil jika belum ada
+func (r *FakeTransactionStatusHistoryRepository) Last() *entity.TransactionStatusHistory {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.Histories) == 0 {
+		return nil
+	}
+	history := r.Histories[len(r.Histories)-1]
+	return &history
+}
+
+// FakeTicketRepository menyimpan tiket di memori dan meniru constraint UNIQUE
+// (transaction_id, seat_number) milik tabel tickets
+type FakeTicketRepository struct {
+	mu      sync.Mutex
+	Tickets []entity.Ticket
+}
+
+func (r *FakeTicketRepository) CreateBatch(ctx context.Context, tickets []*entity.Ticket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ticket := range tickets {
+		duplicate := false
+		for _, existing := range r.Tickets {
+			if existing.TransactionID == ticket.TransactionID && existing.SeatNumber == ticket.SeatNumber {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+
+		ticket.ID = len(r.Tickets) + 1
+		ticket.PurchaseDate = time.Now()
+		r.Tickets = append(r.Tickets, *ticket)
+	}
+	return nil
+}
+
+func (r *FakeTicketRepository) FindByID(ctx context.Context, id int) (*entity.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ticket := range r.Tickets {
+		if ticket.ID == id {
+			return &ticket, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeTicketRepository) FindByCode(ctx context.Context, code string) (*entity.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ticket := range r.Tickets {
+		if ticket.TicketCode == code {
+			return &ticket, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeTicketRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]entity.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tickets []entity.Ticket
+	for _, ticket := range r.Tickets {
+		if ticket.TransactionID == transactionID {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets, nil
+}
+
+func (r *FakeTicketRepository) FindByEventID(ctx context.Context, eventID int) ([]entity.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tickets []entity.Ticket
+	for _, ticket := range r.Tickets {
+		if ticket.EventID == eventID {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets, nil
+}
+
+func (r *FakeTicketRepository) FindByUserID(ctx context.Context, userID, offset, limit int) ([]entity.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tickets []entity.Ticket
+	for _, ticket := range r.Tickets {
+		if ticket.UserID == userID {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	if offset >= len(tickets) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(tickets) {
+		end = len(tickets)
+	}
+	return tickets[offset:end], nil
+}
+
+func (r *FakeTicketRepository) CountByUserID(ctx context.Context, userID int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, ticket := range r.Tickets {
+		if ticket.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *FakeTicketRepository) UpdateStatusByTransactionID(ctx context.Context, transactionID int, from, to entity.TicketStatus) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for i := range r.Tickets {
+		if r.Tickets[i].TransactionID == transactionID && r.Tickets[i].Status == from {
+			r.Tickets[i].Status = to
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *FakeTicketRepository) CheckIn(ctx context.Context, ticketID, staffID int, gate string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Tickets {
+		if r.Tickets[i].ID == ticketID && r.Tickets[i].Status == entity.TicketStatusActive {
+			r.Tickets[i].Status = entity.TicketStatusUsed
+			r.Tickets[i].CheckedInAt = time.Now()
+			r.Tickets[i].CheckedInBy = staffID
+			r.Tickets[i].CheckedInGate = gate
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeTicketRepository) CheckInAt(ctx context.Context, ticketID, staffID int, gate string, scannedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Tickets {
+		ticket := &r.Tickets[i]
+		if ticket.ID != ticketID {
+			continue
+		}
+		if ticket.Status == entity.TicketStatusActive || (ticket.Status == entity.TicketStatusUsed && ticket.CheckedInAt.After(scannedAt)) {
+			ticket.Status = entity.TicketStatusUsed
+			ticket.CheckedInAt = scannedAt
+			ticket.CheckedInBy = staffID
+			ticket.CheckedInGate = gate
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *FakeTicketRepository) Transfer(ctx context.Context, ticketID, fromUserID, toUserID int, qrNonce string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Tickets {
+		ticket := &r.Tickets[i]
+		if ticket.ID == ticketID && ticket.UserID == fromUserID && ticket.Status == entity.TicketStatusActive {
+			ticket.UserID = toUserID
+			ticket.QRNonce = qrNonce
+			ticket.TransferCount++
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+// FakeTicketScanRepository menyimpan log scan di memori dan meniru constraint UNIQUE
+// (device_id, payload_hash, scanned_at) milik tabel ticket_scans
+type FakeTicketScanRepository struct {
+	mu    sync.Mutex
+	Scans []entity.TicketScan
+}
+
+func (r *FakeTicketScanRepository) Create(ctx context.Context, scan *entity.TicketScan) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Scans {
+		if existing.DeviceID == scan.DeviceID && existing.PayloadHash == scan.PayloadHash && existing.ScannedAt.Equal(scan.ScannedAt) {
+			return false, nil
+		}
+	}
+
+	scan.ID = len(r.Scans) + 1
+	scan.SyncedAt = time.Now()
+	r.Scans = append(r.Scans, *scan)
+	return true, nil
+}
+
+func (r *FakeTicketScanRepository) CountByEventAndResult(ctx context.Context, eventID int, result entity.TicketScanResult) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, scan := range r.Scans {
+		if scan.EventID == eventID && scan.Result == result {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FakeTicketTypeRepository menyimpan tipe tiket di memori. Reserve dan Release meniru update
+// kondisional di postgres sehingga sold tidak pernah melewati kuota.
+type FakeTicketTypeRepository struct {
+	mu          sync.Mutex
+	TicketTypes []entity.TicketType
+}
+
+func (r *FakeTicketTypeRepository) Create(ctx context.Context, ticketType *entity.TicketType) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticketType.ID = len(r.TicketTypes) + 1
+	r.TicketTypes = append(r.TicketTypes, *ticketType)
+	return ticketType.ID, nil
+}
+
+func (r *FakeTicketTypeRepository) FindByID(ctx context.Context, id int) (*entity.TicketType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ticketType := range r.TicketTypes {
+		if ticketType.ID == id {
+			return &ticketType, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeTicketTypeRepository) FindByEventID(ctx context.Context, eventID int) ([]entity.TicketType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ticketTypes []entity.TicketType
+	for _, ticketType := range r.TicketTypes {
+		if ticketType.EventID == eventID {
+			ticketTypes = append(ticketTypes, ticketType)
+		}
+	}
+	return ticketTypes, nil
+}
+
+func (r *FakeTicketTypeRepository) Update(ctx context.Context, ticketType *entity.TicketType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.TicketTypes {
+		if r.TicketTypes[i].ID == ticketType.ID {
+			if r.TicketTypes[i].Sold > ticketType.Quota {
+				return repository.ErrTicketTypeSoldOut
+			}
+			sold := r.TicketTypes[i].Sold
+			r.TicketTypes[i] = *ticketType
+			r.TicketTypes[i].Sold = sold
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *FakeTicketTypeRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, ticketType := range r.TicketTypes {
+		if ticketType.ID == id {
+			if ticketType.Sold > 0 {
+				return repository.ErrStatusConflict
+			}
+			r.TicketTypes = append(r.TicketTypes[:i], r.TicketTypes[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeTicketTypeRepository) Reserve(ctx context.Context, id, quantity int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.TicketTypes {
+		if r.TicketTypes[i].ID == id {
+			if r.TicketTypes[i].Sold+quantity > r.TicketTypes[i].Quota {
+				return repository.ErrTicketTypeSoldOut
+			}
+			r.TicketTypes[i].Sold += quantity
+			return nil
+		}
+	}
+	return repository.ErrTicketTypeSoldOut
+}
+
+func (r *FakeTicketTypeRepository) Release(ctx context.Context, id, quantity int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.TicketTypes {
+		if r.TicketTypes[i].ID == id {
+			r.TicketTypes[i].Sold -= quantity
+			if r.TicketTypes[i].Sold < 0 {
+				r.TicketTypes[i].Sold = 0
+			}
+		}
+	}
+	return nil
+}
+
+// FakeTransactionItemRepository menyimpan item transaksi di memori
+type FakeTransactionItemRepository struct {
+	mu    sync.Mutex
+	Items []entity.TransactionItem
+}
+
+func (r *FakeTransactionItemRepository) CreateBatch(ctx context.Context, items []*entity.TransactionItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, item := range items {
+		item.ID = len(r.Items) + 1
+		r.Items = append(r.Items, *item)
+	}
+	return nil
+}
+
+func (r *FakeTransactionItemRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]entity.TransactionItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var items []entity.TransactionItem
+	for _, item := range r.Items {
+		if item.TransactionID == transactionID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// FakePromoCodeRepository menyimpan kode promo dan redemption di memori. Redeem dijalankan
+// di bawah mutex sehingga meniru penguncian FOR UPDATE di postgres.
+type FakePromoCodeRepository struct {
+	mu          sync.Mutex
+	PromoCodes  []entity.PromoCode
+	Redemptions []entity.PromoRedemption
+}
+
+func (r *FakePromoCodeRepository) Create(ctx context.Context, promo *entity.PromoCode) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promo.ID = len(r.PromoCodes) + 1
+	r.PromoCodes = append(r.PromoCodes, *promo)
+	return promo.ID, nil
+}
+
+func (r *FakePromoCodeRepository) FindByID(ctx context.Context, id int) (*entity.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, promo := range r.PromoCodes {
+		if promo.ID == id {
+			return &promo, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakePromoCodeRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]entity.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var promos []entity.PromoCode
+	for _, promo := range r.PromoCodes {
+		if promo.OwnerID == ownerID {
+			promos = append(promos, promo)
+		}
+	}
+	return promos, nil
+}
+
+func (r *FakePromoCodeRepository) FindApplicable(ctx context.Context, code string, eventID, ownerID int) (*entity.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, promo := range r.PromoCodes {
+		if promo.OwnerID == ownerID && strings.EqualFold(promo.Code, code) && (promo.EventID == 0 || promo.EventID == eventID) {
+			return &promo, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakePromoCodeRepository) Update(ctx context.Context, promo *entity.PromoCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.PromoCodes {
+		if r.PromoCodes[i].ID == promo.ID {
+			usedCount := r.PromoCodes[i].UsedCount
+			r.PromoCodes[i] = *promo
+			r.PromoCodes[i].UsedCount = usedCount
+		}
+	}
+	return nil
+}
+
+func (r *FakePromoCodeRepository) Redeem(ctx context.Context, redemption *entity.PromoRedemption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.PromoCodes {
+		promo := &r.PromoCodes[i]
+		if promo.ID != redemption.PromoCodeID {
+			continue
+		}
+
+		if promo.MaxUses > 0 && promo.UsedCount >= promo.MaxUses {
+			return repository.ErrPromoCodeExhausted
+		}
+
+		if promo.PerUserLimit > 0 {
+			userRedemptions := 0
+			for _, existing := range r.Redemptions {
+				if existing.PromoCodeID == promo.ID && existing.UserID == redemption.UserID && existing.Status == entity.PromoRedemptionActive {
+					userRedemptions++
+				}
+			}
+			if userRedemptions >= promo.PerUserLimit {
+				return repository.ErrPromoUserLimitReached
+			}
+		}
+
+		redemption.ID = len(r.Redemptions) + 1
+		redemption.Status = entity.PromoRedemptionActive
+		redemption.CreatedAt = time.Now()
+		r.Redemptions = append(r.Redemptions, *redemption)
+		promo.UsedCount++
+		return nil
+	}
+	return repository.ErrPromoCodeExhausted
+}
+
+func (r *FakePromoCodeRepository) Release(ctx context.Context, transactionID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Redemptions {
+		redemption := &r.Redemptions[i]
+		if redemption.TransactionID != transactionID || redemption.Status != entity.PromoRedemptionActive {
+			continue
+		}
+
+		redemption.Status = entity.PromoRedemptionReleased
+		redemption.ReleasedAt = time.Now()
+		for j := range r.PromoCodes {
+			if r.PromoCodes[j].ID == redemption.PromoCodeID && r.PromoCodes[j].UsedCount > 0 {
+				r.PromoCodes[j].UsedCount--
+			}
+		}
+	}
+	return nil
+}
+
+// FakePricingRuleRepository menyimpan aturan biaya di memori. Upsert mengganti aturan dengan
+// pasangan owner/event yang sama seperti ON CONFLICT di postgres.
+type FakePricingRuleRepository struct {
+	mu    sync.Mutex
+	Rules []entity.PricingRule
+}
+
+func (r *FakePricingRuleRepository) FindDefault(ctx context.Context, ownerID int) (*entity.PricingRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rule := range r.Rules {
+		if rule.OwnerID == ownerID && rule.EventID == 0 {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakePricingRuleRepository) FindByEventID(ctx context.Context, eventID int) (*entity.PricingRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rule := range r.Rules {
+		if eventID != 0 && rule.EventID == eventID {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakePricingRuleRepository) FindApplicable(ctx context.Context, ownerID, eventID int) (*entity.PricingRule, error) {
+	rule, err := r.FindByEventID(ctx, eventID)
+	if err != nil || rule != nil {
+		return rule, err
+	}
+	return r.FindDefault(ctx, ownerID)
+}
+
+func (r *FakePricingRuleRepository) Upsert(ctx context.Context, rule *entity.PricingRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Rules {
+		if r.Rules[i].OwnerID == rule.OwnerID && r.Rules[i].EventID == rule.EventID {
+			rule.ID = r.Rules[i].ID
+			r.Rules[i] = *rule
+			return nil
+		}
+	}
+
+	rule.ID = len(r.Rules) + 1
+	r.Rules = append(r.Rules, *rule)
+	return nil
+}
+
+func (r *FakePricingRuleRepository) DeleteByEventID(ctx context.Context, eventID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Rules {
+		if eventID != 0 && r.Rules[i].EventID == eventID {
+			r.Rules = append(r.Rules[:i], r.Rules[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// FakeRefundRepository menyimpan refund di memori dan meniru unique index parsial
+// refunds(transaction_id) WHERE status <> 'rejected'. CancelledEventTransactions berisi transaksi
+// paid milik event yang dibatalkan, dipakai oleh QueueCancelledEventRefunds.
+type FakeRefundRepository struct {
+	mu                         sync.Mutex
+	Refunds                    []entity.Refund
+	CancelledEventTransactions []entity.Transaction
+}
+
+func (r *FakeRefundRepository) create(refund *entity.Refund) (int, error) {
+	for _, existing := range r.Refunds {
+		if existing.TransactionID == refund.TransactionID && existing.Status != entity.RefundStatusRejected {
+			return 0, repository.ErrRefundExists
+		}
+	}
+
+	refund.ID = len(r.Refunds) + 1
+	r.Refunds = append(r.Refunds, *refund)
+	return refund.ID, nil
+}
+
+func (r *FakeRefundRepository) Create(ctx context.Context, refund *entity.Refund) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.create(refund)
+}
+
+func (r *FakeRefundRepository) FindByID(ctx context.Context, id int) (*entity.Refund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, refund := range r.Refunds {
+		if refund.ID == id {
+			return &refund, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeRefundRepository) FindByTransactionID(ctx context.Context, transactionID int) (*entity.Refund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.Refunds) - 1; i >= 0; i-- {
+		if r.Refunds[i].TransactionID == transactionID {
+			refund := r.Refunds[i]
+			return &refund, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeRefundRepository) FindByEventID(ctx context.Context, eventID int) ([]entity.Refund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var refunds []entity.Refund
+	for _, refund := range r.Refunds {
+		if refund.EventID == eventID {
+			refunds = append(refunds, refund)
+		}
+	}
+	return refunds, nil
+}
+
+func (r *FakeRefundRepository) FindByStatus(ctx context.Context, status entity.RefundStatus, limit int) ([]entity.Refund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var refunds []entity.Refund
+	for _, refund := range r.Refunds {
+		if refund.Status == status && len(refunds) < limit {
+			refunds = append(refunds, refund)
+		}
+	}
+	return refunds, nil
+}
+
+func (r *FakeRefundRepository) UpdateStatus(ctx context.Context, id int, from, to entity.RefundStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Refunds {
+		if r.Refunds[i].ID == id && r.Refunds[i].Status == from {
+			r.Refunds[i].Status = to
+			r.Refunds[i].UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeRefundRepository) StartProcessing(ctx context.Context, id int, staleBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Refunds {
+		refund := &r.Refunds[i]
+		if refund.ID != id {
+			continue
+		}
+		stale := refund.Status == entity.RefundStatusProcessing && refund.ProcessingStartedAt.Before(staleBefore)
+		if refund.Status == entity.RefundStatusApproved || stale {
+			refund.Status = entity.RefundStatusProcessing
+			refund.ProcessingStartedAt = time.Now()
+			refund.UpdatedAt = refund.ProcessingStartedAt
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeRefundRepository) FindStaleProcessing(ctx context.Context, staleBefore time.Time, limit int) ([]entity.Refund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var refunds []entity.Refund
+	for _, refund := range r.Refunds {
+		if refund.Status == entity.RefundStatusProcessing && refund.ProcessingStartedAt.Before(staleBefore) && len(refunds) < limit {
+			refunds = append(refunds, refund)
+		}
+	}
+	return refunds, nil
+}
+
+func (r *FakeRefundRepository) Update(ctx context.Context, refund *entity.Refund) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Refunds {
+		if r.Refunds[i].ID == refund.ID {
+			status, startedAt := r.Refunds[i].Status, r.Refunds[i].ProcessingStartedAt
+			r.Refunds[i] = *refund
+			r.Refunds[i].Status = status
+			r.Refunds[i].ProcessingStartedAt = startedAt
+			r.Refunds[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *FakeRefundRepository) QueueCancelledEventRefunds(ctx context.Context, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queued := 0
+	now := time.Now()
+	for _, transaction := range r.CancelledEventTransactions {
+		approved := false
+		for i := range r.Refunds {
+			refund := &r.Refunds[i]
+			if refund.TransactionID == transaction.ID && refund.Status == entity.RefundStatusRequested {
+				refund.Status = entity.RefundStatusApproved
+				refund.Source = entity.RefundSourceEventCancelled
+				refund.Amount = transaction.TotalAmount
+				refund.ReviewedAt = now
+				approved = true
+				queued++
+			}
+		}
+		if approved || queued >= limit {
+			continue
+		}
+
+		_, err := r.create(&entity.Refund{
+			TransactionID: transaction.ID,
+			EventID:       transaction.EventID,
+			UserID:        transaction.UserID,
+			Amount:        transaction.TotalAmount,
+			Status:        entity.RefundStatusApproved,
+			Source:        entity.RefundSourceEventCancelled,
+			Reason:        "event dibatalkan oleh organizer",
+			ReviewedAt:    now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err == nil {
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+type FakeIdempotencyKeyRepository struct {
+	mu   sync.Mutex
+	Keys []entity.IdempotencyKey
+}
+
+func (r *FakeIdempotencyKeyRepository) Create(ctx context.Context, key *entity.IdempotencyKey, staleBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Keys {
+		existing := &r.Keys[i]
+		if existing.UserID != key.UserID || existing.Key != key.Key {
+			continue
+		}
+		stale := existing.Status == entity.IdempotencyKeyProcessing && existing.CreatedAt.Before(staleBefore)
+		if !existing.ExpiresAt.After(key.CreatedAt) || stale {
+			key.ID = existing.ID
+			*existing = *key
+			return key.ID, nil
+		}
+		return 0, repository.ErrIdempotencyKeyExists
+	}
+
+	key.ID = len(r.Keys) + 1
+	r.Keys = append(r.Keys, *key)
+	return key.ID, nil
+}
+
+func (r *FakeIdempotencyKeyRepository) FindByKey(ctx context.Context, userID int, key string) (*entity.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Keys {
+		if existing.UserID == userID && existing.Key == key && existing.ExpiresAt.After(time.Now()) {
+			return &existing, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeIdempotencyKeyRepository) Complete(ctx context.Context, key *entity.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Keys {
+		if r.Keys[i].ID == key.ID && r.Keys[i].Status == entity.IdempotencyKeyProcessing {
+			r.Keys[i].Status = entity.IdempotencyKeyCompleted
+			r.Keys[i].ResponseCode = key.ResponseCode
+			r.Keys[i].ResponseBody = key.ResponseBody
+			r.Keys[i].ContentType = key.ContentType
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeIdempotencyKeyRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Keys {
+		if r.Keys[i].ID == id && r.Keys[i].Status == entity.IdempotencyKeyProcessing {
+			r.Keys = append(r.Keys[:i], r.Keys[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *FakeIdempotencyKeyRepository) DeleteExpired(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	kept := r.Keys[:0]
+	for _, key := range r.Keys {
+		if key.ExpiresAt.After(now) {
+			kept = append(kept, key)
+		}
+	}
+	deleted := len(r.Keys) - len(kept)
+	r.Keys = kept
+	return deleted, nil
+}
+
+// FakeWaitlistRepository menyimpan antrean di memori. FreeSeats mewakili sisa kursi event yang
+// dipakai OfferSeats, karena kapasitas event sebenarnya ada di EventRepository.
+type FakeWaitlistRepository struct {
+	mu        sync.Mutex
+	Entries   []entity.WaitlistEntry
+	FreeSeats map[int]int
+}
+
+func (r *FakeWaitlistRepository) Create(ctx context.Context, entry *entity.WaitlistEntry) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Entries {
+		if existing.EventID == entry.EventID && existing.UserID == entry.UserID && existing.Active() {
+			return 0, repository.ErrWaitlistEntryExists
+		}
+	}
+
+	entry.ID = len(r.Entries) + 1
+	r.Entries = append(r.Entries, *entry)
+	return entry.ID, nil
+}
+
+func (r *FakeWaitlistRepository) FindByID(ctx context.Context, id int) (*entity.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.Entries {
+		if entry.ID == id {
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeWaitlistRepository) FindActive(ctx context.Context, eventID, userID int) (*entity.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.Entries {
+		if entry.EventID == eventID && entry.UserID == userID && entry.Active() {
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeWaitlistRepository) CountWaitingAhead(ctx context.Context, entry *entity.WaitlistEntry) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ahead := 0
+	for _, existing := range r.Entries {
+		if existing.EventID == entry.EventID && existing.Status == entity.WaitlistStatusWaiting && existing.ID < entry.ID {
+			ahead++
+		}
+	}
+	return ahead, nil
+}
+
+func (r *FakeWaitlistRepository) HasWaiting(ctx context.Context, eventID int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.Entries {
+		if entry.EventID == eventID && entry.Status == entity.WaitlistStatusWaiting {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *FakeWaitlistRepository) FindEventIDsWithWaiting(ctx context.Context) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[int]bool)
+	var eventIDs []int
+	for _, entry := range r.Entries {
+		if entry.Status == entity.WaitlistStatusWaiting && !seen[entry.EventID] && r.FreeSeats[entry.EventID] > 0 {
+			seen[entry.EventID] = true
+			eventIDs = append(eventIDs, entry.EventID)
+		}
+	}
+	return eventIDs, nil
+}
+
+func (r *FakeWaitlistRepository) UpdateStatus(ctx context.Context, id int, from, to entity.WaitlistStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Entries {
+		if r.Entries[i].ID == id && r.Entries[i].Status == from {
+			r.Entries[i].Status = to
+			r.Entries[i].UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeWaitlistRepository) Claim(ctx context.Context, id, transactionID int, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Entries {
+		entry := &r.Entries[i]
+		if entry.ID == id && entry.Status == entity.WaitlistStatusOffered && entry.OfferExpiresAt.After(now) {
+			entry.Status = entity.WaitlistStatusClaimed
+			entry.ClaimedTransactionID = transactionID
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeWaitlistRepository) OfferSeats(ctx context.Context, eventID int, now, expiresAt time.Time) ([]entity.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var offered []entity.WaitlistEntry
+	for i := range r.Entries {
+		entry := &r.Entries[i]
+		if entry.EventID != eventID || entry.Status != entity.WaitlistStatusWaiting {
+			continue
+		}
+		if entry.Quantity > r.FreeSeats[eventID] {
+			break
+		}
+
+		entry.Status = entity.WaitlistStatusOffered
+		entry.OfferedAt = now
+		entry.OfferExpiresAt = expiresAt
+		r.FreeSeats[eventID] -= entry.Quantity
+		offered = append(offered, *entry)
+	}
+	return offered, nil
+}
+
+func (r *FakeWaitlistRepository) ExpireOffers(ctx context.Context, now time.Time, limit int) ([]entity.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var expired []entity.WaitlistEntry
+	for i := range r.Entries {
+		entry := &r.Entries[i]
+		if entry.Status == entity.WaitlistStatusOffered && !entry.OfferExpiresAt.After(now) && len(expired) < limit {
+			entry.Status = entity.WaitlistStatusExpired
+			if r.FreeSeats != nil {
+				r.FreeSeats[entry.EventID] += entry.Quantity
+			}
+			expired = append(expired, *entry)
+		}
+	}
+	return expired, nil
+}
+
+// FakeTicketTransferRepository menyimpan transfer tiket di memori dan meniru unique index
+// parsial satu transfer pending per tiket
+type FakeTicketTransferRepository struct {
+	mu        sync.Mutex
+	Transfers []entity.TicketTransfer
+}
+
+func (r *FakeTicketTransferRepository) Create(ctx context.Context, transfer *entity.TicketTransfer) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Transfers {
+		if existing.TicketID == transfer.TicketID && existing.Status == entity.TicketTransferStatusPending {
+			return 0, repository.ErrTicketTransferExists
+		}
+	}
+
+	transfer.ID = len(r.Transfers) + 1
+	r.Transfers = append(r.Transfers, *transfer)
+	return transfer.ID, nil
+}
+
+func (r *FakeTicketTransferRepository) FindByID(ctx context.Context, id int) (*entity.TicketTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, transfer := range r.Transfers {
+		if transfer.ID == id {
+			return &transfer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeTicketTransferRepository) FindByTicketID(ctx context.Context, ticketID int) ([]entity.TicketTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var transfers []entity.TicketTransfer
+	for _, transfer := range r.Transfers {
+		if transfer.TicketID == ticketID {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers, nil
+}
+
+func (r *FakeTicketTransferRepository) FindPendingByUserID(ctx context.Context, userID int) ([]entity.TicketTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var transfers []entity.TicketTransfer
+	for _, transfer := range r.Transfers {
+		if transfer.Status == entity.TicketTransferStatusPending && (transfer.FromUserID == userID || transfer.ToUserID == userID) {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers, nil
+}
+
+func (r *FakeTicketTransferRepository) UpdateStatus(ctx context.Context, id int, from, to entity.TicketTransferStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Transfers {
+		if r.Transfers[i].ID == id && r.Transfers[i].Status == from {
+			r.Transfers[i].Status = to
+			r.Transfers[i].RespondedAt = time.Now()
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+// FakeVenueRepository menyimpan venue dan kursinya di memori. InUse menandai venue yang
+// masih dipakai event sehingga tidak bisa dihapus.
+type FakeVenueRepository struct {
+	mu     sync.Mutex
+	Venues []entity.Venue
+	Seats  []entity.VenueSeat
+	InUse  map[int]bool
+}
+
+func (r *FakeVenueRepository) Create(ctx context.Context, venue *entity.Venue, seats []*entity.VenueSeat) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	venue.ID = len(r.Venues) + 1
+	venue.SeatCount = len(seats)
+	for _, seat := range seats {
+		seat.ID = len(r.Seats) + 1
+		seat.VenueID = venue.ID
+		r.Seats = append(r.Seats, *seat)
+	}
+	r.Venues = append(r.Venues, *venue)
+	return venue.ID, nil
+}
+
+func (r *FakeVenueRepository) FindByID(ctx context.Context, id int) (*entity.Venue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, venue := range r.Venues {
+		if venue.ID == id {
+			return &venue, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeVenueRepository) FindByOwnerID(ctx context.Context, ownerID int) ([]entity.Venue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var venues []entity.Venue
+	for _, venue := range r.Venues {
+		if venue.OwnerID == ownerID {
+			venues = append(venues, venue)
+		}
+	}
+	return venues, nil
+}
+
+func (r *FakeVenueRepository) FindSeatsByVenueID(ctx context.Context, venueID int) ([]entity.VenueSeat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var seats []entity.VenueSeat
+	for _, seat := range r.Seats {
+		if seat.VenueID == venueID {
+			seats = append(seats, seat)
+		}
+	}
+	return seats, nil
+}
+
+func (r *FakeVenueRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.InUse[id] {
+		return repository.ErrVenueInUse
+	}
+
+	for i, venue := range r.Venues {
+		if venue.ID == id {
+			r.Venues = append(r.Venues[:i], r.Venues[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrVenueInUse
+}
+
+// FakeSeatHoldRepository menyimpan hold kursi di memori dan meniru unique index parsial satu
+// hold held/booked per kursi event. Seats dipakai untuk mencari kursi milik transaksi.
+type FakeSeatHoldRepository struct {
+	mu    sync.Mutex
+	Holds []entity.SeatHold
+	Seats []entity.VenueSeat
+}
+
+func (r *FakeSeatHoldRepository) Hold(ctx context.Context, eventID, userID int, seatIDs []int, now, expiresAt time.Time) ([]entity.SeatHold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seatID := range seatIDs {
+		for _, hold := range r.Holds {
+			// Hold held milik user sendiri akan diganti, sedangkan kursi booked tidak bisa di-hold lagi
+			if hold.EventID == eventID && hold.SeatID == seatID && hold.Occupies(now) &&
+				(hold.UserID != userID || hold.Status == entity.SeatHoldStatusBooked) {
+				return nil, repository.ErrSeatUnavailable
+			}
+		}
+	}
+
+	for i := range r.Holds {
+		hold := &r.Holds[i]
+		if hold.EventID == eventID && hold.Status == entity.SeatHoldStatusHeld &&
+			(hold.UserID == userID || !hold.ExpiresAt.After(now)) {
+			hold.Status = entity.SeatHoldStatusReleased
+		}
+	}
+
+	var holds []entity.SeatHold
+	for _, seatID := range seatIDs {
+		hold := entity.SeatHold{
+			ID:        len(r.Holds) + 1,
+			EventID:   eventID,
+			SeatID:    seatID,
+			UserID:    userID,
+			Status:    entity.SeatHoldStatusHeld,
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		r.Holds = append(r.Holds, hold)
+		holds = append(holds, hold)
+	}
+	return holds, nil
+}
+
+func (r *FakeSeatHoldRepository) FindActiveByEventID(ctx context.Context, eventID int, now time.Time) ([]entity.SeatHold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var holds []entity.SeatHold
+	for _, hold := range r.Holds {
+		if hold.EventID == eventID && hold.Occupies(now) {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
+
+func (r *FakeSeatHoldRepository) FindHeldByUser(ctx context.Context, eventID, userID int, now time.Time) ([]entity.SeatHold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var holds []entity.SeatHold
+	for _, hold := range r.Holds {
+		if hold.EventID == eventID && hold.UserID == userID && hold.Status == entity.SeatHoldStatusHeld && hold.ExpiresAt.After(now) {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
+
+func (r *FakeSeatHoldRepository) FindSeatsByTransactionID(ctx context.Context, transactionID int) ([]entity.VenueSeat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var seats []entity.VenueSeat
+	for _, hold := range r.Holds {
+		if hold.TransactionID != transactionID || hold.Status != entity.SeatHoldStatusBooked {
+			continue
+		}
+		for _, seat := range r.Seats {
+			if seat.ID == hold.SeatID {
+				seats = append(seats, seat)
+			}
+		}
+	}
+	return seats, nil
+}
+
+func (r *FakeSeatHoldRepository) Book(ctx context.Context, eventID, userID, transactionID int, seatIDs []int, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []int
+	for _, seatID := range seatIDs {
+		for i := range r.Holds {
+			hold := &r.Holds[i]
+			if hold.EventID == eventID && hold.UserID == userID && hold.SeatID == seatID &&
+				hold.Status == entity.SeatHoldStatusHeld && hold.ExpiresAt.After(now) {
+				matched = append(matched, i)
+			}
+		}
+	}
+	if len(matched) != len(seatIDs) {
+		return repository.ErrSeatHoldExpired
+	}
+
+	for _, i := range matched {
+		r.Holds[i].Status = entity.SeatHoldStatusBooked
+		r.Holds[i].TransactionID = transactionID
+	}
+	return nil
+}
+
+func (r *FakeSeatHoldRepository) ReleaseByUser(ctx context.Context, eventID, userID int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	released := 0
+	for i := range r.Holds {
+		hold := &r.Holds[i]
+		if hold.EventID == eventID && hold.UserID == userID && hold.Status == entity.SeatHoldStatusHeld {
+			hold.Status = entity.SeatHoldStatusReleased
+			released++
+		}
+	}
+	return released, nil
+}
+
+func (r *FakeSeatHoldRepository) ReleaseByTransactionID(ctx context.Context, transactionID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Holds {
+		hold := &r.Holds[i]
+		if hold.TransactionID == transactionID && hold.Status == entity.SeatHoldStatusBooked {
+			hold.Status = entity.SeatHoldStatusReleased
+		}
+	}
+	return nil
+}
+
+func (r *FakeSeatHoldRepository) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	released := 0
+	for i := range r.Holds {
+		hold := &r.Holds[i]
+		if hold.Status == entity.SeatHoldStatusHeld && !hold.ExpiresAt.After(now) {
+			hold.Status = entity.SeatHoldStatusReleased
+			released++
+		}
+	}
+	return released, nil
+}
+
+type FakeRefreshTokenRepository struct {
+	mu     sync.Mutex
+	Tokens []entity.RefreshToken
+}
+
+func (r *FakeRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token.ID = len(r.Tokens) + 1
+	r.Tokens = append(r.Tokens, *token)
+	return token.ID, nil
+}
+
+func (r *FakeRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.Tokens {
+		if token.TokenHash == tokenHash {
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeRefreshTokenRepository) MarkRotated(ctx context.Context, id int, rotatedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Tokens {
+		token := &r.Tokens[i]
+		if token.ID == id && !token.Rotated() && !token.Revoked() {
+			token.RotatedAt = rotatedAt
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakeRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Tokens {
+		if r.Tokens[i].FamilyID == familyID && !r.Tokens[i].Revoked() {
+			r.Tokens[i].RevokedAt = revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *FakeRefreshTokenRepository) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Tokens {
+		if r.Tokens[i].UserID == userID && !r.Tokens[i].Revoked() {
+			r.Tokens[i].RevokedAt = revokedAt
+		}
+	}
+	return nil
+}
+
+type FakeTokenRevocationRepository struct {
+	mu          sync.Mutex
+	Revocations []entity.TokenRevocation
+}
+
+func (r *FakeTokenRevocationRepository) Create(ctx context.Context, revocation *entity.TokenRevocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Revocations {
+		if revocation.JTI != "" && existing.JTI == revocation.JTI {
+			return nil
+		}
+	}
+
+	revocation.ID = len(r.Revocations) + 1
+	r.Revocations = append(r.Revocations, *revocation)
+	return nil
+}
+
+func (r *FakeTokenRevocationRepository) IsRevoked(ctx context.Context, userID int, jti string, tokenVersion int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, revocation := range r.Revocations {
+		if revocation.ExpiresAt.After(now) && revocation.Covers(userID, jti, tokenVersion) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *FakeTokenRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.Revocations[:0]
+	for _, revocation := range r.Revocations {
+		if revocation.ExpiresAt.After(now) {
+			kept = append(kept, revocation)
+		}
+	}
+	deleted := len(r.Revocations) - len(kept)
+	r.Revocations = kept
+	return deleted, nil
+}
+
+type FakePasswordResetRepository struct {
+	mu     sync.Mutex
+	Resets []entity.PasswordReset
+}
+
+func (r *FakePasswordResetRepository) Create(ctx context.Context, reset *entity.PasswordReset) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reset.ID = len(r.Resets) + 1
+	r.Resets = append(r.Resets, *reset)
+	return reset.ID, nil
+}
+
+func (r *FakePasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordReset, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, reset := range r.Resets {
+		if reset.TokenHash == tokenHash {
+			return &reset, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakePasswordResetRepository) FindLatestByUserID(ctx context.Context, userID int) (*entity.PasswordReset, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var latest *entity.PasswordReset
+	for i := range r.Resets {
+		if r.Resets[i].UserID == userID && (latest == nil || r.Resets[i].CreatedAt.After(latest.CreatedAt)) {
+			reset := r.Resets[i]
+			latest = &reset
+		}
+	}
+	return latest, nil
+}
+
+func (r *FakePasswordResetRepository) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.Resets {
+		if r.Resets[i].ID == id && !r.Resets[i].Used() {
+			r.Resets[i].UsedAt = usedAt
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+func (r *FakePasswordResetRepository) DeleteUnusedByUserID(ctx context.Context, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.Resets[:0]
+	for _, reset := range r.Resets {
+		if reset.UserID != userID || reset.Used() {
+			kept = append(kept, reset)
+		}
+	}
+	r.Resets = kept
+	return nil
+}
+
+type FakeUserMFARepository struct {
+	mu            sync.Mutex
+	MFAs          map[int]*entity.UserMFA
+	RecoveryCodes []entity.MFARecoveryCode
+	nextCodeID    int
+}
+
+func (r *FakeUserMFARepository) FindByUserID(ctx context.Context, userID int) (*entity.UserMFA, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.MFAs[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *mfa
+	return &copied, nil
+}
+
+func (r *FakeUserMFARepository) SavePending(ctx context.Context, mfa *entity.UserMFA) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.MFAs[mfa.UserID]; ok && existing.Enabled() {
+		return repository.ErrStatusConflict
+	}
+	if r.MFAs == nil {
+		r.MFAs = make(map[int]*entity.UserMFA)
+	}
+	copied := *mfa
+	copied.EnabledAt = time.Time{}
+	copied.LastUsedStep = 0
+	r.MFAs[mfa.UserID] = &copied
+	return nil
+}
+
+func (r *FakeUserMFARepository) Enable(ctx context.Context, userID int, enabledAt time.Time, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.MFAs[userID]
+	if !ok || mfa.Enabled() {
+		return repository.ErrStatusConflict
+	}
+	mfa.EnabledAt = enabledAt
+	mfa.LastUsedStep = step
+	return nil
+}
+
+func (r *FakeUserMFARepository) ConsumeStep(ctx context.Context, userID int, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.MFAs[userID]
+	if !ok || mfa.LastUsedStep >= step {
+		return repository.ErrStatusConflict
+	}
+	mfa.LastUsedStep = step
+	return nil
+}
+
+func (r *FakeUserMFARepository) Delete(ctx context.Context, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.MFAs, userID)
+	r.deleteRecoveryCodes(userID)
+	return nil
+}
+
+func (r *FakeUserMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteRecoveryCodes(userID)
+	for _, codeHash := range codeHashes {
+		r.nextCodeID++
+		r.RecoveryCodes = append(r.RecoveryCodes, entity.MFARecoveryCode{
+			ID:        r.nextCodeID,
+			UserID:    userID,
+			CodeHash:  codeHash,
+			CreatedAt: time.Now(),
+		})
+	}
+	return nil
+}
+
+func (r *FakeUserMFARepository) FindUnusedRecoveryCodes(ctx context.Context, userID int) ([]entity.MFARecoveryCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var codes []entity.MFARecoveryCode
+	for _, code := range r.RecoveryCodes {
+		if code.UserID == userID && !code.Used() {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+func (r *FakeUserMFARepository) MarkRecoveryCodeUsed(ctx context.Context, id int, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.RecoveryCodes {
+		if r.RecoveryCodes[i].ID == id && !r.RecoveryCodes[i].Used() {
+			r.RecoveryCodes[i].UsedAt = usedAt
+			return nil
+		}
+	}
+	return repository.ErrStatusConflict
+}
+
+// deleteRecoveryCodes dipanggil dengan mu sudah terkunci
+func (r *FakeUserMFARepository) deleteRecoveryCodes(userID int) {
+	kept := r.RecoveryCodes[:0]
+	for _, code := range r.RecoveryCodes {
+		if code.UserID != userID {
+			kept = append(kept, code)
+		}
+	}
+	r.RecoveryCodes = kept
+}
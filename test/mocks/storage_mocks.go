@@ -0,0 +1,81 @@
+//test/mocks/storage_mocks.go
+
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"ticket-system/internal/domain/storage"
+)
+
+// FakeBlobStorage menyimpan file di memori, cukup untuk memeriksa apa yang diunggah usecase
+type FakeBlobStorage struct {
+	mu           sync.Mutex
+	Objects      map[string][]byte
+	ContentTypes map[string]string
+	PutErr       error
+}
+
+func (s *FakeBlobStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.PutErr != nil {
+		return s.PutErr
+	}
+	if s.Objects == nil {
+		s.Objects = make(map[string][]byte)
+		s.ContentTypes = make(map[string]string)
+	}
+	s.Objects[key] = append([]byte(nil), content...)
+	s.ContentTypes[key] = contentType
+	return nil
+}
+
+func (s *FakeBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.Objects[key]
+	if !ok {
+		return nil, storage.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (s *FakeBlobStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.Objects, key)
+	return nil
+}
+
+func (s *FakeBlobStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "https://storage.test/" + key + "?ttl=" + ttl.String(), nil
+}
+
+// Keys mengembalikan semua key yang tersimpan
+func (s *FakeBlobStorage) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.Objects))
+	for key := range s.Objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// FakeVirusScanner menolak file jika Err diisi, selain itu semua file dianggap bersih
+type FakeVirusScanner struct {
+	Err error
+}
+
+func (s *FakeVirusScanner) Scan(ctx context.Context, content []byte) error {
+	return s.Err
+}
@@ -0,0 +1,44 @@
+//test/repository/event_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestEventUpdateKeepsConcurrentTicketsSold(t *testing.T) {
+	db := openTestDB(t)
+	_, eventID := createTestEvent(t, db, 5)
+
+	ctx := context.Background()
+	eventRepo := postgres.NewEventRepository(db)
+
+	// Salinan event dibaca sebelum penjualan lain commit
+	stale, err := eventRepo.FindByID(ctx, eventID)
+	require.NoError(t, err)
+	require.NoError(t, eventRepo.UpdateTicketsSold(ctx, eventID, 3))
+
+	stale.Title = "Race Test (Update)"
+	require.NoError(t, eventRepo.Update(ctx, stale))
+
+	updated, err := eventRepo.FindByID(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, "Race Test (Update)", updated.Title)
+	assert.Equal(t, 3, updated.TicketsSold)
+
+	// Kapasitas baru di bawah tiket yang sudah terjual ditolak oleh database
+	stale.MaxCapacity = 2
+	err = eventRepo.Update(ctx, stale)
+	assert.ErrorIs(t, err, repository.ErrStatusConflict)
+
+	updated, err = eventRepo.FindByID(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, updated.MaxCapacity)
+}
@@ -0,0 +1,120 @@
+//test/repository/idempotency_key_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func newTestIdempotencyKey(userID int, hash string) *entity.IdempotencyKey {
+	now := time.Now()
+	return &entity.IdempotencyKey{
+		UserID:      userID,
+		Key:         "retry-key",
+		Method:      "POST",
+		Path:        "/api/transactions",
+		RequestHash: hash,
+		Status:      entity.IdempotencyKeyProcessing,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(24 * time.Hour),
+	}
+}
+
+func TestIdempotencyKeyCreateConcurrentAllowsOneRequest(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, _ := createTestEvent(t, db, 1)
+	idempotencyRepo := postgres.NewIdempotencyKeyRepository(db)
+	staleBefore := time.Now().Add(-5 * time.Minute)
+
+	var (
+		wg      sync.WaitGroup
+		created int64
+		exists  int64
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := idempotencyRepo.Create(ctx, newTestIdempotencyKey(userID, "hash-a"), staleBefore)
+			switch {
+			case err == nil:
+				atomic.AddInt64(&created, 1)
+			case errors.Is(err, repository.ErrIdempotencyKeyExists):
+				atomic.AddInt64(&exists, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), created)
+	assert.Equal(t, int64(4), exists)
+
+	key, err := idempotencyRepo.FindByKey(ctx, userID, "retry-key")
+	require.NoError(t, err)
+	require.NotNil(t, key)
+
+	key.ResponseCode = 201
+	key.ContentType = "application/json"
+	key.ResponseBody = []byte(`{"status":true}`)
+	require.NoError(t, idempotencyRepo.Complete(ctx, key))
+	assert.ErrorIs(t, idempotencyRepo.Complete(ctx, key), repository.ErrStatusConflict)
+
+	// Key yang sudah selesai tidak boleh diambil alih meskipun dibuat sebelum staleBefore
+	_, err = idempotencyRepo.Create(ctx, newTestIdempotencyKey(userID, "hash-b"), time.Now().Add(time.Minute))
+	assert.ErrorIs(t, err, repository.ErrIdempotencyKeyExists)
+
+	stored, err := idempotencyRepo.FindByKey(ctx, userID, "retry-key")
+	require.NoError(t, err)
+	assert.Equal(t, entity.IdempotencyKeyCompleted, stored.Status)
+	assert.Equal(t, "hash-a", stored.RequestHash)
+	assert.Equal(t, 201, stored.ResponseCode)
+	assert.Equal(t, []byte(`{"status":true}`), stored.ResponseBody)
+}
+
+func TestIdempotencyKeyCreateTakesOverStaleProcessingKey(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, _ := createTestEvent(t, db, 1)
+	idempotencyRepo := postgres.NewIdempotencyKeyRepository(db)
+
+	stale := newTestIdempotencyKey(userID, "hash-a")
+	stale.CreatedAt = time.Now().Add(-10 * time.Minute)
+	firstID, err := idempotencyRepo.Create(ctx, stale, time.Now().Add(-5*time.Minute))
+	require.NoError(t, err)
+
+	secondID, err := idempotencyRepo.Create(ctx, newTestIdempotencyKey(userID, "hash-b"), time.Now().Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, firstID, secondID)
+
+	key, err := idempotencyRepo.FindByKey(ctx, userID, "retry-key")
+	require.NoError(t, err)
+	assert.Equal(t, "hash-b", key.RequestHash)
+
+	require.NoError(t, idempotencyRepo.Delete(ctx, key.ID))
+	key, err = idempotencyRepo.FindByKey(ctx, userID, "retry-key")
+	require.NoError(t, err)
+	assert.Nil(t, key)
+}
@@ -0,0 +1,109 @@
+//test/repository/promo_code_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestPromoRedeemConcurrentRespectsLimits(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, eventID := createTestEvent(t, db, 100)
+	transactionRepo := postgres.NewTransactionRepository(db)
+	promoRepo := postgres.NewPromoCodeRepository(db)
+
+	promoID, err := promoRepo.Create(ctx, &entity.PromoCode{
+		OwnerID:        userID,
+		EventID:        eventID,
+		Code:           fmt.Sprintf("KILAT%d", eventID),
+		DiscountType:   entity.PromoDiscountFixed,
+		DiscountAmount: entity.IDR(10000),
+		MaxUses:        3,
+		MinQuantity:    1,
+		IsActive:       true,
+	})
+	require.NoError(t, err)
+
+	transactionIDs := make([]int, 10)
+	for i := range transactionIDs {
+		transactionIDs[i], err = transactionRepo.CreateWithReservation(ctx, &entity.Transaction{
+			UserID:          userID,
+			EventID:         eventID,
+			TransactionCode: fmt.Sprintf("TRX-PROMO-%d-%d", eventID, i),
+			Quantity:        1,
+			TotalAmount:     entity.IDR(90000),
+			Status:          "pending",
+			PaymentMethod:   "bank_transfer",
+			PromoCodeID:     promoID,
+			DiscountAmount:  entity.IDR(10000),
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		success   int64
+		exhausted int64
+	)
+
+	start := make(chan struct{})
+	for _, transactionID := range transactionIDs {
+		wg.Add(1)
+		go func(transactionID int) {
+			defer wg.Done()
+			<-start
+
+			err := promoRepo.Redeem(ctx, &entity.PromoRedemption{
+				PromoCodeID:    promoID,
+				TransactionID:  transactionID,
+				UserID:         userID,
+				DiscountAmount: entity.IDR(10000),
+			})
+			switch {
+			case err == nil:
+				atomic.AddInt64(&success, 1)
+			case errors.Is(err, repository.ErrPromoCodeExhausted):
+				atomic.AddInt64(&exhausted, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}(transactionID)
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(3), success)
+	assert.Equal(t, int64(7), exhausted)
+
+	promo, err := promoRepo.FindByID(ctx, promoID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, promo.UsedCount)
+
+	// Release idempoten: transaksi tanpa redemption aktif tidak mengubah used_count
+	for _, transactionID := range transactionIDs {
+		require.NoError(t, promoRepo.Release(ctx, transactionID))
+		require.NoError(t, promoRepo.Release(ctx, transactionID))
+	}
+
+	promo, err = promoRepo.FindByID(ctx, promoID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, promo.UsedCount)
+}
@@ -0,0 +1,153 @@
+//test/repository/refund_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func createPaidTransaction(t *testing.T, transactionRepo repository.TransactionRepository, userID, eventID, index int) int {
+	id, err := transactionRepo.CreateWithReservation(context.Background(), &entity.Transaction{
+		UserID:          userID,
+		EventID:         eventID,
+		TransactionCode: fmt.Sprintf("TRX-REFUND-%d-%d", eventID, index),
+		Quantity:        1,
+		TotalAmount:     entity.IDR(100000),
+		Status:          entity.TransactionStatusPaid,
+		PaymentMethod:   "bank_transfer",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func TestRefundCreateConcurrentAllowsOneOpenRefund(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, eventID := createTestEvent(t, db, 10)
+	refundRepo := postgres.NewRefundRepository(db)
+	transactionID := createPaidTransaction(t, postgres.NewTransactionRepository(db), userID, eventID, 0)
+
+	var (
+		wg      sync.WaitGroup
+		created int64
+		exists  int64
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := refundRepo.Create(ctx, &entity.Refund{
+				TransactionID: transactionID,
+				EventID:       eventID,
+				UserID:        userID,
+				Amount:        entity.IDR(100000),
+				Status:        entity.RefundStatusRequested,
+				Source:        entity.RefundSourceBuyer,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			})
+			switch {
+			case err == nil:
+				atomic.AddInt64(&created, 1)
+			case errors.Is(err, repository.ErrRefundExists):
+				atomic.AddInt64(&exists, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), created)
+	assert.Equal(t, int64(4), exists)
+
+	// Setelah ditolak, pembeli boleh mengajukan lagi
+	refund, err := refundRepo.FindByTransactionID(ctx, transactionID)
+	require.NoError(t, err)
+	require.NoError(t, refundRepo.UpdateStatus(ctx, refund.ID, entity.RefundStatusRequested, entity.RefundStatusRejected))
+	assert.ErrorIs(t, refundRepo.UpdateStatus(ctx, refund.ID, entity.RefundStatusRequested, entity.RefundStatusApproved), repository.ErrStatusConflict)
+
+	_, err = refundRepo.Create(ctx, &entity.Refund{
+		TransactionID: transactionID,
+		EventID:       eventID,
+		UserID:        userID,
+		Amount:        entity.IDR(50000),
+		Status:        entity.RefundStatusRequested,
+		Source:        entity.RefundSourceBuyer,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	})
+	assert.NoError(t, err)
+}
+
+func TestQueueCancelledEventRefunds(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, eventID := createTestEvent(t, db, 10)
+	refundRepo := postgres.NewRefundRepository(db)
+	transactionRepo := postgres.NewTransactionRepository(db)
+
+	requestedID := createPaidTransaction(t, transactionRepo, userID, eventID, 0)
+	plainID := createPaidTransaction(t, transactionRepo, userID, eventID, 1)
+
+	_, err := refundRepo.Create(ctx, &entity.Refund{
+		TransactionID: requestedID,
+		EventID:       eventID,
+		UserID:        userID,
+		Amount:        entity.IDR(50000),
+		Status:        entity.RefundStatusRequested,
+		Source:        entity.RefundSourceBuyer,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `UPDATE events SET status = 'cancelled' WHERE id = $1`, eventID)
+	require.NoError(t, err)
+
+	queued, err := refundRepo.QueueCancelledEventRefunds(ctx, 100)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, queued, 2)
+
+	refunds, err := refundRepo.FindByEventID(ctx, eventID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+
+	for _, refund := range refunds {
+		assert.Equal(t, entity.RefundStatusApproved, refund.Status)
+		assert.Equal(t, entity.RefundSourceEventCancelled, refund.Source)
+		assert.True(t, refund.Amount.Equal(entity.IDR(100000)))
+		assert.Contains(t, []int{requestedID, plainID}, refund.TransactionID)
+	}
+
+	// Putaran berikutnya tidak membuat refund ganda
+	_, err = refundRepo.QueueCancelledEventRefunds(ctx, 100)
+	require.NoError(t, err)
+
+	refunds, err = refundRepo.FindByEventID(ctx, eventID)
+	require.NoError(t, err)
+	assert.Len(t, refunds, 2)
+}
@@ -0,0 +1,127 @@
+//test/repository/ticket_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestTicketCreateBatchIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, eventID := createTestEvent(t, db, 10)
+	transactionRepo := postgres.NewTransactionRepository(db)
+	ticketRepo := postgres.NewTicketRepository(db)
+
+	transactionID, err := transactionRepo.CreateWithReservation(ctx, &entity.Transaction{
+		UserID:          userID,
+		EventID:         eventID,
+		TransactionCode: fmt.Sprintf("TRX-TICKET-%d", eventID),
+		Quantity:        2,
+		TotalAmount:     entity.IDR(200000),
+		Status:          "paid",
+		PaymentMethod:   "bank_transfer",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+	require.NoError(t, err)
+
+	newBatch := func(prefix string) []*entity.Ticket {
+		return []*entity.Ticket{
+			{TransactionID: transactionID, EventID: eventID, UserID: userID, TicketCode: fmt.Sprintf("%s-%d-1", prefix, eventID), SeatNumber: 1, QRNonce: "nonce-1", Status: entity.TicketStatusActive},
+			{TransactionID: transactionID, EventID: eventID, UserID: userID, TicketCode: fmt.Sprintf("%s-%d-2", prefix, eventID), SeatNumber: 2, QRNonce: "nonce-2", Status: entity.TicketStatusActive},
+		}
+	}
+
+	require.NoError(t, ticketRepo.CreateBatch(ctx, newBatch("TKT-A")))
+	// Penerbitan ulang untuk transaksi yang sama tidak boleh menambah tiket
+	require.NoError(t, ticketRepo.CreateBatch(ctx, newBatch("TKT-B")))
+
+	tickets, err := ticketRepo.FindByTransactionID(ctx, transactionID)
+	require.NoError(t, err)
+	assert.Len(t, tickets, 2)
+
+	var ticketsSold int
+	require.NoError(t, db.QueryRow(`SELECT tickets_sold FROM events WHERE id = $1`, eventID).Scan(&ticketsSold))
+	assert.Equal(t, 2, ticketsSold, "tickets_sold hanya dihitung sekali saat reservasi")
+
+	updated, err := ticketRepo.UpdateStatusByTransactionID(ctx, transactionID, entity.TicketStatusActive, entity.TicketStatusRefunded)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+}
+
+func TestTicketCheckInConcurrentScans(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, eventID := createTestEvent(t, db, 10)
+	transactionRepo := postgres.NewTransactionRepository(db)
+	ticketRepo := postgres.NewTicketRepository(db)
+
+	transactionID, err := transactionRepo.CreateWithReservation(ctx, &entity.Transaction{
+		UserID:          userID,
+		EventID:         eventID,
+		TransactionCode: fmt.Sprintf("TRX-CHECKIN-%d", eventID),
+		Quantity:        1,
+		TotalAmount:     entity.IDR(100000),
+		Status:          "paid",
+		PaymentMethod:   "bank_transfer",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+	require.NoError(t, err)
+
+	ticket := &entity.Ticket{TransactionID: transactionID, EventID: eventID, UserID: userID, TicketCode: fmt.Sprintf("TKT-CHECKIN-%d", eventID), SeatNumber: 1, QRNonce: "nonce", Status: entity.TicketStatusActive}
+	require.NoError(t, ticketRepo.CreateBatch(ctx, []*entity.Ticket{ticket}))
+
+	var (
+		wg       sync.WaitGroup
+		success  int64
+		conflict int64
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			err := ticketRepo.CheckIn(ctx, ticket.ID, userID, "Gerbang A")
+			switch {
+			case err == nil:
+				atomic.AddInt64(&success, 1)
+			case errors.Is(err, repository.ErrStatusConflict):
+				atomic.AddInt64(&conflict, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), success)
+	assert.Equal(t, int64(19), conflict)
+
+	stored, err := ticketRepo.FindByID(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TicketStatusUsed, stored.Status)
+	assert.Equal(t, userID, stored.CheckedInBy)
+	assert.False(t, stored.CheckedInAt.IsZero())
+}
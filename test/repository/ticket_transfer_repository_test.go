@@ -0,0 +1,101 @@
+//test/repository/ticket_transfer_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestTicketTransferAllowsOnePendingAndOneAccept(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	ownerID, eventID := createTestEvent(t, db, 10)
+	recipientID, _ := createTestEvent(t, db, 1)
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM ticket_transfers WHERE event_id = $1`, eventID)
+		db.Exec(`DELETE FROM tickets WHERE event_id = $1`, eventID)
+	})
+
+	ticketRepo := postgres.NewTicketRepository(db)
+	transferRepo := postgres.NewTicketTransferRepository(db)
+
+	transactionID := createPaidTransaction(t, postgres.NewTransactionRepository(db), ownerID, eventID, 0)
+	ticket := &entity.Ticket{TransactionID: transactionID, EventID: eventID, UserID: ownerID, TicketCode: fmt.Sprintf("TKT-TRANSFER-%d", eventID), SeatNumber: 1, QRNonce: "nonce-lama", Status: entity.TicketStatusActive}
+	require.NoError(t, ticketRepo.CreateBatch(ctx, []*entity.Ticket{ticket}))
+
+	var (
+		wg      sync.WaitGroup
+		created int64
+		exists  int64
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := transferRepo.Create(ctx, &entity.TicketTransfer{
+				TicketID:   ticket.ID,
+				EventID:    eventID,
+				FromUserID: ownerID,
+				ToUserID:   recipientID,
+				Status:     entity.TicketTransferStatusPending,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			})
+			switch {
+			case err == nil:
+				atomic.AddInt64(&created, 1)
+			case errors.Is(err, repository.ErrTicketTransferExists):
+				atomic.AddInt64(&exists, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), created)
+	assert.Equal(t, int64(4), exists)
+
+	pending, err := transferRepo.FindPendingByUserID(ctx, recipientID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	require.NoError(t, transferRepo.UpdateStatus(ctx, pending[0].ID, entity.TicketTransferStatusPending, entity.TicketTransferStatusAccepted))
+	assert.ErrorIs(t, transferRepo.UpdateStatus(ctx, pending[0].ID, entity.TicketTransferStatusPending, entity.TicketTransferStatusDeclined), repository.ErrStatusConflict)
+
+	require.NoError(t, ticketRepo.Transfer(ctx, ticket.ID, ownerID, recipientID, "nonce-baru"))
+	// Pemilik lama tidak bisa memindahkan tiket yang sudah bukan miliknya
+	assert.ErrorIs(t, ticketRepo.Transfer(ctx, ticket.ID, ownerID, recipientID, "nonce-lain"), repository.ErrStatusConflict)
+
+	stored, err := ticketRepo.FindByID(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, recipientID, stored.UserID)
+	assert.Equal(t, "nonce-baru", stored.QRNonce)
+	assert.Equal(t, 1, stored.TransferCount)
+
+	chain, err := transferRepo.FindByTicketID(ctx, ticket.ID)
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+	assert.Equal(t, entity.TicketTransferStatusAccepted, chain[0].Status)
+	assert.False(t, chain[0].RespondedAt.IsZero())
+}
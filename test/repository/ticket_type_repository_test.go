@@ -0,0 +1,80 @@
+//test/repository/ticket_type_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestTicketTypeReserveNoOversell(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	_, eventID := createTestEvent(t, db, 100)
+	ticketTypeRepo := postgres.NewTicketTypeRepository(db)
+
+	ticketTypeID, err := ticketTypeRepo.Create(ctx, &entity.TicketType{
+		EventID:     eventID,
+		Name:        "VIP",
+		Price:       entity.IDR(250000),
+		Quota:       5,
+		MaxPerOrder: 10,
+	})
+	require.NoError(t, err)
+
+	var (
+		wg      sync.WaitGroup
+		success int64
+		soldOut int64
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			err := ticketTypeRepo.Reserve(ctx, ticketTypeID, 1)
+			switch {
+			case err == nil:
+				atomic.AddInt64(&success, 1)
+			case errors.Is(err, repository.ErrTicketTypeSoldOut):
+				atomic.AddInt64(&soldOut, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int64(5), success)
+	assert.Equal(t, int64(15), soldOut)
+
+	stored, err := ticketTypeRepo.FindByID(ctx, ticketTypeID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stored.Sold)
+
+	// Tipe yang sudah terjual tidak bisa dihapus dan kuotanya tidak bisa diturunkan di bawah sold
+	assert.ErrorIs(t, ticketTypeRepo.Delete(ctx, ticketTypeID), repository.ErrStatusConflict)
+	stored.Quota = 4
+	assert.ErrorIs(t, ticketTypeRepo.Update(ctx, stored), repository.ErrTicketTypeSoldOut)
+
+	require.NoError(t, ticketTypeRepo.Release(ctx, ticketTypeID, 10))
+	stored, err = ticketTypeRepo.FindByID(ctx, ticketTypeID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stored.Sold, "release tidak boleh membuat sold negatif")
+}
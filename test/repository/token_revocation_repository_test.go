@@ -0,0 +1,102 @@
+//test/repository/token_revocation_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestTokenRevocationVisibleToOtherInstances(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, _ := createTestEvent(t, db, 1)
+	jti := "jti-" + time.Now().Format("150405.000000000")
+
+	writer := postgres.NewTokenRevocationRepository(db)
+	require.NoError(t, writer.Create(ctx, &entity.TokenRevocation{
+		UserID: userID, JTI: jti, RevokedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	// Logout kedua untuk token yang sama tidak gagal
+	require.NoError(t, writer.Create(ctx, &entity.TokenRevocation{
+		UserID: userID, JTI: jti, RevokedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	// Instance lain memuat pencabutan dari database saat cache-nya pertama kali dipakai
+	reader := postgres.NewTokenRevocationRepository(db)
+	revoked, err := reader.IsRevoked(ctx, userID, jti, 0)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = reader.IsRevoked(ctx, userID, "jti-lain", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestTokenRevocationDeleteExpired(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, _ := createTestEvent(t, db, 1)
+	revocationRepo := postgres.NewTokenRevocationRepository(db)
+	require.NoError(t, revocationRepo.Create(ctx, &entity.TokenRevocation{
+		UserID: userID, TokenVersion: 1, RevokedAt: time.Now().Add(-2 * time.Hour), ExpiresAt: time.Now().Add(-time.Hour),
+	}))
+
+	deleted, err := revocationRepo.DeleteExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, 1)
+
+	revoked, err := revocationRepo.IsRevoked(ctx, userID, "", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestTokenRevocationCacheFollowsTransaction(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	userID, _ := createTestEvent(t, db, 1)
+	txManager := postgres.NewTxManager(db)
+	revocationRepo := postgres.NewTokenRevocationRepository(db)
+
+	// Cache dimuat lebih dulu agar IsRevoked berikutnya dijawab dari memori
+	revoked, err := revocationRepo.IsRevoked(ctx, userID, "", 0)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	errAbort := errors.New("batal")
+	err = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := revocationRepo.Create(ctx, &entity.TokenRevocation{
+			UserID: userID, TokenVersion: 1, RevokedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+		}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	revoked, err = revocationRepo.IsRevoked(ctx, userID, "", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	err = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return revocationRepo.Create(ctx, &entity.TokenRevocation{
+			UserID: userID, TokenVersion: 1, RevokedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+		})
+	})
+	require.NoError(t, err)
+
+	revoked, err = revocationRepo.IsRevoked(ctx, userID, "", 0)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
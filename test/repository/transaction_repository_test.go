@@ -0,0 +1,346 @@
+//test/repository/transaction_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+// Test di paket ini membutuhkan database PostgreSQL yang sudah dimigrasi,
+// contoh: TEST_DATABASE_DSN="host=localhost user=postgres dbname=ticket_test sslmode=disable"
+func openTestDB(t *testing.T) *sql.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN tidak di-set, test integrasi postgres dilewati")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(20)
+
+	if err := db.Ping(); err != nil {
+		t.Fatalf("gagal terhubung ke database test: %v", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func createTestEvent(t *testing.T, db *sql.DB, capacity int) (userID, eventID int) {
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO users (username, email, password, role, is_verified)
+		VALUES ($1, $2, 'x', 'organizer', TRUE)
+		RETURNING id
+	`, fmt.Sprintf("race_%d", suffix), fmt.Sprintf("race_%d@example.com", suffix)).Scan(&userID)
+	require.NoError(t, err)
+
+	err = db.QueryRowContext(ctx, `
+		INSERT INTO events (owner_id, title, event_date, max_capacity, tickets_sold, price, status)
+		VALUES ($1, 'Race Test', NOW() + INTERVAL '1 day', $2, 0, 100000, 'active')
+		RETURNING id
+	`, userID, capacity).Scan(&eventID)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM tickets WHERE event_id = $1`, eventID)
+		db.Exec(`DELETE FROM transactions WHERE event_id = $1`, eventID)
+		db.Exec(`DELETE FROM events WHERE id = $1`, eventID)
+		db.Exec(`DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	return userID, eventID
+}
+
+func TestCreateWithReservationNoOversell(t *testing.T) {
+	db := openTestDB(t)
+
+	const (
+		capacity = 5
+		buyers   = 200
+	)
+
+	userID, eventID := createTestEvent(t, db, capacity)
+	transactionRepo := postgres.NewTransactionRepository(db)
+
+	var (
+		wg       sync.WaitGroup
+		success  int64
+		soldOut  int64
+		failures int64
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+
+			_, err := transactionRepo.CreateWithReservation(context.Background(), &entity.Transaction{
+				UserID:          userID,
+				EventID:         eventID,
+				TransactionCode: fmt.Sprintf("TRX-RACE-%d-%d", eventID, i),
+				Quantity:        1,
+				TotalAmount:     entity.IDR(100000),
+				Status:          "pending",
+				PaymentMethod:   "bank_transfer",
+				CreatedAt:       time.Now(),
+				UpdatedAt:       time.Now(),
+			})
+
+			switch {
+			case err == nil:
+				atomic.AddInt64(&success, 1)
+			case errors.Is(err, repository.ErrTicketSoldOut):
+				atomic.AddInt64(&soldOut, 1)
+			default:
+				t.Errorf("error tidak terduga: %v", err)
+				atomic.AddInt64(&failures, 1)
+			}
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	var ticketsSold, transactionCount int
+	require.NoError(t, db.QueryRow(`SELECT tickets_sold FROM events WHERE id = $1`, eventID).Scan(&ticketsSold))
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE event_id = $1`, eventID).Scan(&transactionCount))
+
+	assert.Equal(t, int64(capacity), success)
+	assert.Equal(t, int64(buyers-capacity), soldOut)
+	assert.Equal(t, int64(0), failures)
+	assert.Equal(t, capacity, ticketsSold)
+	assert.Equal(t, capacity, transactionCount)
+}
+
+func TestExpireOverdueConcurrentSweepers(t *testing.T) {
+	db := openTestDB(t)
+	userID, eventID := createTestEvent(t, db, 50)
+
+	transactionRepo := postgres.NewTransactionRepository(db)
+	past := time.Now().Add(-time.Minute)
+
+	const pending = 20
+	for i := 0; i < pending; i++ {
+		_, err := transactionRepo.CreateWithReservation(context.Background(), &entity.Transaction{
+			UserID:          userID,
+			EventID:         eventID,
+			TransactionCode: fmt.Sprintf("TRX-EXP-%d-%d", eventID, i),
+			Quantity:        1,
+			TotalAmount:     entity.IDR(100000),
+			Status:          "pending",
+			PaymentMethod:   "bank_transfer",
+			ExpiresAt:       past,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		seen  = map[int]int{}
+		total int
+	)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			expired, err := transactionRepo.ExpireOverdue(context.Background(), time.Now(), 5)
+			if err != nil {
+				t.Errorf("ExpireOverdue gagal: %v", err)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, trx := range expired {
+				seen[trx.ID]++
+				total++
+			}
+		}()
+	}
+	wg.Wait()
+
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "transaksi %d diproses lebih dari sekali", id)
+	}
+	assert.Equal(t, pending, total)
+}
+
+func TestUpdateStatusIsConditional(t *testing.T) {
+	db := openTestDB(t)
+	userID, eventID := createTestEvent(t, db, 5)
+
+	ctx := context.Background()
+	transactionRepo := postgres.NewTransactionRepository(db)
+	historyRepo := postgres.NewTransactionStatusHistoryRepository(db)
+
+	transactionID, err := transactionRepo.CreateWithReservation(ctx, &entity.Transaction{
+		UserID:          userID,
+		EventID:         eventID,
+		TransactionCode: fmt.Sprintf("TRX-STATUS-%d", eventID),
+		Quantity:        1,
+		TotalAmount:     entity.IDR(100000),
+		Status:          entity.TransactionStatusPending,
+		PaymentMethod:   "bank_transfer",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, transactionRepo.UpdateStatus(ctx, transactionID, entity.TransactionStatusPending, entity.TransactionStatusCancelled))
+
+	// Proses kedua masih mengira transaksi berstatus pending
+	err = transactionRepo.UpdateStatus(ctx, transactionID, entity.TransactionStatusPending, entity.TransactionStatusExpired)
+	assert.ErrorIs(t, err, repository.ErrStatusConflict)
+
+	transaction, err := transactionRepo.FindByID(ctx, transactionID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransactionStatusCancelled, transaction.Status)
+
+	_, err = historyRepo.Create(ctx, &entity.TransactionStatusHistory{
+		TransactionID: transactionID,
+		FromStatus:    entity.TransactionStatusPending,
+		ToStatus:      entity.TransactionStatusCancelled,
+		ActorType:     entity.StatusActorUser,
+		ActorID:       userID,
+	})
+	require.NoError(t, err)
+
+	histories, err := historyRepo.FindByTransactionID(ctx, transactionID)
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	assert.Equal(t, entity.TransactionStatusPending, histories[0].FromStatus)
+	assert.Equal(t, entity.TransactionStatusCancelled, histories[0].ToStatus)
+	assert.Equal(t, userID, histories[0].ActorID)
+}
+
+func TestUpdateWritesPricingColumns(t *testing.T) {
+	db := openTestDB(t)
+	userID, eventID := createTestEvent(t, db, 5)
+
+	ctx := context.Background()
+	transactionRepo := postgres.NewTransactionRepository(db)
+
+	transactionID, err := transactionRepo.CreateWithReservation(ctx, &entity.Transaction{
+		UserID:          userID,
+		EventID:         eventID,
+		TransactionCode: fmt.Sprintf("TRX-UPDATE-%d", eventID),
+		Quantity:        1,
+		TotalAmount:     entity.IDR(100000),
+		SubtotalAmount:  entity.IDR(100000),
+		Status:          entity.TransactionStatusPending,
+		PaymentMethod:   "bank_transfer",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+	require.NoError(t, err)
+
+	transaction, err := transactionRepo.FindByID(ctx, transactionID)
+	require.NoError(t, err)
+
+	transaction.DiscountAmount = entity.IDR(10000)
+	transaction.ServiceFeeAmount = entity.IDR(5000)
+	transaction.PlatformFeeAmount = entity.IDR(2000)
+	transaction.PlatformFeeToBuyer = true
+	transaction.TaxAmount = entity.IDR(10670)
+	transaction.TotalAmount = entity.IDR(107670)
+	require.NoError(t, transactionRepo.Update(ctx, transaction))
+
+	updated, err := transactionRepo.FindByID(ctx, transactionID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.IDR(100000), updated.SubtotalAmount)
+	assert.Equal(t, entity.IDR(10000), updated.DiscountAmount)
+	assert.Equal(t, entity.IDR(5000), updated.ServiceFeeAmount)
+	assert.Equal(t, entity.IDR(2000), updated.PlatformFeeAmount)
+	assert.True(t, updated.PlatformFeeToBuyer)
+	assert.Equal(t, entity.IDR(10670), updated.TaxAmount)
+	assert.Equal(t, entity.IDR(107670), updated.TotalAmount)
+}
+
+func TestSumPaidRevenueByEvent(t *testing.T) {
+	db := openTestDB(t)
+	userID, eventID := createTestEvent(t, db, 10)
+
+	ctx := context.Background()
+	transactionRepo := postgres.NewTransactionRepository(db)
+	itemRepo := postgres.NewTransactionItemRepository(db)
+	ticketTypeRepo := postgres.NewTicketTypeRepository(db)
+
+	ticketTypeID, err := ticketTypeRepo.Create(ctx, &entity.TicketType{
+		EventID:     eventID,
+		Name:        "VIP",
+		Price:       entity.IDR(200000),
+		Quota:       5,
+		MaxPerOrder: 10,
+	})
+	require.NoError(t, err)
+
+	create := func(code string, status entity.TransactionStatus, subtotal, discount entity.Money, typed bool) {
+		transactionID, err := transactionRepo.CreateWithReservation(ctx, &entity.Transaction{
+			UserID:          userID,
+			EventID:         eventID,
+			TransactionCode: fmt.Sprintf("%s-%d", code, eventID),
+			Quantity:        1,
+			TotalAmount:     subtotal.Sub(discount),
+			SubtotalAmount:  subtotal,
+			DiscountAmount:  discount,
+			Status:          status,
+			PaymentMethod:   "bank_transfer",
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		})
+		require.NoError(t, err)
+
+		if typed {
+			require.NoError(t, itemRepo.CreateBatch(ctx, []*entity.TransactionItem{{
+				TransactionID:  transactionID,
+				TicketTypeID:   ticketTypeID,
+				TicketTypeName: "VIP",
+				Quantity:       1,
+				UnitPrice:      subtotal,
+				Subtotal:       subtotal,
+			}}))
+		}
+	}
+
+	create("TRX-PAID", entity.TransactionStatusPaid, entity.IDR(200000), entity.IDR(10000), true)
+	create("TRX-PENDING", entity.TransactionStatusPending, entity.IDR(200000), entity.IDR(0), true)
+	create("TRX-UNTYPED", entity.TransactionStatusPaid, entity.IDR(100000), entity.IDR(0), false)
+
+	// Harga dinaikkan setelah transaksi dibuat tidak mengubah pendapatan
+	ticketType, err := ticketTypeRepo.FindByID(ctx, ticketTypeID)
+	require.NoError(t, err)
+	ticketType.Price = entity.IDR(300000)
+	require.NoError(t, ticketTypeRepo.Update(ctx, ticketType))
+
+	revenue, err := transactionRepo.SumPaidRevenueByEvent(ctx, eventID, "IDR")
+	require.NoError(t, err)
+	assert.Equal(t, entity.IDR(300000), revenue.Subtotal)
+	assert.Equal(t, entity.IDR(10000), revenue.Discount)
+	assert.Equal(t, entity.IDR(290000), revenue.Net())
+	assert.Equal(t, map[int]entity.Money{ticketTypeID: entity.IDR(200000)}, revenue.ByTicketType)
+}
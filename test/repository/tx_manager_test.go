@@ -0,0 +1,65 @@
+//test/repository/tx_manager_test.go
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestTxManagerRollsBackAcrossRepositories(t *testing.T) {
+	db := openTestDB(t)
+	userID, eventID := createTestEvent(t, db, 10)
+
+	txManager := postgres.NewTxManager(db)
+	userRepo := postgres.NewUserRepository(db)
+	eventRepo := postgres.NewEventRepository(db)
+
+	errAbort := errors.New("batal")
+	err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := eventRepo.UpdateTicketsSold(ctx, eventID, 3); err != nil {
+			return err
+		}
+		if err := userRepo.UpdateVerificationStatus(ctx, userID, false); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	event, err := eventRepo.FindByID(context.Background(), eventID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, event.TicketsSold)
+
+	user, err := userRepo.FindByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.True(t, user.IsVerified)
+}
+
+func TestTxManagerCommitsAndJoinsOuterTransaction(t *testing.T) {
+	db := openTestDB(t)
+	_, eventID := createTestEvent(t, db, 10)
+
+	txManager := postgres.NewTxManager(db)
+	eventRepo := postgres.NewEventRepository(db)
+
+	err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := eventRepo.UpdateTicketsSold(ctx, eventID, 2); err != nil {
+			return err
+		}
+		return txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return eventRepo.UpdateTicketsSold(ctx, eventID, 1)
+		})
+	})
+	require.NoError(t, err)
+
+	event, err := eventRepo.FindByID(context.Background(), eventID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, event.TicketsSold)
+}
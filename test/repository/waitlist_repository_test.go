@@ -0,0 +1,92 @@
+//test/repository/waitlist_repository_test.go
+
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/repository/postgres"
+)
+
+func TestWaitlistOfferSeatsFIFO(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	_, eventID := createTestEvent(t, db, 3)
+	waitlistRepo := postgres.NewWaitlistRepository(db)
+
+	// Urutan antrean: 2 kursi, 2 kursi, lalu 1 kursi
+	quantities := []int{2, 2, 1}
+	entryIDs := make([]int, len(quantities))
+	for i, quantity := range quantities {
+		userID, _ := createTestEvent(t, db, 1)
+		now := time.Now().Add(time.Duration(i) * time.Millisecond)
+		id, err := waitlistRepo.Create(ctx, &entity.WaitlistEntry{
+			EventID:   eventID,
+			UserID:    userID,
+			Quantity:  quantity,
+			Status:    entity.WaitlistStatusWaiting,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		require.NoError(t, err)
+		entryIDs[i] = id
+
+		if i == 0 {
+			_, err = waitlistRepo.Create(ctx, &entity.WaitlistEntry{
+				EventID: eventID, UserID: userID, Quantity: 1, Status: entity.WaitlistStatusWaiting, CreatedAt: now, UpdatedAt: now,
+			})
+			assert.ErrorIs(t, err, repository.ErrWaitlistEntryExists)
+		}
+	}
+
+	// Satu kursi sudah terjual sehingga hanya tersisa dua kursi
+	_, err := db.ExecContext(ctx, `UPDATE events SET tickets_sold = 1 WHERE id = $1`, eventID)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([][]entity.WaitlistEntry, 3)
+	start := make(chan struct{})
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			now := time.Now()
+			offered, err := waitlistRepo.OfferSeats(ctx, eventID, now, now.Add(30*time.Minute))
+			assert.NoError(t, err)
+			results[i] = offered
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	var offered []entity.WaitlistEntry
+	for _, result := range results {
+		offered = append(offered, result...)
+	}
+	require.Len(t, offered, 1)
+	assert.Equal(t, entryIDs[0], offered[0].ID)
+
+	var ticketsSold int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT tickets_sold FROM events WHERE id = $1`, eventID).Scan(&ticketsSold))
+	assert.Equal(t, 3, ticketsSold)
+
+	third, err := waitlistRepo.FindByID(ctx, entryIDs[2])
+	require.NoError(t, err)
+	assert.Equal(t, entity.WaitlistStatusWaiting, third.Status)
+
+	expired, err := waitlistRepo.ExpireOffers(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, entryIDs[0], expired[0].ID)
+	assert.ErrorIs(t, waitlistRepo.Claim(ctx, entryIDs[0], 0, time.Now()), repository.ErrStatusConflict)
+}
@@ -0,0 +1,75 @@
+//test/storage/local_storage_test.go
+
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/storage"
+	"ticket-system/internal/storage/local"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	localStorage := local.NewStorage(t.TempDir(), "http://localhost:8080/api/files/", "rahasia-test")
+
+	t.Run("Put, Get And Delete", func(t *testing.T) {
+		key := "payment-proofs/1/bukti.png"
+
+		require.NoError(t, localStorage.Put(ctx, key, []byte("isi file"), "image/png"))
+
+		file, err := localStorage.Get(ctx, key)
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		file.Close()
+		assert.Equal(t, "isi file", string(content))
+
+		require.NoError(t, localStorage.Delete(ctx, key))
+
+		_, err = localStorage.Get(ctx, key)
+		assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	})
+
+	t.Run("Rejects Path Traversal", func(t *testing.T) {
+		for _, key := range []string{"../rahasia.txt", "payment-proofs/../../rahasia.txt", "/etc/passwd", ""} {
+			err := localStorage.Put(ctx, key, []byte("x"), "text/plain")
+			assert.ErrorIs(t, err, local.ErrInvalidKey, key)
+		}
+	})
+
+	t.Run("Signed URL", func(t *testing.T) {
+		key := "payment-proofs/1/bukti.png"
+
+		signedURL, err := localStorage.SignedURL(ctx, key, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(signedURL, "http://localhost:8080/api/files/payment-proofs/1/bukti.png?"))
+
+		parsed, err := url.Parse(signedURL)
+		require.NoError(t, err)
+		query := parsed.Query()
+
+		assert.NoError(t, localStorage.VerifySignature(key, query.Get("expires"), query.Get("signature")))
+		assert.ErrorIs(t, localStorage.VerifySignature("payment-proofs/2/bukti.png", query.Get("expires"), query.Get("signature")), local.ErrInvalidSignature)
+		assert.ErrorIs(t, localStorage.VerifySignature(key, "9999999999", query.Get("signature")), local.ErrInvalidSignature)
+	})
+
+	t.Run("Expired Signed URL", func(t *testing.T) {
+		key := "payment-proofs/1/bukti.png"
+
+		signedURL, err := localStorage.SignedURL(ctx, key, -time.Minute)
+		require.NoError(t, err)
+
+		parsed, _ := url.Parse(signedURL)
+		query := parsed.Query()
+
+		assert.ErrorIs(t, localStorage.VerifySignature(key, query.Get("expires"), query.Get("signature")), local.ErrURLExpired)
+	})
+}
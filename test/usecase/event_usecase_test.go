@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
 	"ticket-system/internal/usecase"
 	"ticket-system/test/mocks"
 )
@@ -20,7 +21,7 @@ func TestCreateEvent(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo)
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo, &mocks.FakeTicketTypeRepository{}, new(mocks.MockTransactionRepository))
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -38,7 +39,7 @@ func TestCreateEvent(t *testing.T) {
 			Location:    "Stadion Utama",
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 		}
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
@@ -61,7 +62,7 @@ func TestCreateEvent(t *testing.T) {
 			Location:    "Stadion Utama",
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 		}
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(nil, nil).Once()
@@ -89,7 +90,7 @@ func TestCreateEvent(t *testing.T) {
 			Location:    "Stadion Utama",
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 		}
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
@@ -117,7 +118,7 @@ func TestCreateEvent(t *testing.T) {
 			Location:    "Stadion Utama",
 			EventDate:   time.Now().Add(-24 * time.Hour),
 			MaxCapacity: 1000,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 		}
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
@@ -145,7 +146,7 @@ func TestCreateEvent(t *testing.T) {
 			Location:    "Stadion Utama",
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 		}
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
@@ -165,7 +166,7 @@ func TestGetEventByID(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo)
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo, &mocks.FakeTicketTypeRepository{}, new(mocks.MockTransactionRepository))
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -178,7 +179,7 @@ func TestGetEventByID(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -223,7 +224,7 @@ func TestUpdateEvent(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo)
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo, &mocks.FakeTicketTypeRepository{}, new(mocks.MockTransactionRepository))
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -239,7 +240,7 @@ func TestUpdateEvent(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -249,7 +250,7 @@ func TestUpdateEvent(t *testing.T) {
 			Location:    "Stadion Utama Jakarta",
 			EventDate:   time.Now().Add(48 * time.Hour),
 			MaxCapacity: 1200,
-			Price:       300000,
+			Price:       entity.IDR(300000),
 		}
 		
 		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
@@ -271,7 +272,7 @@ func TestUpdateEvent(t *testing.T) {
 			Location:    "Stadion Utama Jakarta",
 			EventDate:   time.Now().Add(48 * time.Hour),
 			MaxCapacity: 1200,
-			Price:       300000,
+			Price:       entity.IDR(300000),
 		}
 		
 		mockEventRepo.On("FindByID", ctx, eventID).Return(nil, nil).Once()
@@ -297,7 +298,7 @@ func TestUpdateEvent(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -307,7 +308,7 @@ func TestUpdateEvent(t *testing.T) {
 			Location:    "Stadion Utama Jakarta",
 			EventDate:   time.Now().Add(48 * time.Hour),
 			MaxCapacity: 1200,
-			Price:       300000,
+			Price:       entity.IDR(300000),
 		}
 		
 		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
@@ -332,7 +333,7 @@ func TestUpdateEvent(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -342,7 +343,7 @@ func TestUpdateEvent(t *testing.T) {
 			Location:    "Stadion Utama Jakarta",
 			EventDate:   time.Now().Add(48 * time.Hour),
 			MaxCapacity: 400,
-			Price:       300000,
+			Price:       entity.IDR(300000),
 		}
 		
 		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
@@ -354,6 +355,38 @@ func TestUpdateEvent(t *testing.T) {
 		mockEventRepo.AssertExpectations(t)
 	})
 	
+	t.Run("Capacity Below Tickets Sold Concurrently", func(t *testing.T) {
+		eventID := 1
+		userID := 1
+		
+		existingEvent := &entity.Event{
+			ID:          eventID,
+			OwnerID:     userID,
+			Title:       "Konser Musik Rock",
+			EventDate:   time.Now().Add(24 * time.Hour),
+			MaxCapacity: 1000,
+			TicketsSold: 500,
+			Price:       entity.IDR(250000),
+			Status:      "active",
+		}
+		
+		req := usecase.UpdateEventRequest{
+			Title:       "Konser Musik Rock",
+			EventDate:   time.Now().Add(24 * time.Hour),
+			MaxCapacity: 600,
+			Price:       entity.IDR(250000),
+		}
+		
+		// Penjualan yang commit setelah FindByID membuat tiket terjual melebihi kapasitas baru
+		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
+		mockEventRepo.On("Update", ctx, mock.AnythingOfType("*entity.Event")).Return(repository.ErrStatusConflict).Once()
+		
+		err := eventUsecase.UpdateEvent(ctx, eventID, userID, req)
+		
+		assert.EqualError(t, err, "kapasitas tidak boleh lebih kecil dari jumlah tiket yang sudah terjual")
+		mockEventRepo.AssertExpectations(t)
+	})
+	
 	t.Run("Invalid Status", func(t *testing.T) {
 		eventID := 1
 		userID := 1
@@ -367,7 +400,7 @@ func TestUpdateEvent(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -377,7 +410,7 @@ func TestUpdateEvent(t *testing.T) {
 			Location:    "Stadion Utama Jakarta",
 			EventDate:   time.Now().Add(48 * time.Hour),
 			MaxCapacity: 1200,
-			Price:       300000,
+			Price:       entity.IDR(300000),
 			Status:      "invalid_status",
 		}
 		
@@ -389,13 +422,131 @@ func TestUpdateEvent(t *testing.T) {
 		assert.Equal(t, "status tidak valid", err.Error())
 		mockEventRepo.AssertExpectations(t)
 	})
+	
+	t.Run("Cancelled Event Cannot Be Reactivated", func(t *testing.T) {
+		eventID := 1
+		userID := 1
+		
+		existingEvent := &entity.Event{
+			ID:          eventID,
+			OwnerID:     userID,
+			Title:       "Konser Musik Rock",
+			EventDate:   time.Now().Add(24 * time.Hour),
+			MaxCapacity: 1000,
+			TicketsSold: 500,
+			Price:       entity.IDR(250000),
+			Status:      "cancelled",
+		}
+		
+		req := usecase.UpdateEventRequest{
+			Title:       "Konser Musik Rock",
+			EventDate:   time.Now().Add(24 * time.Hour),
+			MaxCapacity: 1000,
+			Price:       entity.IDR(250000),
+			Status:      "active",
+		}
+		
+		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
+		
+		err := eventUsecase.UpdateEvent(ctx, eventID, userID, req)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "event yang sudah dibatalkan tidak dapat diaktifkan kembali", err.Error())
+		mockEventRepo.AssertExpectations(t)
+	})
+	
+	t.Run("Refund Policy", func(t *testing.T) {
+		eventID := 1
+		userID := 1
+		
+		existingEvent := &entity.Event{
+			ID:           eventID,
+			OwnerID:      userID,
+			Title:        "Konser Musik Rock",
+			EventDate:    time.Now().Add(24 * time.Hour),
+			MaxCapacity:  1000,
+			Price:        entity.IDR(250000),
+			Status:       "active",
+			RefundPolicy: entity.RefundPolicy{Percent: entity.OneHundredPercent},
+		}
+		
+		req := usecase.UpdateEventRequest{
+			Title:        "Konser Musik Rock",
+			EventDate:    time.Now().Add(24 * time.Hour),
+			MaxCapacity:  1000,
+			Price:        entity.IDR(250000),
+			RefundPolicy: &entity.RefundPolicy{Allowed: true, DeadlineHours: 72, Percent: 0},
+		}
+		
+		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
+		
+		err := eventUsecase.UpdateEvent(ctx, eventID, userID, req)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "persentase refund harus lebih dari 0 dan maksimal 100", err.Error())
+		
+		req.RefundPolicy.Percent = 5000
+		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
+		mockEventRepo.On("Update", ctx, mock.MatchedBy(func(e *entity.Event) bool {
+			return e.RefundPolicy.Allowed && e.RefundPolicy.DeadlineHours == 72 && e.RefundPolicy.Percent == 5000
+		})).Return(nil).Once()
+		
+		err = eventUsecase.UpdateEvent(ctx, eventID, userID, req)
+		
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+	
+	t.Run("Transfer Policy", func(t *testing.T) {
+		eventID := 1
+		userID := 1
+		
+		existingEvent := &entity.Event{
+			ID:             eventID,
+			OwnerID:        userID,
+			Title:          "Konser Musik Rock",
+			EventDate:      time.Now().Add(24 * time.Hour),
+			MaxCapacity:    1000,
+			Price:          entity.IDR(250000),
+			Status:         "active",
+			RefundPolicy:   entity.RefundPolicy{Percent: entity.OneHundredPercent},
+			TransferPolicy: entity.TransferPolicy{Allowed: true},
+		}
+		
+		req := usecase.UpdateEventRequest{
+			Title:          "Konser Musik Rock",
+			EventDate:      time.Now().Add(24 * time.Hour),
+			MaxCapacity:    1000,
+			Price:          entity.IDR(250000),
+			TransferPolicy: &entity.TransferPolicy{Allowed: true, DeadlineHours: 24, MaxTransfers: -1},
+		}
+		
+		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
+		
+		err := eventUsecase.UpdateEvent(ctx, eventID, userID, req)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "batas jumlah transfer tidak boleh negatif", err.Error())
+		
+		req.TransferPolicy.MaxTransfers = 1
+		mockEventRepo.On("FindByID", ctx, eventID).Return(existingEvent, nil).Once()
+		mockEventRepo.On("Update", ctx, mock.MatchedBy(func(e *entity.Event) bool {
+			return e.TransferPolicy.Allowed && e.TransferPolicy.DeadlineHours == 24 && e.TransferPolicy.MaxTransfers == 1
+		})).Return(nil).Once()
+		
+		err = eventUsecase.UpdateEvent(ctx, eventID, userID, req)
+		
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
 }
 
 func TestGetEventSales(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
 	
-	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo)
+	eventUsecase := usecase.NewEventUsecase(mockEventRepo, mockUserRepo, &mocks.FakeTicketTypeRepository{}, mockTransactionRepo)
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -411,11 +562,15 @@ func TestGetEventSales(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
 		mockEventRepo.On("FindByID", ctx, eventID).Return(event, nil).Once()
+		mockTransactionRepo.On("SumPaidRevenueByEvent", ctx, eventID, "IDR").Return(&entity.EventRevenue{
+			Subtotal: entity.IDR(250000 * 480),
+			Discount: entity.IDR(0),
+		}, nil).Once()
 		
 		sales, err := eventUsecase.GetEventSales(ctx, eventID, userID)
 		
@@ -426,9 +581,11 @@ func TestGetEventSales(t *testing.T) {
 		assert.Equal(t, 1000, sales.MaxCapacity)
 		assert.Equal(t, 500, sales.TicketsSold)
 		assert.Equal(t, 500, sales.AvailableTickets)
-		assert.Equal(t, 250000.0, sales.Price)
-		assert.Equal(t, 250000.0*500, sales.TotalSales)
+		assert.Equal(t, entity.IDR(250000), sales.Price)
+		// 20 tiket masih menunggu pembayaran
+		assert.Equal(t, entity.IDR(250000*480), sales.TotalSales)
 		mockEventRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertExpectations(t)
 	})
 	
 	t.Run("Event Not Found", func(t *testing.T) {
@@ -459,7 +616,7 @@ func TestGetEventSales(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -0,0 +1,119 @@
+//test/usecase/idempotency_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/test/mocks"
+)
+
+func newIdempotencyRequest(hash string) usecase.BeginIdempotencyRequest {
+	return usecase.BeginIdempotencyRequest{
+		UserID:      1,
+		Key:         "key-1",
+		Method:      "POST",
+		Path:        "/api/transactions",
+		RequestHash: hash,
+	}
+}
+
+func TestIdempotencyUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Replay completed response", func(t *testing.T) {
+		repo := &mocks.FakeIdempotencyKeyRepository{}
+		uc := usecase.NewIdempotencyUsecase(repo, "24")
+
+		key, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		assert.Equal(t, entity.IdempotencyKeyProcessing, key.Status)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), key.ExpiresAt, time.Minute)
+
+		require.NoError(t, uc.Complete(ctx, key, 201, "application/json", []byte(`{"id":1}`)))
+
+		replay, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		assert.Equal(t, entity.IdempotencyKeyCompleted, replay.Status)
+		assert.Equal(t, 201, replay.ResponseCode)
+		assert.Equal(t, "application/json", replay.ContentType)
+		assert.Equal(t, []byte(`{"id":1}`), replay.ResponseBody)
+	})
+
+	t.Run("Different request with same key", func(t *testing.T) {
+		repo := &mocks.FakeIdempotencyKeyRepository{}
+		uc := usecase.NewIdempotencyUsecase(repo, "24")
+
+		key, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		require.NoError(t, uc.Complete(ctx, key, 201, "application/json", []byte(`{}`)))
+
+		_, err = uc.Begin(ctx, newIdempotencyRequest("hash-b"))
+		assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyMismatch)
+	})
+
+	t.Run("Same key still processing", func(t *testing.T) {
+		repo := &mocks.FakeIdempotencyKeyRepository{}
+		uc := usecase.NewIdempotencyUsecase(repo, "24")
+
+		_, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+
+		_, err = uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyInProgress)
+	})
+
+	t.Run("Released key can be retried", func(t *testing.T) {
+		repo := &mocks.FakeIdempotencyKeyRepository{}
+		uc := usecase.NewIdempotencyUsecase(repo, "24")
+
+		key, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		require.NoError(t, uc.Release(ctx, key))
+
+		retry, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		assert.Equal(t, entity.IdempotencyKeyProcessing, retry.Status)
+	})
+
+	t.Run("Stale processing key is taken over", func(t *testing.T) {
+		repo := &mocks.FakeIdempotencyKeyRepository{}
+		uc := usecase.NewIdempotencyUsecase(repo, "24")
+
+		_, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		repo.Keys[0].CreatedAt = time.Now().Add(-10 * time.Minute)
+
+		key, err := uc.Begin(ctx, newIdempotencyRequest("hash-b"))
+		require.NoError(t, err)
+		assert.Equal(t, "hash-b", key.RequestHash)
+		assert.Len(t, repo.Keys, 1)
+	})
+
+	t.Run("Expired key is reused and purged", func(t *testing.T) {
+		repo := &mocks.FakeIdempotencyKeyRepository{}
+		uc := usecase.NewIdempotencyUsecase(repo, "24")
+
+		key, err := uc.Begin(ctx, newIdempotencyRequest("hash-a"))
+		require.NoError(t, err)
+		require.NoError(t, uc.Complete(ctx, key, 201, "application/json", []byte(`{}`)))
+		repo.Keys[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+		key, err = uc.Begin(ctx, newIdempotencyRequest("hash-b"))
+		require.NoError(t, err)
+		assert.Equal(t, entity.IdempotencyKeyProcessing, key.Status)
+
+		repo.Keys[0].ExpiresAt = time.Now().Add(-time.Minute)
+		deleted, err := uc.PurgeExpired(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		assert.Empty(t, repo.Keys)
+	})
+}
@@ -0,0 +1,234 @@
+//test/usecase/payment_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/gateway/midtrans"
+	"ticket-system/internal/usecase"
+	"ticket-system/test/mocks"
+)
+
+const fixtureOrderID = "TRX-20250101-123456"
+
+func loadMidtransFixture(t *testing.T, name string) []byte {
+	payload, err := os.ReadFile(filepath.Join("..", "fixtures", "midtrans", name+".json"))
+	require.NoError(t, err)
+	return payload
+}
+
+func TestHandlePaymentNotification(t *testing.T) {
+	ctx := context.Background()
+
+	// Fixture ditandatangani dengan server key ini, jadi verifikasi signature ikut teruji
+	paymentGateway := midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test"})
+
+	newTransaction := func(status entity.TransactionStatus) *entity.Transaction {
+		return &entity.Transaction{
+			ID:              7,
+			EventID:         3,
+			TransactionCode: fixtureOrderID,
+			Quantity:        2,
+			TotalAmount:     entity.IDR(500000),
+			Status:          status,
+			PaymentMethod:   "midtrans",
+		}
+	}
+
+	setup := func() (usecase.PaymentUsecase, *mocks.MockTransactionRepository, *mocks.MockEventRepository, *mocks.MockPaymentRepository) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+		paymentUsecase := usecase.NewPaymentUsecase(mockTransactionRepo, mockEventRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeRefundRepository{}, &mocks.FakeTxManager{}, paymentGateway)
+		return paymentUsecase, mockTransactionRepo, mockEventRepo, mockPaymentRepo
+	}
+
+	t.Run("Settlement Marks Transaction Paid", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, mockEventRepo, mockPaymentRepo := setup()
+		payload := loadMidtransFixture(t, "settlement")
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("pending"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7, Status: "pending"}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.MatchedBy(func(p *entity.Payment) bool {
+			return p.Status == "settlement" &&
+				p.PaymentType == "bank_transfer" &&
+				p.MidtransTransactionID == "9aed5972-5b6a-401e-894b-a32c91ed1a3a" &&
+				!p.PaymentTime.IsZero() &&
+				string(p.CallbackData) == string(payload)
+		})).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPending, entity.TransactionStatusPaid).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, payload)
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertExpectations(t)
+		mockPaymentRepo.AssertExpectations(t)
+		mockEventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Replayed Settlement Is Idempotent", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, _, mockPaymentRepo := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("paid"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7, Status: "settlement"}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement"))
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Challenged Capture Keeps Pending", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, _, mockPaymentRepo := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("pending"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "capture_challenge"))
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expire Releases Seats", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, mockEventRepo, mockPaymentRepo := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("pending"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPending, entity.TransactionStatusExpired).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "expire"))
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Expire After Sweeper Does Not Release Twice", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, mockEventRepo, mockPaymentRepo := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("expired"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "expire"))
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockEventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Settlement After Expiry Queues Refund", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		refundRepo := &mocks.FakeRefundRepository{}
+		paymentUsecase := usecase.NewPaymentUsecase(mockTransactionRepo, mockEventRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, refundRepo, &mocks.FakeTxManager{}, paymentGateway)
+
+		// Notifikasi settlement dikirim dua kali oleh gateway
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("expired"), nil).Twice()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Twice()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.MatchedBy(func(p *entity.Payment) bool {
+			return p.Status == "settlement"
+		})).Return(nil).Twice()
+
+		payload := loadMidtransFixture(t, "settlement")
+		require.NoError(t, paymentUsecase.HandleNotification(ctx, payload))
+		require.NoError(t, paymentUsecase.HandleNotification(ctx, payload))
+
+		// Pembayaran tetap dicatat, transaksi tetap expired, dan dana dikembalikan lewat satu refund
+		mockPaymentRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockEventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+		require.Len(t, refundRepo.Refunds, 1)
+		assert.Equal(t, entity.RefundStatusApproved, refundRepo.Refunds[0].Status)
+		assert.Equal(t, entity.RefundSourceLatePayment, refundRepo.Refunds[0].Source)
+		assert.Equal(t, entity.IDR(500000), refundRepo.Refunds[0].Amount)
+		assert.Equal(t, 3, refundRepo.Refunds[0].EventID)
+	})
+
+	t.Run("Deny Marks Transaction Failed", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, mockEventRepo, mockPaymentRepo := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("pending"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPending, entity.TransactionStatusFailed).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "deny"))
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refund Marks Paid Transaction Refunded", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, mockEventRepo, mockPaymentRepo := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(newTransaction("paid"), nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "refund"))
+
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Signature", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, _, _ := setup()
+		payload := []byte(`{"order_id":"TRX-20250101-123456","status_code":"200","gross_amount":"500000.00","transaction_status":"settlement","signature_key":"palsu"}`)
+
+		err := paymentUsecase.HandleNotification(ctx, payload)
+
+		assert.Error(t, err)
+		assert.Equal(t, "signature notifikasi tidak valid", err.Error())
+		mockTransactionRepo.AssertNotCalled(t, "FindByCodeForUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Transaction Not Found", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, _, _ := setup()
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(nil, nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement"))
+
+		assert.Error(t, err)
+		assert.Equal(t, "transaksi tidak ditemukan", err.Error())
+	})
+
+	t.Run("Amount Mismatch", func(t *testing.T) {
+		paymentUsecase, mockTransactionRepo, _, mockPaymentRepo := setup()
+
+		transaction := newTransaction("pending")
+		transaction.TotalAmount = entity.IDR(750000)
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement"))
+
+		assert.Error(t, err)
+		assert.Equal(t, "nominal pembayaran tidak sesuai dengan transaksi", err.Error())
+		mockPaymentRepo.AssertNotCalled(t, "UpdateFromNotification", mock.Anything, mock.Anything)
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
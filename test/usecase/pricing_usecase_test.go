@@ -0,0 +1,236 @@
+//test/usecase/pricing_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func TestPricingRuleManagement(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	eventID := 7
+
+	newFixture := func() (usecase.PricingUsecase, *mocks.FakePricingRuleRepository) {
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{ID: eventID, OwnerID: organizerID, Currency: "IDR"}, nil)
+
+		pricingRepo := &mocks.FakePricingRuleRepository{}
+		return usecase.NewPricingUsecase(pricingRepo, mockEventRepo), pricingRepo
+	}
+
+	t.Run("Organizer Without Rule Charges Nothing", func(t *testing.T) {
+		pricingUsecase, _ := newFixture()
+
+		rule, err := pricingUsecase.GetOrganizerPricing(ctx, organizerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, organizerID, rule.OwnerID)
+		assert.Equal(t, entity.Percent(0), rule.ServiceFeePercent)
+		assert.Equal(t, entity.Percent(0), rule.TaxPercent)
+	})
+
+	t.Run("Event Rule Overrides Organizer Default", func(t *testing.T) {
+		pricingUsecase, pricingRepo := newFixture()
+
+		_, err := pricingUsecase.UpdateOrganizerPricing(ctx, organizerID, usecase.PricingRuleRequest{TaxPercent: 1100})
+		assert.NoError(t, err)
+
+		rule, err := pricingUsecase.GetEventPricing(ctx, eventID, organizerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rule.EventID)
+		assert.Equal(t, entity.Percent(1100), rule.TaxPercent)
+
+		_, err = pricingUsecase.UpdateEventPricing(ctx, eventID, organizerID, usecase.PricingRuleRequest{
+			ServiceFeePercent:   250,
+			ServiceFeePerTicket: entity.Money{Amount: 2000},
+			PassPlatformFee:     true,
+		})
+		assert.NoError(t, err)
+
+		rule, err = pricingUsecase.GetEventPricing(ctx, eventID, organizerID)
+		assert.NoError(t, err)
+		assert.Equal(t, eventID, rule.EventID)
+		assert.Equal(t, entity.IDR(2000), rule.ServiceFeePerTicket)
+		assert.Equal(t, entity.Percent(0), rule.TaxPercent)
+		assert.Len(t, pricingRepo.Rules, 2)
+
+		err = pricingUsecase.DeleteEventPricing(ctx, eventID, organizerID)
+		assert.NoError(t, err)
+
+		rule, err = pricingUsecase.GetEventPricing(ctx, eventID, organizerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rule.EventID)
+		assert.Equal(t, entity.Percent(1100), rule.TaxPercent)
+	})
+
+	t.Run("Update Replaces Existing Rule", func(t *testing.T) {
+		pricingUsecase, pricingRepo := newFixture()
+
+		_, err := pricingUsecase.UpdateOrganizerPricing(ctx, organizerID, usecase.PricingRuleRequest{TaxPercent: 1100})
+		assert.NoError(t, err)
+		_, err = pricingUsecase.UpdateOrganizerPricing(ctx, organizerID, usecase.PricingRuleRequest{TaxPercent: 1200})
+		assert.NoError(t, err)
+
+		assert.Len(t, pricingRepo.Rules, 1)
+		assert.Equal(t, entity.Percent(1200), pricingRepo.Rules[0].TaxPercent)
+	})
+
+	t.Run("Rejects Invalid Values", func(t *testing.T) {
+		pricingUsecase, _ := newFixture()
+
+		_, err := pricingUsecase.UpdateOrganizerPricing(ctx, organizerID, usecase.PricingRuleRequest{ServiceFeePercent: 10001})
+		assert.EqualError(t, err, "persentase biaya layanan harus antara 0 dan 100")
+
+		_, err = pricingUsecase.UpdateOrganizerPricing(ctx, organizerID, usecase.PricingRuleRequest{ServiceFeePerTicket: entity.IDR(-1)})
+		assert.EqualError(t, err, "biaya layanan per tiket tidak boleh negatif")
+
+		_, err = pricingUsecase.UpdateOrganizerPricing(ctx, organizerID, usecase.PricingRuleRequest{TaxPercent: -100})
+		assert.EqualError(t, err, "persentase pajak harus antara 0 dan 100")
+
+		_, err = pricingUsecase.UpdateEventPricing(ctx, eventID, organizerID, usecase.PricingRuleRequest{ServiceFeePerTicket: entity.NewMoney(150, "USD")})
+		assert.EqualError(t, err, "mata uang biaya layanan harus sama dengan mata uang event")
+	})
+
+	t.Run("Rejects Event Of Another Organizer", func(t *testing.T) {
+		pricingUsecase, pricingRepo := newFixture()
+
+		_, err := pricingUsecase.UpdateEventPricing(ctx, eventID, 99, usecase.PricingRuleRequest{TaxPercent: 1100})
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk mengatur biaya event ini")
+
+		err = pricingUsecase.DeleteEventPricing(ctx, eventID, 99)
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk mengatur biaya event ini")
+		assert.Empty(t, pricingRepo.Rules)
+	})
+}
+
+func TestCreateTransactionWithPricingRule(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	userID := 2
+	eventID := 7
+
+	event := &entity.Event{
+		ID:          eventID,
+		OwnerID:     organizerID,
+		Title:       "Konser",
+		MaxCapacity: 100,
+		Price:       entity.IDR(250000),
+		Currency:    "IDR",
+		Status:      "active",
+	}
+
+	// Aturan default organizer hanya berisi PPN, aturan event menambah biaya layanan dan
+	// meneruskan komisi platform ke pembeli
+	rules := []entity.PricingRule{
+		{ID: 1, OwnerID: organizerID, TaxPercent: 1000},
+		{ID: 2, OwnerID: organizerID, EventID: eventID, ServiceFeePercent: 500, ServiceFeePerTicket: entity.IDR(2500), TaxPercent: 1100, PassPlatformFee: true},
+	}
+
+	newFixture := func(paymentGateway *mocks.MockPaymentGateway, paymentRepo *mocks.MockPaymentRepository, rules ...entity.PricingRule) (usecase.TransactionUsecase, *mocks.MockTransactionRepository) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		mockUserRepo.On("FindByID", mock.Anything, mock.Anything).Return(&entity.User{ID: userID, Role: "user", Email: "user@example.com"}, nil)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(event, nil)
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, paymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{Rules: rules}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, paymentGateway, &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "2.5", utils.SMTPConfig{})
+		return transactionUsecase, mockTransactionRepo
+	}
+
+	request := func(paymentMethod string) usecase.CreateTransactionRequest {
+		return usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Quantity:      2,
+			PaymentMethod: paymentMethod,
+		}
+	}
+
+	t.Run("Event Rule Components Are Stored And Returned", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo := newFixture(new(mocks.MockPaymentGateway), new(mocks.MockPaymentRepository), rules...)
+
+		var created *entity.Transaction
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entity.Transaction)
+		}).Return(10, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, request("bank_transfer"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.IDR(500000), created.SubtotalAmount)
+		assert.Equal(t, entity.IDR(30000), created.ServiceFeeAmount)
+		assert.Equal(t, entity.IDR(12500), created.PlatformFeeAmount)
+		assert.True(t, created.PlatformFeeToBuyer)
+		assert.Equal(t, entity.IDR(59675), created.TaxAmount)
+		assert.Equal(t, entity.IDR(602175), created.TotalAmount)
+
+		assert.Equal(t, entity.IDR(602175), response.TotalAmount)
+		assert.Equal(t, entity.IDR(500000), response.Pricing.Subtotal)
+		assert.Equal(t, entity.IDR(30000), response.Pricing.ServiceFee)
+		assert.Equal(t, entity.IDR(12500), *response.Pricing.PlatformFee)
+		assert.Equal(t, entity.IDR(59675), response.Pricing.Tax)
+		assert.Equal(t, entity.IDR(602175), response.Pricing.Total)
+	})
+
+	t.Run("Absorbed Platform Fee Is Recorded But Not Charged", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo := newFixture(new(mocks.MockPaymentGateway), new(mocks.MockPaymentRepository), rules[0])
+
+		var created *entity.Transaction
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entity.Transaction)
+		}).Return(10, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, request("bank_transfer"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.IDR(12500), created.PlatformFeeAmount)
+		assert.False(t, created.PlatformFeeToBuyer)
+		assert.Equal(t, entity.IDR(50000), created.TaxAmount)
+		assert.Equal(t, entity.IDR(550000), response.TotalAmount)
+		assert.Nil(t, response.Pricing.PlatformFee)
+	})
+
+	t.Run("Midtrans Items Add Up To Gross Amount", func(t *testing.T) {
+		mockPaymentGateway := new(mocks.MockPaymentGateway)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		transactionUsecase, mockTransactionRepo := newFixture(mockPaymentGateway, mockPaymentRepo, rules...)
+
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(10, nil).Once()
+		mockPaymentGateway.On("CreatePayment", ctx, mock.MatchedBy(func(p gateway.PaymentRequest) bool {
+			var sum int64
+			for _, item := range p.Items {
+				sum += item.Price * int64(item.Quantity)
+			}
+			return p.GrossAmount == 602175 && sum == p.GrossAmount && len(p.Items) == 4
+		})).Return(&gateway.PaymentSession{Token: "snap-token"}, nil).Once()
+		mockPaymentRepo.On("Create", ctx, mock.AnythingOfType("*entity.Payment")).Return(1, nil).Once()
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, request("midtrans"))
+
+		assert.NoError(t, err)
+		mockPaymentGateway.AssertExpectations(t)
+	})
+
+	t.Run("Per Ticket Fee In Other Currency Is Rejected", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo := newFixture(new(mocks.MockPaymentGateway), new(mocks.MockPaymentRepository), entity.PricingRule{
+			ID: 1, OwnerID: organizerID, ServiceFeePerTicket: entity.NewMoney(150, "USD"),
+		})
+
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, request("bank_transfer"))
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "mata uang biaya layanan tidak sesuai dengan mata uang event")
+		mockTransactionRepo.AssertNotCalled(t, "CreateWithReservation", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,263 @@
+//test/usecase/promo_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func TestPromoCodeManagement(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	eventID := 7
+
+	newFixture := func() (usecase.PromoUsecase, *mocks.FakePromoCodeRepository) {
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{ID: eventID, OwnerID: organizerID}, nil)
+
+		promoRepo := &mocks.FakePromoCodeRepository{}
+		return usecase.NewPromoUsecase(promoRepo, mockEventRepo), promoRepo
+	}
+
+	t.Run("Create Normalizes Code And Defaults", func(t *testing.T) {
+		promoUsecase, promoRepo := newFixture()
+
+		promo, err := promoUsecase.CreatePromoCode(ctx, organizerID, usecase.PromoCodeRequest{
+			EventID:         eventID,
+			Code:            " early-bird ",
+			DiscountType:    "percentage",
+			DiscountPercent: 2000,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "EARLY-BIRD", promo.Code)
+		assert.Equal(t, 1, promo.MinQuantity)
+		assert.True(t, promo.IsActive)
+		assert.Len(t, promoRepo.PromoCodes, 1)
+	})
+
+	t.Run("Create Rejects Invalid Discount", func(t *testing.T) {
+		promoUsecase, _ := newFixture()
+
+		_, err := promoUsecase.CreatePromoCode(ctx, organizerID, usecase.PromoCodeRequest{Code: "HEMAT", DiscountType: "percentage", DiscountPercent: 15000})
+		assert.EqualError(t, err, "diskon persentase tidak boleh lebih dari 100")
+
+		_, err = promoUsecase.CreatePromoCode(ctx, organizerID, usecase.PromoCodeRequest{Code: "HEMAT", DiscountType: "bogo", DiscountAmount: entity.IDR(10)})
+		assert.EqualError(t, err, "tipe diskon tidak valid")
+	})
+
+	t.Run("Create Rejects Event Of Another Organizer", func(t *testing.T) {
+		promoUsecase, _ := newFixture()
+
+		_, err := promoUsecase.CreatePromoCode(ctx, 99, usecase.PromoCodeRequest{EventID: eventID, Code: "HEMAT", DiscountType: "fixed", DiscountAmount: entity.IDR(10000)})
+
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk membuat kode promo di event ini")
+	})
+
+	t.Run("Create Rejects Duplicate Code", func(t *testing.T) {
+		promoUsecase, _ := newFixture()
+
+		_, err := promoUsecase.CreatePromoCode(ctx, organizerID, usecase.PromoCodeRequest{Code: "HEMAT", DiscountType: "fixed", DiscountAmount: entity.IDR(10000)})
+		assert.NoError(t, err)
+
+		_, err = promoUsecase.CreatePromoCode(ctx, organizerID, usecase.PromoCodeRequest{Code: "hemat", DiscountType: "fixed", DiscountAmount: entity.IDR(5000)})
+		assert.EqualError(t, err, "kode promo sudah digunakan")
+	})
+
+	t.Run("Update Rejects Max Uses Below Used Count", func(t *testing.T) {
+		promoUsecase, promoRepo := newFixture()
+		promoRepo.PromoCodes = []entity.PromoCode{{ID: 1, OwnerID: organizerID, Code: "HEMAT", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), MaxUses: 10, UsedCount: 5, IsActive: true}}
+
+		_, err := promoUsecase.UpdatePromoCode(ctx, 1, organizerID, usecase.PromoCodeRequest{Code: "HEMAT", DiscountType: "fixed", DiscountAmount: entity.IDR(10000), MaxUses: 3})
+
+		assert.EqualError(t, err, "batas pemakaian tidak boleh lebih kecil dari jumlah pemakaian")
+	})
+
+	t.Run("Deactivate Hides Other Organizer Code", func(t *testing.T) {
+		promoUsecase, promoRepo := newFixture()
+		promoRepo.PromoCodes = []entity.PromoCode{{ID: 1, OwnerID: organizerID, Code: "HEMAT", IsActive: true}}
+
+		err := promoUsecase.DeactivatePromoCode(ctx, 1, 99)
+		assert.EqualError(t, err, "kode promo tidak ditemukan")
+
+		err = promoUsecase.DeactivatePromoCode(ctx, 1, organizerID)
+		assert.NoError(t, err)
+		assert.False(t, promoRepo.PromoCodes[0].IsActive)
+	})
+}
+
+func TestCreateTransactionWithPromoCode(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	userID := 2
+	eventID := 7
+
+	newFixture := func(promos ...entity.PromoCode) (usecase.TransactionUsecase, *mocks.MockTransactionRepository, *mocks.FakePromoCodeRepository) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		mockUserRepo.On("FindByID", mock.Anything, mock.Anything).Return(&entity.User{ID: userID, Role: "user"}, nil)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{
+			ID:          eventID,
+			OwnerID:     organizerID,
+			Title:       "Konser",
+			MaxCapacity: 100,
+			Price:       entity.IDR(100000),
+			Status:      "active",
+		}, nil)
+		mockEventRepo.On("UpdateTicketsSold", mock.Anything, eventID, mock.Anything).Return(nil)
+
+		promoRepo := &mocks.FakePromoCodeRepository{PromoCodes: promos}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, promoRepo, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		return transactionUsecase, mockTransactionRepo, promoRepo
+	}
+
+	request := func(code string, quantity int) usecase.CreateTransactionRequest {
+		return usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Quantity:      quantity,
+			PromoCode:     code,
+			PaymentMethod: "bank_transfer",
+		}
+	}
+
+	t.Run("Percentage Discount Is Stored On Transaction", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, promoRepo := newFixture(entity.PromoCode{
+			ID: 1, OwnerID: organizerID, EventID: eventID, Code: "HEMAT20",
+			DiscountType: entity.PromoDiscountPercentage, DiscountPercent: 2000, MinQuantity: 1, IsActive: true,
+		})
+
+		var created *entity.Transaction
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entity.Transaction)
+		}).Return(10, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, request("hemat20", 3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.IDR(240000), response.TotalAmount)
+		assert.Equal(t, entity.IDR(60000), *response.DiscountAmount)
+		assert.Equal(t, "HEMAT20", response.PromoCode)
+		assert.Equal(t, 1, created.PromoCodeID)
+		assert.Equal(t, entity.IDR(60000), created.DiscountAmount)
+
+		assert.Equal(t, 1, promoRepo.PromoCodes[0].UsedCount)
+		assert.Len(t, promoRepo.Redemptions, 1)
+		assert.Equal(t, 10, promoRepo.Redemptions[0].TransactionID)
+	})
+
+	t.Run("Organizer-Wide Fixed Discount Never Exceeds Subtotal", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, _ := newFixture(entity.PromoCode{
+			ID: 1, OwnerID: organizerID, Code: "GRATIS",
+			DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(500000), MinQuantity: 1, IsActive: true,
+		})
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(10, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, request("GRATIS", 2))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.IDR(0), response.TotalAmount)
+		assert.Equal(t, entity.IDR(200000), *response.DiscountAmount)
+	})
+
+	t.Run("Code Of Another Organizer Is Invalid", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, _ := newFixture(entity.PromoCode{
+			ID: 1, OwnerID: 99, Code: "HEMAT", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), MinQuantity: 1, IsActive: true,
+		})
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, request("HEMAT", 1))
+
+		assert.EqualError(t, err, "kode promo tidak valid")
+		mockTransactionRepo.AssertNotCalled(t, "CreateWithReservation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired And Minimum Quantity Rules Apply", func(t *testing.T) {
+		transactionUsecase, _, _ := newFixture(
+			entity.PromoCode{ID: 1, OwnerID: organizerID, Code: "LAMA", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), MinQuantity: 1, IsActive: true, ValidUntil: time.Now().Add(-time.Hour)},
+			entity.PromoCode{ID: 2, OwnerID: organizerID, Code: "ROMBONGAN", DiscountType: entity.PromoDiscountPercentage, DiscountPercent: 1500, MinQuantity: 5, IsActive: true},
+		)
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, request("LAMA", 1))
+		assert.EqualError(t, err, "kode promo tidak berlaku saat ini")
+
+		_, err = transactionUsecase.CreateTransaction(ctx, userID, request("ROMBONGAN", 4))
+		assert.EqualError(t, err, "jumlah tiket belum memenuhi minimum kode promo")
+	})
+
+	t.Run("Per-User Limit Is Enforced", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, promoRepo := newFixture(entity.PromoCode{
+			ID: 1, OwnerID: organizerID, Code: "SEKALI", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), PerUserLimit: 1, MinQuantity: 1, IsActive: true,
+		})
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(10, nil).Once()
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(11, nil).Once()
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, request("SEKALI", 1))
+		assert.NoError(t, err)
+
+		_, err = transactionUsecase.CreateTransaction(ctx, userID, request("SEKALI", 1))
+		assert.EqualError(t, err, "batas pemakaian kode promo untuk akun anda sudah tercapai")
+		assert.Equal(t, 1, promoRepo.PromoCodes[0].UsedCount)
+	})
+
+	t.Run("Concurrent Redemptions Respect Usage Cap", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, promoRepo := newFixture(entity.PromoCode{
+			ID: 1, OwnerID: organizerID, Code: "KILAT", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), MaxUses: 3, MinQuantity: 1, IsActive: true,
+		})
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(10, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				transactionUsecase.CreateTransaction(ctx, userID, request("KILAT", 1))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 3, promoRepo.PromoCodes[0].UsedCount)
+		assert.Len(t, promoRepo.Redemptions, 3)
+	})
+
+	t.Run("Cancel Releases Redemption", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, promoRepo := newFixture(entity.PromoCode{
+			ID: 1, OwnerID: organizerID, Code: "SEKALI", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), PerUserLimit: 1, MaxUses: 1, UsedCount: 1, MinQuantity: 1, IsActive: true,
+		})
+		promoRepo.Redemptions = []entity.PromoRedemption{{ID: 1, PromoCodeID: 1, TransactionID: 10, UserID: userID, DiscountAmount: entity.IDR(10000), Status: entity.PromoRedemptionActive}}
+
+		mockTransactionRepo.On("FindByID", ctx, 10).Return(&entity.Transaction{
+			ID:             10,
+			UserID:         userID,
+			EventID:        eventID,
+			Quantity:       1,
+			Status:         entity.TransactionStatusPending,
+			PromoCodeID:    1,
+			DiscountAmount: entity.IDR(10000),
+		}, nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 10, entity.TransactionStatusPending, entity.TransactionStatusCancelled).Return(nil).Once()
+
+		err := transactionUsecase.CancelTransaction(ctx, userID, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, promoRepo.PromoCodes[0].UsedCount)
+		assert.Equal(t, entity.PromoRedemptionReleased, promoRepo.Redemptions[0].Status)
+
+		// Kode bisa dipakai lagi oleh pembeli yang sama setelah dilepas
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(11, nil).Once()
+		_, err = transactionUsecase.CreateTransaction(ctx, userID, request("SEKALI", 1))
+		assert.NoError(t, err)
+	})
+}
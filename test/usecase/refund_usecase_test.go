@@ -0,0 +1,436 @@
+//test/usecase/refund_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/usecase"
+	"ticket-system/test/mocks"
+)
+
+type refundFixture struct {
+	usecase         usecase.RefundUsecase
+	transactionRepo *mocks.MockTransactionRepository
+	eventRepo       *mocks.MockEventRepository
+	refundRepo      *mocks.FakeRefundRepository
+	ticketRepo      *mocks.FakeTicketRepository
+	historyRepo     *mocks.FakeTransactionStatusHistoryRepository
+	paymentGateway  *mocks.FakePaymentGateway
+}
+
+func newRefundFixture() *refundFixture {
+	f := &refundFixture{
+		transactionRepo: new(mocks.MockTransactionRepository),
+		eventRepo:       new(mocks.MockEventRepository),
+		refundRepo:      &mocks.FakeRefundRepository{},
+		ticketRepo:      &mocks.FakeTicketRepository{},
+		historyRepo:     &mocks.FakeTransactionStatusHistoryRepository{},
+		paymentGateway:  &mocks.FakePaymentGateway{},
+	}
+	f.usecase = usecase.NewRefundUsecase(f.refundRepo, f.transactionRepo, f.eventRepo, f.historyRepo, f.ticketRepo, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, f.paymentGateway)
+	return f
+}
+
+func refundTestEvent(policy entity.RefundPolicy) *entity.Event {
+	return &entity.Event{
+		ID:           3,
+		OwnerID:      10,
+		Title:        "Konser Musik",
+		EventDate:    time.Now().Add(7 * 24 * time.Hour),
+		Status:       "active",
+		RefundPolicy: policy,
+	}
+}
+
+func refundTestTransaction(paymentMethod string) *entity.Transaction {
+	return &entity.Transaction{
+		ID:              7,
+		UserID:          1,
+		EventID:         3,
+		TransactionCode: fixtureOrderID,
+		Quantity:        2,
+		TotalAmount:     entity.IDR(500000),
+		Status:          entity.TransactionStatusPaid,
+		PaymentMethod:   paymentMethod,
+	}
+}
+
+func TestRequestRefund(t *testing.T) {
+	ctx := context.Background()
+	seventyFive, _ := entity.ParsePercent("75")
+	policy := entity.RefundPolicy{Allowed: true, DeadlineHours: 48, Percent: seventyFive}
+	req := usecase.RequestRefundRequest{Reason: "tidak bisa hadir"}
+
+	t.Run("Success Uses Policy Percent", func(t *testing.T) {
+		f := newRefundFixture()
+		f.transactionRepo.On("FindByID", ctx, 7).Return(refundTestTransaction("midtrans"), nil)
+		f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+
+		refund, err := f.usecase.RequestRefund(ctx, 1, 7, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.RefundStatusRequested, refund.Status)
+		assert.Equal(t, entity.RefundSourceBuyer, refund.Source)
+		assert.True(t, refund.Amount.Equal(entity.IDR(375000)))
+		assert.Len(t, f.refundRepo.Refunds, 1)
+	})
+
+	t.Run("Duplicate Request", func(t *testing.T) {
+		f := newRefundFixture()
+		f.transactionRepo.On("FindByID", ctx, 7).Return(refundTestTransaction("midtrans"), nil)
+		f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+
+		_, err := f.usecase.RequestRefund(ctx, 1, 7, req)
+		require.NoError(t, err)
+
+		_, err = f.usecase.RequestRefund(ctx, 1, 7, req)
+		assert.ErrorIs(t, err, repository.ErrRefundExists)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		closeEvent := refundTestEvent(policy)
+		closeEvent.EventDate = time.Now().Add(24 * time.Hour)
+
+		notPaid := refundTestTransaction("midtrans")
+		notPaid.Status = entity.TransactionStatusPending
+
+		cancelledEvent := refundTestEvent(policy)
+		cancelledEvent.Status = "cancelled"
+
+		tests := []struct {
+			name        string
+			userID      int
+			reason      string
+			transaction *entity.Transaction
+			event       *entity.Event
+			usedTicket  bool
+			expected    string
+		}{
+			{"Empty Reason", 1, " ", refundTestTransaction("midtrans"), refundTestEvent(policy), false, "alasan refund harus diisi"},
+			{"Not Owner", 2, "tidak bisa hadir", refundTestTransaction("midtrans"), refundTestEvent(policy), false, "anda tidak memiliki izin untuk transaksi ini"},
+			{"Not Paid", 1, "tidak bisa hadir", notPaid, refundTestEvent(policy), false, "hanya transaksi dengan status paid yang dapat direfund"},
+			{"Policy Disallows", 1, "tidak bisa hadir", refundTestTransaction("midtrans"), refundTestEvent(entity.RefundPolicy{Percent: entity.OneHundredPercent}), false, "event ini tidak menerima pengajuan refund"},
+			{"Past Deadline", 1, "tidak bisa hadir", refundTestTransaction("midtrans"), closeEvent, false, "batas waktu pengajuan refund sudah lewat"},
+			{"Event Cancelled", 1, "tidak bisa hadir", refundTestTransaction("midtrans"), cancelledEvent, false, "event dibatalkan, refund akan diproses otomatis"},
+			{"Ticket Used", 1, "tidak bisa hadir", refundTestTransaction("midtrans"), refundTestEvent(policy), true, "transaksi dengan tiket yang sudah digunakan tidak dapat direfund"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				f := newRefundFixture()
+				f.transactionRepo.On("FindByID", ctx, 7).Return(tt.transaction, nil)
+				f.eventRepo.On("FindByID", ctx, 3).Return(tt.event, nil)
+				if tt.usedTicket {
+					f.ticketRepo.Tickets = []entity.Ticket{
+						{ID: 1, TransactionID: 7, Status: entity.TicketStatusActive},
+						{ID: 2, TransactionID: 7, Status: entity.TicketStatusUsed},
+					}
+				}
+
+				refund, err := f.usecase.RequestRefund(ctx, tt.userID, 7, usecase.RequestRefundRequest{Reason: tt.reason})
+
+				assert.Nil(t, refund)
+				require.Error(t, err)
+				assert.Equal(t, tt.expected, err.Error())
+				assert.Empty(t, f.refundRepo.Refunds)
+			})
+		}
+	})
+
+	t.Run("Ticket Transferred", func(t *testing.T) {
+		f := newRefundFixture()
+		f.transactionRepo.On("FindByID", ctx, 7).Return(refundTestTransaction("midtrans"), nil)
+		f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+		f.ticketRepo.Tickets = []entity.Ticket{
+			{ID: 1, TransactionID: 7, UserID: 1, Status: entity.TicketStatusActive},
+			{ID: 2, TransactionID: 7, UserID: 5, Status: entity.TicketStatusActive, TransferCount: 1},
+		}
+
+		refund, err := f.usecase.RequestRefund(ctx, 1, 7, req)
+
+		assert.Nil(t, refund)
+		assert.EqualError(t, err, "transaksi dengan tiket yang sudah dipindahtangankan tidak dapat direfund")
+		assert.Empty(t, f.refundRepo.Refunds)
+	})
+}
+
+func TestApproveRefund(t *testing.T) {
+	ctx := context.Background()
+	policy := entity.RefundPolicy{Allowed: true, DeadlineHours: 24, Percent: entity.OneHundredPercent}
+
+	requested := func() entity.Refund {
+		return entity.Refund{
+			ID:            1,
+			TransactionID: 7,
+			EventID:       3,
+			UserID:        1,
+			Amount:        entity.IDR(500000),
+			Status:        entity.RefundStatusRequested,
+			Source:        entity.RefundSourceBuyer,
+			Reason:        "tidak bisa hadir",
+		}
+	}
+
+	t.Run("Partial Refund Through Gateway", func(t *testing.T) {
+		f := newRefundFixture()
+		f.refundRepo.Refunds = []entity.Refund{requested()}
+		f.ticketRepo.Tickets = []entity.Ticket{
+			{ID: 1, TransactionID: 7, Status: entity.TicketStatusActive},
+			{ID: 2, TransactionID: 7, Status: entity.TicketStatusActive},
+		}
+		transaction := refundTestTransaction("midtrans")
+		f.transactionRepo.On("FindByID", ctx, 7).Return(transaction, nil)
+		f.transactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		f.transactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+		f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+		f.eventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+		amount := entity.IDR(400000)
+		refund, err := f.usecase.ApproveRefund(ctx, 10, 1, usecase.ApproveRefundRequest{Amount: &amount})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.RefundStatusCompleted, refund.Status)
+		assert.True(t, refund.Amount.Equal(entity.IDR(400000)))
+		assert.Equal(t, 10, refund.ReviewedBy)
+		assert.Equal(t, "RF-1", refund.GatewayRefundID)
+		assert.False(t, refund.CompletedAt.IsZero())
+
+		require.Len(t, f.paymentGateway.Refunds, 1)
+		assert.Equal(t, fixtureOrderID, f.paymentGateway.Refunds[0].OrderID)
+		assert.Equal(t, "REFUND-1", f.paymentGateway.Refunds[0].RefundKey)
+		assert.Equal(t, int64(400000), f.paymentGateway.Refunds[0].Amount)
+
+		for _, ticket := range f.ticketRepo.Tickets {
+			assert.Equal(t, entity.TicketStatusRefunded, ticket.Status)
+		}
+		last := f.historyRepo.Last()
+		require.NotNil(t, last)
+		assert.Equal(t, entity.TransactionStatusRefunded, last.ToStatus)
+		assert.Equal(t, entity.StatusActorOrganizer, last.ActorType)
+		f.transactionRepo.AssertExpectations(t)
+		f.eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Gateway Failure Can Be Retried", func(t *testing.T) {
+		f := newRefundFixture()
+		f.refundRepo.Refunds = []entity.Refund{requested()}
+		f.paymentGateway.RefundErr = errors.New("midtrans menolak refund (kode 412): transaksi belum settle")
+		transaction := refundTestTransaction("midtrans")
+		f.transactionRepo.On("FindByID", ctx, 7).Return(transaction, nil)
+		f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+
+		refund, err := f.usecase.ApproveRefund(ctx, 10, 1, usecase.ApproveRefundRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.RefundStatusFailed, refund.Status)
+		assert.Contains(t, refund.FailureReason, "belum settle")
+		assert.Equal(t, entity.TransactionStatusPaid, transaction.Status)
+		f.transactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		f.paymentGateway.RefundErr = nil
+		f.transactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		f.transactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+		f.eventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+		refund, err = f.usecase.ApproveRefund(ctx, 10, 1, usecase.ApproveRefundRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.RefundStatusCompleted, refund.Status)
+		assert.Empty(t, refund.FailureReason)
+		assert.Len(t, f.paymentGateway.Refunds, 1)
+	})
+
+	t.Run("Already Refunded By Gateway Notification", func(t *testing.T) {
+		f := newRefundFixture()
+		f.refundRepo.Refunds = []entity.Refund{requested()}
+		transaction := refundTestTransaction("midtrans")
+		transaction.Status = entity.TransactionStatusRefunded
+		f.transactionRepo.On("FindByID", ctx, 7).Return(transaction, nil)
+		f.transactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+
+		refund, err := f.usecase.ApproveRefund(ctx, 10, 1, usecase.ApproveRefundRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.RefundStatusCompleted, refund.Status)
+		assert.Empty(t, f.paymentGateway.Refunds)
+		f.transactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		f.eventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Requests", func(t *testing.T) {
+		tooMuch := entity.IDR(500001)
+		zero := entity.IDR(0)
+		usd := entity.NewMoney(100, "USD")
+
+		tests := []struct {
+			name        string
+			organizerID int
+			status      entity.RefundStatus
+			amount      *entity.Money
+			expected    string
+		}{
+			{"Not Owner", 11, entity.RefundStatusRequested, nil, "anda tidak memiliki izin untuk mengelola refund event ini"},
+			{"Already Completed", 10, entity.RefundStatusCompleted, nil, "hanya refund berstatus requested atau failed yang dapat disetujui"},
+			{"Exceeds Total", 10, entity.RefundStatusRequested, &tooMuch, "nominal refund harus lebih dari 0 dan tidak melebihi total transaksi"},
+			{"Zero Amount", 10, entity.RefundStatusRequested, &zero, "nominal refund harus lebih dari 0 dan tidak melebihi total transaksi"},
+			{"Other Currency", 10, entity.RefundStatusRequested, &usd, "mata uang refund harus sama dengan mata uang transaksi"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				f := newRefundFixture()
+				refund := requested()
+				refund.Status = tt.status
+				f.refundRepo.Refunds = []entity.Refund{refund}
+				f.transactionRepo.On("FindByID", ctx, 7).Return(refundTestTransaction("midtrans"), nil)
+				f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(policy), nil)
+
+				result, err := f.usecase.ApproveRefund(ctx, tt.organizerID, 1, usecase.ApproveRefundRequest{Amount: tt.amount})
+
+				assert.Nil(t, result)
+				require.Error(t, err)
+				assert.Equal(t, tt.expected, err.Error())
+				assert.Equal(t, tt.status, f.refundRepo.Refunds[0].Status)
+				assert.Empty(t, f.paymentGateway.Refunds)
+			})
+		}
+	})
+}
+
+func TestRejectRefund(t *testing.T) {
+	ctx := context.Background()
+	f := newRefundFixture()
+	f.refundRepo.Refunds = []entity.Refund{{ID: 1, TransactionID: 7, EventID: 3, Amount: entity.IDR(500000), Status: entity.RefundStatusRequested}}
+	f.transactionRepo.On("FindByID", ctx, 7).Return(refundTestTransaction("midtrans"), nil)
+	f.eventRepo.On("FindByID", ctx, 3).Return(refundTestEvent(entity.RefundPolicy{}), nil)
+
+	_, err := f.usecase.RejectRefund(ctx, 10, 1, usecase.RejectRefundRequest{})
+	require.Error(t, err)
+	assert.Equal(t, "alasan penolakan harus diisi", err.Error())
+
+	refund, err := f.usecase.RejectRefund(ctx, 10, 1, usecase.RejectRefundRequest{Reason: "sudah lewat kebijakan"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.RefundStatusRejected, refund.Status)
+	assert.Equal(t, "sudah lewat kebijakan", f.refundRepo.Refunds[0].RejectReason)
+	assert.Empty(t, f.paymentGateway.Refunds)
+
+	// Pengajuan baru boleh dibuat setelah pengajuan sebelumnya ditolak
+	_, err = f.refundRepo.Create(ctx, &entity.Refund{TransactionID: 7, Status: entity.RefundStatusRequested})
+	assert.NoError(t, err)
+}
+
+func TestProcessRefundsForCancelledEvent(t *testing.T) {
+	ctx := context.Background()
+	f := newRefundFixture()
+
+	midtransTransaction := refundTestTransaction("midtrans")
+	manualTransaction := refundTestTransaction("bank_transfer")
+	manualTransaction.ID = 8
+	manualTransaction.UserID = 2
+	manualTransaction.TransactionCode = "TRX-20250101-654321"
+	manualTransaction.Quantity = 1
+	manualTransaction.TotalAmount = entity.IDR(250000)
+
+	f.refundRepo.CancelledEventTransactions = []entity.Transaction{*midtransTransaction, *manualTransaction}
+	// Pengajuan pembeli yang belum direview ikut disetujui dengan nominal penuh
+	f.refundRepo.Refunds = []entity.Refund{{ID: 1, TransactionID: 7, EventID: 3, UserID: 1, Amount: entity.IDR(250000), Status: entity.RefundStatusRequested, Source: entity.RefundSourceBuyer}}
+
+	f.transactionRepo.On("FindByID", ctx, 7).Return(midtransTransaction, nil)
+	f.transactionRepo.On("FindByID", ctx, 8).Return(manualTransaction, nil)
+	f.transactionRepo.On("FindByCodeForUpdate", ctx, midtransTransaction.TransactionCode).Return(midtransTransaction, nil).Once()
+	f.transactionRepo.On("FindByCodeForUpdate", ctx, manualTransaction.TransactionCode).Return(manualTransaction, nil).Once()
+	f.transactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+	f.transactionRepo.On("UpdateStatus", ctx, 8, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+	f.eventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+	f.eventRepo.On("UpdateTicketsSold", ctx, 3, -1).Return(nil).Once()
+
+	completed, err := f.usecase.ProcessRefunds(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, completed)
+	require.Len(t, f.refundRepo.Refunds, 2)
+	for _, refund := range f.refundRepo.Refunds {
+		assert.Equal(t, entity.RefundStatusCompleted, refund.Status)
+		assert.Equal(t, entity.RefundSourceEventCancelled, refund.Source)
+	}
+	assert.True(t, f.refundRepo.Refunds[0].Amount.Equal(entity.IDR(500000)))
+
+	// Hanya pembayaran midtrans yang dikembalikan lewat payment gateway
+	require.Len(t, f.paymentGateway.Refunds, 1)
+	assert.Equal(t, int64(500000), f.paymentGateway.Refunds[0].Amount)
+	assert.Equal(t, entity.StatusActorSystem, f.historyRepo.Last().ActorType)
+	f.transactionRepo.AssertExpectations(t)
+	f.eventRepo.AssertExpectations(t)
+
+	// Putaran berikutnya tidak memproses ulang refund yang sudah selesai
+	completed, err = f.usecase.ProcessRefunds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, completed)
+	assert.Len(t, f.paymentGateway.Refunds, 1)
+}
+
+func TestProcessRefundsRedrivesStaleProcessing(t *testing.T) {
+	ctx := context.Background()
+	f := newRefundFixture()
+
+	// Refund 1 macet sejak server berhenti saat menunggu gateway, refund 2 masih diproses worker lain
+	f.refundRepo.Refunds = []entity.Refund{
+		{ID: 1, TransactionID: 7, EventID: 3, UserID: 1, Amount: entity.IDR(500000), Status: entity.RefundStatusProcessing, Source: entity.RefundSourceBuyer, ReviewedBy: 10, ProcessingStartedAt: time.Now().Add(-time.Hour)},
+		{ID: 2, TransactionID: 8, EventID: 3, UserID: 2, Amount: entity.IDR(250000), Status: entity.RefundStatusProcessing, Source: entity.RefundSourceBuyer, ReviewedBy: 10, ProcessingStartedAt: time.Now()},
+	}
+	transaction := refundTestTransaction("midtrans")
+	f.transactionRepo.On("FindByID", ctx, 7).Return(transaction, nil).Once()
+	f.transactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+	f.transactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+	f.eventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+	completed, err := f.usecase.ProcessRefunds(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, entity.RefundStatusCompleted, f.refundRepo.Refunds[0].Status)
+	assert.Equal(t, entity.RefundStatusProcessing, f.refundRepo.Refunds[1].Status)
+
+	require.Len(t, f.paymentGateway.Refunds, 1)
+	assert.Equal(t, "REFUND-1", f.paymentGateway.Refunds[0].RefundKey)
+	f.transactionRepo.AssertExpectations(t)
+	f.eventRepo.AssertExpectations(t)
+}
+
+func TestProcessRefundsForLatePayment(t *testing.T) {
+	ctx := context.Background()
+	f := newRefundFixture()
+
+	f.refundRepo.Refunds = []entity.Refund{
+		{ID: 1, TransactionID: 7, EventID: 3, UserID: 1, Amount: entity.IDR(500000), Status: entity.RefundStatusApproved, Source: entity.RefundSourceLatePayment},
+	}
+	transaction := refundTestTransaction("midtrans")
+	transaction.Status = entity.TransactionStatusExpired
+	f.transactionRepo.On("FindByID", ctx, 7).Return(transaction, nil).Once()
+	f.transactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+
+	completed, err := f.usecase.ProcessRefunds(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, entity.RefundStatusCompleted, f.refundRepo.Refunds[0].Status)
+	require.Len(t, f.paymentGateway.Refunds, 1)
+	assert.Equal(t, int64(500000), f.paymentGateway.Refunds[0].Amount)
+
+	// Transaksi tetap expired dan kursinya tidak dilepas dua kali
+	f.transactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	f.eventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+	assert.Empty(t, f.historyRepo.Histories)
+}
@@ -0,0 +1,382 @@
+//test/usecase/seat_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+// newSeatedVenueRepo membuat venue 1 milik organizer 1 dengan kursi A-1 sampai A-4 di section VIP
+func newSeatedVenueRepo() *mocks.FakeVenueRepository {
+	venueRepo := &mocks.FakeVenueRepository{}
+	seats := []*entity.VenueSeat{
+		{Section: "VIP", Row: "A", Number: "1", X: 10, Y: 10, Accessible: true},
+		{Section: "VIP", Row: "A", Number: "2", X: 20, Y: 10},
+		{Section: "VIP", Row: "A", Number: "3", X: 30, Y: 10},
+		{Section: "VIP", Row: "A", Number: "4", X: 40, Y: 10},
+	}
+	venueRepo.Create(context.Background(), &entity.Venue{OwnerID: 1, Name: "Gedung Kesenian"}, seats)
+	return venueRepo
+}
+
+func newSeatedEvent() *entity.Event {
+	return &entity.Event{
+		ID:          1,
+		OwnerID:     1,
+		Title:       "Konser Orkestra",
+		EventDate:   time.Now().Add(48 * time.Hour),
+		MaxCapacity: 4,
+		Price:       entity.IDR(100000),
+		Status:      "active",
+		VenueID:     1,
+	}
+}
+
+func TestCreateVenue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Flattens sections rows and seats", func(t *testing.T) {
+		venueRepo := &mocks.FakeVenueRepository{}
+		uc := usecase.NewVenueUsecase(venueRepo, new(mocks.MockEventRepository), &mocks.FakeTicketTypeRepository{})
+
+		venue, err := uc.CreateVenue(ctx, 1, usecase.CreateVenueRequest{
+			Name: "Gedung Kesenian",
+			Sections: []usecase.VenueSectionRequest{
+				{Name: "VIP", Rows: []usecase.VenueRowRequest{
+					{Label: "A", Seats: []usecase.VenueSeatRequest{{Number: "1", Accessible: true}, {Number: "2"}}},
+				}},
+				{Name: "Balkon", Rows: []usecase.VenueRowRequest{
+					{Label: "A", Seats: []usecase.VenueSeatRequest{{Number: "1", X: 5, Y: 40}}},
+				}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, venue.Venue.SeatCount)
+		require.Len(t, venue.Seats, 3)
+		assert.Equal(t, "VIP A-1", venue.Seats[0].Label())
+		assert.True(t, venue.Seats[0].Accessible)
+		assert.Equal(t, "Balkon A-1", venue.Seats[2].Label())
+		assert.Equal(t, 40.0, venue.Seats[2].Y)
+	})
+
+	t.Run("Duplicate seat in row", func(t *testing.T) {
+		uc := usecase.NewVenueUsecase(&mocks.FakeVenueRepository{}, new(mocks.MockEventRepository), &mocks.FakeTicketTypeRepository{})
+
+		_, err := uc.CreateVenue(ctx, 1, usecase.CreateVenueRequest{
+			Name: "Gedung Kesenian",
+			Sections: []usecase.VenueSectionRequest{{Name: "VIP", Rows: []usecase.VenueRowRequest{
+				{Label: "A", Seats: []usecase.VenueSeatRequest{{Number: "1"}, {Number: " 1 "}}},
+			}}},
+		})
+		assert.EqualError(t, err, "nomor kursi tidak boleh duplikat dalam satu baris")
+	})
+
+	t.Run("Venue without seats", func(t *testing.T) {
+		uc := usecase.NewVenueUsecase(&mocks.FakeVenueRepository{}, new(mocks.MockEventRepository), &mocks.FakeTicketTypeRepository{})
+
+		_, err := uc.CreateVenue(ctx, 1, usecase.CreateVenueRequest{Name: "Gedung Kosong"})
+		assert.EqualError(t, err, "venue harus memiliki minimal satu kursi")
+	})
+}
+
+func TestAssignVenue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Capacity follows seat count", func(t *testing.T) {
+		event := newSeatedEvent()
+		event.VenueID = 0
+		event.MaxCapacity = 100
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		eventRepo.On("AssignVenue", ctx, 1, 1, 4).Return(nil).Once()
+		uc := usecase.NewVenueUsecase(newSeatedVenueRepo(), eventRepo, &mocks.FakeTicketTypeRepository{})
+
+		updated, err := uc.AssignVenue(ctx, 1, 1, usecase.AssignVenueRequest{VenueID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.VenueID)
+		assert.Equal(t, 4, updated.MaxCapacity)
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Event with sales", func(t *testing.T) {
+		event := newSeatedEvent()
+		event.TicketsSold = 1
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		uc := usecase.NewVenueUsecase(newSeatedVenueRepo(), eventRepo, &mocks.FakeTicketTypeRepository{})
+
+		_, err := uc.AssignVenue(ctx, 1, 1, usecase.AssignVenueRequest{VenueID: 1})
+		assert.EqualError(t, err, "venue tidak dapat diubah setelah tiket terjual")
+		eventRepo.AssertNotCalled(t, "AssignVenue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Ticket type quota exceeds seats", func(t *testing.T) {
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(newSeatedEvent(), nil)
+		ticketTypeRepo := &mocks.FakeTicketTypeRepository{
+			TicketTypes: []entity.TicketType{{ID: 1, EventID: 1, Name: "VIP", Quota: 5}},
+		}
+		uc := usecase.NewVenueUsecase(newSeatedVenueRepo(), eventRepo, ticketTypeRepo)
+
+		_, err := uc.AssignVenue(ctx, 1, 1, usecase.AssignVenueRequest{VenueID: 1})
+		assert.EqualError(t, err, "total kuota tipe tiket melebihi jumlah kursi venue")
+	})
+
+	t.Run("Venue of another organizer", func(t *testing.T) {
+		event := newSeatedEvent()
+		event.OwnerID = 2
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		uc := usecase.NewVenueUsecase(newSeatedVenueRepo(), eventRepo, &mocks.FakeTicketTypeRepository{})
+
+		_, err := uc.AssignVenue(ctx, 1, 2, usecase.AssignVenueRequest{VenueID: 1})
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk mengelola venue ini")
+	})
+}
+
+func TestHoldSeats(t *testing.T) {
+	ctx := context.Background()
+
+	newFixture := func() (usecase.SeatUsecase, *mocks.FakeSeatHoldRepository) {
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(newSeatedEvent(), nil)
+		holdRepo := &mocks.FakeSeatHoldRepository{}
+		return usecase.NewSeatUsecase(holdRepo, newSeatedVenueRepo(), eventRepo, "5"), holdRepo
+	}
+
+	t.Run("Two buyers never hold the same seat", func(t *testing.T) {
+		uc, _ := newFixture()
+
+		hold, err := uc.HoldSeats(ctx, 10, 1, usecase.HoldSeatsRequest{SeatIDs: []int{1, 2}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int{1, 2}, hold.SeatIDs)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), hold.ExpiresAt, 5*time.Second)
+
+		_, err = uc.HoldSeats(ctx, 11, 1, usecase.HoldSeatsRequest{SeatIDs: []int{2, 3}})
+		assert.ErrorIs(t, err, repository.ErrSeatUnavailable)
+
+		seatMap, err := uc.GetSeatMap(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, seatMap.Available)
+		assert.Equal(t, usecase.SeatStatusHeld, seatMap.Seats[1].Status)
+		assert.Equal(t, usecase.SeatStatusAvailable, seatMap.Seats[2].Status)
+	})
+
+	t.Run("New hold replaces previous hold of the same buyer", func(t *testing.T) {
+		uc, _ := newFixture()
+
+		_, err := uc.HoldSeats(ctx, 10, 1, usecase.HoldSeatsRequest{SeatIDs: []int{1, 2}})
+		require.NoError(t, err)
+		_, err = uc.HoldSeats(ctx, 10, 1, usecase.HoldSeatsRequest{SeatIDs: []int{3}})
+		require.NoError(t, err)
+
+		hold, err := uc.GetSeatHold(ctx, 10, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{3}, hold.SeatIDs)
+
+		_, err = uc.HoldSeats(ctx, 11, 1, usecase.HoldSeatsRequest{SeatIDs: []int{1}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Expired hold frees the seat", func(t *testing.T) {
+		uc, holdRepo := newFixture()
+		holdRepo.Holds = []entity.SeatHold{
+			{ID: 1, EventID: 1, SeatID: 1, UserID: 10, Status: entity.SeatHoldStatusHeld, ExpiresAt: time.Now().Add(-time.Second)},
+		}
+
+		_, err := uc.HoldSeats(ctx, 11, 1, usecase.HoldSeatsRequest{SeatIDs: []int{1}})
+		require.NoError(t, err)
+		assert.Equal(t, entity.SeatHoldStatusReleased, holdRepo.Holds[0].Status)
+	})
+
+	t.Run("Seat outside event venue", func(t *testing.T) {
+		uc, _ := newFixture()
+
+		_, err := uc.HoldSeats(ctx, 10, 1, usecase.HoldSeatsRequest{SeatIDs: []int{99}})
+		assert.EqualError(t, err, "kursi tidak ditemukan di denah venue event")
+	})
+
+	t.Run("Too many seats", func(t *testing.T) {
+		uc, _ := newFixture()
+
+		_, err := uc.HoldSeats(ctx, 10, 1, usecase.HoldSeatsRequest{SeatIDs: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}})
+		assert.EqualError(t, err, "jumlah kursi yang dipilih melebihi batas per pembeli")
+	})
+
+	t.Run("Event without seat map", func(t *testing.T) {
+		event := newSeatedEvent()
+		event.VenueID = 0
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		uc := usecase.NewSeatUsecase(&mocks.FakeSeatHoldRepository{}, newSeatedVenueRepo(), eventRepo, "5")
+
+		_, err := uc.HoldSeats(ctx, 10, 1, usecase.HoldSeatsRequest{SeatIDs: []int{1}})
+		assert.EqualError(t, err, "event tidak memiliki kursi bernomor")
+	})
+}
+
+func TestExpireSeatHolds(t *testing.T) {
+	holdRepo := &mocks.FakeSeatHoldRepository{
+		Holds: []entity.SeatHold{
+			{ID: 1, EventID: 1, SeatID: 1, UserID: 10, Status: entity.SeatHoldStatusHeld, ExpiresAt: time.Now().Add(-time.Minute)},
+			{ID: 2, EventID: 1, SeatID: 2, UserID: 11, Status: entity.SeatHoldStatusHeld, ExpiresAt: time.Now().Add(time.Minute)},
+			{ID: 3, EventID: 1, SeatID: 3, UserID: 12, Status: entity.SeatHoldStatusBooked, ExpiresAt: time.Now().Add(-time.Minute)},
+		},
+	}
+	uc := usecase.NewSeatUsecase(holdRepo, &mocks.FakeVenueRepository{}, new(mocks.MockEventRepository), "5")
+
+	released, err := uc.ExpireSeatHolds(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	assert.Equal(t, entity.SeatHoldStatusReleased, holdRepo.Holds[0].Status)
+	assert.Equal(t, entity.SeatHoldStatusHeld, holdRepo.Holds[1].Status)
+	assert.Equal(t, entity.SeatHoldStatusBooked, holdRepo.Holds[2].Status)
+}
+
+func TestCreateTransactionWithSeats(t *testing.T) {
+	ctx := context.Background()
+
+	newFixture := func(event *entity.Event, holds []entity.SeatHold) (usecase.TransactionUsecase, *mocks.MockTransactionRepository, *mocks.FakeSeatHoldRepository) {
+		transactionRepo := new(mocks.MockTransactionRepository)
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindByID", ctx, 10).Return(&entity.User{ID: 10, Role: "user"}, nil)
+		holdRepo := &mocks.FakeSeatHoldRepository{Holds: holds}
+
+		transactionUsecase := usecase.NewTransactionUsecase(transactionRepo, eventRepo, userRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, holdRepo, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		return transactionUsecase, transactionRepo, holdRepo
+	}
+
+	heldBy := func(userID int, seatIDs ...int) []entity.SeatHold {
+		var holds []entity.SeatHold
+		for _, seatID := range seatIDs {
+			holds = append(holds, entity.SeatHold{
+				ID: len(holds) + 1, EventID: 1, SeatID: seatID, UserID: userID,
+				Status: entity.SeatHoldStatusHeld, ExpiresAt: time.Now().Add(5 * time.Minute),
+			})
+		}
+		return holds
+	}
+
+	t.Run("Books held seats and releases the rest", func(t *testing.T) {
+		transactionUsecase, transactionRepo, holdRepo := newFixture(newSeatedEvent(), heldBy(10, 1, 2, 3))
+		transactionRepo.On("CreateWithReservation", mock.Anything, mock.MatchedBy(func(transaction *entity.Transaction) bool {
+			return transaction.Quantity == 2
+		})).Return(42, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, 10, usecase.CreateTransactionRequest{
+			EventID: 1, SeatIDs: []int{1, 2}, PaymentMethod: "bank_transfer",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, response.Quantity)
+		assert.Equal(t, entity.IDR(200000), response.TotalAmount)
+
+		assert.Equal(t, entity.SeatHoldStatusBooked, holdRepo.Holds[0].Status)
+		assert.Equal(t, 42, holdRepo.Holds[0].TransactionID)
+		assert.Equal(t, entity.SeatHoldStatusBooked, holdRepo.Holds[1].Status)
+		assert.Equal(t, entity.SeatHoldStatusReleased, holdRepo.Holds[2].Status)
+		transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Seat no longer held by buyer", func(t *testing.T) {
+		holds := heldBy(10, 1)
+		holds[0].ExpiresAt = time.Now().Add(-time.Second)
+		transactionUsecase, transactionRepo, _ := newFixture(newSeatedEvent(), holds)
+		transactionRepo.On("CreateWithReservation", mock.Anything, mock.Anything).Return(42, nil).Once()
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 10, usecase.CreateTransactionRequest{
+			EventID: 1, SeatIDs: []int{1}, PaymentMethod: "bank_transfer",
+		})
+		assert.ErrorIs(t, err, repository.ErrSeatHoldExpired)
+	})
+
+	t.Run("Seated event requires seats", func(t *testing.T) {
+		transactionUsecase, transactionRepo, _ := newFixture(newSeatedEvent(), nil)
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 10, usecase.CreateTransactionRequest{
+			EventID: 1, Quantity: 2, PaymentMethod: "bank_transfer",
+		})
+		assert.EqualError(t, err, "kursi harus dipilih untuk event dengan denah venue")
+		transactionRepo.AssertNotCalled(t, "CreateWithReservation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate seats", func(t *testing.T) {
+		transactionUsecase, _, _ := newFixture(newSeatedEvent(), heldBy(10, 1))
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 10, usecase.CreateTransactionRequest{
+			EventID: 1, SeatIDs: []int{1, 1}, PaymentMethod: "bank_transfer",
+		})
+		assert.EqualError(t, err, "kursi yang dipilih tidak boleh duplikat")
+	})
+
+	t.Run("General admission event rejects seats", func(t *testing.T) {
+		event := newSeatedEvent()
+		event.VenueID = 0
+		transactionUsecase, _, _ := newFixture(event, nil)
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 10, usecase.CreateTransactionRequest{
+			EventID: 1, Quantity: 1, SeatIDs: []int{1}, PaymentMethod: "bank_transfer",
+		})
+		assert.EqualError(t, err, "event tidak memiliki kursi bernomor")
+	})
+}
+
+func TestSeatedTicketIssuance(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockEventRepo := new(mocks.MockEventRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	ticketRepo := &mocks.FakeTicketRepository{}
+	holdRepo := &mocks.FakeSeatHoldRepository{
+		Seats: []entity.VenueSeat{
+			{ID: 5, VenueID: 1, Section: "VIP", Row: "B", Number: "12"},
+			{ID: 6, VenueID: 1, Section: "VIP", Row: "B", Number: "13"},
+		},
+		Holds: []entity.SeatHold{
+			{ID: 1, EventID: 3, SeatID: 5, UserID: 2, TransactionID: 1, Status: entity.SeatHoldStatusBooked},
+			{ID: 2, EventID: 3, SeatID: 6, UserID: 2, TransactionID: 1, Status: entity.SeatHoldStatusBooked},
+		},
+	}
+
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, ticketRepo, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, holdRepo, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+	transaction := &entity.Transaction{
+		ID:              1,
+		UserID:          2,
+		EventID:         3,
+		TransactionCode: "TRX-20230101-123456",
+		Quantity:        2,
+		TotalAmount:     entity.IDR(200000),
+		Status:          entity.TransactionStatusWaitingVerification,
+		PaymentMethod:   "bank_transfer",
+	}
+
+	mockUserRepo.On("FindByID", ctx, organizerID).Return(&entity.User{ID: organizerID, Role: "organizer"}, nil).Once()
+	mockTransactionRepo.On("FindByID", ctx, transaction.ID).Return(transaction, nil).Once()
+	mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(&entity.Event{ID: 3, OwnerID: organizerID, VenueID: 1}, nil).Once()
+	mockTransactionRepo.On("VerifyPayment", ctx, transaction.ID, organizerID).Return(nil).Once()
+
+	err := transactionUsecase.VerifyPayment(ctx, organizerID, transaction.ID)
+	require.NoError(t, err)
+
+	require.Len(t, ticketRepo.Tickets, 2)
+	assert.Equal(t, 5, ticketRepo.Tickets[0].SeatID)
+	assert.Equal(t, "VIP B-12", ticketRepo.Tickets[0].SeatLabel)
+	assert.Equal(t, 6, ticketRepo.Tickets[1].SeatID)
+	assert.Equal(t, "VIP B-13", ticketRepo.Tickets[1].SeatLabel)
+}
@@ -0,0 +1,241 @@
+//test/usecase/ticket_checkin_test.go
+
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+const testQRSecret = "rahasia-qr-test"
+
+// testManifestKey adalah key manifest yang terpisah dari testQRSecret, seed 32 byte dalam base64
+var testManifestKey, _ = utils.ParseTicketManifestKey("AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+
+// newTicketUsecase membangun TicketUsecase di atas repository yang disiapkan masing-masing test
+func newTicketUsecase(ticketRepo *mocks.FakeTicketRepository, scanRepo *mocks.FakeTicketScanRepository, eventRepo *mocks.MockEventRepository) usecase.TicketUsecase {
+	return usecase.NewTicketUsecase(ticketRepo, scanRepo, eventRepo, &mocks.FakeTxManager{}, testQRSecret, testManifestKey)
+}
+
+func newCheckInFixture() (usecase.TicketUsecase, *mocks.FakeTicketRepository, *mocks.MockEventRepository) {
+	mockEventRepo := new(mocks.MockEventRepository)
+	ticketRepo := &mocks.FakeTicketRepository{
+		Tickets: []entity.Ticket{
+			{ID: 1, TransactionID: 7, EventID: 3, UserID: 2, SeatNumber: 1, TicketCode: "TKT-A", QRNonce: "nonce-a", Status: entity.TicketStatusActive},
+			{ID: 2, TransactionID: 7, EventID: 3, UserID: 2, SeatNumber: 2, TicketCode: "TKT-B", QRNonce: "nonce-b", Status: entity.TicketStatusRefunded},
+			{ID: 3, TransactionID: 8, EventID: 4, UserID: 2, SeatNumber: 1, TicketCode: "TKT-C", QRNonce: "nonce-c", Status: entity.TicketStatusActive},
+		},
+	}
+
+	return newTicketUsecase(ticketRepo, &mocks.FakeTicketScanRepository{}, mockEventRepo), ticketRepo, mockEventRepo
+}
+
+func TestTicketQR(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Holder Gets Signed Payload", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(&entity.Event{ID: 3, OwnerID: 1}, nil).Once()
+
+		ticket, err := ticketUsecase.GetTicketByCode(ctx, 2, "TKT-A")
+		require.NoError(t, err)
+
+		claim, err := utils.ParseTicketQRPayload(ticket.QRPayload, testQRSecret)
+		require.NoError(t, err)
+		assert.Equal(t, 1, claim.TicketID)
+		assert.Equal(t, 3, claim.EventID)
+		assert.Equal(t, "nonce-a", claim.Nonce)
+	})
+
+	t.Run("Event Owner Does Not Get Payload", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(&entity.Event{ID: 3, OwnerID: 1}, nil).Once()
+
+		ticket, err := ticketUsecase.GetTicketByCode(ctx, 1, "TKT-A")
+		require.NoError(t, err)
+		assert.Empty(t, ticket.QRPayload)
+	})
+
+	t.Run("Renders PNG For Holder", func(t *testing.T) {
+		ticketUsecase, _, _ := newCheckInFixture()
+
+		image, err := ticketUsecase.GetTicketQR(ctx, 2, "TKT-A")
+		require.NoError(t, err)
+
+		_, err = png.Decode(bytes.NewReader(image))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Other User Cannot Get PNG", func(t *testing.T) {
+		ticketUsecase, _, _ := newCheckInFixture()
+
+		_, err := ticketUsecase.GetTicketQR(ctx, 9, "TKT-A")
+
+		assert.Error(t, err)
+		assert.Equal(t, "anda tidak memiliki izin untuk melihat tiket ini", err.Error())
+	})
+
+	t.Run("Tampered Payload Rejected", func(t *testing.T) {
+		payload := utils.GenerateTicketQRPayload(1, 3, "nonce-a", testQRSecret)
+
+		_, err := utils.ParseTicketQRPayload(strings.Replace(payload, "TQ1.1.", "TQ1.2.", 1), testQRSecret)
+		assert.Error(t, err)
+
+		_, err = utils.ParseTicketQRPayload(payload, "kunci-lain")
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckIn(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	event := &entity.Event{ID: 3, Title: "Konser Musik", OwnerID: organizerID}
+
+	checkIn := func(ticketUsecase usecase.TicketUsecase, ticketID, eventID int, nonce string) (*usecase.TicketResponse, error) {
+		return ticketUsecase.CheckIn(ctx, organizerID, 3, usecase.CheckInRequest{
+			QRPayload: utils.GenerateTicketQRPayload(ticketID, eventID, nonce, testQRSecret),
+		})
+	}
+
+	t.Run("Marks Ticket Used", func(t *testing.T) {
+		ticketUsecase, ticketRepo, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		ticket, err := checkIn(ticketUsecase, 1, 3, "nonce-a")
+
+		require.NoError(t, err)
+		assert.Equal(t, "used", ticket.Status)
+		assert.False(t, ticket.CheckedInAt.IsZero())
+		assert.Equal(t, organizerID, ticketRepo.Tickets[0].CheckedInBy)
+	})
+
+	t.Run("Second Scan Is Already Checked In", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Twice()
+
+		_, err := checkIn(ticketUsecase, 1, 3, "nonce-a")
+		require.NoError(t, err)
+
+		ticket, err := checkIn(ticketUsecase, 1, 3, "nonce-a")
+
+		assert.Error(t, err)
+		assert.Equal(t, "tiket sudah digunakan", err.Error())
+		require.NotNil(t, ticket)
+		assert.False(t, ticket.CheckedInAt.IsZero())
+	})
+
+	t.Run("Concurrent Scans Check In Once", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil)
+
+		var wg sync.WaitGroup
+		var success, alreadyUsed int64
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := checkIn(ticketUsecase, 1, 3, "nonce-a")
+				switch {
+				case err == nil:
+					atomic.AddInt64(&success, 1)
+				case err.Error() == "tiket sudah digunakan":
+					atomic.AddInt64(&alreadyUsed, 1)
+				default:
+					t.Errorf("error tidak terduga: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(1), success)
+		assert.Equal(t, int64(19), alreadyUsed)
+	})
+
+	t.Run("Refunded Ticket Rejected", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		ticket, err := checkIn(ticketUsecase, 2, 3, "nonce-b")
+
+		assert.Error(t, err)
+		assert.Equal(t, "tiket sudah di-refund", err.Error())
+		assert.Equal(t, "refunded", ticket.Status)
+	})
+
+	t.Run("Ticket For Another Event Rejected", func(t *testing.T) {
+		ticketUsecase, ticketRepo, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		_, err := checkIn(ticketUsecase, 3, 4, "nonce-c")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, usecase.ErrTicketWrongEvent)
+		assert.Equal(t, entity.TicketStatusActive, ticketRepo.Tickets[2].Status)
+	})
+
+	t.Run("Forged Event ID Rejected", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		// Signature valid tetapi event di payload tidak sesuai dengan tiket di database
+		_, err := checkIn(ticketUsecase, 3, 3, "nonce-c")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrInvalidTicketQR)
+	})
+
+	t.Run("Stale Nonce Rejected", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		_, err := checkIn(ticketUsecase, 1, 3, "nonce-lama")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrInvalidTicketQR)
+	})
+
+	t.Run("Invalid Signature Rejected", func(t *testing.T) {
+		ticketUsecase, _, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		_, err := ticketUsecase.CheckIn(ctx, organizerID, 3, usecase.CheckInRequest{
+			QRPayload: utils.GenerateTicketQRPayload(1, 3, "nonce-a", "kunci-palsu"),
+		})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrInvalidTicketQR)
+	})
+
+	t.Run("Organizer Of Another Event", func(t *testing.T) {
+		ticketUsecase, ticketRepo, mockEventRepo := newCheckInFixture()
+		mockEventRepo.On("FindByID", ctx, 3).Return(&entity.Event{ID: 3, OwnerID: 99}, nil).Once()
+
+		_, err := checkIn(ticketUsecase, 1, 3, "nonce-a")
+
+		assert.Error(t, err)
+		assert.Equal(t, "anda tidak memiliki izin untuk melakukan check-in di event ini", err.Error())
+		assert.Equal(t, entity.TicketStatusActive, ticketRepo.Tickets[0].Status)
+	})
+
+	t.Run("Empty Payload", func(t *testing.T) {
+		ticketUsecase, _, _ := newCheckInFixture()
+
+		_, err := ticketUsecase.CheckIn(ctx, organizerID, 3, usecase.CheckInRequest{})
+
+		assert.Error(t, err)
+		assert.Equal(t, "qr tiket harus diisi", err.Error())
+	})
+}
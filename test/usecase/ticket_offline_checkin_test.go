@@ -0,0 +1,255 @@
+//test/usecase/ticket_offline_checkin_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func newOfflineCheckInFixture() (usecase.TicketUsecase, *mocks.FakeTicketRepository, *mocks.FakeTicketScanRepository) {
+	mockEventRepo := new(mocks.MockEventRepository)
+	mockEventRepo.On("FindByID", context.Background(), 3).Return(&entity.Event{ID: 3, OwnerID: 1}, nil)
+
+	ticketRepo := &mocks.FakeTicketRepository{
+		Tickets: []entity.Ticket{
+			{ID: 1, EventID: 3, UserID: 2, SeatNumber: 1, TicketCode: "TKT-A", QRNonce: "nonce-a", Status: entity.TicketStatusActive},
+			{ID: 2, EventID: 3, UserID: 2, SeatNumber: 2, TicketCode: "TKT-B", QRNonce: "nonce-b", Status: entity.TicketStatusRefunded},
+			{ID: 3, EventID: 3, UserID: 5, SeatNumber: 1, TicketCode: "TKT-C", QRNonce: "nonce-c", Status: entity.TicketStatusActive},
+			{ID: 4, EventID: 4, UserID: 5, SeatNumber: 1, TicketCode: "TKT-D", QRNonce: "nonce-d", Status: entity.TicketStatusActive},
+		},
+	}
+	scanRepo := &mocks.FakeTicketScanRepository{}
+
+	return newTicketUsecase(ticketRepo, scanRepo, mockEventRepo), ticketRepo, scanRepo
+}
+
+func TestCheckInManifest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Signed Manifest Lists Event Tickets", func(t *testing.T) {
+		ticketUsecase, _, _ := newOfflineCheckInFixture()
+
+		response, err := ticketUsecase.GetCheckInManifest(ctx, 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 3, response.TicketCount)
+
+		// Public key diambil terpisah, bukan dari manifest yang sedang diverifikasi
+		publicKey, err := ticketUsecase.GetCheckInPublicKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Ed25519", publicKey.Algorithm)
+
+		payload, err := base64.RawURLEncoding.DecodeString(response.Payload)
+		require.NoError(t, err)
+		assert.True(t, utils.VerifyTicketManifest(payload, response.Signature, publicKey.PublicKey))
+
+		var manifest usecase.CheckInManifest
+		require.NoError(t, json.Unmarshal(payload, &manifest))
+		assert.Equal(t, 3, manifest.EventID)
+		require.Len(t, manifest.Tickets, 3)
+		assert.Equal(t, utils.TicketNonceHash("nonce-a"), manifest.Tickets[0].NonceHash)
+		assert.Equal(t, "refunded", manifest.Tickets[1].Status)
+		assert.NotContains(t, string(payload), "nonce-a", "nonce asli tidak boleh ikut di manifest")
+
+		// Manifest yang diubah di perangkat harus gagal diverifikasi
+		tampered := append([]byte{}, payload...)
+		tampered[len(tampered)-3] = 'X'
+		assert.False(t, utils.VerifyTicketManifest(tampered, response.Signature, publicKey.PublicKey))
+
+		// Manifest yang ditandatangani key lain tidak lolos verifikasi dengan public key yang dipercaya
+		otherKey, err := utils.ParseTicketManifestKey(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+		require.NoError(t, err)
+		assert.False(t, utils.VerifyTicketManifest(payload, utils.SignTicketManifest(payload, otherKey), publicKey.PublicKey))
+	})
+
+	t.Run("Signing Key Not Configured", func(t *testing.T) {
+		mockEventRepo := new(mocks.MockEventRepository)
+		ticketUsecase := usecase.NewTicketUsecase(&mocks.FakeTicketRepository{}, &mocks.FakeTicketScanRepository{}, mockEventRepo, &mocks.FakeTxManager{}, testQRSecret, nil)
+
+		_, err := ticketUsecase.GetCheckInManifest(ctx, 1, 3)
+		assert.EqualError(t, err, "manifest check-in offline belum dikonfigurasi")
+
+		_, err = ticketUsecase.GetCheckInPublicKey(ctx)
+		assert.EqualError(t, err, "manifest check-in offline belum dikonfigurasi")
+		mockEventRepo.AssertNotCalled(t, "FindByID")
+	})
+
+	t.Run("Organizer Of Another Event", func(t *testing.T) {
+		ticketUsecase, _, _ := newOfflineCheckInFixture()
+
+		_, err := ticketUsecase.GetCheckInManifest(ctx, 99, 3)
+
+		assert.Error(t, err)
+		assert.Equal(t, "anda tidak memiliki izin untuk melakukan check-in di event ini", err.Error())
+	})
+}
+
+func TestSyncCheckIns(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	qr := func(ticketID, eventID int, nonce string) string {
+		return utils.GenerateTicketQRPayload(ticketID, eventID, nonce, testQRSecret)
+	}
+
+	t.Run("Earliest Scan Across Gates Wins", func(t *testing.T) {
+		ticketUsecase, ticketRepo, _ := newOfflineCheckInFixture()
+
+		// Gerbang B mengunggah lebih dulu, tetapi scan di gerbang A terjadi lebih awal
+		response, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{
+			DeviceID: "device-b",
+			Scans: []usecase.CheckInScan{
+				{QRPayload: qr(1, 3, "nonce-a"), Gate: "B", ScannedAt: base.Add(5 * time.Minute)},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "checked_in", response.Results[0].Result)
+
+		response, err = ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{
+			DeviceID: "device-a",
+			Scans: []usecase.CheckInScan{
+				{QRPayload: qr(1, 3, "nonce-a"), Gate: "A", ScannedAt: base},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "checked_in", response.Results[0].Result)
+
+		assert.Equal(t, entity.TicketStatusUsed, ticketRepo.Tickets[0].Status)
+		assert.Equal(t, "A", ticketRepo.Tickets[0].CheckedInGate)
+		assert.True(t, base.Equal(ticketRepo.Tickets[0].CheckedInAt))
+	})
+
+	t.Run("Later Scan Reported As Duplicate", func(t *testing.T) {
+		ticketUsecase, _, _ := newOfflineCheckInFixture()
+
+		response, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{
+			DeviceID: "device-a",
+			Scans: []usecase.CheckInScan{
+				// Urutan di batch tidak menentukan, yang dipakai waktu scan
+				{QRPayload: qr(1, 3, "nonce-a"), Gate: "B", ScannedAt: base.Add(time.Minute)},
+				{QRPayload: qr(1, 3, "nonce-a"), Gate: "A", ScannedAt: base},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "duplicate", response.Results[0].Result)
+		assert.Equal(t, "A", response.Results[0].FirstGate)
+		assert.True(t, base.Equal(response.Results[0].FirstScannedAt))
+		assert.Equal(t, "checked_in", response.Results[1].Result)
+		assert.Equal(t, 1, response.Accepted)
+		assert.Equal(t, 1, response.Duplicates)
+		assert.Equal(t, 1, response.Attendance.DuplicateScans)
+	})
+
+	t.Run("Offline Scan After Online Check-In Is Duplicate", func(t *testing.T) {
+		ticketUsecase, _, _ := newOfflineCheckInFixture()
+
+		_, err := ticketUsecase.CheckIn(ctx, 1, 3, usecase.CheckInRequest{QRPayload: qr(1, 3, "nonce-a"), Gate: "Utama"})
+		require.NoError(t, err)
+
+		response, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{
+			DeviceID: "device-a",
+			Scans: []usecase.CheckInScan{
+				{QRPayload: qr(1, 3, "nonce-a"), Gate: "A", ScannedAt: time.Now().Add(time.Minute)},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "duplicate", response.Results[0].Result)
+		assert.Equal(t, "Utama", response.Results[0].FirstGate)
+	})
+
+	t.Run("Rejected Scans", func(t *testing.T) {
+		ticketUsecase, _, scanRepo := newOfflineCheckInFixture()
+
+		response, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{
+			DeviceID: "device-a",
+			Scans: []usecase.CheckInScan{
+				{QRPayload: qr(2, 3, "nonce-b"), ScannedAt: base},
+				{QRPayload: qr(4, 4, "nonce-d"), ScannedAt: base},
+				{QRPayload: utils.GenerateTicketQRPayload(3, 3, "nonce-c", "kunci-palsu"), ScannedAt: base},
+				{QRPayload: qr(3, 3, "nonce-c")},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "not_active", response.Results[0].Result)
+		assert.Equal(t, "wrong_event", response.Results[1].Result)
+		assert.Equal(t, "invalid", response.Results[2].Result)
+		assert.Equal(t, "invalid", response.Results[3].Result, "scan tanpa waktu tidak bisa dipakai menentukan urutan")
+		assert.Equal(t, 4, response.Rejected)
+		assert.Len(t, scanRepo.Scans, 4)
+	})
+
+	t.Run("Resent Batch Is Not Counted Twice", func(t *testing.T) {
+		ticketUsecase, _, scanRepo := newOfflineCheckInFixture()
+
+		req := usecase.CheckInSyncRequest{
+			DeviceID: "device-a",
+			Scans: []usecase.CheckInScan{
+				{QRPayload: qr(1, 3, "nonce-a"), Gate: "A", ScannedAt: base},
+				{QRPayload: qr(3, 3, "nonce-c"), Gate: "A", ScannedAt: base.Add(time.Second)},
+			},
+		}
+
+		_, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, req)
+		require.NoError(t, err)
+
+		response, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "already_synced", response.Results[0].Result)
+		assert.Equal(t, "already_synced", response.Results[1].Result)
+		assert.Equal(t, 0, response.Duplicates)
+		assert.Len(t, scanRepo.Scans, 2)
+		assert.Equal(t, 2, response.Attendance.CheckedIn)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		ticketUsecase, _, _ := newOfflineCheckInFixture()
+
+		_, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{Scans: []usecase.CheckInScan{{}}})
+		assert.Equal(t, "device id harus diisi", err.Error())
+
+		_, err = ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{DeviceID: "device-a"})
+		assert.Equal(t, "data scan tidak boleh kosong", err.Error())
+
+		_, err = ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{DeviceID: "device-a", Scans: make([]usecase.CheckInScan, 1001)})
+		assert.Equal(t, "jumlah scan melebihi batas per sinkronisasi", err.Error())
+	})
+}
+
+func TestGetAttendance(t *testing.T) {
+	ctx := context.Background()
+	ticketUsecase, _, _ := newOfflineCheckInFixture()
+
+	_, err := ticketUsecase.SyncCheckIns(ctx, 1, 3, usecase.CheckInSyncRequest{
+		DeviceID: "device-a",
+		Scans: []usecase.CheckInScan{
+			{QRPayload: utils.GenerateTicketQRPayload(1, 3, "nonce-a", testQRSecret), Gate: "A", ScannedAt: time.Now()},
+		},
+	})
+	require.NoError(t, err)
+
+	attendance, err := ticketUsecase.GetAttendance(ctx, 1, 3)
+
+	require.NoError(t, err)
+	// Tiket yang di-refund tidak dihitung
+	assert.Equal(t, 2, attendance.Summary.TotalTickets)
+	assert.Equal(t, 1, attendance.Summary.CheckedIn)
+	assert.Equal(t, 1, attendance.Summary.NotCheckedIn)
+	require.Len(t, attendance.Attendees, 1)
+	assert.Equal(t, "TKT-A", attendance.Attendees[0].TicketCode)
+	assert.Equal(t, "A", attendance.Attendees[0].Gate)
+}
@@ -0,0 +1,237 @@
+//test/usecase/ticket_transfer_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+type ticketTransferFixture struct {
+	usecase      usecase.TicketTransferUsecase
+	ticketRepo   *mocks.FakeTicketRepository
+	transferRepo *mocks.FakeTicketTransferRepository
+	refundRepo   *mocks.FakeRefundRepository
+	eventRepo    *mocks.MockEventRepository
+	event        *entity.Event
+}
+
+// newTicketTransferFixture menyiapkan tiket TKT-A milik user 2 untuk event 3 yang diselenggarakan user 1
+func newTicketTransferFixture() *ticketTransferFixture {
+	f := &ticketTransferFixture{
+		ticketRepo: &mocks.FakeTicketRepository{
+			Tickets: []entity.Ticket{
+				{ID: 1, TransactionID: 7, EventID: 3, UserID: 2, SeatNumber: 1, TicketCode: "TKT-A", QRNonce: "nonce-a", Status: entity.TicketStatusActive},
+			},
+		},
+		transferRepo: &mocks.FakeTicketTransferRepository{},
+		refundRepo:   &mocks.FakeRefundRepository{},
+		eventRepo:    new(mocks.MockEventRepository),
+		event: &entity.Event{
+			ID:             3,
+			OwnerID:        1,
+			Title:          "Konser Musik",
+			EventDate:      time.Now().Add(7 * 24 * time.Hour),
+			Status:         "active",
+			TransferPolicy: entity.TransferPolicy{Allowed: true, DeadlineHours: 24, MaxTransfers: 2},
+		},
+	}
+	f.eventRepo.On("FindByID", mock.Anything, 3).Return(f.event, nil).Maybe()
+
+	sender := &entity.User{ID: 2, Username: "pengirim", Email: "pengirim@example.com"}
+	recipient := &entity.User{ID: 5, Username: "penerima", Email: "penerima@example.com"}
+
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindByID", mock.Anything, 2).Return(sender, nil).Maybe()
+	userRepo.On("FindByID", mock.Anything, 5).Return(recipient, nil).Maybe()
+	userRepo.On("FindByUsername", mock.Anything, "penerima").Return(recipient, nil).Maybe()
+	userRepo.On("FindByUsername", mock.Anything, "pengirim").Return(sender, nil).Maybe()
+	userRepo.On("FindByEmail", mock.Anything, "penerima@example.com").Return(recipient, nil).Maybe()
+	userRepo.On("FindByUsername", mock.Anything, "tidak-ada").Return(nil, nil).Maybe()
+
+	f.usecase = usecase.NewTicketTransferUsecase(f.transferRepo, f.ticketRepo, f.eventRepo, userRepo, f.refundRepo, &mocks.FakeTxManager{}, utils.SMTPConfig{})
+	return f
+}
+
+func TestTicketTransferFlow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Accept Moves Ticket And Rotates QR", func(t *testing.T) {
+		f := newTicketTransferFixture()
+
+		transfer, err := f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "pending", transfer.Status)
+		assert.Equal(t, "penerima", transfer.ToUsername)
+		assert.Equal(t, 2, f.ticketRepo.Tickets[0].UserID)
+
+		pending, err := f.usecase.GetPendingTransfers(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "TKT-A", pending[0].TicketCode)
+
+		accepted, err := f.usecase.AcceptTransfer(ctx, 5, transfer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "accepted", accepted.Status)
+		assert.NotNil(t, accepted.RespondedAt)
+
+		ticket := f.ticketRepo.Tickets[0]
+		assert.Equal(t, 5, ticket.UserID)
+		assert.Equal(t, 1, ticket.TransferCount)
+		assert.NotEqual(t, "nonce-a", ticket.QRNonce)
+
+		// QR lama milik pengirim ditolak di pintu masuk, QR baru diterima
+		ticketUsecase := newTicketUsecase(f.ticketRepo, &mocks.FakeTicketScanRepository{}, f.eventRepo)
+		_, err = ticketUsecase.CheckIn(ctx, 1, 3, usecase.CheckInRequest{QRPayload: utils.GenerateTicketQRPayload(1, 3, "nonce-a", testQRSecret)})
+		assert.EqualError(t, err, "qr tiket tidak valid")
+
+		_, err = ticketUsecase.CheckIn(ctx, 1, 3, usecase.CheckInRequest{QRPayload: utils.GenerateTicketQRPayload(1, 3, ticket.QRNonce, testQRSecret)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Chain Of Custody", func(t *testing.T) {
+		f := newTicketTransferFixture()
+
+		first, err := f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		require.NoError(t, err)
+		_, err = f.usecase.DeclineTransfer(ctx, 5, first.ID)
+		require.NoError(t, err)
+
+		second, err := f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		require.NoError(t, err)
+		_, err = f.usecase.AcceptTransfer(ctx, 5, second.ID)
+		require.NoError(t, err)
+
+		back, err := f.usecase.InitiateTransfer(ctx, 5, "TKT-A", usecase.InitiateTransferRequest{Recipient: "pengirim"})
+		require.NoError(t, err)
+		_, err = f.usecase.AcceptTransfer(ctx, 2, back.ID)
+		require.NoError(t, err)
+
+		chain, err := f.usecase.GetTicketTransfers(ctx, 2, "TKT-A")
+		require.NoError(t, err)
+		require.Len(t, chain, 3)
+		assert.Equal(t, []string{"declined", "accepted", "accepted"}, []string{chain[0].Status, chain[1].Status, chain[2].Status})
+		assert.Equal(t, 5, chain[2].FromUserID)
+
+		// Organizer event juga boleh melihat riwayat, pemilik sebelumnya tidak
+		_, err = f.usecase.GetTicketTransfers(ctx, 1, "TKT-A")
+		assert.NoError(t, err)
+
+		_, err = f.usecase.GetTicketTransfers(ctx, 5, "TKT-A")
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk melihat tiket ini")
+
+		// Batas dua kali transfer sudah tercapai
+		_, err = f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		assert.EqualError(t, err, "tiket sudah mencapai batas jumlah transfer")
+	})
+
+	t.Run("Accept Rechecks Event Policy", func(t *testing.T) {
+		f := newTicketTransferFixture()
+
+		transfer, err := f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		require.NoError(t, err)
+
+		f.event.TransferPolicy.Allowed = false
+
+		_, err = f.usecase.AcceptTransfer(ctx, 5, transfer.ID)
+		assert.EqualError(t, err, "event ini tidak mengizinkan transfer tiket")
+		assert.Equal(t, 2, f.ticketRepo.Tickets[0].UserID)
+		assert.Equal(t, "nonce-a", f.ticketRepo.Tickets[0].QRNonce)
+
+		// Pengirim tetap bisa membatalkan transfer yang menggantung
+		cancelled, err := f.usecase.CancelTransfer(ctx, 2, transfer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", cancelled.Status)
+	})
+
+	t.Run("Only Recipient Responds And Only Sender Cancels", func(t *testing.T) {
+		f := newTicketTransferFixture()
+
+		transfer, err := f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		require.NoError(t, err)
+
+		_, err = f.usecase.AcceptTransfer(ctx, 2, transfer.ID)
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk transfer tiket ini")
+
+		_, err = f.usecase.CancelTransfer(ctx, 5, transfer.ID)
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk transfer tiket ini")
+
+		_, err = f.usecase.DeclineTransfer(ctx, 5, transfer.ID)
+		require.NoError(t, err)
+
+		_, err = f.usecase.AcceptTransfer(ctx, 5, transfer.ID)
+		assert.EqualError(t, err, "transfer tiket sudah diproses")
+		assert.Equal(t, 2, f.ticketRepo.Tickets[0].UserID)
+	})
+}
+
+func TestInitiateTicketTransferRejected(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		userID    int
+		recipient string
+		setup     func(f *ticketTransferFixture)
+		expected  string
+	}{
+		{"Empty Recipient", 2, " ", nil, "penerima transfer harus diisi"},
+		{"Not Owner", 5, "pengirim", nil, "anda tidak memiliki izin untuk memindahtangankan tiket ini"},
+		{"Unknown Recipient", 2, "tidak-ada", nil, "penerima transfer tidak ditemukan"},
+		{"Self Transfer", 2, "pengirim", nil, "tiket tidak dapat dipindahtangankan ke akun sendiri"},
+		{"Transfers Disabled", 2, "penerima", func(f *ticketTransferFixture) {
+			f.event.TransferPolicy.Allowed = false
+		}, "event ini tidak mengizinkan transfer tiket"},
+		{"Past Deadline", 2, "penerima", func(f *ticketTransferFixture) {
+			f.event.EventDate = time.Now().Add(12 * time.Hour)
+		}, "batas waktu transfer tiket sudah lewat"},
+		{"Limit Reached", 2, "penerima", func(f *ticketTransferFixture) {
+			f.ticketRepo.Tickets[0].TransferCount = 2
+		}, "tiket sudah mencapai batas jumlah transfer"},
+		{"Ticket Used", 2, "penerima", func(f *ticketTransferFixture) {
+			f.ticketRepo.Tickets[0].Status = entity.TicketStatusUsed
+		}, "hanya tiket aktif yang dapat dipindahtangankan"},
+		{"Event Cancelled", 2, "penerima", func(f *ticketTransferFixture) {
+			f.event.Status = "cancelled"
+		}, "tiket untuk event yang tidak aktif tidak dapat dipindahtangankan"},
+		{"Open Refund", 2, "penerima", func(f *ticketTransferFixture) {
+			f.refundRepo.Refunds = []entity.Refund{{ID: 1, TransactionID: 7, Status: entity.RefundStatusRequested}}
+		}, "tiket dengan pengajuan refund tidak dapat dipindahtangankan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTicketTransferFixture()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			transfer, err := f.usecase.InitiateTransfer(ctx, tt.userID, "TKT-A", usecase.InitiateTransferRequest{Recipient: tt.recipient})
+
+			assert.Nil(t, transfer)
+			assert.EqualError(t, err, tt.expected)
+			assert.Empty(t, f.transferRepo.Transfers)
+		})
+	}
+
+	t.Run("Pending Transfer Exists", func(t *testing.T) {
+		f := newTicketTransferFixture()
+
+		_, err := f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		require.NoError(t, err)
+
+		_, err = f.usecase.InitiateTransfer(ctx, 2, "TKT-A", usecase.InitiateTransferRequest{Recipient: "penerima"})
+		assert.ErrorIs(t, err, repository.ErrTicketTransferExists)
+	})
+}
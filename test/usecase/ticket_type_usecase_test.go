@@ -0,0 +1,365 @@
+//test/usecase/ticket_type_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func TestTicketTypeManagement(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	eventID := 7
+
+	newFixture := func() (usecase.EventUsecase, *mocks.MockEventRepository, *mocks.FakeTicketTypeRepository) {
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{
+			ID:          eventID,
+			OwnerID:     organizerID,
+			MaxCapacity: 100,
+			Price:       entity.IDR(50000),
+			Status:      "active",
+		}, nil)
+
+		ticketTypeRepo := &mocks.FakeTicketTypeRepository{}
+		return usecase.NewEventUsecase(mockEventRepo, new(mocks.MockUserRepository), ticketTypeRepo, new(mocks.MockTransactionRepository)), mockEventRepo, ticketTypeRepo
+	}
+
+	t.Run("Create Applies Default Per-Order Limit", func(t *testing.T) {
+		eventUsecase, _, ticketTypeRepo := newFixture()
+
+		ticketType, err := eventUsecase.CreateTicketType(ctx, eventID, organizerID, usecase.TicketTypeRequest{
+			Name:  " VIP ",
+			Price: entity.IDR(250000),
+			Quota: 20,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "VIP", ticketType.Name)
+		assert.Equal(t, 10, ticketType.MaxPerOrder)
+		assert.Len(t, ticketTypeRepo.TicketTypes, 1)
+	})
+
+	t.Run("Create Rejects Non Owner", func(t *testing.T) {
+		eventUsecase, _, ticketTypeRepo := newFixture()
+
+		_, err := eventUsecase.CreateTicketType(ctx, eventID, 99, usecase.TicketTypeRequest{Name: "VIP", Quota: 20})
+
+		assert.EqualError(t, err, "anda tidak memiliki izin untuk mengelola tipe tiket event ini")
+		assert.Empty(t, ticketTypeRepo.TicketTypes)
+	})
+
+	t.Run("Create Rejects Duplicate Name", func(t *testing.T) {
+		eventUsecase, _, _ := newFixture()
+
+		_, err := eventUsecase.CreateTicketType(ctx, eventID, organizerID, usecase.TicketTypeRequest{Name: "VIP", Quota: 20})
+		assert.NoError(t, err)
+
+		_, err = eventUsecase.CreateTicketType(ctx, eventID, organizerID, usecase.TicketTypeRequest{Name: "vip", Quota: 10})
+		assert.EqualError(t, err, "nama tipe tiket sudah digunakan di event ini")
+	})
+
+	t.Run("Create Rejects Quota Above Event Capacity", func(t *testing.T) {
+		eventUsecase, _, _ := newFixture()
+
+		_, err := eventUsecase.CreateTicketType(ctx, eventID, organizerID, usecase.TicketTypeRequest{Name: "Regular", Quota: 80})
+		assert.NoError(t, err)
+
+		_, err = eventUsecase.CreateTicketType(ctx, eventID, organizerID, usecase.TicketTypeRequest{Name: "VIP", Quota: 21})
+		assert.EqualError(t, err, "total kuota tipe tiket melebihi kapasitas event")
+	})
+
+	t.Run("Create Rejects Sales Window Ending Before It Starts", func(t *testing.T) {
+		eventUsecase, _, _ := newFixture()
+		start := time.Now().Add(24 * time.Hour)
+
+		_, err := eventUsecase.CreateTicketType(ctx, eventID, organizerID, usecase.TicketTypeRequest{
+			Name:       "Early Bird",
+			Quota:      10,
+			SalesStart: start,
+			SalesEnd:   start.Add(-time.Hour),
+		})
+
+		assert.EqualError(t, err, "akhir penjualan harus setelah awal penjualan")
+	})
+
+	t.Run("Update Rejects Quota Below Sold", func(t *testing.T) {
+		eventUsecase, _, ticketTypeRepo := newFixture()
+		ticketTypeRepo.TicketTypes = []entity.TicketType{{ID: 1, EventID: eventID, Name: "VIP", Quota: 20, Sold: 15, MaxPerOrder: 10}}
+
+		_, err := eventUsecase.UpdateTicketType(ctx, eventID, 1, organizerID, usecase.TicketTypeRequest{Name: "VIP", Quota: 10})
+
+		assert.EqualError(t, err, "kuota tidak boleh lebih kecil dari jumlah tiket yang sudah terjual")
+		assert.Equal(t, 20, ticketTypeRepo.TicketTypes[0].Quota)
+	})
+
+	t.Run("Update Rejects Type From Another Event", func(t *testing.T) {
+		eventUsecase, _, ticketTypeRepo := newFixture()
+		ticketTypeRepo.TicketTypes = []entity.TicketType{{ID: 1, EventID: eventID + 1, Name: "VIP", Quota: 20}}
+
+		_, err := eventUsecase.UpdateTicketType(ctx, eventID, 1, organizerID, usecase.TicketTypeRequest{Name: "VIP", Quota: 30})
+
+		assert.EqualError(t, err, "tipe tiket tidak ditemukan")
+	})
+
+	t.Run("Delete Rejects Sold Type", func(t *testing.T) {
+		eventUsecase, _, ticketTypeRepo := newFixture()
+		ticketTypeRepo.TicketTypes = []entity.TicketType{{ID: 1, EventID: eventID, Name: "VIP", Quota: 20, Sold: 1}}
+
+		err := eventUsecase.DeleteTicketType(ctx, eventID, 1, organizerID)
+
+		assert.EqualError(t, err, "tipe tiket yang sudah terjual tidak dapat dihapus")
+		assert.Len(t, ticketTypeRepo.TicketTypes, 1)
+	})
+
+	t.Run("Event Capacity Cannot Drop Below Total Quota", func(t *testing.T) {
+		eventUsecase, _, ticketTypeRepo := newFixture()
+		ticketTypeRepo.TicketTypes = []entity.TicketType{
+			{ID: 1, EventID: eventID, Name: "VIP", Quota: 20},
+			{ID: 2, EventID: eventID, Name: "Regular", Quota: 60},
+		}
+
+		err := eventUsecase.UpdateEvent(ctx, eventID, organizerID, usecase.UpdateEventRequest{Title: "Konser", MaxCapacity: 70})
+
+		assert.EqualError(t, err, "kapasitas tidak boleh lebih kecil dari total kuota tipe tiket")
+	})
+
+	t.Run("Sales Break Down Revenue Per Type", func(t *testing.T) {
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{
+			ID:          eventID,
+			OwnerID:     organizerID,
+			MaxCapacity: 100,
+			TicketsSold: 17,
+			Price:       entity.IDR(50000),
+		}, nil)
+		ticketTypeRepo := &mocks.FakeTicketTypeRepository{TicketTypes: []entity.TicketType{
+			{ID: 1, EventID: eventID, Name: "VIP", Price: entity.IDR(250000), Quota: 20, Sold: 5},
+			{ID: 2, EventID: eventID, Name: "Regular", Price: entity.IDR(100000), Quota: 60, Sold: 10},
+		}}
+		// Harga VIP sudah dinaikkan setelah 5 tiket terjual seharga 200.000, dan 2 tiket Regular masih
+		// menunggu pembayaran sehingga belum masuk pendapatan
+		transactionRepo := new(mocks.MockTransactionRepository)
+		transactionRepo.On("SumPaidRevenueByEvent", ctx, eventID, "IDR").Return(&entity.EventRevenue{
+			Subtotal: entity.IDR(1000000 + 800000 + 2*50000),
+			Discount: entity.IDR(50000),
+			ByTicketType: map[int]entity.Money{
+				1: entity.IDR(1000000),
+				2: entity.IDR(800000),
+			},
+		}, nil)
+		eventUsecase := usecase.NewEventUsecase(mockEventRepo, new(mocks.MockUserRepository), ticketTypeRepo, transactionRepo)
+
+		sales, err := eventUsecase.GetEventSales(ctx, eventID, organizerID)
+
+		assert.NoError(t, err)
+		assert.Len(t, sales.TicketTypes, 2)
+		assert.Equal(t, 15, sales.TicketTypes[0].Remaining)
+		assert.Equal(t, entity.IDR(1000000), sales.TicketTypes[0].Revenue)
+		assert.Equal(t, 50, sales.TicketTypes[1].Remaining)
+		assert.Equal(t, entity.IDR(800000), sales.TicketTypes[1].Revenue)
+		// Kursi tanpa tipe tiket ikut dalam subtotal, diskon promo mengurangi total penjualan
+		assert.Equal(t, entity.IDR(50000), sales.TotalDiscount)
+		assert.Equal(t, entity.IDR(1000000+800000+2*50000-50000), sales.TotalSales)
+	})
+}
+
+func TestCreateTransactionWithTicketTypes(t *testing.T) {
+	ctx := context.Background()
+	userID := 2
+	eventID := 7
+
+	newFixture := func(ticketTypes []entity.TicketType) (usecase.TransactionUsecase, *mocks.MockTransactionRepository, *mocks.FakeTicketTypeRepository, *mocks.FakeTransactionItemRepository) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+
+		mockUserRepo.On("FindByID", ctx, userID).Return(&entity.User{ID: userID, Role: "user"}, nil)
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{
+			ID:          eventID,
+			Title:       "Konser",
+			MaxCapacity: 100,
+			Price:       entity.IDR(50000),
+			Status:      "active",
+		}, nil)
+
+		ticketTypeRepo := &mocks.FakeTicketTypeRepository{TicketTypes: ticketTypes}
+		itemRepo := &mocks.FakeTransactionItemRepository{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, ticketTypeRepo, itemRepo, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		return transactionUsecase, mockTransactionRepo, ticketTypeRepo, itemRepo
+	}
+
+	tiers := func() []entity.TicketType {
+		return []entity.TicketType{
+			{ID: 1, EventID: eventID, Name: "VIP", Price: entity.IDR(250000), Quota: 5, MaxPerOrder: 4},
+			{ID: 2, EventID: eventID, Name: "Regular", Price: entity.IDR(100000), Quota: 50, MaxPerOrder: 10},
+		}
+	}
+
+	t.Run("Line Items Set Quantity And Total", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, ticketTypeRepo, itemRepo := newFixture(tiers())
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(10, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+			EventID: eventID,
+			Items: []usecase.TransactionItemRequest{
+				{TicketTypeID: 1, Quantity: 2},
+				{TicketTypeID: 2, Quantity: 3},
+				{TicketTypeID: 1, Quantity: 1},
+			},
+			PaymentMethod: "bank_transfer",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6, response.Quantity)
+		assert.Equal(t, entity.IDR(3*250000+3*100000), response.TotalAmount)
+		assert.Len(t, response.Items, 2)
+
+		assert.Equal(t, 3, ticketTypeRepo.TicketTypes[0].Sold)
+		assert.Equal(t, 3, ticketTypeRepo.TicketTypes[1].Sold)
+		assert.Len(t, itemRepo.Items, 2)
+		for _, item := range itemRepo.Items {
+			assert.Equal(t, 10, item.TransactionID)
+		}
+	})
+
+	t.Run("Event With Types Requires Items", func(t *testing.T) {
+		transactionUsecase, mockTransactionRepo, _, _ := newFixture(tiers())
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Quantity:      2,
+			PaymentMethod: "bank_transfer",
+		})
+
+		assert.EqualError(t, err, "tipe tiket harus dipilih")
+		mockTransactionRepo.AssertNotCalled(t, "CreateWithReservation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Per-Order Limit Applies To Merged Items", func(t *testing.T) {
+		transactionUsecase, _, _, _ := newFixture(tiers())
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+			EventID: eventID,
+			Items: []usecase.TransactionItemRequest{
+				{TicketTypeID: 1, Quantity: 3},
+				{TicketTypeID: 1, Quantity: 2},
+			},
+			PaymentMethod: "bank_transfer",
+		})
+
+		assert.EqualError(t, err, "jumlah tiket melebihi batas pembelian per transaksi")
+	})
+
+	t.Run("Type Outside Sales Window Is Rejected", func(t *testing.T) {
+		types := tiers()
+		types[0].SalesStart = time.Now().Add(time.Hour)
+		transactionUsecase, _, _, _ := newFixture(types)
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Items:         []usecase.TransactionItemRequest{{TicketTypeID: 1, Quantity: 1}},
+			PaymentMethod: "bank_transfer",
+		})
+
+		assert.EqualError(t, err, "tipe tiket tidak sedang dijual")
+	})
+
+	t.Run("Sold Out Type Is Rejected", func(t *testing.T) {
+		types := tiers()
+		types[0].Sold = 4
+		transactionUsecase, _, _, _ := newFixture(types)
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Items:         []usecase.TransactionItemRequest{{TicketTypeID: 1, Quantity: 2}},
+			PaymentMethod: "bank_transfer",
+		})
+
+		assert.EqualError(t, err, "kuota tipe tiket tidak mencukupi")
+	})
+
+	t.Run("Unknown Type Is Rejected", func(t *testing.T) {
+		transactionUsecase, _, _, _ := newFixture(tiers())
+
+		_, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Items:         []usecase.TransactionItemRequest{{TicketTypeID: 99, Quantity: 1}},
+			PaymentMethod: "bank_transfer",
+		})
+
+		assert.EqualError(t, err, "tipe tiket tidak ditemukan")
+	})
+
+	t.Run("Cancel Returns Quota To Each Type", func(t *testing.T) {
+		types := tiers()
+		types[0].Sold = 2
+		types[1].Sold = 3
+		transactionUsecase, mockTransactionRepo, ticketTypeRepo, itemRepo := newFixture(types)
+		itemRepo.Items = []entity.TransactionItem{
+			{ID: 1, TransactionID: 10, TicketTypeID: 1, Quantity: 2},
+			{ID: 2, TransactionID: 10, TicketTypeID: 2, Quantity: 3},
+		}
+
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockEventRepo.On("UpdateTicketsSold", mock.Anything, eventID, -5).Return(nil).Once()
+		transactionUsecase = usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, ticketTypeRepo, itemRepo, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 10).Return(&entity.Transaction{
+			ID:       10,
+			UserID:   userID,
+			EventID:  eventID,
+			Quantity: 5,
+			Status:   entity.TransactionStatusPending,
+		}, nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 10, entity.TransactionStatusPending, entity.TransactionStatusCancelled).Return(nil).Once()
+
+		err := transactionUsecase.CancelTransaction(ctx, userID, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ticketTypeRepo.TicketTypes[0].Sold)
+		assert.Equal(t, 0, ticketTypeRepo.TicketTypes[1].Sold)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Issued Tickets Carry Their Type", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		ticketRepo := &mocks.FakeTicketRepository{}
+		itemRepo := &mocks.FakeTransactionItemRepository{Items: []entity.TransactionItem{
+			{ID: 1, TransactionID: 10, TicketTypeID: 1, Quantity: 2},
+			{ID: 2, TransactionID: 10, TicketTypeID: 2, Quantity: 1},
+		}}
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, ticketRepo, &mocks.FakeTicketTypeRepository{}, itemRepo, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		organizerID := 1
+		mockUserRepo.On("FindByID", ctx, organizerID).Return(&entity.User{ID: organizerID, Role: "organizer"}, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, 10).Return(&entity.Transaction{
+			ID:       10,
+			UserID:   userID,
+			EventID:  eventID,
+			Quantity: 3,
+			Status:   entity.TransactionStatusWaitingVerification,
+		}, nil).Once()
+		mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{ID: eventID, OwnerID: organizerID}, nil).Once()
+		mockTransactionRepo.On("VerifyPayment", ctx, 10, organizerID).Return(nil).Once()
+
+		err := transactionUsecase.VerifyPayment(ctx, organizerID, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, ticketRepo.Tickets, 3)
+		assert.Equal(t, []int{1, 1, 2}, []int{ticketRepo.Tickets[0].TicketTypeID, ticketRepo.Tickets[1].TicketTypeID, ticketRepo.Tickets[2].TicketTypeID})
+	})
+}
@@ -0,0 +1,203 @@
+//test/usecase/ticket_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/gateway/midtrans"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func TestTicketIssuance(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+
+	t.Run("Verified Payment Issues One Ticket Per Seat", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		ticketRepo := &mocks.FakeTicketRepository{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, ticketRepo, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		transaction := &entity.Transaction{
+			ID:              1,
+			UserID:          2,
+			EventID:         3,
+			TransactionCode: "TRX-20230101-123456",
+			Quantity:        3,
+			TotalAmount:     entity.IDR(750000),
+			Status:          entity.TransactionStatusWaitingVerification,
+			PaymentMethod:   "bank_transfer",
+		}
+
+		mockUserRepo.On("FindByID", ctx, organizerID).Return(&entity.User{ID: organizerID, Role: "organizer"}, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, transaction.ID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(&entity.Event{ID: 3, OwnerID: organizerID}, nil).Once()
+		mockTransactionRepo.On("VerifyPayment", ctx, transaction.ID, organizerID).Return(nil).Once()
+
+		err := transactionUsecase.VerifyPayment(ctx, organizerID, transaction.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, ticketRepo.Tickets, 3)
+
+		codes := make(map[string]bool)
+		for i, ticket := range ticketRepo.Tickets {
+			assert.Equal(t, transaction.ID, ticket.TransactionID)
+			assert.Equal(t, transaction.EventID, ticket.EventID)
+			assert.Equal(t, transaction.UserID, ticket.UserID)
+			assert.Equal(t, i+1, ticket.SeatNumber)
+			assert.Equal(t, entity.TicketStatusActive, ticket.Status)
+			assert.True(t, strings.HasPrefix(ticket.TicketCode, "TKT-"))
+			assert.Len(t, ticket.TicketCode, 28)
+			codes[ticket.TicketCode] = true
+		}
+		assert.Len(t, codes, 3, "kode tiket harus unik")
+
+		// Kursi sudah dihitung saat transaksi dibuat
+		mockEventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Settlement Issues Tickets Once", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		ticketRepo := &mocks.FakeTicketRepository{}
+
+		paymentUsecase := usecase.NewPaymentUsecase(mockTransactionRepo, mockEventRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, ticketRepo, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeRefundRepository{}, &mocks.FakeTxManager{}, midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test"}))
+
+		transaction := &entity.Transaction{
+			ID:              7,
+			UserID:          2,
+			EventID:         3,
+			TransactionCode: fixtureOrderID,
+			Quantity:        2,
+			TotalAmount:     entity.IDR(500000),
+			Status:          entity.TransactionStatusPending,
+			PaymentMethod:   "midtrans",
+		}
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Twice()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Twice()
+		mockTransactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPending, entity.TransactionStatusPaid).Return(nil).Once()
+
+		assert.NoError(t, paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement")))
+		assert.Len(t, ticketRepo.Tickets, 2)
+
+		// Notifikasi yang dikirim ulang tidak menerbitkan tiket baru
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		assert.NoError(t, paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement")))
+		assert.Len(t, ticketRepo.Tickets, 2)
+	})
+
+	t.Run("Refund Invalidates Tickets", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		ticketRepo := &mocks.FakeTicketRepository{
+			Tickets: []entity.Ticket{
+				{ID: 1, TransactionID: 7, EventID: 3, SeatNumber: 1, TicketCode: "TKT-A", Status: entity.TicketStatusActive},
+				{ID: 2, TransactionID: 7, EventID: 3, SeatNumber: 2, TicketCode: "TKT-B", Status: entity.TicketStatusActive},
+			},
+		}
+
+		paymentUsecase := usecase.NewPaymentUsecase(mockTransactionRepo, mockEventRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, ticketRepo, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeRefundRepository{}, &mocks.FakeTxManager{}, midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test"}))
+
+		transaction := &entity.Transaction{ID: 7, EventID: 3, TransactionCode: fixtureOrderID, Quantity: 2, TotalAmount: entity.IDR(500000), Status: entity.TransactionStatusPaid}
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 7).Return(&entity.Payment{ID: 1, TransactionID: 7}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 7, entity.TransactionStatusPaid, entity.TransactionStatusRefunded).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+
+		assert.NoError(t, paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "refund")))
+
+		for _, ticket := range ticketRepo.Tickets {
+			assert.Equal(t, entity.TicketStatusRefunded, ticket.Status)
+		}
+	})
+}
+
+func TestGetTickets(t *testing.T) {
+	ctx := context.Background()
+	buyerID := 2
+	organizerID := 1
+
+	event := &entity.Event{ID: 3, Title: "Konser Musik", Location: "Jakarta", OwnerID: organizerID}
+
+	newUsecase := func() (usecase.TicketUsecase, *mocks.MockEventRepository) {
+		mockEventRepo := new(mocks.MockEventRepository)
+		ticketRepo := &mocks.FakeTicketRepository{
+			Tickets: []entity.Ticket{
+				{ID: 1, TransactionID: 7, EventID: 3, UserID: buyerID, SeatNumber: 1, TicketCode: "TKT-A", Status: entity.TicketStatusActive},
+				{ID: 2, TransactionID: 7, EventID: 3, UserID: buyerID, SeatNumber: 2, TicketCode: "TKT-B", Status: entity.TicketStatusActive},
+				{ID: 3, TransactionID: 8, EventID: 3, UserID: 9, SeatNumber: 1, TicketCode: "TKT-C", Status: entity.TicketStatusActive},
+			},
+		}
+		return newTicketUsecase(ticketRepo, &mocks.FakeTicketScanRepository{}, mockEventRepo), mockEventRepo
+	}
+
+	t.Run("Buyer Lists Own Tickets", func(t *testing.T) {
+		ticketUsecase, mockEventRepo := newUsecase()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		tickets, total, err := ticketUsecase.GetUserTickets(ctx, buyerID, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, tickets, 2)
+		assert.Equal(t, "Konser Musik", tickets[0].EventTitle)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Buyer Gets Ticket By Code", func(t *testing.T) {
+		ticketUsecase, mockEventRepo := newUsecase()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		ticket, err := ticketUsecase.GetTicketByCode(ctx, buyerID, "TKT-A")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "TKT-A", ticket.TicketCode)
+		assert.Equal(t, "active", ticket.Status)
+	})
+
+	t.Run("Event Owner Gets Ticket By Code", func(t *testing.T) {
+		ticketUsecase, mockEventRepo := newUsecase()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		_, err := ticketUsecase.GetTicketByCode(ctx, organizerID, "TKT-A")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Other User Cannot See Ticket", func(t *testing.T) {
+		ticketUsecase, mockEventRepo := newUsecase()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+
+		ticket, err := ticketUsecase.GetTicketByCode(ctx, 9, "TKT-A")
+
+		assert.Nil(t, ticket)
+		assert.Error(t, err)
+		assert.Equal(t, "anda tidak memiliki izin untuk melihat tiket ini", err.Error())
+	})
+
+	t.Run("Ticket Not Found", func(t *testing.T) {
+		ticketUsecase, _ := newUsecase()
+
+		_, err := ticketUsecase.GetTicketByCode(ctx, buyerID, "TKT-TIDAK-ADA")
+
+		assert.Error(t, err)
+		assert.Equal(t, "tiket tidak ditemukan", err.Error())
+	})
+}
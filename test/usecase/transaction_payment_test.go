@@ -0,0 +1,150 @@
+//test/usecase/transaction_payment_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/gateway"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func TestCreateTransactionWithMidtrans(t *testing.T) {
+	ctx := context.Background()
+
+	user := &entity.User{
+		ID:       1,
+		Username: "testuser",
+		Email:    "user@example.com",
+		Role:     "user",
+	}
+
+	event := &entity.Event{
+		ID:          1,
+		Title:       "Konser Musik",
+		EventDate:   time.Now().Add(24 * time.Hour),
+		MaxCapacity: 1000,
+		TicketsSold: 500,
+		Price:       entity.IDR(250000),
+		Status:      "active",
+	}
+
+	req := usecase.CreateTransactionRequest{
+		EventID:       event.ID,
+		Quantity:      2,
+		PaymentMethod: "midtrans",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		mockPaymentGateway := new(mocks.MockPaymentGateway)
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, mockPaymentGateway, &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "30", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockUserRepo.On("FindByID", ctx, user.ID).Return(user, nil).Once()
+		mockEventRepo.On("FindByID", ctx, event.ID).Return(event, nil).Once()
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(7, nil).Once()
+		mockPaymentGateway.On("CreatePayment", ctx, mock.MatchedBy(func(p gateway.PaymentRequest) bool {
+			return p.GrossAmount == 500000 &&
+				p.ExpiryMinutes == 30 &&
+				len(p.Items) == 1 && p.Items[0].Price == 250000 && p.Items[0].Quantity == 2 &&
+				p.Customer.Email == user.Email
+		})).Return(&gateway.PaymentSession{
+			Token:       "snap-token-123",
+			RedirectURL: "https://app.sandbox.midtrans.com/snap/v2/vtweb/snap-token-123",
+		}, nil).Once()
+		mockPaymentRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.Payment) bool {
+			return p.TransactionID == 7 && p.Status == "pending" && p.Amount.Equal(entity.IDR(500000)) && p.SnapToken == "snap-token-123"
+		})).Return(3, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, user.ID, req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		assert.Equal(t, 7, response.ID)
+		assert.Equal(t, "midtrans", response.PaymentMethod)
+		assert.Equal(t, "snap-token-123", response.SnapToken)
+		assert.Equal(t, "https://app.sandbox.midtrans.com/snap/v2/vtweb/snap-token-123", response.RedirectURL)
+
+		mockTransactionRepo.AssertExpectations(t)
+		mockPaymentGateway.AssertExpectations(t)
+		mockPaymentRepo.AssertExpectations(t)
+	})
+
+	t.Run("Gateway Error Releases Seats", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		mockPaymentGateway := new(mocks.MockPaymentGateway)
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, mockPaymentGateway, &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockUserRepo.On("FindByID", ctx, user.ID).Return(user, nil).Once()
+		mockEventRepo.On("FindByID", ctx, event.ID).Return(event, nil).Once()
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(8, nil).Once()
+		mockPaymentGateway.On("CreatePayment", ctx, mock.AnythingOfType("gateway.PaymentRequest")).Return(nil, errors.New("midtrans menolak transaksi (HTTP 401)")).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 8, entity.TransactionStatusPending, entity.TransactionStatusFailed).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, event.ID, -req.Quantity).Return(nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, user.ID, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, "gagal membuat pembayaran, silakan coba lagi", err.Error())
+
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+		mockPaymentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetTransactionByIDWithMidtrans(t *testing.T) {
+	ctx := context.Background()
+
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockEventRepo := new(mocks.MockEventRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, mockPaymentRepo, &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+	transaction := &entity.Transaction{
+		ID:              7,
+		UserID:          1,
+		EventID:         1,
+		TransactionCode: "TRX-20250101-123456",
+		Quantity:        2,
+		TotalAmount:     entity.IDR(500000),
+		Status:          "pending",
+		PaymentMethod:   "midtrans",
+	}
+
+	mockTransactionRepo.On("FindByID", ctx, transaction.ID).Return(transaction, nil).Once()
+	mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(&entity.Event{ID: 1, Title: "Konser Musik"}, nil).Once()
+	mockPaymentRepo.On("FindByTransactionID", ctx, transaction.ID).Return(&entity.Payment{
+		TransactionID: transaction.ID,
+		SnapToken:     "snap-token-123",
+		RedirectURL:   "https://app.sandbox.midtrans.com/snap/v2/vtweb/snap-token-123",
+	}, nil).Once()
+
+	response, err := transactionUsecase.GetTransactionByID(ctx, 1, transaction.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "snap-token-123", response.SnapToken)
+	assert.Equal(t, "https://app.sandbox.midtrans.com/snap/v2/vtweb/snap-token-123", response.RedirectURL)
+
+	mockPaymentRepo.AssertExpectations(t)
+}
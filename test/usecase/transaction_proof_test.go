@@ -0,0 +1,186 @@
+//test/usecase/transaction_proof_test.go
+
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/storage"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+// pngProof cukup berisi signature PNG agar dikenali sebagai image/png
+var pngProof = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+func newProofTransaction() *entity.Transaction {
+	return &entity.Transaction{
+		ID:              1,
+		UserID:          1,
+		EventID:         2,
+		TransactionCode: "TRX-20230101-123456",
+		Quantity:        2,
+		TotalAmount:     entity.IDR(500000),
+		Status:          entity.TransactionStatusPending,
+		PaymentMethod:   "bank_transfer",
+	}
+}
+
+func TestUploadPaymentProofFile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Stores File In Blob Storage", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		blobStorage := &mocks.FakeBlobStorage{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), blobStorage, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		pdfProof := []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+
+		var storedKey string
+		mockTransactionRepo.On("FindByID", ctx, 1).Return(newProofTransaction(), nil).Once()
+		mockTransactionRepo.On("UpdatePaymentProof", ctx, 1, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+			storedKey = args.String(2)
+		}).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 1, entity.TransactionStatusPending, entity.TransactionStatusWaitingVerification).Return(nil).Once()
+
+		err := transactionUsecase.UploadPaymentProof(ctx, 1, usecase.UploadPaymentProofRequest{
+			TransactionID: "1",
+			FileName:      "bukti.jpg", // nama file tidak menentukan tipe
+			Content:       pdfProof,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(storedKey, "payment-proofs/1/"))
+		assert.True(t, strings.HasSuffix(storedKey, ".pdf"))
+		assert.Equal(t, pdfProof, blobStorage.Objects[storedKey])
+		assert.Equal(t, "application/pdf", blobStorage.ContentTypes[storedKey])
+		mockTransactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects Unsupported File Type", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		blobStorage := &mocks.FakeBlobStorage{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), blobStorage, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 1).Return(newProofTransaction(), nil).Once()
+
+		err := transactionUsecase.UploadPaymentProof(ctx, 1, usecase.UploadPaymentProofRequest{
+			TransactionID: "1",
+			FileName:      "bukti.png",
+			Content:       []byte("<html><script>alert(1)</script></html>"),
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, "tipe file bukti pembayaran harus JPG, PNG, atau PDF", err.Error())
+		assert.Empty(t, blobStorage.Keys())
+	})
+
+	t.Run("Rejects File Over Size Limit", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		blobStorage := &mocks.FakeBlobStorage{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), blobStorage, &mocks.FakeVirusScanner{}, "60", "3", "1", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 1).Return(newProofTransaction(), nil).Once()
+
+		err := transactionUsecase.UploadPaymentProof(ctx, 1, usecase.UploadPaymentProofRequest{
+			TransactionID: "1",
+			FileName:      "bukti.png",
+			Content:       append(pngProof, bytes.Repeat([]byte{0}, 1024)...),
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, "ukuran file bukti pembayaran melebihi batas", err.Error())
+		assert.Empty(t, blobStorage.Keys())
+	})
+
+	t.Run("Rejects Infected File", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		blobStorage := &mocks.FakeBlobStorage{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), blobStorage, &mocks.FakeVirusScanner{Err: storage.ErrInfectedFile}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 1).Return(newProofTransaction(), nil).Once()
+
+		err := transactionUsecase.UploadPaymentProof(ctx, 1, usecase.UploadPaymentProofRequest{
+			TransactionID: "1",
+			FileName:      "bukti.png",
+			Content:       pngProof,
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, "file bukti pembayaran terdeteksi mengandung virus", err.Error())
+		assert.Empty(t, blobStorage.Keys())
+	})
+
+	t.Run("Removes File When Status Update Fails", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		blobStorage := &mocks.FakeBlobStorage{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), blobStorage, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 1).Return(newProofTransaction(), nil).Once()
+		mockTransactionRepo.On("UpdatePaymentProof", ctx, 1, mock.AnythingOfType("string")).Return(errors.New("database error")).Once()
+
+		err := transactionUsecase.UploadPaymentProof(ctx, 1, usecase.UploadPaymentProofRequest{
+			TransactionID: "1",
+			FileName:      "bukti.png",
+			Content:       pngProof,
+		})
+
+		assert.Error(t, err)
+		assert.Empty(t, blobStorage.Keys())
+	})
+}
+
+func TestPaymentProofSignedURL(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 5
+
+	transaction := newProofTransaction()
+	transaction.Status = entity.TransactionStatusWaitingVerification
+	transaction.PaymentProof = "payment-proofs/1/1700000000.png"
+
+	event := &entity.Event{ID: 2, Title: "Konser Musik", OwnerID: organizerID}
+
+	t.Run("Event Owner Gets Signed URL", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, transaction.ID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
+
+		response, err := transactionUsecase.GetTransactionByID(ctx, organizerID, transaction.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://storage.test/payment-proofs/1/1700000000.png?ttl=15m0s", response.PaymentProofURL)
+	})
+
+	t.Run("Buyer Does Not Get Signed URL", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByCode", ctx, transaction.TransactionCode).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
+
+		response, err := transactionUsecase.GetTransactionByCode(ctx, transaction.UserID, transaction.TransactionCode)
+
+		assert.NoError(t, err)
+		assert.Empty(t, response.PaymentProofURL)
+	})
+}
@@ -0,0 +1,78 @@
+//test/usecase/transaction_reservation_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+// Perlindungan oversell sesungguhnya ada di UPDATE kondisional CreateWithReservation yang diuji
+// di test/repository. Test ini memastikan usecase membatalkan seluruh transaksi database saat
+// reservasi itu menolak, sehingga tidak ada item, redemption promo, maupun riwayat status yang tersisa.
+func TestCreateTransactionRollsBackWhenSoldOut(t *testing.T) {
+	ctx := context.Background()
+	userID := 2
+	eventID := 7
+
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockEventRepo := new(mocks.MockEventRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	paymentGateway := new(mocks.MockPaymentGateway)
+
+	mockUserRepo.On("FindByID", ctx, userID).Return(&entity.User{ID: userID, Role: "user"}, nil)
+	mockEventRepo.On("FindByID", ctx, eventID).Return(&entity.Event{
+		ID:          eventID,
+		OwnerID:     1,
+		Title:       "Konser Kecil",
+		EventDate:   time.Now().Add(24 * time.Hour),
+		MaxCapacity: 10,
+		TicketsSold: 9,
+		Price:       entity.IDR(100000),
+		Status:      "active",
+	}, nil)
+	// Pengecekan awal masih melihat satu kursi tersisa, pembeli lain mengambilnya sebelum reservasi
+	mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(0, repository.ErrTicketSoldOut).Once()
+
+	historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+	itemRepo := &mocks.FakeTransactionItemRepository{}
+	promoRepo := &mocks.FakePromoCodeRepository{
+		PromoCodes: []entity.PromoCode{
+			{ID: 1, OwnerID: 1, EventID: eventID, Code: "HEMAT", DiscountType: entity.PromoDiscountFixed, DiscountAmount: entity.IDR(10000), MinQuantity: 1, IsActive: true},
+		},
+	}
+	txManager := &mocks.FakeTxManager{}
+
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, itemRepo, promoRepo, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, txManager, paymentGateway, &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+	response, err := transactionUsecase.CreateTransaction(ctx, userID, usecase.CreateTransactionRequest{
+		EventID:       eventID,
+		Quantity:      1,
+		PromoCode:     "HEMAT",
+		PaymentMethod: "midtrans",
+	})
+
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, repository.ErrTicketSoldOut)
+
+	assert.Equal(t, 1, txManager.RolledBack)
+	assert.Equal(t, 0, txManager.Committed)
+	assert.Empty(t, historyRepo.Histories)
+	assert.Empty(t, itemRepo.Items)
+	assert.Empty(t, promoRepo.Redemptions)
+	assert.Equal(t, 0, promoRepo.PromoCodes[0].UsedCount)
+
+	// Pembayaran Midtrans baru dibuat setelah transaksi tersimpan
+	paymentGateway.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertExpectations(t)
+}
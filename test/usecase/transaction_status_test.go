@@ -0,0 +1,169 @@
+//test/usecase/transaction_status_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/gateway/midtrans"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func TestTransactionStatusHistory(t *testing.T) {
+	ctx := context.Background()
+
+	newTransaction := func(status entity.TransactionStatus) *entity.Transaction {
+		return &entity.Transaction{
+			ID:              5,
+			UserID:          1,
+			EventID:         2,
+			TransactionCode: "TRX-20250101-000005",
+			Quantity:        1,
+			TotalAmount:     entity.IDR(250000),
+			Status:          status,
+			PaymentMethod:   "bank_transfer",
+		}
+	}
+
+	t.Run("Cancel Records History", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 5).Return(newTransaction(entity.TransactionStatusPending), nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 5, entity.TransactionStatusPending, entity.TransactionStatusCancelled).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 2, -1).Return(nil).Once()
+
+		err := transactionUsecase.CancelTransaction(ctx, 1, 5)
+
+		require.NoError(t, err)
+		history := historyRepo.Last()
+		require.NotNil(t, history)
+		assert.Equal(t, 5, history.TransactionID)
+		assert.Equal(t, entity.TransactionStatusPending, history.FromStatus)
+		assert.Equal(t, entity.TransactionStatusCancelled, history.ToStatus)
+		assert.Equal(t, entity.StatusActorUser, history.ActorType)
+		assert.Equal(t, 1, history.ActorID)
+		assert.NotEmpty(t, history.Reason)
+	})
+
+	t.Run("Cancel Of Cancelled Transaction Rejected", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 5).Return(newTransaction(entity.TransactionStatusCancelled), nil).Once()
+
+		err := transactionUsecase.CancelTransaction(ctx, 1, 5)
+
+		assert.Error(t, err)
+		assert.Equal(t, "hanya transaksi dengan status pending yang dapat dibatalkan", err.Error())
+		assert.Nil(t, historyRepo.Last())
+	})
+
+	t.Run("Concurrent Change Returns Conflict", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 5).Return(newTransaction(entity.TransactionStatusPending), nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 5, entity.TransactionStatusPending, entity.TransactionStatusCancelled).Return(repository.ErrStatusConflict).Once()
+
+		err := transactionUsecase.CancelTransaction(ctx, 1, 5)
+
+		assert.ErrorIs(t, err, repository.ErrStatusConflict)
+		assert.Nil(t, historyRepo.Last())
+		mockEventRepo.AssertNotCalled(t, "UpdateTicketsSold", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Get By ID Exposes History", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{
+			Histories: []entity.TransactionStatusHistory{
+				{ID: 1, TransactionID: 5, ToStatus: entity.TransactionStatusPending, ActorType: entity.StatusActorUser, ActorID: 1, CreatedAt: time.Now().Add(-time.Hour)},
+				{ID: 2, TransactionID: 5, FromStatus: entity.TransactionStatusPending, ToStatus: entity.TransactionStatusWaitingVerification, ActorType: entity.StatusActorUser, ActorID: 1, CreatedAt: time.Now()},
+				{ID: 3, TransactionID: 6, ToStatus: entity.TransactionStatusPending, ActorType: entity.StatusActorUser, ActorID: 9},
+			},
+		}
+
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+		mockTransactionRepo.On("FindByID", ctx, 5).Return(newTransaction(entity.TransactionStatusWaitingVerification), nil).Once()
+		mockEventRepo.On("FindByID", ctx, 2).Return(&entity.Event{ID: 2, Title: "Konser Musik"}, nil).Once()
+
+		response, err := transactionUsecase.GetTransactionByID(ctx, 1, 5)
+
+		require.NoError(t, err)
+		require.Len(t, response.StatusHistory, 2)
+		assert.Equal(t, "", response.StatusHistory[0].FromStatus)
+		assert.Equal(t, "pending", response.StatusHistory[0].ToStatus)
+		assert.Equal(t, "pending", response.StatusHistory[1].FromStatus)
+		assert.Equal(t, "waiting_verification", response.StatusHistory[1].ToStatus)
+	})
+
+	t.Run("Gateway Cannot Revive Cancelled Transaction", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+
+		paymentUsecase := usecase.NewPaymentUsecase(mockTransactionRepo, mockEventRepo, mockPaymentRepo, historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeRefundRepository{}, &mocks.FakeTxManager{}, midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test"}))
+
+		transaction := newTransaction(entity.TransactionStatusCancelled)
+		transaction.TransactionCode = fixtureOrderID
+		transaction.TotalAmount = entity.IDR(500000)
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 5).Return(&entity.Payment{ID: 1, TransactionID: 5}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement"))
+
+		assert.NoError(t, err)
+		assert.Nil(t, historyRepo.Last())
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Gateway Payment Recorded As Gateway Actor", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockPaymentRepo := new(mocks.MockPaymentRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+
+		paymentUsecase := usecase.NewPaymentUsecase(mockTransactionRepo, new(mocks.MockEventRepository), mockPaymentRepo, historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeRefundRepository{}, &mocks.FakeTxManager{}, midtrans.NewClient(midtrans.Config{ServerKey: "SB-Mid-server-test"}))
+
+		transaction := newTransaction(entity.TransactionStatusPending)
+		transaction.TransactionCode = fixtureOrderID
+		transaction.TotalAmount = entity.IDR(500000)
+
+		mockTransactionRepo.On("FindByCodeForUpdate", ctx, fixtureOrderID).Return(transaction, nil).Once()
+		mockPaymentRepo.On("FindByTransactionID", ctx, 5).Return(&entity.Payment{ID: 1, TransactionID: 5}, nil).Once()
+		mockPaymentRepo.On("UpdateFromNotification", ctx, mock.AnythingOfType("*entity.Payment")).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, 5, entity.TransactionStatusPending, entity.TransactionStatusPaid).Return(nil).Once()
+
+		err := paymentUsecase.HandleNotification(ctx, loadMidtransFixture(t, "settlement"))
+
+		require.NoError(t, err)
+		history := historyRepo.Last()
+		require.NotNil(t, history)
+		assert.Equal(t, entity.TransactionStatusPaid, history.ToStatus)
+		assert.Equal(t, entity.StatusActorPaymentGateway, history.ActorType)
+		assert.Equal(t, 0, history.ActorID)
+		assert.Contains(t, history.Reason, "settlement")
+	})
+}
@@ -5,6 +5,7 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
 	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
 	"ticket-system/test/mocks"
 )
 
@@ -21,7 +24,7 @@ func TestCreateTransaction(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -43,7 +46,7 @@ func TestCreateTransaction(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -55,8 +58,7 @@ func TestCreateTransaction(t *testing.T) {
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
 		mockEventRepo.On("FindByID", ctx, eventID).Return(event, nil).Once()
-		mockTransactionRepo.On("Create", ctx, mock.AnythingOfType("*entity.Transaction")).Return(1, nil).Once()
-		mockEventRepo.On("UpdateTicketsSold", ctx, eventID, 2).Return(nil).Once()
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(1, nil).Once()
 		
 		response, err := transactionUsecase.CreateTransaction(ctx, userID, req)
 		
@@ -66,11 +68,12 @@ func TestCreateTransaction(t *testing.T) {
 		assert.Equal(t, eventID, response.EventID)
 		assert.Equal(t, event.Title, response.EventTitle)
 		assert.Equal(t, req.Quantity, response.Quantity)
-		assert.Equal(t, float64(req.Quantity)*event.Price, response.TotalAmount)
+		assert.Equal(t, event.Price.Mul(int64(req.Quantity)), response.TotalAmount)
 		assert.Equal(t, "pending", response.Status)
 		assert.Equal(t, req.PaymentMethod, response.PaymentMethod)
 		assert.NotEmpty(t, response.TransactionCode)
 		assert.NotEmpty(t, response.PaymentDetail)
+		assert.WithinDuration(t, time.Now().Add(60*time.Minute), response.ExpiresAt, 5*time.Second)
 		
 		mockUserRepo.AssertExpectations(t)
 		mockEventRepo.AssertExpectations(t)
@@ -146,7 +149,7 @@ func TestCreateTransaction(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "cancelled",
 		}
 		
@@ -188,7 +191,7 @@ func TestCreateTransaction(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 999,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -230,7 +233,7 @@ func TestCreateTransaction(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -272,7 +275,7 @@ func TestCreateTransaction(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -314,7 +317,7 @@ func TestCreateTransaction(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -326,7 +329,7 @@ func TestCreateTransaction(t *testing.T) {
 		
 		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
 		mockEventRepo.On("FindByID", ctx, eventID).Return(event, nil).Once()
-		mockTransactionRepo.On("Create", ctx, mock.AnythingOfType("*entity.Transaction")).Return(0, errors.New("database error")).Once()
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(0, errors.New("database error")).Once()
 		
 		response, err := transactionUsecase.CreateTransaction(ctx, userID, req)
 		
@@ -338,6 +341,50 @@ func TestCreateTransaction(t *testing.T) {
 		mockEventRepo.AssertExpectations(t)
 		mockTransactionRepo.AssertExpectations(t)
 	})
+	
+	t.Run("Sold Out During Reservation", func(t *testing.T) {
+		userID := 1
+		eventID := 1
+		
+		user := &entity.User{
+			ID:       userID,
+			Username: "testuser",
+			Email:    "user@example.com",
+			Role:     "user",
+		}
+		
+		event := &entity.Event{
+			ID:          eventID,
+			Title:       "Konser Musik",
+			Description: "Konser musik tahunan",
+			Location:    "Jakarta Convention Center",
+			EventDate:   time.Now().Add(24 * time.Hour),
+			MaxCapacity: 1000,
+			TicketsSold: 998,
+			Price:       entity.IDR(250000),
+			Status:      "active",
+		}
+		
+		req := usecase.CreateTransactionRequest{
+			EventID:       eventID,
+			Quantity:      2,
+			PaymentMethod: "bank_transfer",
+		}
+		
+		mockUserRepo.On("FindByID", ctx, userID).Return(user, nil).Once()
+		mockEventRepo.On("FindByID", ctx, eventID).Return(event, nil).Once()
+		mockTransactionRepo.On("CreateWithReservation", ctx, mock.AnythingOfType("*entity.Transaction")).Return(0, repository.ErrTicketSoldOut).Once()
+		
+		response, err := transactionUsecase.CreateTransaction(ctx, userID, req)
+		
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, repository.ErrTicketSoldOut)
+		assert.Equal(t, "jumlah tiket yang diminta melebihi kapasitas", err.Error())
+		
+		mockUserRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertExpectations(t)
+	})
 }
 
 func TestGetTransactionByID(t *testing.T) {
@@ -345,7 +392,7 @@ func TestGetTransactionByID(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
 	ctx := context.Background()
 	
 	t.Run("Success - Owner", func(t *testing.T) {
@@ -358,7 +405,7 @@ func TestGetTransactionByID(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -374,7 +421,7 @@ func TestGetTransactionByID(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
 		}
 		
@@ -404,7 +451,7 @@ func TestGetTransactionByID(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -412,13 +459,6 @@ func TestGetTransactionByID(t *testing.T) {
 			UpdatedAt:       time.Now(),
 		}
 		
-		organizer := &entity.User{
-			ID:       userID,
-			Username: "organizer",
-			Email:    "organizer@example.com",
-			Role:     "organizer",
-		}
-		
 		event := &entity.Event{
 			ID:          2,
 			Title:       "Konser Musik",
@@ -427,12 +467,12 @@ func TestGetTransactionByID(t *testing.T) {
 			EventDate:   time.Now().Add(24 * time.Hour),
 			MaxCapacity: 1000,
 			TicketsSold: 500,
-			Price:       250000,
+			Price:       entity.IDR(250000),
 			Status:      "active",
+			OwnerID:     userID,
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
-		mockUserRepo.On("FindByID", ctx, userID).Return(organizer, nil).Once()
 		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
 		
 		response, err := transactionUsecase.GetTransactionByID(ctx, userID, transactionID)
@@ -445,7 +485,6 @@ func TestGetTransactionByID(t *testing.T) {
 		assert.Equal(t, event.Title, response.EventTitle)
 		
 		mockTransactionRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
 		mockEventRepo.AssertExpectations(t)
 	})
 	
@@ -474,7 +513,7 @@ func TestGetTransactionByID(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -482,15 +521,15 @@ func TestGetTransactionByID(t *testing.T) {
 			UpdatedAt:       time.Now(),
 		}
 		
-		regularUser := &entity.User{
-			ID:       userID,
-			Username: "regularuser",
-			Email:    "user@example.com",
-			Role:     "user",
+		event := &entity.Event{
+			ID:      2,
+			Title:   "Konser Musik",
+			Status:  "active",
+			OwnerID: 3,
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
-		mockUserRepo.On("FindByID", ctx, userID).Return(regularUser, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
 		
 		response, err := transactionUsecase.GetTransactionByID(ctx, userID, transactionID)
 		
@@ -499,7 +538,45 @@ func TestGetTransactionByID(t *testing.T) {
 		assert.Equal(t, "anda tidak memiliki izin untuk melihat transaksi ini", err.Error())
 		
 		mockTransactionRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+	
+t.Run("Organizer Of Another Event", func(t *testing.T) {
+		otherOrganizerID := 4
+		transactionID := 1
+		
+		transaction := &entity.Transaction{
+			ID:              transactionID,
+			UserID:          1,
+			EventID:         2,
+			TransactionCode: "TRX-20230101-123456",
+			Quantity:        2,
+			TotalAmount:     entity.IDR(500000),
+			Status:          "pending",
+			PaymentMethod:   "bank_transfer",
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+		
+		event := &entity.Event{
+			ID:      2,
+			Title:   "Konser Musik",
+			Status:  "active",
+			OwnerID: 3,
+		}
+		
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
+		
+		response, err := transactionUsecase.GetTransactionByID(ctx, otherOrganizerID, transactionID)
+		
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, "anda tidak memiliki izin untuk melihat transaksi ini", err.Error())
+		
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "FindByID", ctx, otherOrganizerID)
 	})
 	
 	t.Run("Event Not Found", func(t *testing.T) {
@@ -512,7 +589,7 @@ func TestGetTransactionByID(t *testing.T) {
 			EventID:         99,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -539,13 +616,12 @@ func TestUploadPaymentProof(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
 		userID := 1
 		transactionID := 1
-		proofURL := "https://example.com/proof.jpg"
 		
 		transaction := &entity.Transaction{
 			ID:              transactionID,
@@ -553,7 +629,7 @@ func TestUploadPaymentProof(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -563,12 +639,15 @@ func TestUploadPaymentProof(t *testing.T) {
 		
 		req := usecase.UploadPaymentProofRequest{
 			TransactionID: "1",
-			ProofURL:      proofURL,
+			FileName:      "bukti.png",
+			Content:       pngProof,
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
-		mockTransactionRepo.On("UpdatePaymentProof", ctx, transactionID, proofURL).Return(nil).Once()
-		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, "waiting_verification").Return(nil).Once()
+		mockTransactionRepo.On("UpdatePaymentProof", ctx, transactionID, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "payment-proofs/1/") && strings.HasSuffix(key, ".png")
+		})).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, entity.TransactionStatusPending, entity.TransactionStatusWaitingVerification).Return(nil).Once()
 		
 		err := transactionUsecase.UploadPaymentProof(ctx, userID, req)
 		
@@ -578,11 +657,11 @@ func TestUploadPaymentProof(t *testing.T) {
 	
 	t.Run("Invalid Transaction ID", func(t *testing.T) {
 		userID := 1
-		proofURL := "https://example.com/proof.jpg"
 		
 		req := usecase.UploadPaymentProofRequest{
 			TransactionID: "invalid",
-			ProofURL:      proofURL,
+			FileName:      "bukti.png",
+			Content:       pngProof,
 		}
 		
 		err := transactionUsecase.UploadPaymentProof(ctx, userID, req)
@@ -594,11 +673,11 @@ func TestUploadPaymentProof(t *testing.T) {
 	t.Run("Transaction Not Found", func(t *testing.T) {
 		userID := 1
 		transactionID := 99
-		proofURL := "https://example.com/proof.jpg"
 		
 		req := usecase.UploadPaymentProofRequest{
 			TransactionID: "99",
-			ProofURL:      proofURL,
+			FileName:      "bukti.png",
+			Content:       pngProof,
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(nil, nil).Once()
@@ -613,7 +692,6 @@ func TestUploadPaymentProof(t *testing.T) {
 	t.Run("Unauthorized Access", func(t *testing.T) {
 		userID := 2
 		transactionID := 1
-		proofURL := "https://example.com/proof.jpg"
 		
 		transaction := &entity.Transaction{
 			ID:              transactionID,
@@ -621,7 +699,7 @@ func TestUploadPaymentProof(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -631,7 +709,8 @@ func TestUploadPaymentProof(t *testing.T) {
 		
 		req := usecase.UploadPaymentProofRequest{
 			TransactionID: "1",
-			ProofURL:      proofURL,
+			FileName:      "bukti.png",
+			Content:       pngProof,
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
@@ -646,7 +725,6 @@ func TestUploadPaymentProof(t *testing.T) {
 	t.Run("Invalid Status", func(t *testing.T) {
 		userID := 1
 		transactionID := 1
-		proofURL := "https://example.com/proof.jpg"
 		
 		transaction := &entity.Transaction{
 			ID:              transactionID,
@@ -654,7 +732,7 @@ func TestUploadPaymentProof(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "waiting_verification",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -664,7 +742,8 @@ func TestUploadPaymentProof(t *testing.T) {
 		
 		req := usecase.UploadPaymentProofRequest{
 			TransactionID: "1",
-			ProofURL:      proofURL,
+			FileName:      "bukti.png",
+			Content:       pngProof,
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
@@ -676,7 +755,7 @@ func TestUploadPaymentProof(t *testing.T) {
 		mockTransactionRepo.AssertExpectations(t)
 	})
 	
-	t.Run("Empty Proof URL", func(t *testing.T) {
+	t.Run("Empty Proof File", func(t *testing.T) {
 		userID := 1
 		transactionID := 1
 		
@@ -686,7 +765,7 @@ func TestUploadPaymentProof(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -696,7 +775,7 @@ func TestUploadPaymentProof(t *testing.T) {
 		
 		req := usecase.UploadPaymentProofRequest{
 			TransactionID: "1",
-			ProofURL:      "",
+			FileName:      "bukti.png",
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
@@ -704,7 +783,7 @@ func TestUploadPaymentProof(t *testing.T) {
 		err := transactionUsecase.UploadPaymentProof(ctx, userID, req)
 		
 		assert.Error(t, err)
-		assert.Equal(t, "URL bukti pembayaran tidak boleh kosong", err.Error())
+		assert.Equal(t, "file bukti pembayaran tidak boleh kosong", err.Error())
 		mockTransactionRepo.AssertExpectations(t)
 	})
 }
@@ -714,9 +793,11 @@ func TestVerifyPayment(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
 	ctx := context.Background()
 	
+	event := &entity.Event{ID: 3, Title: "Konser Musik", Status: "active", OwnerID: 1}
+	
 	t.Run("Success", func(t *testing.T) {
 		organizerID := 1
 		transactionID := 1
@@ -734,7 +815,7 @@ func TestVerifyPayment(t *testing.T) {
 			EventID:         3,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "waiting_verification",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -745,6 +826,7 @@ func TestVerifyPayment(t *testing.T) {
 		
 		mockUserRepo.On("FindByID", ctx, organizerID).Return(organizer, nil).Once()
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
 		mockTransactionRepo.On("VerifyPayment", ctx, transactionID, organizerID).Return(nil).Once()
 		
 		err := transactionUsecase.VerifyPayment(ctx, organizerID, transactionID)
@@ -752,6 +834,7 @@ func TestVerifyPayment(t *testing.T) {
 		assert.NoError(t, err)
 		mockUserRepo.AssertExpectations(t)
 		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
 	})
 	
 	t.Run("Not an Organizer", func(t *testing.T) {
@@ -794,6 +877,7 @@ func TestVerifyPayment(t *testing.T) {
 		assert.Equal(t, "transaksi tidak ditemukan", err.Error())
 		mockUserRepo.AssertExpectations(t)
 		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
 	})
 	
 	t.Run("Invalid Status", func(t *testing.T) {
@@ -813,7 +897,7 @@ func TestVerifyPayment(t *testing.T) {
 			EventID:         3,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending", // Not waiting_verification
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -824,6 +908,7 @@ func TestVerifyPayment(t *testing.T) {
 		
 		mockUserRepo.On("FindByID", ctx, organizerID).Return(organizer, nil).Once()
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
 		
 		err := transactionUsecase.VerifyPayment(ctx, organizerID, transactionID)
 		
@@ -831,6 +916,222 @@ func TestVerifyPayment(t *testing.T) {
 		assert.Equal(t, "hanya transaksi dengan status menunggu verifikasi yang dapat diverifikasi", err.Error())
 		mockUserRepo.AssertExpectations(t)
 		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+	
+	t.Run("Organizer Of Another Event", func(t *testing.T) {
+		otherOrganizerID := 4
+		transactionID := 1
+		
+		otherOrganizer := &entity.User{
+			ID:       otherOrganizerID,
+			Username: "organizer_lain",
+			Email:    "organizer.lain@example.com",
+			Role:     "organizer",
+		}
+		
+		transaction := &entity.Transaction{
+			ID:              transactionID,
+			UserID:          2,
+			EventID:         3,
+			TransactionCode: "TRX-20230101-123456",
+			Quantity:        2,
+			TotalAmount:     entity.IDR(500000),
+			Status:          "waiting_verification",
+			PaymentMethod:   "bank_transfer",
+			PaymentProof:    "https://example.com/proof.jpg",
+		}
+		
+		mockUserRepo.On("FindByID", ctx, otherOrganizerID).Return(otherOrganizer, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, transaction.EventID).Return(event, nil).Once()
+		
+		err := transactionUsecase.VerifyPayment(ctx, otherOrganizerID, transactionID)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "anda tidak memiliki izin untuk memverifikasi pembayaran transaksi ini", err.Error())
+		mockTransactionRepo.AssertNotCalled(t, "VerifyPayment", ctx, transactionID, otherOrganizerID)
+	})
+}
+
+func TestRejectPayment(t *testing.T) {
+	ctx := context.Background()
+	organizerID := 1
+	buyerID := 2
+	transactionID := 1
+	
+	organizer := &entity.User{ID: organizerID, Username: "organizer", Email: "organizer@example.com", Role: "organizer"}
+	buyer := &entity.User{ID: buyerID, Username: "testuser", Email: "user@example.com", Role: "user"}
+	
+	newTransaction := func() *entity.Transaction {
+		return &entity.Transaction{
+			ID:              transactionID,
+			UserID:          buyerID,
+			EventID:         3,
+			TransactionCode: "TRX-20230101-123456",
+			Quantity:        2,
+			TotalAmount:     entity.IDR(500000),
+			Status:          entity.TransactionStatusWaitingVerification,
+			PaymentMethod:   "bank_transfer",
+			PaymentProof:    "https://example.com/proof.jpg",
+			ExpiresAt:       time.Now().Add(-time.Minute),
+		}
+	}
+	
+	event := &entity.Event{ID: 3, Title: "Konser Musik", Status: "active", OwnerID: organizerID}
+	
+	req := usecase.RejectPaymentRequest{Reason: "Nominal transfer tidak sesuai"}
+	
+	t.Run("Success - Returned To Pending", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+		
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		mockUserRepo.On("FindByID", ctx, organizerID).Return(organizer, nil).Once()
+		mockUserRepo.On("FindByID", ctx, buyerID).Return(buyer, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(newTransaction(), nil).Once()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, entity.TransactionStatusWaitingVerification, entity.TransactionStatusPending).Return(nil).Once()
+		mockTransactionRepo.On("UpdateExpiresAt", ctx, transactionID, mock.MatchedBy(func(expiresAt time.Time) bool {
+			return expiresAt.After(time.Now().Add(59 * time.Minute))
+		})).Return(nil).Once()
+		
+		err := transactionUsecase.RejectPayment(ctx, organizerID, transactionID, req)
+		
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertExpectations(t)
+		
+		history := historyRepo.Last()
+		assert.NotNil(t, history)
+		assert.Equal(t, entity.TransactionStatusPending, history.ToStatus)
+		assert.Equal(t, entity.StatusActorOrganizer, history.ActorType)
+		assert.Equal(t, organizerID, history.ActorID)
+		assert.Equal(t, req.Reason, history.Reason)
+	})
+	
+	t.Run("Success - Rejected After Max Attempts", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{
+			Histories: []entity.TransactionStatusHistory{
+				{TransactionID: transactionID, FromStatus: entity.TransactionStatusWaitingVerification, ToStatus: entity.TransactionStatusPending},
+				{TransactionID: transactionID, FromStatus: entity.TransactionStatusWaitingVerification, ToStatus: entity.TransactionStatusPending},
+			},
+		}
+		
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		mockUserRepo.On("FindByID", ctx, organizerID).Return(organizer, nil).Once()
+		mockUserRepo.On("FindByID", ctx, buyerID).Return(buyer, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(newTransaction(), nil).Once()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, entity.TransactionStatusWaitingVerification, entity.TransactionStatusRejected).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 3, -2).Return(nil).Once()
+		
+		err := transactionUsecase.RejectPayment(ctx, organizerID, transactionID, req)
+		
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertNotCalled(t, "UpdateExpiresAt", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, entity.TransactionStatusRejected, historyRepo.Last().ToStatus)
+	})
+	
+	t.Run("Resubmit After Rejection", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+		
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		transaction := newTransaction()
+		transaction.Status = entity.TransactionStatusPending
+		
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
+		mockTransactionRepo.On("UpdatePaymentProof", ctx, transactionID, mock.AnythingOfType("string")).Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, entity.TransactionStatusPending, entity.TransactionStatusWaitingVerification).Return(nil).Once()
+		
+		err := transactionUsecase.UploadPaymentProof(ctx, buyerID, usecase.UploadPaymentProofRequest{
+			TransactionID: "1",
+			FileName:      "bukti-baru.png",
+			Content:       pngProof,
+		})
+		
+		assert.NoError(t, err)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+	
+	t.Run("Not Organizer", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		
+		transactionUsecase := usecase.NewTransactionUsecase(new(mocks.MockTransactionRepository), new(mocks.MockEventRepository), mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		mockUserRepo.On("FindByID", ctx, buyerID).Return(buyer, nil).Once()
+		
+		err := transactionUsecase.RejectPayment(ctx, buyerID, transactionID, req)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "hanya organizer yang dapat menolak pembayaran", err.Error())
+	})
+	
+	t.Run("Empty Reason", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		
+		transactionUsecase := usecase.NewTransactionUsecase(new(mocks.MockTransactionRepository), new(mocks.MockEventRepository), mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		mockUserRepo.On("FindByID", ctx, organizerID).Return(organizer, nil).Once()
+		
+		err := transactionUsecase.RejectPayment(ctx, organizerID, transactionID, usecase.RejectPaymentRequest{Reason: "   "})
+		
+		assert.Error(t, err)
+		assert.Equal(t, "alasan penolakan harus diisi", err.Error())
+	})
+	
+	t.Run("Invalid Status", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		transaction := newTransaction()
+		transaction.Status = entity.TransactionStatusPending
+		
+		mockUserRepo.On("FindByID", ctx, organizerID).Return(organizer, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+		
+		err := transactionUsecase.RejectPayment(ctx, organizerID, transactionID, req)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "hanya transaksi dengan status menunggu verifikasi yang dapat ditolak", err.Error())
+	})
+	
+	t.Run("Organizer Of Another Event", func(t *testing.T) {
+		otherOrganizerID := 4
+		otherOrganizer := &entity.User{ID: otherOrganizerID, Username: "organizer_lain", Email: "organizer.lain@example.com", Role: "organizer"}
+		
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockEventRepo := new(mocks.MockEventRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		historyRepo := &mocks.FakeTransactionStatusHistoryRepository{}
+		
+		transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), historyRepo, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		
+		mockUserRepo.On("FindByID", ctx, otherOrganizerID).Return(otherOrganizer, nil).Once()
+		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(newTransaction(), nil).Once()
+		mockEventRepo.On("FindByID", ctx, 3).Return(event, nil).Once()
+		
+		err := transactionUsecase.RejectPayment(ctx, otherOrganizerID, transactionID, req)
+		
+		assert.Error(t, err)
+		assert.Equal(t, "anda tidak memiliki izin untuk menolak pembayaran transaksi ini", err.Error())
+		mockTransactionRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.Nil(t, historyRepo.Last())
 	})
 }
 
@@ -839,7 +1140,7 @@ func TestCancelTransaction(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -852,7 +1153,7 @@ func TestCancelTransaction(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -861,7 +1162,7 @@ func TestCancelTransaction(t *testing.T) {
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
-		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, "cancelled").Return(nil).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, entity.TransactionStatusPending, entity.TransactionStatusCancelled).Return(nil).Once()
 		mockEventRepo.On("UpdateTicketsSold", ctx, transaction.EventID, -transaction.Quantity).Return(nil).Once()
 		
 		err := transactionUsecase.CancelTransaction(ctx, userID, transactionID)
@@ -894,7 +1195,7 @@ func TestCancelTransaction(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -921,7 +1222,7 @@ func TestCancelTransaction(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "waiting_verification", // Not pending
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -948,7 +1249,7 @@ func TestCancelTransaction(t *testing.T) {
 			EventID:         2,
 			TransactionCode: "TRX-20230101-123456",
 			Quantity:        2,
-			TotalAmount:     500000,
+			TotalAmount:     entity.IDR(500000),
 			Status:          "pending",
 			PaymentMethod:   "bank_transfer",
 			PaymentDetail:   "Bank Transfer Details",
@@ -957,7 +1258,7 @@ func TestCancelTransaction(t *testing.T) {
 		}
 		
 		mockTransactionRepo.On("FindByID", ctx, transactionID).Return(transaction, nil).Once()
-		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, "cancelled").Return(errors.New("database error")).Once()
+		mockTransactionRepo.On("UpdateStatus", ctx, transactionID, entity.TransactionStatusPending, entity.TransactionStatusCancelled).Return(errors.New("database error")).Once()
 		
 		err := transactionUsecase.CancelTransaction(ctx, userID, transactionID)
 		
@@ -972,7 +1273,7 @@ func TestGetUserTransactions(t *testing.T) {
 	mockEventRepo := new(mocks.MockEventRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	
-	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
 	ctx := context.Background()
 	
 	t.Run("Success", func(t *testing.T) {
@@ -988,7 +1289,7 @@ func TestGetUserTransactions(t *testing.T) {
 				EventID:         2,
 				TransactionCode: "TRX-20230101-123456",
 				Quantity:        2,
-				TotalAmount:     500000,
+				TotalAmount:     entity.IDR(500000),
 				Status:          "pending",
 				PaymentMethod:   "bank_transfer",
 				PaymentDetail:   "Bank Transfer Details",
@@ -1001,7 +1302,7 @@ func TestGetUserTransactions(t *testing.T) {
 				EventID:         3,
 				TransactionCode: "TRX-20230102-654321",
 				Quantity:        1,
-				TotalAmount:     250000,
+				TotalAmount:     entity.IDR(250000),
 				Status:          "success",
 				PaymentMethod:   "qris",
 				PaymentDetail:   "QRIS Payment Details",
@@ -1079,7 +1380,7 @@ func TestGetUserTransactions(t *testing.T) {
 				EventID:         2,
 				TransactionCode: "TRX-20230101-123456",
 				Quantity:        2,
-				TotalAmount:     500000,
+				TotalAmount:     entity.IDR(500000),
 				Status:          "pending",
 				PaymentMethod:   "bank_transfer",
 				PaymentDetail:   "Bank Transfer Details",
@@ -1120,6 +1421,56 @@ func TestGetUserTransactions(t *testing.T) {
 		assert.Nil(t, responses)
 		assert.Equal(t, "database error", err.Error())
 		
+		mockTransactionRepo.AssertExpectations(t)
+	})
+}
+
+func TestExpirePendingTransactions(t *testing.T) {
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockEventRepo := new(mocks.MockEventRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	
+	transactionUsecase := usecase.NewTransactionUsecase(mockTransactionRepo, mockEventRepo, mockUserRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, &mocks.FakeWaitlistRepository{}, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+	ctx := context.Background()
+	
+	t.Run("Releases Seats Of Expired Transactions", func(t *testing.T) {
+		expired := []entity.Transaction{
+			{ID: 1, EventID: 2, TransactionCode: "TRX-20230101-000001", Quantity: 2, Status: "expired"},
+			{ID: 2, EventID: 3, TransactionCode: "TRX-20230101-000002", Quantity: 1, Status: "expired"},
+		}
+		
+		mockTransactionRepo.On("ExpireOverdue", ctx, mock.AnythingOfType("time.Time"), 100).Return(expired, nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 2, -2).Return(nil).Once()
+		mockEventRepo.On("UpdateTicketsSold", ctx, 3, -1).Return(nil).Once()
+		
+		count, err := transactionUsecase.ExpirePendingTransactions(ctx)
+		
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		
+		mockTransactionRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+	
+	t.Run("Nothing To Expire", func(t *testing.T) {
+		mockTransactionRepo.On("ExpireOverdue", ctx, mock.AnythingOfType("time.Time"), 100).Return([]entity.Transaction{}, nil).Once()
+		
+		count, err := transactionUsecase.ExpirePendingTransactions(ctx)
+		
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		
+		mockTransactionRepo.AssertExpectations(t)
+	})
+	
+	t.Run("Database Error", func(t *testing.T) {
+		mockTransactionRepo.On("ExpireOverdue", ctx, mock.AnythingOfType("time.Time"), 100).Return(nil, errors.New("database error")).Once()
+		
+		count, err := transactionUsecase.ExpirePendingTransactions(ctx)
+		
+		assert.Error(t, err)
+		assert.Equal(t, 0, count)
+		
 		mockTransactionRepo.AssertExpectations(t)
 	})
 }
\ No newline at end of file
@@ -0,0 +1,236 @@
+//test/usecase/user_mfa_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+)
+
+// enrollMFA mengaktifkan 2FA langsung di repository agar test login tidak perlu melewati SetupMFA
+// dan EnableMFA.
+func (f *userFixture) enrollMFA(t *testing.T) string {
+	secret, err := utils.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	// Fixture tidak mengisi MFA_SECRET_KEY sehingga secret dienkripsi dengan JWT secret
+	encrypted, err := utils.EncryptSecret(secret, sessionTestSecret)
+	require.NoError(t, err)
+
+	f.userMFARepo.MFAs = map[int]*entity.UserMFA{
+		f.user.ID: {UserID: f.user.ID, SecretEncrypted: encrypted, EnabledAt: time.Now().Add(-time.Hour)},
+	}
+	return secret
+}
+
+func currentTOTP(t *testing.T, secret string) string {
+	code, err := utils.GenerateTOTPCode(secret, utils.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestTOTPRFC6238Vectors(t *testing.T) {
+	// Vektor SHA-1 dari RFC 6238 lampiran B, dipotong ke 6 digit terakhir
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := utils.GenerateTOTPCode(secret, utils.TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "waktu %d", unix)
+	}
+
+	step, ok := utils.ValidateTOTPCode(secret, "081804", time.Unix(1111111109+30, 0))
+	assert.True(t, ok, "kode dari satu periode sebelumnya masih diterima")
+	assert.Equal(t, int64(1111111109/30), step)
+
+	_, ok = utils.ValidateTOTPCode(secret, "081804", time.Unix(1111111109+90, 0))
+	assert.False(t, ok)
+}
+
+func TestMFAEnrolment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Setup returns provisioning URI and stores encrypted secret", func(t *testing.T) {
+		f := newUserFixture(t)
+
+		setup, err := f.userUsecase.SetupMFA(ctx, 7)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(setup.OTPAuthURL, "otpauth://totp/"))
+		assert.Contains(t, setup.OTPAuthURL, "secret="+setup.Secret)
+		assert.Contains(t, setup.OTPAuthURL, "Event:budi@example.com")
+		assert.True(t, strings.HasPrefix(setup.QRCode, "data:image/png;base64,"))
+
+		stored := f.userMFARepo.MFAs[7]
+		require.NotNil(t, stored)
+		assert.False(t, stored.Enabled())
+		assert.NotContains(t, stored.SecretEncrypted, setup.Secret)
+		decrypted, err := utils.DecryptSecret(stored.SecretEncrypted, sessionTestSecret)
+		require.NoError(t, err)
+		assert.Equal(t, setup.Secret, decrypted)
+	})
+
+	t.Run("Enable requires valid code and returns hashed recovery codes", func(t *testing.T) {
+		f := newUserFixture(t)
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		setup, err := f.userUsecase.SetupMFA(ctx, 7)
+		require.NoError(t, err)
+
+		_, err = f.userUsecase.EnableMFA(ctx, 7, usecase.MFACodeRequest{Code: "000000"})
+		assert.EqualError(t, err, "kode 2fa tidak valid")
+
+		codes, err := f.userUsecase.EnableMFA(ctx, 7, usecase.MFACodeRequest{Code: currentTOTP(t, setup.Secret)})
+		require.NoError(t, err)
+		require.Len(t, codes.RecoveryCodes, 10)
+		assert.True(t, f.userMFARepo.MFAs[7].Enabled())
+
+		require.Len(t, f.userMFARepo.RecoveryCodes, 10)
+		match, err := utils.VerifyPassword(codes.RecoveryCodes[0], f.userMFARepo.RecoveryCodes[0].CodeHash)
+		require.NoError(t, err)
+		assert.True(t, match)
+		assert.NotEqual(t, codes.RecoveryCodes[0], f.userMFARepo.RecoveryCodes[0].CodeHash)
+
+		// Sesi yang dibuka sebelum 2FA aktif dicabut
+		assert.True(t, f.refreshTokenRepo.Tokens[0].Revoked())
+		claims, err := utils.ValidateToken(login.Token, sessionTestSecret)
+		require.NoError(t, err)
+		revoked, err := f.tokenRevocationRepo.IsRevoked(ctx, claims.UserID, claims.ID, claims.TokenVersion)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+
+		_, err = f.userUsecase.SetupMFA(ctx, 7)
+		assert.EqualError(t, err, "2fa sudah aktif")
+	})
+
+	t.Run("Disable requires password and code", func(t *testing.T) {
+		f := newUserFixture(t)
+		secret := f.enrollMFA(t)
+
+		err := f.userUsecase.DisableMFA(ctx, 7, usecase.DisableMFARequest{Password: "salah123", Code: currentTOTP(t, secret)})
+		assert.EqualError(t, err, "password saat ini salah")
+
+		err = f.userUsecase.DisableMFA(ctx, 7, usecase.DisableMFARequest{Password: "password123", Code: "000000"})
+		assert.EqualError(t, err, "kode 2fa tidak valid")
+
+		err = f.userUsecase.DisableMFA(ctx, 7, usecase.DisableMFARequest{Password: "password123", Code: currentTOTP(t, secret)})
+		require.NoError(t, err)
+		assert.Empty(t, f.userMFARepo.MFAs)
+
+		status, err := f.userUsecase.GetMFAStatus(ctx, 7)
+		require.NoError(t, err)
+		assert.False(t, status.Enabled)
+	})
+}
+
+func TestLoginMFA(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Login returns challenge instead of session", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.enrollMFA(t)
+
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+
+		assert.True(t, login.MFARequired)
+		assert.Empty(t, login.Token)
+		assert.Empty(t, login.RefreshToken)
+		assert.Empty(t, f.refreshTokenRepo.Tokens)
+
+		claims, err := utils.ValidateToken(login.MFAToken, sessionTestSecret)
+		require.NoError(t, err)
+		assert.Equal(t, utils.TokenTypeMFAChallenge, claims.TokenType)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+	})
+
+	t.Run("TOTP completes login once", func(t *testing.T) {
+		f := newUserFixture(t)
+		secret := f.enrollMFA(t)
+
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		code := currentTOTP(t, secret)
+
+		session, err := f.userUsecase.LoginMFA(ctx, usecase.LoginMFARequest{MFAToken: login.MFAToken, Code: code})
+		require.NoError(t, err)
+		claims, err := utils.ValidateToken(session.Token, sessionTestSecret)
+		require.NoError(t, err)
+		assert.Equal(t, utils.TokenTypeAccess, claims.TokenType)
+		assert.True(t, claims.MFA)
+		assert.NotEmpty(t, session.RefreshToken)
+
+		// Challenge yang sudah ditukar tidak bisa dipakai lagi
+		_, err = f.userUsecase.LoginMFA(ctx, usecase.LoginMFARequest{MFAToken: login.MFAToken, Code: code})
+		assert.EqualError(t, err, "token mfa tidak valid")
+
+		// Kode TOTP yang sama tidak bisa dipakai untuk challenge baru
+		relogin, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		_, err = f.userUsecase.LoginMFA(ctx, usecase.LoginMFARequest{MFAToken: relogin.MFAToken, Code: code})
+		assert.EqualError(t, err, "kode 2fa tidak valid")
+
+		// Refresh mempertahankan status 2FA sesi
+		refreshed, err := f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+		require.NoError(t, err)
+		claims, err = utils.ValidateToken(refreshed.Token, sessionTestSecret)
+		require.NoError(t, err)
+		assert.True(t, claims.MFA)
+	})
+
+	t.Run("Recovery code is single use", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.enrollMFA(t)
+		hash, err := utils.GeneratePassword("abcd-efgh")
+		require.NoError(t, err)
+		require.NoError(t, f.userMFARepo.ReplaceRecoveryCodes(ctx, 7, []string{hash}))
+
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		_, err = f.userUsecase.LoginMFA(ctx, usecase.LoginMFARequest{MFAToken: login.MFAToken, Code: "ABCD-EFGH"})
+		require.NoError(t, err)
+
+		relogin, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		_, err = f.userUsecase.LoginMFA(ctx, usecase.LoginMFARequest{MFAToken: relogin.MFAToken, Code: "abcd-efgh"})
+		assert.EqualError(t, err, "kode 2fa tidak valid")
+	})
+
+	t.Run("Access token cannot be used as challenge", func(t *testing.T) {
+		f := newUserFixture(t)
+		secret := f.enrollMFA(t)
+		accessToken, err := utils.GenerateJWT(7, "budi", "budi@example.com", "user", utils.TokenTypeAccess, false, 0, sessionTestSecret, time.Minute)
+		require.NoError(t, err)
+
+		_, err = f.userUsecase.LoginMFA(ctx, usecase.LoginMFARequest{MFAToken: accessToken, Code: currentTOTP(t, secret)})
+		assert.EqualError(t, err, "token mfa tidak valid")
+	})
+}
+
+func TestLoginWithoutMFA(t *testing.T) {
+	f := newUserFixture(t)
+
+	login, err := f.userUsecase.Login(context.Background(), usecase.LoginRequest{Username: "budi", Password: "password123"})
+	require.NoError(t, err)
+	assert.False(t, login.MFARequired)
+
+	claims, err := utils.ValidateToken(login.Token, sessionTestSecret)
+	require.NoError(t, err)
+	assert.False(t, claims.MFA)
+}
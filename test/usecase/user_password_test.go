@@ -0,0 +1,232 @@
+//test/usecase/user_password_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+type userFixture struct {
+	userUsecase         usecase.UserUsecase
+	user                *entity.User
+	userRepo            *mocks.MockUserRepository
+	passwordResetRepo   *mocks.FakePasswordResetRepository
+	refreshTokenRepo    *mocks.FakeRefreshTokenRepository
+	tokenRevocationRepo *mocks.FakeTokenRevocationRepository
+	userMFARepo         *mocks.FakeUserMFARepository
+	txManager           *mocks.FakeTxManager
+}
+
+func newUserFixture(t *testing.T) *userFixture {
+	hashedPassword, err := utils.GeneratePassword("password123")
+	require.NoError(t, err)
+
+	user := &entity.User{ID: 7, Username: "budi", Email: "budi@example.com", Password: hashedPassword, Role: "user", IsVerified: true}
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindByUsername", mock.Anything, "budi").Return(user, nil)
+	userRepo.On("FindByID", mock.Anything, 7).Return(user, nil)
+	userRepo.On("FindByIDForUpdate", mock.Anything, 7).Return(user, nil)
+	userRepo.On("FindByEmail", mock.Anything, "budi@example.com").Return(user, nil)
+	userRepo.On("FindByEmail", mock.Anything, "siapa@example.com").Return(nil, nil)
+	userRepo.On("UpdatePassword", mock.Anything, 7, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		user.Password = args.String(2)
+	})
+	incrementTokenVersion := userRepo.On("IncrementTokenVersion", mock.Anything, 7).Return(0, nil)
+	incrementTokenVersion.Run(func(args mock.Arguments) {
+		user.TokenVersion++
+		incrementTokenVersion.ReturnArguments = mock.Arguments{user.TokenVersion, nil}
+	})
+
+	f := &userFixture{
+		user:                user,
+		userRepo:            userRepo,
+		passwordResetRepo:   &mocks.FakePasswordResetRepository{},
+		refreshTokenRepo:    &mocks.FakeRefreshTokenRepository{},
+		tokenRevocationRepo: &mocks.FakeTokenRevocationRepository{},
+		userMFARepo:         &mocks.FakeUserMFARepository{},
+		txManager:           &mocks.FakeTxManager{},
+	}
+	f.userUsecase = usecase.NewUserUsecase(userRepo, nil, nil, f.refreshTokenRepo, f.tokenRevocationRepo, f.passwordResetRepo, f.userMFARepo, f.txManager, sessionTestSecret, "15", "720", "30", "5", utils.SMTPConfig{}, "http://localhost:8080", "", "")
+	return f
+}
+
+// seedReset menyimpan token reset langsung ke repository karena token mentah hanya dikirim lewat email
+func (f *userFixture) seedReset(token string, createdAt time.Time, expiredAt time.Time) {
+	f.passwordResetRepo.Create(context.Background(), &entity.PasswordReset{
+		UserID:    f.user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiredAt: expiredAt,
+		CreatedAt: createdAt,
+	})
+}
+
+// failTokenVersion membuat pencabutan sesi gagal di tengah jalan
+func (f *userFixture) failTokenVersion() {
+	for _, call := range f.userRepo.ExpectedCalls {
+		if call.Method == "IncrementTokenVersion" {
+			call.Unset()
+			break
+		}
+	}
+	f.userRepo.On("IncrementTokenVersion", mock.Anything, 7).Return(0, errors.New("database down"))
+}
+
+func TestForgotPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unknown email does not leak", func(t *testing.T) {
+		f := newUserFixture(t)
+
+		err := f.userUsecase.ForgotPassword(ctx, usecase.ForgotPasswordRequest{Email: "siapa@example.com"})
+		assert.NoError(t, err)
+		assert.Empty(t, f.passwordResetRepo.Resets)
+	})
+
+	t.Run("Stores hashed token and replaces unused ones", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.seedReset("token-lama", time.Now().Add(-10*time.Minute), time.Now().Add(20*time.Minute))
+
+		err := f.userUsecase.ForgotPassword(ctx, usecase.ForgotPasswordRequest{Email: "budi@example.com"})
+		require.NoError(t, err)
+
+		require.Len(t, f.passwordResetRepo.Resets, 1)
+		reset := f.passwordResetRepo.Resets[0]
+		assert.NotEqual(t, utils.HashToken("token-lama"), reset.TokenHash)
+		assert.Len(t, reset.TokenHash, 64)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), reset.ExpiredAt, 5*time.Second)
+	})
+
+	t.Run("Silently skips requests within cooldown", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.seedReset("token-baru", time.Now().Add(-10*time.Second), time.Now().Add(30*time.Minute))
+
+		err := f.userUsecase.ForgotPassword(ctx, usecase.ForgotPasswordRequest{Email: "budi@example.com"})
+		assert.NoError(t, err)
+		require.Len(t, f.passwordResetRepo.Resets, 1)
+		assert.Equal(t, utils.HashToken("token-baru"), f.passwordResetRepo.Resets[0].TokenHash)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Token is single use and revokes sessions", func(t *testing.T) {
+		f := newUserFixture(t)
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		f.seedReset("token-reset", time.Now().Add(-time.Minute), time.Now().Add(29*time.Minute))
+
+		req := usecase.ResetPasswordRequest{Token: "token-reset", Password: "rahasiabaru", RetypePassword: "rahasiabaru"}
+		require.NoError(t, f.userUsecase.ResetPassword(ctx, req))
+
+		match, err := utils.VerifyPassword("rahasiabaru", f.user.Password)
+		require.NoError(t, err)
+		assert.True(t, match)
+		assert.True(t, f.refreshTokenRepo.Tokens[0].Revoked())
+
+		claims, err := utils.ValidateToken(login.Token, sessionTestSecret)
+		require.NoError(t, err)
+		revoked, err := f.tokenRevocationRepo.IsRevoked(ctx, claims.UserID, claims.ID, claims.TokenVersion)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+
+		// Login dengan password baru langsung setelah reset tidak ikut dicabut
+		relogin, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "rahasiabaru"})
+		require.NoError(t, err)
+		reloginClaims, err := utils.ValidateToken(relogin.Token, sessionTestSecret)
+		require.NoError(t, err)
+		revoked, err = f.tokenRevocationRepo.IsRevoked(ctx, reloginClaims.UserID, reloginClaims.ID, reloginClaims.TokenVersion)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		err = f.userUsecase.ResetPassword(ctx, req)
+		assert.EqualError(t, err, "token reset password tidak valid")
+	})
+
+	t.Run("Failed session revocation rolls back the reset", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.seedReset("token-reset", time.Now().Add(-time.Minute), time.Now().Add(29*time.Minute))
+		f.failTokenVersion()
+
+		err := f.userUsecase.ResetPassword(ctx, usecase.ResetPasswordRequest{Token: "token-reset", Password: "rahasiabaru", RetypePassword: "rahasiabaru"})
+		assert.EqualError(t, err, "database down")
+
+		// MarkUsed, UpdatePassword dan pencabutan sesi berada di satu transaksi yang dibatalkan
+		f.userRepo.AssertCalled(t, "UpdatePassword", mock.Anything, 7, mock.Anything)
+		assert.Equal(t, 0, f.txManager.Committed)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.seedReset("token-lama", time.Now().Add(-time.Hour), time.Now().Add(-30*time.Minute))
+
+		err := f.userUsecase.ResetPassword(ctx, usecase.ResetPasswordRequest{Token: "token-lama", Password: "rahasiabaru", RetypePassword: "rahasiabaru"})
+		assert.EqualError(t, err, "token reset password sudah kedaluwarsa")
+		f.userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		f := newUserFixture(t)
+
+		err := f.userUsecase.ResetPassword(ctx, usecase.ResetPasswordRequest{Token: "ngawur", Password: "rahasiabaru", RetypePassword: "rahasiabaru"})
+		assert.EqualError(t, err, "token reset password tidak valid")
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Requires current password", func(t *testing.T) {
+		f := newUserFixture(t)
+
+		err := f.userUsecase.ChangePassword(ctx, 7, usecase.ChangePasswordRequest{CurrentPassword: "salah123", NewPassword: "rahasiabaru", RetypePassword: "rahasiabaru"})
+		assert.EqualError(t, err, "password saat ini salah")
+		f.userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects unchanged password", func(t *testing.T) {
+		f := newUserFixture(t)
+
+		err := f.userUsecase.ChangePassword(ctx, 7, usecase.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "password123", RetypePassword: "password123"})
+		assert.EqualError(t, err, "password baru harus berbeda dari password saat ini")
+	})
+
+	t.Run("Updates password and revokes sessions", func(t *testing.T) {
+		f := newUserFixture(t)
+		_, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+
+		err = f.userUsecase.ChangePassword(ctx, 7, usecase.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "rahasiabaru", RetypePassword: "rahasiabaru"})
+		require.NoError(t, err)
+
+		match, err := utils.VerifyPassword("rahasiabaru", f.user.Password)
+		require.NoError(t, err)
+		assert.True(t, match)
+		assert.True(t, f.refreshTokenRepo.Tokens[0].Revoked())
+		require.Len(t, f.tokenRevocationRepo.Revocations, 1)
+		assert.Empty(t, f.tokenRevocationRepo.Revocations[0].JTI)
+	})
+	t.Run("Failed session revocation rolls back the new password", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.failTokenVersion()
+
+		err := f.userUsecase.ChangePassword(ctx, 7, usecase.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "rahasiabaru", RetypePassword: "rahasiabaru"})
+		assert.EqualError(t, err, "database down")
+
+		f.userRepo.AssertCalled(t, "UpdatePassword", mock.Anything, 7, mock.Anything)
+		assert.Equal(t, 0, f.txManager.Committed)
+		assert.Empty(t, f.tokenRevocationRepo.Revocations)
+	})
+}
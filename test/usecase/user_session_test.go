@@ -0,0 +1,204 @@
+//test/usecase/user_session_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+const sessionTestSecret = "rahasia_test_sesi"
+
+func TestLoginIssuesSession(t *testing.T) {
+	ctx := context.Background()
+	f := newUserFixture(t)
+
+	response, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+	require.NoError(t, err)
+	assert.Equal(t, 900, response.ExpiresIn)
+
+	claims, err := utils.ValidateToken(response.Token, sessionTestSecret)
+	require.NoError(t, err)
+	assert.Equal(t, utils.TokenTypeAccess, claims.TokenType)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	// Refresh token opaque, bukan JWT, dan hanya hash-nya yang disimpan
+	_, err = utils.ValidateToken(response.RefreshToken, sessionTestSecret)
+	assert.Error(t, err)
+	require.Len(t, f.refreshTokenRepo.Tokens, 1)
+	assert.Equal(t, utils.HashToken(response.RefreshToken), f.refreshTokenRepo.Tokens[0].TokenHash)
+	assert.NotEqual(t, response.RefreshToken, f.refreshTokenRepo.Tokens[0].TokenHash)
+	assert.WithinDuration(t, time.Now().Add(720*time.Hour), f.refreshTokenRepo.Tokens[0].ExpiresAt, 5*time.Second)
+}
+
+func TestRefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rotates token within the same family", func(t *testing.T) {
+		f := newUserFixture(t)
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+
+		refreshed, err := f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+		require.NoError(t, err)
+		assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+		assert.Equal(t, "budi", refreshed.Username)
+
+		claims, err := utils.ValidateToken(refreshed.Token, sessionTestSecret)
+		require.NoError(t, err)
+		assert.Equal(t, 7, claims.UserID)
+
+		require.Len(t, f.refreshTokenRepo.Tokens, 2)
+		assert.True(t, f.refreshTokenRepo.Tokens[0].Rotated())
+		assert.False(t, f.refreshTokenRepo.Tokens[1].Rotated())
+		assert.Equal(t, f.refreshTokenRepo.Tokens[0].FamilyID, f.refreshTokenRepo.Tokens[1].FamilyID)
+
+		// Rotasi berjalan di bawah kunci baris user agar tidak bertabrakan dengan logout dari semua perangkat
+		f.userRepo.AssertCalled(t, "FindByIDForUpdate", mock.Anything, 7)
+		assert.Equal(t, 1, f.txManager.Committed)
+	})
+
+	t.Run("Replayed token revokes the whole family", func(t *testing.T) {
+		f := newUserFixture(t)
+		login, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		otherSession, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+
+		refreshed, err := f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+		require.NoError(t, err)
+
+		_, err = f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+		assert.EqualError(t, err, "refresh token sudah pernah dipakai, silakan login kembali")
+
+		_, err = f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken})
+		assert.EqualError(t, err, "refresh token tidak valid")
+
+		// Sesi login lain milik user yang sama tidak ikut dicabut
+		_, err = f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: otherSession.RefreshToken})
+		assert.NoError(t, err)
+		assert.Len(t, f.refreshTokenRepo.Tokens, 4)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		f := newUserFixture(t)
+		f.refreshTokenRepo.Tokens = []entity.RefreshToken{
+			{ID: 1, UserID: 7, FamilyID: "family", TokenHash: utils.HashToken("expired"), ExpiresAt: time.Now().Add(-time.Minute)},
+		}
+
+		_, err := f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: "expired"})
+		assert.EqualError(t, err, "refresh token sudah kedaluwarsa")
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		f := newUserFixture(t)
+
+		_, err := f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: "tidak-ada"})
+		assert.EqualError(t, err, "refresh token tidak valid")
+	})
+}
+
+func TestLogout(t *testing.T) {
+	ctx := context.Background()
+
+	login := func(t *testing.T, f *userFixture) (*usecase.LoginResponse, *utils.JWTClaim) {
+		response, err := f.userUsecase.Login(ctx, usecase.LoginRequest{Username: "budi", Password: "password123"})
+		require.NoError(t, err)
+		claims, err := utils.ValidateToken(response.Token, sessionTestSecret)
+		require.NoError(t, err)
+		require.NotEmpty(t, claims.ID)
+		return response, claims
+	}
+
+	t.Run("Revokes current access token and its refresh token", func(t *testing.T) {
+		f := newUserFixture(t)
+		revocationUsecase := usecase.NewTokenRevocationUsecase(f.tokenRevocationRepo)
+		current, currentClaims := login(t, f)
+		other, otherClaims := login(t, f)
+		assert.NotEqual(t, currentClaims.ID, otherClaims.ID)
+
+		err := f.userUsecase.Logout(ctx, currentClaims, usecase.LogoutRequest{RefreshToken: current.RefreshToken})
+		require.NoError(t, err)
+
+		revoked, err := revocationUsecase.IsRevoked(ctx, currentClaims)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+		assert.Equal(t, currentClaims.ExpiresAt.Time, f.tokenRevocationRepo.Revocations[0].ExpiresAt)
+
+		revoked, err = revocationUsecase.IsRevoked(ctx, otherClaims)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		_, err = f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: current.RefreshToken})
+		assert.EqualError(t, err, "refresh token tidak valid")
+		_, err = f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: other.RefreshToken})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Refresh token of another user is ignored", func(t *testing.T) {
+		f := newUserFixture(t)
+		current, currentClaims := login(t, f)
+		f.refreshTokenRepo.Tokens = append(f.refreshTokenRepo.Tokens, entity.RefreshToken{
+			ID: 99, UserID: 8, FamilyID: "family-lain", TokenHash: utils.HashToken("milik-user-lain"), ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		err := f.userUsecase.Logout(ctx, currentClaims, usecase.LogoutRequest{RefreshToken: "milik-user-lain"})
+		require.NoError(t, err)
+		assert.False(t, f.refreshTokenRepo.Tokens[1].Revoked())
+
+		_, err = f.userUsecase.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: current.RefreshToken})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Logout all revokes every session", func(t *testing.T) {
+		f := newUserFixture(t)
+		revocationUsecase := usecase.NewTokenRevocationUsecase(f.tokenRevocationRepo)
+		_, firstClaims := login(t, f)
+		_, secondClaims := login(t, f)
+
+		require.NoError(t, f.userUsecase.LogoutAll(ctx, 7))
+		assert.Equal(t, 1, f.txManager.Committed)
+
+		for _, claims := range []*utils.JWTClaim{firstClaims, secondClaims} {
+			revoked, err := revocationUsecase.IsRevoked(ctx, claims)
+			require.NoError(t, err)
+			assert.True(t, revoked)
+		}
+		for _, token := range f.refreshTokenRepo.Tokens {
+			assert.True(t, token.Revoked())
+		}
+
+		// Login setelah pencabutan tetap berlaku walau terbit di detik yang sama
+		_, laterClaims := login(t, f)
+		revoked, err := revocationUsecase.IsRevoked(ctx, laterClaims)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), f.tokenRevocationRepo.Revocations[0].ExpiresAt, 5*time.Second)
+	})
+}
+
+func TestPurgeExpiredRevocations(t *testing.T) {
+	tokenRevocationRepo := &mocks.FakeTokenRevocationRepository{
+		Revocations: []entity.TokenRevocation{
+			{ID: 1, UserID: 7, JTI: "lama", RevokedAt: time.Now().Add(-2 * time.Hour), ExpiresAt: time.Now().Add(-time.Hour)},
+			{ID: 2, UserID: 7, JTI: "baru", RevokedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+
+	deleted, err := usecase.NewTokenRevocationUsecase(tokenRevocationRepo).PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	require.Len(t, tokenRevocationRepo.Revocations, 1)
+	assert.Equal(t, "baru", tokenRevocationRepo.Revocations[0].JTI)
+}
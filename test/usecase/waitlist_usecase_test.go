@@ -0,0 +1,241 @@
+//test/usecase/waitlist_usecase_test.go
+
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ticket-system/internal/domain/entity"
+	"ticket-system/internal/domain/repository"
+	"ticket-system/internal/usecase"
+	"ticket-system/pkg/utils"
+	"ticket-system/test/mocks"
+)
+
+func newSoldOutEvent() *entity.Event {
+	return &entity.Event{
+		ID:          1,
+		Title:       "Konser Musik",
+		EventDate:   time.Now().Add(48 * time.Hour),
+		MaxCapacity: 10,
+		TicketsSold: 10,
+		Price:       entity.IDR(100000),
+		Status:      "active",
+	}
+}
+
+func TestJoinWaitlist(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sold out event queues in FIFO order", func(t *testing.T) {
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(newSoldOutEvent(), nil)
+		waitlistRepo := &mocks.FakeWaitlistRepository{}
+		uc := usecase.NewWaitlistUsecase(waitlistRepo, eventRepo, new(mocks.MockUserRepository), &mocks.FakeTxManager{}, "30", utils.SMTPConfig{})
+
+		first, err := uc.JoinWaitlist(ctx, 1, 1, usecase.JoinWaitlistRequest{Quantity: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "waiting", first.Status)
+		assert.Equal(t, 1, first.Position)
+
+		second, err := uc.JoinWaitlist(ctx, 2, 1, usecase.JoinWaitlistRequest{Quantity: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, second.Position)
+
+		_, err = uc.JoinWaitlist(ctx, 1, 1, usecase.JoinWaitlistRequest{Quantity: 1})
+		assert.ErrorIs(t, err, repository.ErrWaitlistEntryExists)
+	})
+
+	t.Run("Tickets still available", func(t *testing.T) {
+		event := newSoldOutEvent()
+		event.TicketsSold = 5
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		uc := usecase.NewWaitlistUsecase(&mocks.FakeWaitlistRepository{}, eventRepo, new(mocks.MockUserRepository), &mocks.FakeTxManager{}, "30", utils.SMTPConfig{})
+
+		_, err := uc.JoinWaitlist(ctx, 1, 1, usecase.JoinWaitlistRequest{Quantity: 2})
+		assert.EqualError(t, err, "tiket masih tersedia, silakan langsung membeli")
+	})
+
+	t.Run("Free seats behind existing queue", func(t *testing.T) {
+		event := newSoldOutEvent()
+		event.TicketsSold = 9
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+		waitlistRepo := &mocks.FakeWaitlistRepository{
+			Entries: []entity.WaitlistEntry{{ID: 1, EventID: 1, UserID: 5, Quantity: 3, Status: entity.WaitlistStatusWaiting}},
+		}
+		uc := usecase.NewWaitlistUsecase(waitlistRepo, eventRepo, new(mocks.MockUserRepository), &mocks.FakeTxManager{}, "30", utils.SMTPConfig{})
+
+		entry, err := uc.JoinWaitlist(ctx, 1, 1, usecase.JoinWaitlistRequest{Quantity: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, entry.Position)
+	})
+}
+
+func TestProcessWaitlist(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Expired offer rolls to next in line", func(t *testing.T) {
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", mock.Anything, 1).Return(newSoldOutEvent(), nil)
+		eventRepo.On("UpdateTicketsSold", mock.Anything, 1, -2).Return(nil).Once()
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindByID", mock.Anything, mock.Anything).Return(&entity.User{ID: 2, Username: "buyer", Email: "buyer@example.com"}, nil)
+
+		waitlistRepo := &mocks.FakeWaitlistRepository{
+			FreeSeats: map[int]int{1: 0},
+			Entries: []entity.WaitlistEntry{
+				{ID: 1, EventID: 1, UserID: 1, Quantity: 2, Status: entity.WaitlistStatusOffered, OfferExpiresAt: time.Now().Add(-time.Minute)},
+				{ID: 2, EventID: 1, UserID: 2, Quantity: 2, Status: entity.WaitlistStatusWaiting},
+				{ID: 3, EventID: 1, UserID: 3, Quantity: 1, Status: entity.WaitlistStatusWaiting},
+			},
+		}
+		uc := usecase.NewWaitlistUsecase(waitlistRepo, eventRepo, userRepo, &mocks.FakeTxManager{}, "30", utils.SMTPConfig{})
+
+		offered, err := uc.ProcessWaitlist(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, offered)
+
+		assert.Equal(t, entity.WaitlistStatusExpired, waitlistRepo.Entries[0].Status)
+		assert.Equal(t, entity.WaitlistStatusOffered, waitlistRepo.Entries[1].Status)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), waitlistRepo.Entries[1].OfferExpiresAt, 5*time.Second)
+		assert.Equal(t, entity.WaitlistStatusWaiting, waitlistRepo.Entries[2].Status)
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Head of queue that does not fit blocks later entries", func(t *testing.T) {
+		waitlistRepo := &mocks.FakeWaitlistRepository{
+			FreeSeats: map[int]int{1: 1},
+			Entries: []entity.WaitlistEntry{
+				{ID: 1, EventID: 1, UserID: 1, Quantity: 2, Status: entity.WaitlistStatusWaiting},
+				{ID: 2, EventID: 1, UserID: 2, Quantity: 1, Status: entity.WaitlistStatusWaiting},
+			},
+		}
+		uc := usecase.NewWaitlistUsecase(waitlistRepo, new(mocks.MockEventRepository), new(mocks.MockUserRepository), &mocks.FakeTxManager{}, "30", utils.SMTPConfig{})
+
+		offered, err := uc.ProcessWaitlist(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, offered)
+		assert.Equal(t, entity.WaitlistStatusWaiting, waitlistRepo.Entries[1].Status)
+	})
+}
+
+func TestLeaveWaitlist(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Leaving with an offer releases seats", func(t *testing.T) {
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("UpdateTicketsSold", ctx, 1, -2).Return(nil).Once()
+		waitlistRepo := &mocks.FakeWaitlistRepository{
+			Entries: []entity.WaitlistEntry{
+				{ID: 1, EventID: 1, UserID: 1, Quantity: 2, Status: entity.WaitlistStatusOffered, OfferExpiresAt: time.Now().Add(time.Minute)},
+			},
+		}
+		uc := usecase.NewWaitlistUsecase(waitlistRepo, eventRepo, new(mocks.MockUserRepository), &mocks.FakeTxManager{}, "30", utils.SMTPConfig{})
+
+		require.NoError(t, uc.LeaveWaitlist(ctx, 1, 1))
+		assert.Equal(t, entity.WaitlistStatusCancelled, waitlistRepo.Entries[0].Status)
+		eventRepo.AssertExpectations(t)
+
+		assert.EqualError(t, uc.LeaveWaitlist(ctx, 1, 1), "anda tidak terdaftar di waitlist event ini")
+	})
+}
+
+func TestCreateTransactionFromWaitlist(t *testing.T) {
+	ctx := context.Background()
+	user := &entity.User{ID: 1, Username: "buyer", Email: "buyer@example.com", Role: "user"}
+
+	newFixture := func(entries []entity.WaitlistEntry) (usecase.TransactionUsecase, *mocks.MockTransactionRepository, *mocks.FakeWaitlistRepository) {
+		transactionRepo := new(mocks.MockTransactionRepository)
+		eventRepo := new(mocks.MockEventRepository)
+		eventRepo.On("FindByID", ctx, 1).Return(newSoldOutEvent(), nil)
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindByID", ctx, 1).Return(user, nil)
+		waitlistRepo := &mocks.FakeWaitlistRepository{Entries: entries}
+
+		transactionUsecase := usecase.NewTransactionUsecase(transactionRepo, eventRepo, userRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, waitlistRepo, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+		return transactionUsecase, transactionRepo, waitlistRepo
+	}
+
+	t.Run("Claim offer uses held seats", func(t *testing.T) {
+		transactionUsecase, transactionRepo, waitlistRepo := newFixture([]entity.WaitlistEntry{
+			{ID: 7, EventID: 1, UserID: 1, Quantity: 2, Status: entity.WaitlistStatusOffered, OfferExpiresAt: time.Now().Add(10 * time.Minute)},
+		})
+		transactionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(42, nil).Once()
+
+		response, err := transactionUsecase.CreateTransaction(ctx, 1, usecase.CreateTransactionRequest{
+			EventID:         1,
+			Quantity:        2,
+			PaymentMethod:   "bank_transfer",
+			WaitlistEntryID: 7,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 42, response.ID)
+		assert.Equal(t, entity.WaitlistStatusClaimed, waitlistRepo.Entries[0].Status)
+		assert.Equal(t, 42, waitlistRepo.Entries[0].ClaimedTransactionID)
+		transactionRepo.AssertNotCalled(t, "CreateWithReservation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Offer of another user", func(t *testing.T) {
+		transactionUsecase, _, _ := newFixture([]entity.WaitlistEntry{
+			{ID: 7, EventID: 1, UserID: 2, Quantity: 2, Status: entity.WaitlistStatusOffered, OfferExpiresAt: time.Now().Add(10 * time.Minute)},
+		})
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 1, usecase.CreateTransactionRequest{
+			EventID: 1, Quantity: 2, PaymentMethod: "bank_transfer", WaitlistEntryID: 7,
+		})
+		assert.EqualError(t, err, "penawaran waitlist tidak ditemukan atau sudah tidak berlaku")
+	})
+
+	t.Run("Expired offer", func(t *testing.T) {
+		transactionUsecase, _, _ := newFixture([]entity.WaitlistEntry{
+			{ID: 7, EventID: 1, UserID: 1, Quantity: 2, Status: entity.WaitlistStatusOffered, OfferExpiresAt: time.Now().Add(-time.Second)},
+		})
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 1, usecase.CreateTransactionRequest{
+			EventID: 1, Quantity: 2, PaymentMethod: "bank_transfer", WaitlistEntryID: 7,
+		})
+		assert.EqualError(t, err, "penawaran waitlist tidak ditemukan atau sudah tidak berlaku")
+	})
+
+	t.Run("Quantity differs from offer", func(t *testing.T) {
+		transactionUsecase, _, _ := newFixture([]entity.WaitlistEntry{
+			{ID: 7, EventID: 1, UserID: 1, Quantity: 2, Status: entity.WaitlistStatusOffered, OfferExpiresAt: time.Now().Add(10 * time.Minute)},
+		})
+
+		_, err := transactionUsecase.CreateTransaction(ctx, 1, usecase.CreateTransactionRequest{
+			EventID: 1, Quantity: 3, PaymentMethod: "bank_transfer", WaitlistEntryID: 7,
+		})
+		assert.EqualError(t, err, "jumlah tiket harus sama dengan jumlah pada penawaran waitlist")
+	})
+}
+
+func TestCreateTransactionRespectsWaitlistQueue(t *testing.T) {
+	ctx := context.Background()
+
+	event := newSoldOutEvent()
+	event.TicketsSold = 8
+	eventRepo := new(mocks.MockEventRepository)
+	eventRepo.On("FindByID", ctx, 1).Return(event, nil)
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("FindByID", ctx, 1).Return(&entity.User{ID: 1, Role: "user"}, nil)
+	transactionRepo := new(mocks.MockTransactionRepository)
+	waitlistRepo := &mocks.FakeWaitlistRepository{
+		Entries: []entity.WaitlistEntry{{ID: 1, EventID: 1, UserID: 2, Quantity: 3, Status: entity.WaitlistStatusWaiting}},
+	}
+
+	transactionUsecase := usecase.NewTransactionUsecase(transactionRepo, eventRepo, userRepo, new(mocks.MockPaymentRepository), &mocks.FakeTransactionStatusHistoryRepository{}, &mocks.FakeTicketRepository{}, &mocks.FakeTicketTypeRepository{}, &mocks.FakeTransactionItemRepository{}, &mocks.FakePromoCodeRepository{}, &mocks.FakePricingRuleRepository{}, waitlistRepo, &mocks.FakeSeatHoldRepository{}, &mocks.FakeTxManager{}, new(mocks.MockPaymentGateway), &mocks.FakeBlobStorage{}, &mocks.FakeVirusScanner{}, "60", "3", "2048", "15", "0", utils.SMTPConfig{})
+
+	_, err := transactionUsecase.CreateTransaction(ctx, 1, usecase.CreateTransactionRequest{
+		EventID: 1, Quantity: 1, PaymentMethod: "bank_transfer",
+	})
+	assert.EqualError(t, err, "jumlah tiket yang diminta melebihi kapasitas")
+	transactionRepo.AssertNotCalled(t, "CreateWithReservation", mock.Anything, mock.Anything)
+}